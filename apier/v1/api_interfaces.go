@@ -21,9 +21,11 @@ package v1
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/billruns"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/dispatchers"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/scheduler"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
@@ -126,6 +128,7 @@ type CacheSv1Interface interface {
 	LoadCache(args *utils.AttrReloadCacheWithArgDispatcher, reply *string) error
 	ReplicateSet(args *utils.ArgCacheReplicateSet, reply *string) (err error)
 	ReplicateRemove(args *utils.ArgCacheReplicateRemove, reply *string) (err error)
+	GetCacheChunk(args *utils.ArgsGetCacheChunk, reply *utils.CacheChunk) (err error)
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 }
 
@@ -140,6 +143,7 @@ type SchedulerSv1Interface interface {
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 	ExecuteActions(attr *utils.AttrsExecuteActions, reply *string) error
 	ExecuteActionPlans(attr *utils.AttrsExecuteActionPlans, reply *string) error
+	GetLeaderStatus(args *utils.TenantWithArgDispatcher, reply *scheduler.LeaderStatus) error
 }
 
 type CDRsV1Interface interface {
@@ -147,6 +151,10 @@ type CDRsV1Interface interface {
 	ProcessEvent(arg *engine.ArgV1ProcessEvent, reply *string) error
 	ProcessExternalCDR(cdr *engine.ExternalCDRWithArgDispatcher, reply *string) error
 	RateCDRs(arg *engine.ArgRateCDRs, reply *string) error
+	RateCDRsReport(arg *engine.ArgRateCDRsReport, reply *engine.RateCDRsReport) error
+	GetDedupStats(args *utils.TenantWithArgDispatcher, reply *engine.CDRsDedupStats) error
+	ArchiveCDRs(args *utils.TenantWithArgDispatcher, reply *[]*engine.CDRsArchive) error
+	GetCDRsReport(args *engine.ArgCDRsReport, reply *[]*engine.CDRsReportGroup) error
 	StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error
 	GetCDRsCount(args *utils.RPCCDRsFilterWithArgDispatcher, reply *int64) error
 	GetCDRs(args *utils.RPCCDRsFilterWithArgDispatcher, reply *[]*engine.CDR) error
@@ -180,6 +188,11 @@ type RateSv1Interface interface {
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 }
 
+type BillRunSv1Interface interface {
+	GenerateInvoices(args *billruns.ArgsBillRun, reply *billruns.BillRun) error
+	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
+}
+
 type ReplicatorSv1Interface interface {
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 	GetAccount(args *utils.StringWithApiKey, reply *engine.Account) error
@@ -207,6 +220,7 @@ type ReplicatorSv1Interface interface {
 	GetDispatcherProfile(tntID *utils.TenantIDWithArgDispatcher, reply *engine.DispatcherProfile) error
 	GetRateProfile(tntID *utils.TenantIDWithArgDispatcher, reply *engine.RateProfile) error
 	GetDispatcherHost(tntID *utils.TenantIDWithArgDispatcher, reply *engine.DispatcherHost) error
+	GetLookupTableValue(args *engine.LookupTableValueArgs, reply *string) error
 	GetItemLoadIDs(itemID *utils.StringWithApiKey, reply *map[string]int64) error
 	SetThresholdProfile(th *engine.ThresholdProfileWithArgDispatcher, reply *string) error
 	SetThreshold(th *engine.ThresholdWithArgDispatcher, reply *string) error
@@ -232,6 +246,7 @@ type ReplicatorSv1Interface interface {
 	SetActionPlan(args *engine.SetActionPlanArgWithArgDispatcher, reply *string) error
 	SetAccountActionPlans(args *engine.SetAccountActionPlansArgWithArgDispatcher, reply *string) error
 	SetDispatcherHost(dpp *engine.DispatcherHostWithArgDispatcher, reply *string) error
+	SetLookupTable(lt *engine.LookupTableWithArgDispatcher, reply *string) error
 	RemoveThreshold(args *utils.TenantIDWithArgDispatcher, reply *string) error
 	SetLoadIDs(args *utils.LoadIDsWithArgDispatcher, reply *string) error
 	RemoveDestination(id *utils.StringWithApiKey, reply *string) error
@@ -255,9 +270,13 @@ type ReplicatorSv1Interface interface {
 	RemoveChargerProfile(args *utils.TenantIDWithArgDispatcher, reply *string) error
 	RemoveDispatcherProfile(args *utils.TenantIDWithArgDispatcher, reply *string) error
 	RemoveDispatcherHost(args *utils.TenantIDWithArgDispatcher, reply *string) error
+	RemoveLookupTable(args *utils.TenantIDWithArgDispatcher, reply *string) error
 	RemoveRateProfile(args *utils.TenantIDWithArgDispatcher, reply *string) error
 
 	GetIndexes(args *utils.GetIndexesArg, reply *map[string]utils.StringSet) error
 	SetIndexes(args *utils.SetIndexesArg, reply *string) error
 	RemoveIndexes(args *utils.GetIndexesArg, reply *string) error
+	GetChecksums(args *utils.StringWithApiKey, reply *map[string]string) error
+	Resync(args *engine.ArgsReplicationResync, reply *map[string]map[string]*engine.ReplicationDiff) error
+	GetReplicationStats(args *utils.TenantWithArgDispatcher, reply *engine.ReplicationStats) error
 }
@@ -113,3 +113,7 @@ func TestRateSv1Interface(t *testing.T) {
 	_ = RateSv1Interface(NewDispatcherRateSv1(nil))
 	_ = RateSv1Interface(NewRateSv1(nil))
 }
+
+func TestBillRunSv1Interface(t *testing.T) {
+	_ = BillRunSv1Interface(NewBillRunSv1(nil))
+}
@@ -50,6 +50,7 @@ type APIerSv1 struct {
 	SchedulerService SchedulerGeter  // Need to have them capitalize so we can export in V2
 	FilterS          *engine.FilterS //Used for CDR Exporter
 	ConnMgr          *engine.ConnManager
+	AuditS           *engine.AuditS // audit trail of the administrative calls
 
 	StorDBChan chan engine.StorDB
 }
@@ -628,6 +629,7 @@ type AttrSetActionPlan struct {
 	ActionPlan      []*AttrActionPlan // Set of actions this Actions profile will perform
 	Overwrite       bool              // If previously defined, will be overwritten
 	ReloadScheduler bool              // Enables automatic reload of the scheduler (eg: useful when adding a single action timing)
+	CatchUp         string            // Executes the runs missed while the scheduler was down: <""|*once|*all>
 }
 
 type AttrActionPlan struct {
@@ -636,7 +638,7 @@ type AttrActionPlan struct {
 	Months    string  // semicolon separated list of months this timing is valid on, *any or empty supported
 	MonthDays string  // semicolon separated list of month's days this timing is valid on, *any or empty supported
 	WeekDays  string  // semicolon separated list of week day names this timing is valid on *any or empty supported
-	Time      string  // String representing the time this timing starts on, *asap supported
+	Time      string  // String representing the time this timing starts on, *asap or cron expression supported
 	Weight    float64 // Binding's weight
 }
 
@@ -650,6 +652,9 @@ func (apiv1 *APIerSv1) SetActionPlan(attrs *AttrSetActionPlan, reply *string) (e
 			return fmt.Errorf("%s:Action:%s:%v", utils.ErrMandatoryIeMissing.Error(), at.ActionsId, missing)
 		}
 	}
+	if err = engine.CheckCatchUp(attrs.CatchUp); err != nil {
+		return
+	}
 	_, err = guardian.Guardian.Guard(func() (interface{}, error) {
 		var prevAccountIDs utils.StringMap
 		if prevAP, err := apiv1.DataManager.GetActionPlan(attrs.Id, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
@@ -660,7 +665,8 @@ func (apiv1 *APIerSv1) SetActionPlan(attrs *AttrSetActionPlan, reply *string) (e
 			prevAccountIDs = prevAP.AccountIDs
 		}
 		ap := &engine.ActionPlan{
-			Id: attrs.Id,
+			Id:      attrs.Id,
+			CatchUp: attrs.CatchUp,
 		}
 		for _, apiAtm := range attrs.ActionPlan {
 			if exists, err := apiv1.DataManager.HasData(utils.ACTION_PREFIX, apiAtm.ActionsId, ""); err != nil {
@@ -671,6 +677,11 @@ func (apiv1 *APIerSv1) SetActionPlan(attrs *AttrSetActionPlan, reply *string) (e
 			timing := new(engine.RITiming)
 			if dfltTiming, isDefault := checkDefaultTiming(apiAtm.Time); isDefault {
 				timing = dfltTiming
+			} else if utils.IsCronExpr(apiAtm.Time) {
+				if err := engine.CheckCronExpr(apiAtm.Time); err != nil {
+					return 0, fmt.Errorf("%s:%s", utils.ErrUnsupportedFormat.Error(), apiAtm.Time)
+				}
+				timing.StartTime = apiAtm.Time
 			} else {
 				timing.Years.Parse(apiAtm.Years, ";")
 				timing.Months.Parse(apiAtm.Months, ";")
@@ -970,6 +981,65 @@ func (apiv1 *APIerSv1) LoadTariffPlanFromFolder(attrs *utils.AttrLoadTpFromFolde
 	return nil
 }
 
+// DiffTariffPlanFromFolder will load the tariffplan from folder into TpReader object
+// and will return the differences compared with the database, without writing anything
+func (apiv1 *APIerSv1) DiffTariffPlanFromFolder(attrs *utils.AttrLoadTpFromFolder,
+	reply *map[string]*engine.TPDiff) error {
+	// verify if FolderPath is present
+	if len(attrs.FolderPath) == 0 {
+		return fmt.Errorf("%s:%s", utils.ErrMandatoryIeMissing.Error(), "FolderPath")
+	}
+	// check if exists or is valid
+	if fi, err := os.Stat(attrs.FolderPath); err != nil {
+		if strings.HasSuffix(err.Error(), "no such file or directory") {
+			return utils.ErrInvalidPath
+		}
+		return utils.NewErrServerError(err)
+	} else if !fi.IsDir() {
+		return utils.ErrInvalidPath
+	}
+	loader, err := engine.NewTpReader(apiv1.DataManager.DataDB(),
+		engine.NewFileCSVStorage(utils.CSV_SEP, attrs.FolderPath),
+		"", apiv1.Config.GeneralCfg().DefaultTimezone,
+		apiv1.Config.ApierCfg().CachesConns, apiv1.Config.ApierCfg().SchedulerConns)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if err := loader.LoadAll(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	diffs, err := loader.DiffWithDatabase()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = diffs
+	return nil
+}
+
+// DiffTariffPlanFromStorDb will load the tariffplan from storDb into TpReader object
+// and will return the differences compared with the database, without writing anything
+func (apiv1 *APIerSv1) DiffTariffPlanFromStorDb(attrs *AttrLoadTpFromStorDb,
+	reply *map[string]*engine.TPDiff) error {
+	if len(attrs.TPid) == 0 {
+		return utils.NewErrMandatoryIeMissing("TPid")
+	}
+	dbReader, err := engine.NewTpReader(apiv1.DataManager.DataDB(), apiv1.StorDb,
+		attrs.TPid, apiv1.Config.GeneralCfg().DefaultTimezone,
+		apiv1.Config.ApierCfg().CachesConns, apiv1.Config.ApierCfg().SchedulerConns)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if err := dbReader.LoadAll(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	diffs, err := dbReader.DiffWithDatabase()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = diffs
+	return nil
+}
+
 // RemoveTPFromFolder will load the tarrifplan from folder into TpReader object
 // and will delete if from database
 func (apiv1 *APIerSv1) RemoveTPFromFolder(attrs *utils.AttrLoadTpFromFolder, reply *string) error {
@@ -1408,6 +1478,9 @@ func (apiv1 *APIerSv1) ListenAndServe(stopChan chan struct{}) (err error) {
 			}
 			apiv1.CdrDb = stordb
 			apiv1.StorDb = stordb
+			if apiv1.AuditS != nil {
+				apiv1.AuditS.SetStorDB(stordb)
+			}
 		}
 	}
 }
@@ -0,0 +1,27 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetAuditRecords queries the audit trail of the administrative calls
+func (apierSv1 *APIerSv1) GetAuditRecords(args *utils.AuditRecordsFilter, reply *[]*utils.AuditRecord) error {
+	return apierSv1.AuditS.V1GetAuditRecords(args, reply)
+}
@@ -0,0 +1,51 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/billruns"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewBillRunSv1 initializes BillRunSv1
+func NewBillRunSv1(bS *billruns.BillRunS) *BillRunSv1 {
+	return &BillRunSv1{bS: bS}
+}
+
+// BillRunSv1 exports RPC from BillRunS
+type BillRunSv1 struct {
+	bS *billruns.BillRunS
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (bSv1 *BillRunSv1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(bSv1, serviceMethod, args, reply)
+}
+
+// GenerateInvoices runs the billing of a tenant over a period
+func (bSv1 *BillRunSv1) GenerateInvoices(args *billruns.ArgsBillRun, reply *billruns.BillRun) error {
+	return bSv1.bS.V1GenerateInvoices(args, reply)
+}
+
+// Ping return pong if the service is active
+func (bSv1 *BillRunSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
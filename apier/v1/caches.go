@@ -120,6 +120,11 @@ func (chSv1 *CacheSv1) ReplicateRemove(args *utils.ArgCacheReplicateRemove, repl
 	return chSv1.cacheS.V1ReplicateRemove(args, reply)
 }
 
+// GetCacheChunk exports one chunk of a partition so a peer can warm up its cache
+func (chSv1 *CacheSv1) GetCacheChunk(args *utils.ArgsGetCacheChunk, reply *utils.CacheChunk) (err error) {
+	return chSv1.cacheS.V1GetCacheChunk(args, reply)
+}
+
 // Call implements rpcclient.ClientConnector interface for internal RPC
 func (chSv1 *CacheSv1) Call(serviceMethod string,
 	args interface{}, reply interface{}) error {
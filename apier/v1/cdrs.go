@@ -109,6 +109,26 @@ func (cdrSv1 *CDRsV1) RateCDRs(arg *engine.ArgRateCDRs, reply *string) error {
 	return cdrSv1.CDRs.V1RateCDRs(arg, reply)
 }
 
+// RateCDRsReport re-rates the CDRs with the current tariffs and reports the cost differences
+func (cdrSv1 *CDRsV1) RateCDRsReport(arg *engine.ArgRateCDRsReport, reply *engine.RateCDRsReport) error {
+	return cdrSv1.CDRs.V1RateCDRsReport(arg, reply)
+}
+
+// GetDedupStats returns the counters of the duplicate CDRs detected
+func (cdrSv1 *CDRsV1) GetDedupStats(args *utils.TenantWithArgDispatcher, reply *engine.CDRsDedupStats) error {
+	return cdrSv1.CDRs.V1GetDedupStats(args, reply)
+}
+
+// ArchiveCDRs moves the old CDRs out of StorDB based on the archive rules
+func (cdrSv1 *CDRsV1) ArchiveCDRs(args *utils.TenantWithArgDispatcher, reply *[]*engine.CDRsArchive) error {
+	return cdrSv1.CDRs.V1ArchiveCDRs(args, reply)
+}
+
+// GetCDRsReport returns the CDRs aggregated by the requested fields
+func (cdrSv1 *CDRsV1) GetCDRsReport(args *engine.ArgCDRsReport, reply *[]*engine.CDRsReportGroup) error {
+	return cdrSv1.CDRs.V1GetCDRsReport(args, reply)
+}
+
 // StoreSMCost will store
 func (cdrSv1 *CDRsV1) StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error {
 	return cdrSv1.CDRs.V1StoreSessionCost(attr, reply)
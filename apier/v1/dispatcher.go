@@ -24,6 +24,7 @@ import (
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/dispatchers"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/scheduler"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
@@ -657,6 +658,11 @@ func (dS *DispatcherCacheSv1) ReplicateRemove(args *utils.ArgCacheReplicateRemov
 	return dS.dS.CacheSv1ReplicateRemove(args, reply)
 }
 
+// GetCacheChunk exports one chunk of a partition so a peer can warm up its cache
+func (dS *DispatcherCacheSv1) GetCacheChunk(args *utils.ArgsGetCacheChunk, reply *utils.CacheChunk) (err error) {
+	return dS.dS.CacheSv1GetCacheChunk(args, reply)
+}
+
 // Ping used to determinate if component is active
 func (dS *DispatcherCacheSv1) Ping(args *utils.CGREventWithArgDispatcher, reply *string) error {
 	return dS.dS.CacheSv1Ping(args, reply)
@@ -715,6 +721,11 @@ func (dS *DispatcherSchedulerSv1) ExecuteActionPlans(args *utils.AttrsExecuteAct
 	return dS.dS.SchedulerSv1ExecuteActionPlans(args, reply)
 }
 
+// GetLeaderStatus returns the status of the scheduler leader election
+func (dS *DispatcherSchedulerSv1) GetLeaderStatus(args *utils.TenantWithArgDispatcher, reply *scheduler.LeaderStatus) (err error) {
+	return dS.dS.SchedulerSv1GetLeaderStatus(args, reply)
+}
+
 func NewDispatcherSv1(dS *dispatchers.DispatcherService) *DispatcherSv1 {
 	return &DispatcherSv1{dS: dS}
 }
@@ -763,6 +774,22 @@ func (dS *DispatcherSCDRsV1) RateCDRs(args *engine.ArgRateCDRs, reply *string) e
 	return dS.dS.CDRsV1RateCDRs(args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) RateCDRsReport(args *engine.ArgRateCDRsReport, reply *engine.RateCDRsReport) error {
+	return dS.dS.CDRsV1RateCDRsReport(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetDedupStats(args *utils.TenantWithArgDispatcher, reply *engine.CDRsDedupStats) error {
+	return dS.dS.CDRsV1GetDedupStats(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) ArchiveCDRs(args *utils.TenantWithArgDispatcher, reply *[]*engine.CDRsArchive) error {
+	return dS.dS.CDRsV1ArchiveCDRs(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetCDRsReport(args *engine.ArgCDRsReport, reply *[]*engine.CDRsReportGroup) error {
+	return dS.dS.CDRsV1GetCDRsReport(args, reply)
+}
+
 func (dS *DispatcherSCDRsV1) ProcessExternalCDR(args *engine.ExternalCDRWithArgDispatcher, reply *string) error {
 	return dS.dS.CDRsV1ProcessExternalCDR(args, reply)
 }
@@ -993,6 +1020,11 @@ func (dS *DispatcherReplicatorSv1) GetDispatcherHost(tntID *utils.TenantIDWithAr
 	return dS.dS.ReplicatorSv1GetDispatcherHost(tntID, reply)
 }
 
+// GetLookupTableValue
+func (dS *DispatcherReplicatorSv1) GetLookupTableValue(args *engine.LookupTableValueArgs, reply *string) error {
+	return dS.dS.ReplicatorSv1GetLookupTableValue(args, reply)
+}
+
 // GetItemLoadIDs
 func (dS *DispatcherReplicatorSv1) GetItemLoadIDs(itemID *utils.StringWithApiKey, reply *map[string]int64) error {
 	return dS.dS.ReplicatorSv1GetItemLoadIDs(itemID, reply)
@@ -1120,6 +1152,11 @@ func (dS *DispatcherReplicatorSv1) SetDispatcherHost(args *engine.DispatcherHost
 	return dS.dS.ReplicatorSv1SetDispatcherHost(args, reply)
 }
 
+// SetLookupTable
+func (dS *DispatcherReplicatorSv1) SetLookupTable(args *engine.LookupTableWithArgDispatcher, reply *string) error {
+	return dS.dS.ReplicatorSv1SetLookupTable(args, reply)
+}
+
 // RemoveThreshold
 func (dS *DispatcherReplicatorSv1) RemoveThreshold(args *utils.TenantIDWithArgDispatcher, reply *string) error {
 	return dS.dS.ReplicatorSv1RemoveThreshold(args, reply)
@@ -1235,6 +1272,11 @@ func (dS *DispatcherReplicatorSv1) RemoveDispatcherHost(args *utils.TenantIDWith
 	return dS.dS.ReplicatorSv1RemoveDispatcherHost(args, reply)
 }
 
+// RemoveLookupTable
+func (dS *DispatcherReplicatorSv1) RemoveLookupTable(args *utils.TenantIDWithArgDispatcher, reply *string) error {
+	return dS.dS.ReplicatorSv1RemoveLookupTable(args, reply)
+}
+
 // RemoveRateProfile
 func (dS *DispatcherReplicatorSv1) RemoveRateProfile(args *utils.TenantIDWithArgDispatcher, reply *string) error {
 	return dS.dS.ReplicatorSv1RemoveRateProfile(args, reply)
@@ -1255,6 +1297,21 @@ func (dS *DispatcherReplicatorSv1) RemoveIndexes(args *utils.GetIndexesArg, repl
 	return dS.dS.ReplicatorSv1RemoveIndexes(args, reply)
 }
 
+// GetChecksums .
+func (dS *DispatcherReplicatorSv1) GetChecksums(args *utils.StringWithApiKey, reply *map[string]string) error {
+	return dS.dS.ReplicatorSv1GetChecksums(args, reply)
+}
+
+// Resync .
+func (dS *DispatcherReplicatorSv1) Resync(args *engine.ArgsReplicationResync, reply *map[string]map[string]*engine.ReplicationDiff) error {
+	return dS.dS.ReplicatorSv1Resync(args, reply)
+}
+
+// GetReplicationStats .
+func (dS *DispatcherReplicatorSv1) GetReplicationStats(args *utils.TenantWithArgDispatcher, reply *engine.ReplicationStats) error {
+	return dS.dS.ReplicatorSv1GetReplicationStats(args, reply)
+}
+
 func NewDispatcherRateSv1(dps *dispatchers.DispatcherService) *DispatcherRateSv1 {
 	return &DispatcherRateSv1{dR: dps}
 }
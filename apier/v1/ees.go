@@ -41,3 +41,21 @@ func (eSv1 *EventExporterSv1) ProcessEvent(args *utils.CGREventWithOpts,
 	reply *string) error {
 	return eSv1.eeS.V1ProcessEvent(args, reply)
 }
+
+// GetFailedExports returns the exports which failed all the retries
+func (eSv1 *EventExporterSv1) GetFailedExports(args *ees.ArgsFailedExports,
+	reply *[]*ees.FailedExport) error {
+	return eSv1.eeS.V1GetFailedExports(args, reply)
+}
+
+// ReplayFailedExports exports again the failed exports
+func (eSv1 *EventExporterSv1) ReplayFailedExports(args *ees.ArgsFailedExports,
+	reply *string) error {
+	return eSv1.eeS.V1ReplayFailedExports(args, reply)
+}
+
+// PurgeFailedExports removes the failed exports without exporting them
+func (eSv1 *EventExporterSv1) PurgeFailedExports(args *ees.ArgsFailedExports,
+	reply *string) error {
+	return eSv1.eeS.V1PurgeFailedExports(args, reply)
+}
@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LookupTableWithCache is used to set the entries of a LookupTable
+type LookupTableWithCache struct {
+	*engine.LookupTable
+	Replace bool // remove the entries which are not part of the LookupTable
+	Cache   *string
+}
+
+// SetLookupTable merges the entries into the LookupTable or replaces them if Replace is set
+func (apiv1 *APIerSv1) SetLookupTable(args *LookupTableWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(args.LookupTable, []string{utils.Tenant, utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apiv1.DataManager.SetLookupTable(args.LookupTable, args.Replace); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apiv1.callCacheLookupTable(GetCacheOpt(args.Cache), args.TenantID()); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// GetLookupTableValue returns the value of a key (or of its longest prefix) within the LookupTable
+func (apiv1 *APIerSv1) GetLookupTableValue(args *engine.LookupTableValueArgs, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.Tenant, utils.ID, utils.Key}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	getValue := apiv1.DataManager.GetLookupTableValue
+	if args.Prefix {
+		getValue = apiv1.DataManager.GetLookupTablePrefixValue
+	}
+	var value string
+	if value, err = getValue(args.Tenant, args.ID, args.Key,
+		true, true, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = value
+	return
+}
+
+// RemoveLookupTable removes all the entries of the LookupTable
+func (apiv1 *APIerSv1) RemoveLookupTable(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Tenant, utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apiv1.DataManager.RemoveLookupTable(arg.Tenant, arg.ID); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apiv1.callCacheLookupTable(GetCacheOpt(arg.Cache), arg.TenantID()); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// callCacheLookupTable drops the cached entries of the LookupTable
+// since they are cached individually they cannot be reloaded
+func (apiv1 *APIerSv1) callCacheLookupTable(cacheOpt, tntID string) (err error) {
+	var reply string
+	switch cacheOpt {
+	case utils.META_NONE:
+		return
+	case utils.MetaClear:
+		return apiv1.ConnMgr.Call(apiv1.Config.ApierCfg().CachesConns, nil,
+			utils.CacheSv1Clear, &utils.AttrCacheIDsWithArgDispatcher{
+				CacheIDs: []string{utils.CacheLookupTables},
+			}, &reply)
+	default:
+		return apiv1.ConnMgr.Call(apiv1.Config.ApierCfg().CachesConns, nil,
+			utils.CacheSv1RemoveGroup, &utils.ArgsGetGroupWithArgDispatcher{
+				ArgsGetGroup: utils.ArgsGetGroup{
+					CacheID: utils.CacheLookupTables,
+					GroupID: tntID,
+				},
+			}, &reply)
+	}
+}
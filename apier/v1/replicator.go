@@ -277,6 +277,11 @@ func (rplSv1 *ReplicatorSv1) GetDispatcherHost(tntID *utils.TenantIDWithArgDispa
 	return nil
 }
 
+func (rplSv1 *ReplicatorSv1) GetLookupTableValue(args *engine.LookupTableValueArgs, reply *string) (err error) {
+	*reply, err = rplSv1.dm.DataDB().GetLookupTableValueDrv(args.Tenant, args.ID, args.Key)
+	return
+}
+
 func (rplSv1 *ReplicatorSv1) GetRateProfile(tntID *utils.TenantIDWithArgDispatcher, reply *engine.RateProfile) error {
 	if rcv, err := rplSv1.dm.DataDB().GetRateProfileDrv(tntID.Tenant, tntID.ID); err != nil {
 		return err
@@ -503,6 +508,14 @@ func (rplSv1 *ReplicatorSv1) SetDispatcherHost(dpp *engine.DispatcherHostWithArg
 	return nil
 }
 
+func (rplSv1 *ReplicatorSv1) SetLookupTable(lt *engine.LookupTableWithArgDispatcher, reply *string) error {
+	if err := rplSv1.dm.DataDB().SetLookupTableDrv(lt.LookupTable, lt.Replace); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
 func (rplSv1 *ReplicatorSv1) SetRateProfile(dpp *engine.RateProfileWithArgDispatcher, reply *string) error {
 	if err := rplSv1.dm.DataDB().SetRateProfileDrv(dpp.RateProfile); err != nil {
 		return err
@@ -714,6 +727,14 @@ func (rplSv1 *ReplicatorSv1) RemoveDispatcherHost(args *utils.TenantIDWithArgDis
 	return nil
 }
 
+func (rplSv1 *ReplicatorSv1) RemoveLookupTable(args *utils.TenantIDWithArgDispatcher, reply *string) error {
+	if err := rplSv1.dm.DataDB().RemoveLookupTableDrv(args.Tenant, args.ID); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
 func (rplSv1 *ReplicatorSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -746,3 +767,29 @@ func (rplSv1 *ReplicatorSv1) RemoveIndexes(args *utils.GetIndexesArg, reply *str
 	*reply = utils.OK
 	return nil
 }
+
+// GetChecksums returns the checksums of the items of one type, compared by Resync
+func (rplSv1 *ReplicatorSv1) GetChecksums(args *utils.StringWithApiKey, reply *map[string]string) error {
+	sums, err := rplSv1.dm.GetReplicationChecksums(args.Arg)
+	if err != nil {
+		return err
+	}
+	*reply = sums
+	return nil
+}
+
+// Resync compares the items with the replication_conns and repairs the differences
+func (rplSv1 *ReplicatorSv1) Resync(args *engine.ArgsReplicationResync, reply *map[string]map[string]*engine.ReplicationDiff) error {
+	report, err := rplSv1.dm.ResyncReplication(args)
+	if err != nil {
+		return err
+	}
+	*reply = report
+	return nil
+}
+
+// GetReplicationStats returns the replication metrics
+func (rplSv1 *ReplicatorSv1) GetReplicationStats(args *utils.TenantWithArgDispatcher, reply *engine.ReplicationStats) error {
+	*reply = *rplSv1.dm.GetReplicationStats()
+	return nil
+}
@@ -21,6 +21,7 @@ package v1
 import (
 	"errors"
 
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/scheduler"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -108,3 +109,20 @@ func (self *APIerSv1) GetScheduledActions(args *scheduler.ArgsGetScheduledAction
 	*reply = rpl
 	return nil
 }
+
+// GetActionExecutions queries the execution history of the ActionPlans, the most recent first
+func (self *APIerSv1) GetActionExecutions(args *utils.ActionExecutionsFilter, reply *[]*utils.ActionExecution) (err error) {
+	execStorage, canCast := self.StorDb.(engine.ActionExecutionStorage)
+	if !canCast {
+		return utils.ErrNotImplemented
+	}
+	var execs []*utils.ActionExecution
+	if execs, err = execStorage.GetActionExecutions(args); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = execs
+	return
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/scheduler"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -169,6 +170,16 @@ func (schdSv1 *SchedulerSv1) ExecuteActionPlans(attr *utils.AttrsExecuteActionPl
 	return nil
 }
 
+// GetLeaderStatus returns the status of the leader election, including the current leader
+func (schdSv1 *SchedulerSv1) GetLeaderStatus(args *utils.TenantWithArgDispatcher, reply *scheduler.LeaderStatus) (err error) {
+	ls, err := scheduler.GetLeaderStatus(schdSv1.cgrcfg, schdSv1.dm)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = *ls
+	return
+}
+
 // Ping returns Pong
 func (schdSv1 *SchedulerSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
 	*reply = utils.Pong
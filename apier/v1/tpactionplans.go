@@ -21,6 +21,7 @@ package v1
 import (
 	"fmt"
 
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -35,6 +36,9 @@ func (apiv1 *APIerSv1) SetTPActionPlan(attrs *utils.TPActionPlan, reply *string)
 			return fmt.Errorf("%s:Action:%s:%v", utils.ErrMandatoryIeMissing.Error(), at.ActionsId, missing)
 		}
 	}
+	if err := engine.CheckCatchUp(attrs.CatchUp); err != nil {
+		return err
+	}
 	if err := apiv1.StorDb.SetTPActionPlans([]*utils.TPActionPlan{attrs}); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -0,0 +1,299 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package billruns
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// cdrsPageSize is the number of CDRs queried at once from CDRs
+const cdrsPageSize = 1000
+
+// NewBillRunS instantiates the BillRunS
+func NewBillRunS(cfg *config.CGRConfig, dm *engine.DataManager,
+	connMgr *engine.ConnManager) *BillRunS {
+	return &BillRunS{
+		cfg:     cfg,
+		dm:      dm,
+		connMgr: connMgr,
+	}
+}
+
+// BillRunS generates the invoices out of the rated CDRs and the recurring charges of the accounts
+type BillRunS struct {
+	cfg     *config.CGRConfig
+	dm      *engine.DataManager
+	connMgr *engine.ConnManager
+}
+
+// ListenAndServe keeps the service alive
+func (bS *BillRunS) ListenAndServe(exitChan chan bool, cfgRld chan struct{}) (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s>",
+		utils.CoreS, utils.BillRunS))
+	for {
+		select {
+		case e := <-exitChan: // global exit
+			bS.Shutdown()
+			exitChan <- e // put back for the others listening for shutdown request
+			return
+		case rld := <-cfgRld: // configuration was reloaded
+			cfgRld <- rld
+		}
+	}
+}
+
+// Shutdown is called to shutdown the service
+func (bS *BillRunS) Shutdown() (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown <%s>", utils.CoreS, utils.BillRunS))
+	return
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (bS *BillRunS) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(bS, serviceMethod, args, reply)
+}
+
+// processCDRs pages through the rated CDRs of the billing period, passing each page CDR to process
+// so only one page is kept in memory
+func (bS *BillRunS) processCDRs(args *ArgsBillRun, process func(*engine.CDR)) (err error) {
+	fltr := &utils.RPCCDRsFilter{
+		Tenants:         []string{args.Tenant},
+		Accounts:        args.Accounts,
+		AnswerTimeStart: args.StartTime,
+		AnswerTimeEnd:   args.EndTime,
+		OrderBy:         utils.OrderID,
+	}
+	if runIDs := bS.cfg.BillRunSCfg().RunIDs; len(runIDs) != 0 {
+		fltr.RunIDs = runIDs // only the configured charger runs are billed so the same usage is not summed twice
+	} else {
+		fltr.NotRunIDs = []string{utils.MetaRaw}
+	}
+	for offset := 0; ; offset += cdrsPageSize {
+		fltr.Paginator = utils.Paginator{
+			Limit:  utils.IntPointer(cdrsPageSize),
+			Offset: utils.IntPointer(offset),
+		}
+		var page []*engine.CDR
+		if err = bS.connMgr.Call(bS.cfg.BillRunSCfg().CDRsConns, nil,
+			utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{
+				RPCCDRsFilter: fltr,
+				ArgDispatcher: args.ArgDispatcher,
+				TenantArg:     &utils.TenantArg{Tenant: args.Tenant},
+			}, &page); err != nil {
+			if isNotFound(err) {
+				err = nil
+				return
+			}
+			return
+		}
+		for _, cdr := range page {
+			process(cdr)
+		}
+		if len(page) < cdrsPageSize {
+			return
+		}
+	}
+}
+
+// accountIDs returns the accounts of the tenant defined in DataDB
+func (bS *BillRunS) accountIDs(tnt string) (acnts []string, err error) {
+	var keys []string
+	if keys, err = bS.dm.DataDB().GetKeysForPrefix(
+		utils.ACCOUNT_PREFIX + tnt + utils.CONCATENATED_KEY_SEP); err != nil {
+		return
+	}
+	acnts = make([]string, len(keys))
+	for i, key := range keys {
+		acnts[i] = strings.TrimPrefix(key, utils.ACCOUNT_PREFIX+tnt+utils.CONCATENATED_KEY_SEP)
+	}
+	return
+}
+
+// recurringCharges returns the monetary debits scheduled for the account in the [sTime, eTime) interval
+func (bS *BillRunS) recurringCharges(acntID string, sTime, eTime time.Time) (rcs []*RecurringCharge, err error) {
+	var apIDs []string
+	if apIDs, err = bS.dm.GetAccountActionPlans(acntID,
+		false, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	roundDec := bS.cfg.GeneralCfg().RoundingDecimals
+	for _, apID := range apIDs {
+		var ap *engine.ActionPlan
+		if ap, err = bS.dm.GetActionPlan(apID,
+			false, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		for _, at := range ap.ActionTimings {
+			if at.Timing == nil || at.Timing.Timing == nil || at.IsASAP() {
+				continue
+			}
+			var acts engine.Actions
+			if acts, err = bS.dm.GetActions(at.ActionsID,
+				false, utils.NonTransactional); err != nil {
+				if err == utils.ErrNotFound {
+					err = nil
+					continue
+				}
+				return
+			}
+			amount := recurringAmount(acts)
+			if amount == 0 {
+				continue
+			}
+			occurs := occurrences(at, sTime, eTime)
+			if occurs == 0 {
+				continue
+			}
+			rcs = append(rcs, &RecurringCharge{
+				ActionPlanID: apID,
+				ActionsID:    at.ActionsID,
+				Occurrences:  occurs,
+				Amount:       amount,
+				Cost:         utils.Round(amount*float64(occurs), roundDec, utils.ROUNDING_MIDDLE),
+			})
+		}
+	}
+	return
+}
+
+// writeInvoices writes the invoices in the export path using the requested formats
+func (bS *BillRunS) writeInvoices(br *BillRun, formats []string) (err error) {
+	var tmpl *template.Template
+	for _, frmt := range formats {
+		if frmt == utils.MetaHTML { // parsed once for all the invoices of the bill run
+			if tmpl, err = bS.htmlTemplate(); err != nil {
+				return
+			}
+			break
+		}
+	}
+	for _, inv := range br.Invoices {
+		for _, frmt := range formats {
+			wrtr := invoiceWriters[frmt]
+			fPath := path.Join(bS.cfg.BillRunSCfg().ExportPath,
+				fmt.Sprintf("%s_%s_%s%s", br.ID, inv.Tenant, inv.Account, wrtr.suffix))
+			var fl *os.File
+			if fl, err = os.Create(fPath); err != nil {
+				return
+			}
+			err = wrtr.write(bS, tmpl, fl, inv)
+			if errClose := fl.Close(); err == nil {
+				err = errClose
+			}
+			if err != nil {
+				return
+			}
+			br.Files = append(br.Files, fPath)
+		}
+	}
+	return
+}
+
+// V1GenerateInvoices runs the billing for a tenant over a period, returning the invoices per account
+func (bS *BillRunS) V1GenerateInvoices(args *ArgsBillRun, reply *BillRun) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.StartTime, utils.EndTime}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = bS.cfg.GeneralCfg().DefaultTenant
+	}
+	for _, frmt := range args.Formats {
+		if _, has := invoiceWriters[frmt]; !has {
+			return utils.NewErrServerError(fmt.Errorf("unsupported invoice format: <%s>", frmt))
+		}
+	}
+	br := &BillRun{
+		ID:     utils.GenUUID(),
+		Tenant: tnt,
+	}
+	if br.StartTime, err = utils.ParseTimeDetectLayout(args.StartTime,
+		bS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if br.EndTime, err = utils.ParseTimeDetectLayout(args.EndTime,
+		bS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if !br.StartTime.Before(br.EndTime) {
+		return utils.NewErrServerError(fmt.Errorf("StartTime: %s is not before EndTime: %s",
+			args.StartTime, args.EndTime))
+	}
+	args.Tenant = tnt
+	invoices := make(map[string]*Invoice)
+	acnts := args.Accounts
+	if len(acnts) == 0 {
+		if acnts, err = bS.accountIDs(tnt); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	for _, acnt := range acnts {
+		invoices[acnt] = newInvoice(br, acnt)
+	}
+	if err = bS.processCDRs(args, func(cdr *engine.CDR) {
+		inv, has := invoices[cdr.Account]
+		if !has {
+			inv = newInvoice(br, cdr.Account)
+			invoices[cdr.Account] = inv
+		}
+		inv.addCDR(cdr)
+	}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	roundDec := bS.cfg.GeneralCfg().RoundingDecimals
+	for acnt, inv := range invoices {
+		if inv.RecurringCharges, err = bS.recurringCharges(
+			utils.ConcatenatedKey(tnt, acnt), br.StartTime, br.EndTime); err != nil {
+			return utils.NewErrServerError(err)
+		}
+		inv.finalize(roundDec)
+		br.Invoices = append(br.Invoices, inv)
+	}
+	sort.Slice(br.Invoices, func(i, j int) bool {
+		return br.Invoices[i].Account < br.Invoices[j].Account
+	})
+	if err = bS.writeInvoices(br, args.Formats); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = *br
+	return
+}
+
+// isNotFound checks for the NOT_FOUND error, also when wrapped as server error by the remote
+func isNotFound(err error) bool {
+	return err.Error() == utils.ErrNotFound.Error() ||
+		err.Error() == utils.NewErrServerError(utils.ErrNotFound).Error()
+}
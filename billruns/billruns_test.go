@@ -0,0 +1,250 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package billruns
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// testMockCDRs serves the CDRs out of a slice
+type testMockCDRs struct {
+	cdrs []*engine.CDR
+}
+
+func (mCDRs *testMockCDRs) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.CDRsV1GetCDRs {
+		return utils.ErrNotImplemented
+	}
+	fltr := args.(*utils.RPCCDRsFilterWithArgDispatcher).RPCCDRsFilter
+	var cdrs []*engine.CDR
+	for _, cdr := range mCDRs.cdrs {
+		if len(fltr.Accounts) != 0 && !utils.IsSliceMember(fltr.Accounts, cdr.Account) ||
+			len(fltr.RunIDs) != 0 && !utils.IsSliceMember(fltr.RunIDs, cdr.RunID) {
+			continue
+		}
+		cdrs = append(cdrs, cdr)
+	}
+	if *fltr.Offset >= len(cdrs) {
+		return utils.NewErrServerError(utils.ErrNotFound)
+	}
+	cdrs = cdrs[*fltr.Offset:]
+	if len(cdrs) > *fltr.Limit {
+		cdrs = cdrs[:*fltr.Limit]
+	}
+	*reply.(*[]*engine.CDR) = cdrs
+	return nil
+}
+
+func testBillRunCDR(acnt, tor string, usage time.Duration, cost float64, dstID string) (cdr *engine.CDR) {
+	cdr = &engine.CDR{
+		CGRID:    utils.GenUUID(),
+		RunID:    utils.MetaDefault,
+		ToR:      tor,
+		Tenant:   "cgrates.org",
+		Category: "call",
+		Account:  acnt,
+		Subject:  acnt,
+		Usage:    usage,
+		Cost:     cost,
+	}
+	if dstID != utils.EmptyString {
+		cdr.CostDetails = &engine.EventCost{
+			Charges: []*engine.ChargingInterval{{RatingID: "RT1"}},
+			Rating:  engine.Rating{"RT1": &engine.RatingUnit{RatingFiltersID: "RF1"}},
+			RatingFilters: engine.RatingFilters{
+				"RF1": engine.RatingMatchedFilters{utils.DestinationID: dstID}},
+		}
+	}
+	return
+}
+
+func TestBillRunSV1GenerateInvoices(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.BillRunSCfg().CDRsConns = []string{"*billRunCDRs"}
+	exportPath, err := ioutil.TempDir(utils.EmptyString, "billruns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(exportPath)
+	cfg.BillRunSCfg().ExportPath = exportPath
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	for _, acnt := range []string{"cgrates.org:1001", "cgrates.org:1003"} {
+		if err := dm.SetAccount(&engine.Account{ID: acnt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dm.SetActions("ACT_FEE", engine.Actions{
+		{Id: "ACT_FEE", ActionType: utils.DEBIT, Balance: &engine.BalanceFilter{
+			Type: utils.StringPointer(utils.MONETARY), Value: &utils.ValueFormula{Static: 5}}},
+		{Id: "ACT_FEE", ActionType: utils.TOPUP, Balance: &engine.BalanceFilter{
+			Type: utils.StringPointer(utils.VOICE), Value: &utils.ValueFormula{Static: 60}}},
+	}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetActionPlan("AP_MONTHLY", &engine.ActionPlan{
+		Id:         "AP_MONTHLY",
+		AccountIDs: utils.StringMap{"cgrates.org:1003": true},
+		ActionTimings: []*engine.ActionTiming{{
+			Uuid: "AT_MONTHLY",
+			Timing: &engine.RateInterval{Timing: &engine.RITiming{
+				Months:    utils.Months{},
+				MonthDays: utils.MonthDays{1},
+				StartTime: "00:00:00",
+			}},
+			ActionsID: "ACT_FEE",
+		}},
+	}, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetAccountActionPlans("cgrates.org:1003", []string{"AP_MONTHLY"}, true); err != nil {
+		t.Fatal(err)
+	}
+	supplierCDR := testBillRunCDR("1001", utils.VOICE, 2*time.Minute, 0.8, "DST_FR")
+	supplierCDR.RunID = "*supplier_cost" // not billed out of the default run_ids
+	cdrsChan := make(chan rpcclient.ClientConnector, 1)
+	cdrsChan <- &testMockCDRs{cdrs: []*engine.CDR{
+		testBillRunCDR("1001", utils.VOICE, time.Minute, 0.6, "DST_FR"),
+		testBillRunCDR("1001", utils.VOICE, 2*time.Minute, 1.2, "DST_FR"),
+		testBillRunCDR("1001", utils.VOICE, time.Minute, 0.1, "DST_DE"),
+		testBillRunCDR("1001", utils.VOICE, time.Minute, -1, utils.EmptyString),
+		testBillRunCDR("1002", utils.SMS, 1, 0.05, utils.EmptyString),
+		supplierCDR,
+	}}
+	bS := NewBillRunS(cfg, dm, engine.NewConnManager(cfg,
+		map[string]chan rpcclient.ClientConnector{"*billRunCDRs": cdrsChan}))
+
+	var br BillRun
+	if err := bS.V1GenerateInvoices(&ArgsBillRun{StartTime: "2020-07-01T00:00:00Z"}, &br); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.EndTime).Error() {
+		t.Errorf("expecting mandatory error, received: %v", err)
+	}
+	if err := bS.V1GenerateInvoices(&ArgsBillRun{
+		StartTime: "2020-07-01T00:00:00Z",
+		EndTime:   "2020-09-01T00:00:00Z",
+		Formats:   []string{utils.MetaJSON, utils.MetaCSV, utils.MetaHTML},
+	}, &br); err != nil {
+		t.Fatal(err)
+	}
+	if br.Tenant != "cgrates.org" {
+		t.Errorf("unexpected tenant: %q", br.Tenant)
+	}
+	eInvs := []*Invoice{
+		{
+			Account: "1001",
+			UsageCharges: []*UsageCharge{
+				{DestinationID: "DST_DE", Category: "call", ToR: utils.VOICE, Subject: "1001",
+					CDRs: 1, Usage: time.Minute, Cost: 0.1},
+				{DestinationID: "DST_FR", Category: "call", ToR: utils.VOICE, Subject: "1001",
+					CDRs: 2, Usage: 3 * time.Minute, Cost: 1.8},
+			},
+			CDRs:        3,
+			UnratedCDRs: 1,
+			UsageCost:   1.9,
+			Total:       1.9,
+		},
+		{
+			Account: "1002",
+			UsageCharges: []*UsageCharge{
+				{DestinationID: utils.META_NONE, Category: "call", ToR: utils.SMS, Subject: "1002",
+					CDRs: 1, Usage: 1, Cost: 0.05},
+			},
+			CDRs:      1,
+			UsageCost: 0.05,
+			Total:     0.05,
+		},
+		{
+			Account: "1003",
+			RecurringCharges: []*RecurringCharge{
+				{ActionPlanID: "AP_MONTHLY", ActionsID: "ACT_FEE", Occurrences: 2, Amount: 5, Cost: 10},
+			},
+			RecurringCost: 10,
+			Total:         10,
+		},
+	}
+	for _, inv := range eInvs {
+		inv.ID = utils.ConcatenatedKey(br.ID, inv.Account)
+		inv.BillRunID = br.ID
+		inv.Tenant = "cgrates.org"
+		inv.StartTime = time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+		inv.EndTime = time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if !reflect.DeepEqual(eInvs, br.Invoices) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eInvs), utils.ToJSON(br.Invoices))
+	}
+	if len(br.Files) != 9 {
+		t.Fatalf("expecting 9 files, received: %v", br.Files)
+	}
+	for _, fPath := range br.Files {
+		if _, err := os.Stat(fPath); err != nil {
+			t.Error(err)
+		}
+	}
+	eCSV := `Tenant,Account,Type,ID,Category,ToR,Subject,Count,Usage,Cost
+cgrates.org,1001,*usage,DST_DE,call,*voice,1001,1,1m0s,0.10000
+cgrates.org,1001,*usage,DST_FR,call,*voice,1001,2,3m0s,1.80000
+cgrates.org,1001,*total,` + br.ID + `:1001,,,,3,,1.90000
+`
+	if rcv, err := ioutil.ReadFile(path.Join(exportPath, br.ID+"_cgrates.org_1001.csv")); err != nil {
+		t.Error(err)
+	} else if string(rcv) != eCSV {
+		t.Errorf("expecting: %s, received: %s", eCSV, string(rcv))
+	}
+
+	if err := bS.V1GenerateInvoices(&ArgsBillRun{
+		StartTime: "2020-07-01T00:00:00Z",
+		EndTime:   "2020-09-01T00:00:00Z",
+		Formats:   []string{"*pdf"},
+	}, &br); err == nil || err.Error() != "SERVER_ERROR: unsupported invoice format: <*pdf>" {
+		t.Errorf("expecting unsupported format error, received: %v", err)
+	}
+}
+
+func TestBillRunsOccurrences(t *testing.T) {
+	at := &engine.ActionTiming{
+		Timing: &engine.RateInterval{Timing: &engine.RITiming{
+			WeekDays:  utils.WeekDays{time.Monday},
+			StartTime: "10:00:00",
+		}},
+	}
+	// Mondays of July 2020: 6, 13, 20, 27
+	if rcv := occurrences(at, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)); rcv != 4 {
+		t.Errorf("expecting 4 occurrences, received: %d", rcv)
+	}
+	// the start of the period is inclusive, the end exclusive
+	if rcv := occurrences(at, time.Date(2020, 7, 6, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 13, 10, 0, 0, 0, time.UTC)); rcv != 1 {
+		t.Errorf("expecting 1 occurrence, received: %d", rcv)
+	}
+	if rcv := occurrences(&engine.ActionTiming{}, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)); rcv != 0 {
+		t.Errorf("expecting no occurrence, received: %d", rcv)
+	}
+}
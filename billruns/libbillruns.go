@@ -0,0 +1,201 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package billruns
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgsBillRun are the arguments used to generate the invoices of a tenant
+type ArgsBillRun struct {
+	Tenant    string
+	Accounts  []string // limit the bill-run to these accounts, all the accounts of the tenant if empty
+	StartTime string   // start of the billing period, inclusive
+	EndTime   string   // end of the billing period, exclusive
+	Formats   []string // write the invoices in the export path using these formats <*json|*csv|*html>
+	*utils.ArgDispatcher
+}
+
+// BillRun is the result of a bill-run
+type BillRun struct {
+	ID        string
+	Tenant    string
+	StartTime time.Time
+	EndTime   time.Time
+	Invoices  []*Invoice
+	Files     []string // invoice documents written in the export path
+}
+
+// Invoice groups the charges of one account over the billing period
+type Invoice struct {
+	ID               string
+	BillRunID        string
+	Tenant           string
+	Account          string
+	StartTime        time.Time
+	EndTime          time.Time
+	UsageCharges     []*UsageCharge
+	RecurringCharges []*RecurringCharge
+	CDRs             int // number of CDRs billed
+	UnratedCDRs      int // CDRs ignored since they were not rated
+	UsageCost        float64
+	RecurringCost    float64
+	Total            float64
+}
+
+// UsageCharge aggregates the CDRs sharing the same destination group, category, ToR and subject
+type UsageCharge struct {
+	DestinationID string
+	Category      string
+	ToR           string
+	Subject       string
+	CDRs          int
+	Usage         time.Duration
+	Cost          float64
+}
+
+// RecurringCharge is a monetary debit scheduled by an ActionPlan of the account
+type RecurringCharge struct {
+	ActionPlanID string
+	ActionsID    string
+	Occurrences  int     // number of executions inside the billing period
+	Amount       float64 // amount debited on each execution
+	Cost         float64
+}
+
+// newInvoice builds an empty invoice for the account
+func newInvoice(br *BillRun, acnt string) *Invoice {
+	return &Invoice{
+		ID:        utils.ConcatenatedKey(br.ID, acnt),
+		BillRunID: br.ID,
+		Tenant:    br.Tenant,
+		Account:   acnt,
+		StartTime: br.StartTime,
+		EndTime:   br.EndTime,
+	}
+}
+
+// addCDR adds the CDR cost to the matching usage charge
+func (inv *Invoice) addCDR(cdr *engine.CDR) {
+	if cdr.Cost < 0 { // not rated
+		inv.UnratedCDRs++
+		return
+	}
+	inv.CDRs++
+	dstID := cdrDestinationID(cdr)
+	var uc *UsageCharge
+	for _, chrg := range inv.UsageCharges {
+		if chrg.DestinationID == dstID &&
+			chrg.Category == cdr.Category &&
+			chrg.ToR == cdr.ToR &&
+			chrg.Subject == cdr.Subject {
+			uc = chrg
+			break
+		}
+	}
+	if uc == nil {
+		uc = &UsageCharge{
+			DestinationID: dstID,
+			Category:      cdr.Category,
+			ToR:           cdr.ToR,
+			Subject:       cdr.Subject,
+		}
+		inv.UsageCharges = append(inv.UsageCharges, uc)
+	}
+	uc.CDRs++
+	uc.Usage += cdr.Usage
+	uc.Cost += cdr.Cost
+}
+
+// finalize sorts the charges and computes the totals
+func (inv *Invoice) finalize(roundDec int) {
+	sort.Slice(inv.UsageCharges, func(i, j int) bool {
+		ui, uj := inv.UsageCharges[i], inv.UsageCharges[j]
+		if ui.DestinationID != uj.DestinationID {
+			return ui.DestinationID < uj.DestinationID
+		}
+		if ui.Category != uj.Category {
+			return ui.Category < uj.Category
+		}
+		if ui.ToR != uj.ToR {
+			return ui.ToR < uj.ToR
+		}
+		return ui.Subject < uj.Subject
+	})
+	inv.UsageCost, inv.RecurringCost = 0, 0
+	for _, uc := range inv.UsageCharges {
+		uc.Cost = utils.Round(uc.Cost, roundDec, utils.ROUNDING_MIDDLE)
+		inv.UsageCost += uc.Cost
+	}
+	for _, rc := range inv.RecurringCharges {
+		inv.RecurringCost += rc.Cost
+	}
+	inv.UsageCost = utils.Round(inv.UsageCost, roundDec, utils.ROUNDING_MIDDLE)
+	inv.RecurringCost = utils.Round(inv.RecurringCost, roundDec, utils.ROUNDING_MIDDLE)
+	inv.Total = utils.Round(inv.UsageCost+inv.RecurringCost, roundDec, utils.ROUNDING_MIDDLE)
+}
+
+// cdrDestinationID returns the destination profile matched when the CDR was rated
+func cdrDestinationID(cdr *engine.CDR) string {
+	if cdr.CostDetails == nil {
+		return utils.META_NONE
+	}
+	for _, cIl := range cdr.CostDetails.Charges {
+		rtUnit, has := cdr.CostDetails.Rating[cIl.RatingID]
+		if !has || rtUnit.RatingFiltersID == utils.EmptyString {
+			continue
+		}
+		if dstID := utils.IfaceAsString(
+			cdr.CostDetails.RatingFilters[rtUnit.RatingFiltersID][utils.DestinationID]); dstID != utils.EmptyString {
+			return dstID
+		}
+	}
+	return utils.META_NONE
+}
+
+// recurringAmount returns the monetary amount debited by the actions
+func recurringAmount(acts engine.Actions) (amount float64) {
+	for _, act := range acts {
+		if act.ActionType != utils.DEBIT &&
+			act.ActionType != utils.DEBIT_RESET {
+			continue
+		}
+		if act.Balance == nil ||
+			act.Balance.GetType() != utils.MONETARY {
+			continue
+		}
+		amount += act.Balance.GetValue()
+	}
+	return
+}
+
+// occurrences returns how many times the ActionTiming executes in the [sTime, eTime) interval
+func occurrences(at *engine.ActionTiming, sTime, eTime time.Time) (occurs int) {
+	at = at.Clone() // GetNextStartTime is normalizing and caching on the timing
+	for tm := sTime.Add(-time.Nanosecond); ; occurs++ {
+		at.ResetStartTimeCache()
+		if tm = at.GetNextStartTime(tm); tm.IsZero() || !tm.Before(eTime) {
+			return
+		}
+	}
+}
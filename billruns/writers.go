@@ -0,0 +1,118 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package billruns
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"io"
+	"strconv"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	chargeUsage     = "*usage"
+	chargeRecurring = "*recurring"
+	chargeTotal     = "*total"
+)
+
+// invoiceWriter writes an invoice document in one format
+type invoiceWriter struct {
+	suffix string
+	write  func(bS *BillRunS, tmpl *template.Template, w io.Writer, inv *Invoice) error
+}
+
+// invoiceWriters are the supported invoice formats, other documents (ie: PDF) can be hooked in here
+var invoiceWriters = map[string]*invoiceWriter{
+	utils.MetaJSON: {suffix: utils.JSNSuffix, write: writeJSONInvoice},
+	utils.MetaCSV:  {suffix: utils.CSVSuffix, write: writeCSVInvoice},
+	utils.MetaHTML: {suffix: utils.HTMLSuffix, write: writeHTMLInvoice},
+}
+
+func writeJSONInvoice(_ *BillRunS, _ *template.Template, w io.Writer, inv *Invoice) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent(utils.EmptyString, "\t")
+	return enc.Encode(inv)
+}
+
+// writeCSVInvoice writes one line per charge followed by the invoice total
+func writeCSVInvoice(bS *BillRunS, _ *template.Template, w io.Writer, inv *Invoice) (err error) {
+	csvWrtr := csv.NewWriter(w)
+	if err = csvWrtr.Write([]string{utils.Tenant, utils.Account, utils.Type, utils.ID,
+		utils.Category, utils.ToR, utils.Subject, utils.Count, utils.Usage, utils.Cost}); err != nil {
+		return
+	}
+	roundDec := bS.cfg.GeneralCfg().RoundingDecimals
+	for _, uc := range inv.UsageCharges {
+		if err = csvWrtr.Write([]string{inv.Tenant, inv.Account, chargeUsage, uc.DestinationID,
+			uc.Category, uc.ToR, uc.Subject, strconv.Itoa(uc.CDRs), uc.Usage.String(),
+			strconv.FormatFloat(uc.Cost, 'f', roundDec, 64)}); err != nil {
+			return
+		}
+	}
+	for _, rc := range inv.RecurringCharges {
+		if err = csvWrtr.Write([]string{inv.Tenant, inv.Account, chargeRecurring,
+			utils.ConcatenatedKey(rc.ActionPlanID, rc.ActionsID), utils.EmptyString,
+			utils.EmptyString, utils.EmptyString, strconv.Itoa(rc.Occurrences), utils.EmptyString,
+			strconv.FormatFloat(rc.Cost, 'f', roundDec, 64)}); err != nil {
+			return
+		}
+	}
+	if err = csvWrtr.Write([]string{inv.Tenant, inv.Account, chargeTotal, inv.ID,
+		utils.EmptyString, utils.EmptyString, utils.EmptyString, strconv.Itoa(inv.CDRs), utils.EmptyString,
+		strconv.FormatFloat(inv.Total, 'f', roundDec, 64)}); err != nil {
+		return
+	}
+	csvWrtr.Flush()
+	return csvWrtr.Error()
+}
+
+// htmlTemplate parses the configured html_template or the built-in one
+func (bS *BillRunS) htmlTemplate() (*template.Template, error) {
+	if tmplPath := bS.cfg.BillRunSCfg().HTMLTemplate; tmplPath != utils.EmptyString {
+		return template.ParseFiles(tmplPath)
+	}
+	return template.New(utils.MetaHTML).Parse(defaultHTMLTemplate)
+}
+
+// writeHTMLInvoice renders the invoice with the template parsed for the bill run
+func writeHTMLInvoice(_ *BillRunS, tmpl *template.Template, w io.Writer, inv *Invoice) error {
+	return tmpl.Execute(w, inv)
+}
+
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Invoice {{.ID}}</title></head>
+<body>
+<h1>Invoice {{.ID}}</h1>
+<p>Account: {{.Tenant}}:{{.Account}}<br>Period: {{.StartTime}} - {{.EndTime}}</p>
+<table>
+<tr><th>Destination</th><th>Category</th><th>ToR</th><th>Subject</th><th>CDRs</th><th>Usage</th><th>Cost</th></tr>
+{{range .UsageCharges}}<tr><td>{{.DestinationID}}</td><td>{{.Category}}</td><td>{{.ToR}}</td><td>{{.Subject}}</td><td>{{.CDRs}}</td><td>{{.Usage}}</td><td>{{.Cost}}</td></tr>
+{{end}}</table>
+{{if .RecurringCharges}}<table>
+<tr><th>ActionPlan</th><th>Actions</th><th>Occurrences</th><th>Amount</th><th>Cost</th></tr>
+{{range .RecurringCharges}}<tr><td>{{.ActionPlanID}}</td><td>{{.ActionsID}}</td><td>{{.Occurrences}}</td><td>{{.Amount}}</td><td>{{.Cost}}</td></tr>
+{{end}}</table>
+{{end}}<p>Usage: {{.UsageCost}}<br>Recurring: {{.RecurringCost}}<br>Total: {{.Total}}</p>
+</body>
+</html>
+`
@@ -46,6 +46,7 @@ var (
 	caPath          = cgrConsoleFlags.String("ca_path", "", "path to CA for tls connection(only for self sign certificate)")
 	tls             = cgrConsoleFlags.Bool("tls", false, "TLS connection")
 	replyTimeOut    = cgrConsoleFlags.Int("reply_timeout", 300, "Reply timeout in seconds ")
+	token           = cgrConsoleFlags.String("token", "", "API token used to authenticate on the server")
 	client          *rpcclient.RPCClient
 )
 
@@ -135,6 +136,12 @@ func main() {
 		cgrConsoleFlags.PrintDefaults()
 		log.Fatal("Could not connect to server " + *server)
 	}
+	if *token != utils.EmptyString {
+		var reply string
+		if err = client.Call(utils.AuthSv1Authenticate, *token, &reply); err != nil {
+			log.Fatal("Could not authenticate on server " + *server + ": " + err.Error())
+		}
+	}
 
 	if len(cgrConsoleFlags.Args()) != 0 {
 		executeCommand(strings.Join(cgrConsoleFlags.Args(), " "))
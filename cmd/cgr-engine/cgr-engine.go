@@ -115,7 +115,7 @@ func startRpc(server *utils.Server, internalRaterChan,
 	internalAttrSChan, internalChargerSChan, internalThdSChan, internalSuplSChan,
 	internalSMGChan, internalAnalyzerSChan, internalDispatcherSChan,
 	internalLoaderSChan, internalRALsv1Chan, internalCacheSChan,
-	internalEEsChan, internalRateSChan chan rpcclient.ClientConnector,
+	internalEEsChan, internalRateSChan, internalBillRunSChan chan rpcclient.ClientConnector,
 	exitChan chan bool) {
 	if !cfg.DispatcherSCfg().Enabled {
 		select { // Any of the rpc methods will unlock listening to rpc requests
@@ -149,6 +149,8 @@ func startRpc(server *utils.Server, internalRaterChan,
 			internalEEsChan <- eeS
 		case rateS := <-internalRateSChan:
 			internalRateSChan <- rateS
+		case billRunS := <-internalBillRunSChan:
+			internalBillRunSChan <- billRunS
 		}
 	} else {
 		select {
@@ -422,6 +424,7 @@ func main() {
 	internalLoaderSChan := make(chan rpcclient.ClientConnector, 1)
 	internalEEsChan := make(chan rpcclient.ClientConnector, 1)
 	internalRateSChan := make(chan rpcclient.ClientConnector, 1)
+	internalBillRunSChan := make(chan rpcclient.ClientConnector, 1)
 
 	// initialize the connManager before creating the DMService
 	// because we need to pass the connection to it
@@ -447,6 +450,7 @@ func main() {
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs):           internalRALsChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs):            internalEEsChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS):          internalRateSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaBillRuns):       internalBillRunSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaDispatchers):    internalDispatcherSChan,
 	})
 
@@ -463,6 +467,15 @@ func main() {
 
 	// Rpc/http server
 	server := utils.NewServer()
+	if cfg.RPCAuthzCfg().Enabled {
+		server.SetAuthorizer(utils.NewRPCAuthorizer(
+			cfg.RPCAuthzCfg().DefaultRole,
+			cfg.RPCAuthzCfg().Roles,
+			cfg.RPCAuthzCfg().Tokens,
+			cfg.RPCAuthzCfg().Users,
+			cfg.HTTPCfg().HTTPAuthUsers,
+		))
+	}
 
 	if *httpPprofPath != "" {
 		go server.RegisterProfiler(*httpPprofPath)
@@ -496,7 +509,7 @@ func main() {
 	routeS := services.NewRouteService(cfg, dmService, cacheS, filterSChan, server,
 		internalRouteSChan, connManager)
 
-	schS := services.NewSchedulerService(cfg, dmService, cacheS, filterSChan,
+	schS := services.NewSchedulerService(cfg, dmService, storDBService, cacheS, filterSChan,
 		server, internalSchedulerSChan, connManager)
 
 	rals := services.NewRalService(cfg, cacheS, server,
@@ -533,6 +546,8 @@ func main() {
 		services.NewRateService(cfg, cacheS, filterSChan, dmService,
 			server, exitChan, internalRateSChan),
 		services.NewSIPAgent(cfg, filterSChan, exitChan, connManager),
+		services.NewBillRunService(cfg, dmService, connManager,
+			server, exitChan, internalBillRunSChan),
 	)
 	srvManager.StartServices()
 	// Start FilterS
@@ -565,6 +580,7 @@ func main() {
 	engine.IntRPC.AddInternalRPCClient(utils.CoreSv1, internalCoreSv1Chan)
 	engine.IntRPC.AddInternalRPCClient(utils.RALsV1, internalRALsChan)
 	engine.IntRPC.AddInternalRPCClient(utils.RateSv1, internalRateSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.BillRunSv1, internalBillRunSChan)
 
 	initConfigSv1(internalConfigChan, server)
 
@@ -574,7 +590,8 @@ func main() {
 		internalAttributeSChan, internalChargerSChan, internalThresholdSChan,
 		internalRouteSChan, internalSessionSChan, internalAnalyzerSChan,
 		internalDispatcherSChan, internalLoaderSChan, internalRALsChan,
-		internalCacheSChan, internalEEsChan, internalRateSChan, exitChan)
+		internalCacheSChan, internalEEsChan, internalRateSChan,
+		internalBillRunSChan, exitChan)
 	<-exitChan
 
 	if *cpuProfDir != "" { // wait to end cpuProfiling
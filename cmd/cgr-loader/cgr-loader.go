@@ -83,6 +83,8 @@ var (
 		"Enable detailed verbose logging output")
 	dryRun = cgrLoaderFlags.Bool("dry_run", false,
 		"When true will not save loaded data to dataDb but just parse it for consistency and errors.")
+	diff = cgrLoaderFlags.Bool("diff", false,
+		"When true will not save loaded data to dataDb but print the differences compared with dataDb.")
 	fieldSep = cgrLoaderFlags.String("field_sep", ",",
 		`Separator for csv file (by default "," is used)`)
 
@@ -336,6 +338,21 @@ func main() {
 		return
 	}
 
+	if *diff { // show what the load would change, without saving it
+		var diffs map[string]*engine.TPDiff
+		if diffs, err = tpReader.DiffWithDatabase(); err != nil {
+			log.Fatal("Could not compare with database: ", err)
+		}
+		fmt.Println(utils.ToIJSON(diffs))
+		return
+	}
+
+	if ldrCfg.CacheCfg().RemoteInvalidation {
+		if err = tpReader.PublishCacheInvalidations(); err != nil {
+			log.Fatal("Could not start the cache invalidation: ", err)
+		}
+	}
+
 	if *remove {
 		if err = tpReader.RemoveFromDatabase(*verbose, *disableReverse); err != nil {
 			log.Fatal("Could not delete from database: ", err)
@@ -30,6 +30,8 @@ type ApierCfg struct {
 	CachesConns     []string // connections towards Cache
 	SchedulerConns  []string // connections towards Scheduler
 	AttributeSConns []string // connections towards AttributeS
+	Audit           bool     // record the administrative calls into StorDB
+	AuditMethods    []string // patterns of the methods recorded by the audit trail
 }
 
 func (aCfg *ApierCfg) loadFromJsonCfg(jsnCfg *ApierJsonCfg) (err error) {
@@ -72,6 +74,13 @@ func (aCfg *ApierCfg) loadFromJsonCfg(jsnCfg *ApierJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Audit != nil {
+		aCfg.Audit = *jsnCfg.Audit
+	}
+	if jsnCfg.Audit_methods != nil {
+		aCfg.AuditMethods = make([]string, len(*jsnCfg.Audit_methods))
+		copy(aCfg.AuditMethods, *jsnCfg.Audit_methods)
+	}
 
 	return nil
 }
@@ -110,6 +119,8 @@ func (aCfg *ApierCfg) AsMapInterface() map[string]interface{} {
 		utils.CachesConnsCfg:     cachesConns,
 		utils.SchedulerConnsCfg:  schedulerConns,
 		utils.AttributeSConnsCfg: attributeSConns,
+		utils.AuditCfg:           aCfg.Audit,
+		utils.AuditMethodsCfg:    aCfg.AuditMethods,
 	}
 
 }
@@ -69,6 +69,8 @@ func TestApierCfgAsMapInterface(t *testing.T) {
 		"caches_conns":[],
 		"scheduler_conns": [],
 		"attributes_conns": [],
+		"audit": true,
+		"audit_methods": ["*.Set*"],
 	},
 }`
 	eMap := map[string]interface{}{
@@ -76,6 +78,8 @@ func TestApierCfgAsMapInterface(t *testing.T) {
 		"caches_conns":     []string{},
 		"scheduler_conns":  []string{},
 		"attributes_conns": []string{},
+		"audit":            true,
+		"audit_methods":    []string{"*.Set*"},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -100,6 +104,8 @@ func TestApierCfgAsMapInterface(t *testing.T) {
 		"caches_conns":     []string{"*internal"},
 		"scheduler_conns":  []string{"*internal"},
 		"attributes_conns": []string{"*internal"},
+		"audit":            true,
+		"audit_methods":    []string{"*.Set*"},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -18,7 +18,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // AttributeSCfg is the configuration of attribute service
 type AttributeSCfg struct {
@@ -28,9 +34,10 @@ type AttributeSCfg struct {
 	PrefixIndexedFields *[]string
 	ProcessRuns         int
 	NestedFields        bool
+	HTTPLookups         map[string]*HTTPLookupCfg // external lookups used by the *http_lookup attributes
 }
 
-func (alS *AttributeSCfg) loadFromJsonCfg(jsnCfg *AttributeSJsonCfg) (err error) {
+func (alS *AttributeSCfg) loadFromJsonCfg(jsnCfg *AttributeSJsonCfg, separator string) (err error) {
 	if jsnCfg == nil {
 		return
 	}
@@ -60,10 +67,29 @@ func (alS *AttributeSCfg) loadFromJsonCfg(jsnCfg *AttributeSJsonCfg) (err error)
 	if jsnCfg.Nested_fields != nil {
 		alS.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Http_lookups != nil {
+		if alS.HTTPLookups == nil {
+			alS.HTTPLookups = make(map[string]*HTTPLookupCfg)
+		}
+		for lkID, jsnLkCfg := range *jsnCfg.Http_lookups {
+			lkCfg, has := alS.HTTPLookups[lkID]
+			if !has {
+				lkCfg = &HTTPLookupCfg{
+					Method:      http.MethodGet,
+					Timeout:     2 * time.Second,
+					FallbackTTL: 10 * time.Second,
+				}
+			}
+			if err = lkCfg.loadFromJsonCfg(jsnLkCfg, separator); err != nil {
+				return
+			}
+			alS.HTTPLookups[lkID] = lkCfg
+		}
+	}
 	return
 }
 
-func (alS *AttributeSCfg) AsMapInterface() map[string]interface{} {
+func (alS *AttributeSCfg) AsMapInterface(separator string) map[string]interface{} {
 	stringIndexedFields := []string{}
 	if alS.StringIndexedFields != nil {
 		stringIndexedFields = make([]string, len(*alS.StringIndexedFields))
@@ -78,6 +104,10 @@ func (alS *AttributeSCfg) AsMapInterface() map[string]interface{} {
 			prefixIndexedFields[i] = item
 		}
 	}
+	httpLookups := make(map[string]interface{}, len(alS.HTTPLookups))
+	for lkID, lkCfg := range alS.HTTPLookups {
+		httpLookups[lkID] = lkCfg.AsMapInterface(separator)
+	}
 	return map[string]interface{}{
 		utils.EnabledCfg:             alS.Enabled,
 		utils.IndexedSelectsCfg:      alS.IndexedSelects,
@@ -85,6 +115,90 @@ func (alS *AttributeSCfg) AsMapInterface() map[string]interface{} {
 		utils.PrefixIndexedFieldsCfg: prefixIndexedFields,
 		utils.ProcessRunsCfg:         alS.ProcessRuns,
 		utils.NestedFieldsCfg:        alS.NestedFields,
+		utils.HTTPLookupsCfg:         httpLookups,
+	}
+
+}
+
+// HTTPLookupCfg is the configuration of an external HTTP service queried by AttributeS
+type HTTPLookupCfg struct {
+	URL         RSRParsers            // built out of the event
+	Method      string                // <GET|POST>
+	QueryParams map[string]RSRParsers // appended to the URL, escaped
+	Body        RSRParsers            // sent as JSON with POST
+	ReplyPath   string                // path of the value within the JSON reply
+	Timeout     time.Duration
+	Fallback    string        // used when the service cannot be queried
+	FallbackTTL time.Duration // how long the fallback is used before querying the service again
+}
+
+func (lkCfg *HTTPLookupCfg) loadFromJsonCfg(jsnCfg *HTTPLookupJsonCfg, separator string) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Url != nil {
+		if lkCfg.URL, err = NewRSRParsers(*jsnCfg.Url, true, separator); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Method != nil {
+		lkCfg.Method = *jsnCfg.Method
+	}
+	if jsnCfg.Query_params != nil {
+		lkCfg.QueryParams = make(map[string]RSRParsers, len(*jsnCfg.Query_params))
+		for param, val := range *jsnCfg.Query_params {
+			if lkCfg.QueryParams[param], err = NewRSRParsers(val, true, separator); err != nil {
+				return
+			}
+		}
+	}
+	if jsnCfg.Body != nil {
+		if lkCfg.Body, err = NewRSRParsers(*jsnCfg.Body, true, separator); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reply_path != nil {
+		lkCfg.ReplyPath = *jsnCfg.Reply_path
+	}
+	if jsnCfg.Timeout != nil {
+		if lkCfg.Timeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Fallback != nil {
+		lkCfg.Fallback = *jsnCfg.Fallback
+	}
+	if jsnCfg.Fallback_ttl != nil {
+		if lkCfg.FallbackTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Fallback_ttl); err != nil {
+			return
+		}
 	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (lkCfg *HTTPLookupCfg) AsMapInterface(separator string) map[string]interface{} {
+	queryParams := make(map[string]interface{}, len(lkCfg.QueryParams))
+	for param, val := range lkCfg.QueryParams {
+		queryParams[param] = rsrParsersRules(val, separator)
+	}
+	return map[string]interface{}{
+		utils.UrlCfg:         rsrParsersRules(lkCfg.URL, separator),
+		utils.MethodCfg:      lkCfg.Method,
+		utils.QueryParamsCfg: queryParams,
+		utils.BodyCfg:        rsrParsersRules(lkCfg.Body, separator),
+		utils.ReplyPathCfg:   lkCfg.ReplyPath,
+		utils.TimeoutCfg:     lkCfg.Timeout.String(),
+		utils.FallbackCfg:    lkCfg.Fallback,
+		utils.FallbackTTLCfg: lkCfg.FallbackTTL.String(),
+	}
+}
 
+// rsrParsersRules composes back the rules of the parsers
+func rsrParsersRules(prsrs RSRParsers, separator string) string {
+	rules := make([]string, len(prsrs))
+	for i, prsr := range prsrs {
+		rules[i] = prsr.Rules
+	}
+	return strings.Join(rules, separator)
 }
@@ -18,20 +18,22 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
 
 func TestAttributeSCfgloadFromJsonCfg(t *testing.T) {
 	var attscfg, expected AttributeSCfg
-	if err := attscfg.loadFromJsonCfg(nil); err != nil {
+	if err := attscfg.loadFromJsonCfg(nil, utils.INFIELD_SEP); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(attscfg, expected) {
 		t.Errorf("Expected: %+v ,recived: %+v", expected, attscfg)
 	}
-	if err := attscfg.loadFromJsonCfg(new(AttributeSJsonCfg)); err != nil {
+	if err := attscfg.loadFromJsonCfg(new(AttributeSJsonCfg), utils.INFIELD_SEP); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(attscfg, expected) {
 		t.Errorf("Expected: %+v ,recived: %+v", expected, attscfg)
@@ -53,7 +55,7 @@ func TestAttributeSCfgloadFromJsonCfg(t *testing.T) {
 		t.Error(err)
 	} else if jsnAttSCfg, err := jsnCfg.AttributeServJsonCfg(); err != nil {
 		t.Error(err)
-	} else if err = attscfg.loadFromJsonCfg(jsnAttSCfg); err != nil {
+	} else if err = attscfg.loadFromJsonCfg(jsnAttSCfg, utils.INFIELD_SEP); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(expected, attscfg) {
 		t.Errorf("Expected: %+v , recived: %+v", expected, attscfg)
@@ -76,14 +78,73 @@ func TestAttributeSCfgAsMapInterface(t *testing.T) {
 		"indexed_selects":       false,
 		"nested_fields":         false,
 		"string_indexed_fields": []string{},
+		"http_lookups":          map[string]interface{}{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
 	} else if jsnAttSCfg, err := jsnCfg.AttributeServJsonCfg(); err != nil {
 		t.Error(err)
-	} else if err = attscfg.loadFromJsonCfg(jsnAttSCfg); err != nil {
+	} else if err = attscfg.loadFromJsonCfg(jsnAttSCfg, utils.INFIELD_SEP); err != nil {
 		t.Error(err)
-	} else if rcv := attscfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+	} else if rcv := attscfg.AsMapInterface(utils.INFIELD_SEP); !reflect.DeepEqual(eMap, rcv) {
 		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
 	}
 }
+
+func TestAttributeSCfgHTTPLookups(t *testing.T) {
+	var attscfg AttributeSCfg
+	cfgJSONStr := `{
+"attributes": {
+	"http_lookups": {
+		"CRM_CUSTOMER": {
+			"url": "http://crm.local/customers/;~*req.Account",
+			"query_params": {"fields": "tier"},
+			"reply_path": "customer.tier",
+			"fallback": "standard",
+			"fallback_ttl": "1m",
+		},
+		"CRM_CREDIT": {
+			"url": "http://crm.local/credit",
+			"method": "POST",
+			"body": "{\"account\":\";~*req.Account;\"}",
+			"timeout": "500ms",
+		},
+	},
+	},
+}`
+	eMap := map[string]interface{}{
+		"CRM_CUSTOMER": map[string]interface{}{
+			"url":          "http://crm.local/customers/;~*req.Account",
+			"method":       http.MethodGet,
+			"query_params": map[string]interface{}{"fields": "tier"},
+			"body":         "",
+			"reply_path":   "customer.tier",
+			"timeout":      "2s",
+			"fallback":     "standard",
+			"fallback_ttl": "1m0s",
+		},
+		"CRM_CREDIT": map[string]interface{}{
+			"url":          "http://crm.local/credit",
+			"method":       http.MethodPost,
+			"query_params": map[string]interface{}{},
+			"body":         `{"account":";~*req.Account;"}`,
+			"reply_path":   "",
+			"timeout":      "500ms",
+			"fallback":     "",
+			"fallback_ttl": "10s",
+		},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Fatal(err)
+	} else if jsnAttSCfg, err := jsnCfg.AttributeServJsonCfg(); err != nil {
+		t.Fatal(err)
+	} else if err = attscfg.loadFromJsonCfg(jsnAttSCfg, utils.INFIELD_SEP); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := attscfg.AsMapInterface(utils.INFIELD_SEP)[utils.HTTPLookupsCfg]; !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+	if lkCfg := attscfg.HTTPLookups["CRM_CREDIT"]; lkCfg.Timeout != 500*time.Millisecond {
+		t.Errorf("Expected timeout 500ms, received: %v", lkCfg.Timeout)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// BillRunSCfg is the configuration of the bill-run service
+type BillRunSCfg struct {
+	Enabled      bool
+	CDRsConns    []string // connections towards CDRs
+	ExportPath   string   // folder where the invoices are written
+	HTMLTemplate string   // path towards the template used for *html invoices
+	RunIDs       []string // RunIDs of the CDRs considered in the invoices
+}
+
+func (bCfg *BillRunSCfg) loadFromJsonCfg(jsnCfg *BillRunSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		bCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Cdrs_conns != nil {
+		bCfg.CDRsConns = make([]string, len(*jsnCfg.Cdrs_conns))
+		for idx, conn := range *jsnCfg.Cdrs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if conn == utils.MetaInternal {
+				bCfg.CDRsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)
+			} else {
+				bCfg.CDRsConns[idx] = conn
+			}
+		}
+	}
+	if jsnCfg.Export_path != nil {
+		bCfg.ExportPath = *jsnCfg.Export_path
+	}
+	if jsnCfg.Html_template != nil {
+		bCfg.HTMLTemplate = *jsnCfg.Html_template
+	}
+	if jsnCfg.Run_ids != nil {
+		bCfg.RunIDs = make([]string, len(*jsnCfg.Run_ids))
+		copy(bCfg.RunIDs, *jsnCfg.Run_ids)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (bCfg *BillRunSCfg) AsMapInterface() map[string]interface{} {
+	cdrsConns := make([]string, len(bCfg.CDRsConns))
+	for i, item := range bCfg.CDRsConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)
+		if item == buf {
+			cdrsConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaCDRs, utils.EmptyString)
+		} else {
+			cdrsConns[i] = item
+		}
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:      bCfg.Enabled,
+		utils.CDRsConnsCfg:    cdrsConns,
+		utils.ExportPathCfg:   bCfg.ExportPath,
+		utils.HTMLTemplateCfg: bCfg.HTMLTemplate,
+		utils.RunIDsCfg:       bCfg.RunIDs,
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestBillRunSCfgloadFromJsonCfg(t *testing.T) {
+	var bCfg, expected BillRunSCfg
+	if err := bCfg.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(bCfg, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, bCfg)
+	}
+	cfgJSONStr := `{
+"billruns": {
+	"enabled": true,
+	"cdrs_conns": ["*internal", "*conn1"],
+	"export_path": "/tmp/billruns",
+	"html_template": "/usr/share/cgrates/invoice.html",
+	"run_ids": ["*default", "run1"],
+},
+}`
+	expected = BillRunSCfg{
+		Enabled:      true,
+		CDRsConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
+		ExportPath:   "/tmp/billruns",
+		HTMLTemplate: "/usr/share/cgrates/invoice.html",
+		RunIDs:       []string{utils.MetaDefault, "run1"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnBCfg, err := jsnCfg.BillRunSCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = bCfg.loadFromJsonCfg(jsnBCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, bCfg) {
+		t.Errorf("Expected: %+v , recived: %+v", expected, bCfg)
+	}
+}
+
+func TestBillRunSCfgAsMapInterface(t *testing.T) {
+	bCfg := &BillRunSCfg{
+		Enabled:    true,
+		CDRsConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
+		ExportPath: "/tmp/billruns",
+		RunIDs:     []string{utils.MetaDefault},
+	}
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:      true,
+		utils.CDRsConnsCfg:    []string{utils.MetaInternal, "*conn1"},
+		utils.ExportPathCfg:   "/tmp/billruns",
+		utils.HTMLTemplateCfg: "",
+		utils.RunIDsCfg:       []string{utils.MetaDefault},
+	}
+	if rcv := bCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v , recived: %+v", eMap, rcv)
+	}
+}
@@ -76,8 +76,11 @@ func (cParam *CacheParamCfg) AsMapInterface() map[string]interface{} {
 
 // CacheCfg used to store the cache config
 type CacheCfg struct {
-	Partitions       map[string]*CacheParamCfg
-	ReplicationConns []string
+	Partitions         map[string]*CacheParamCfg
+	ReplicationConns   []string
+	RemoteInvalidation bool     // drop the cached items written by the other engines sharing the DataDB
+	PrecacheConns      []string // peer engines to warm up the precached partitions from
+	PrecacheChunkSize  int
 }
 
 func (cCfg *CacheCfg) loadFromJsonCfg(jsnCfg *CacheJsonCfg) (err error) {
@@ -102,6 +105,21 @@ func (cCfg *CacheCfg) loadFromJsonCfg(jsnCfg *CacheJsonCfg) (err error) {
 			cCfg.ReplicationConns[idx] = connID
 		}
 	}
+	if jsnCfg.Remote_invalidation != nil {
+		cCfg.RemoteInvalidation = *jsnCfg.Remote_invalidation
+	}
+	if jsnCfg.Precache_conns != nil {
+		cCfg.PrecacheConns = make([]string, len(*jsnCfg.Precache_conns))
+		for idx, connID := range *jsnCfg.Precache_conns {
+			if connID == utils.MetaInternal {
+				return fmt.Errorf("precache connection ID needs to be different than *internal")
+			}
+			cCfg.PrecacheConns[idx] = connID
+		}
+	}
+	if jsnCfg.Precache_chunk_size != nil {
+		cCfg.PrecacheChunkSize = *jsnCfg.Precache_chunk_size
+	}
 
 	return nil
 }
@@ -136,10 +154,17 @@ func (cCfg *CacheCfg) AsMapInterface() map[string]interface{} {
 	for i, item := range cCfg.ReplicationConns {
 		replicationConns[i] = item
 	}
+	precacheConns := make([]string, len(cCfg.PrecacheConns))
+	for i, item := range cCfg.PrecacheConns {
+		precacheConns[i] = item
+	}
 
 	return map[string]interface{}{
-		utils.PartitionsCfg: partitions,
-		utils.RplConnsCfg:   replicationConns,
+		utils.PartitionsCfg:         partitions,
+		utils.RplConnsCfg:           replicationConns,
+		utils.RemoteInvalidationCfg: cCfg.RemoteInvalidation,
+		utils.PrecacheConnsCfg:      precacheConns,
+		utils.PrecacheChunkSizeCfg:  cCfg.PrecacheChunkSize,
 	}
 
 }
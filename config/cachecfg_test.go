@@ -71,6 +71,7 @@ func TestCacheCfgloadFromJsonCfg(t *testing.T) {
 		"*reverse_destinations": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},	
 		"*rating_plans": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false},
 		},
+	"remote_invalidation": true,
 	},
 }`
 	expected = &CacheCfg{
@@ -79,6 +80,7 @@ func TestCacheCfgloadFromJsonCfg(t *testing.T) {
 			"*reverse_destinations": &CacheParamCfg{Limit: -1, TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			"*rating_plans":         &CacheParamCfg{Limit: -1, TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		},
+		RemoteInvalidation: true,
 	}
 	cachecfg = new(CacheCfg)
 	cachecfg.Partitions = make(map[string]*CacheParamCfg)
@@ -141,7 +143,10 @@ func TestCacheCfgAsMapInterface(t *testing.T) {
 			"*reverse_destinations": map[string]interface{}{"limit": -1, "ttl": "", "static_ttl": false, "precache": false},
 			"*rating_plans":         map[string]interface{}{"limit": -1, "ttl": "", "static_ttl": false, "precache": false},
 		},
-		"replication_conns": []string{},
+		"replication_conns":   []string{},
+		"remote_invalidation": false,
+		"precache_conns":      []string{},
+		"precache_chunk_size": 0,
 	}
 	cachecfg = new(CacheCfg)
 	cachecfg.Partitions = make(map[string]*CacheParamCfg)
@@ -161,6 +166,9 @@ func TestCacheCfgAsMapInterface(t *testing.T) {
 		"*reverse_destinations": {"limit": -1, "ttl": "1m", "static_ttl": false, "precache": false},	
 		"*rating_plans": {"limit": 10, "ttl": "", "static_ttl": true, "precache": true},
 		},
+	"remote_invalidation": true,
+	"precache_conns": ["peer"],
+	"precache_chunk_size": 500,
 	},
 }`
 	eMap = map[string]interface{}{
@@ -169,7 +177,10 @@ func TestCacheCfgAsMapInterface(t *testing.T) {
 			"*reverse_destinations": map[string]interface{}{"limit": -1, "ttl": "1m0s", "static_ttl": false, "precache": false},
 			"*rating_plans":         map[string]interface{}{"limit": 10, "ttl": "", "static_ttl": true, "precache": true},
 		},
-		"replication_conns": []string{},
+		"replication_conns":   []string{},
+		"remote_invalidation": true,
+		"precache_conns":      []string{"peer"},
+		"precache_chunk_size": 500,
 	}
 	cachecfg = new(CacheCfg)
 	cachecfg.Partitions = make(map[string]*CacheParamCfg)
@@ -20,6 +20,7 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -37,6 +38,11 @@ type CdrsCfg struct {
 	OnlineCDRExports []string // list of CDRE templates to use for real-time CDR exports
 	SchedulerConns   []string
 	EEsConns         []string
+	DedupPolicy      string        // handling of the duplicated CDRs <*none|*drop|*update_if_newer|*merge>
+	DedupFields      []string      // event fields building the key of the duplicates
+	DedupWindow      time.Duration // interval since first seen in which an event is considered duplicate
+	DedupNewerField  string        // field compared by the *update_if_newer policy
+	Archive          *CdrsArchiveCfg
 }
 
 //loadFromJsonCfg loads Cdrs config from JsonCfg
@@ -130,6 +136,23 @@ func (cdrscfg *CdrsCfg) loadFromJsonCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 		}
 	}
 
+	if jsnCdrsCfg.Dedup_policy != nil {
+		cdrscfg.DedupPolicy = *jsnCdrsCfg.Dedup_policy
+	}
+	if jsnCdrsCfg.Dedup_fields != nil {
+		cdrscfg.DedupFields = make([]string, len(*jsnCdrsCfg.Dedup_fields))
+		for i, fldName := range *jsnCdrsCfg.Dedup_fields {
+			cdrscfg.DedupFields[i] = fldName
+		}
+	}
+	if jsnCdrsCfg.Dedup_window != nil {
+		if cdrscfg.DedupWindow, err = utils.ParseDurationWithNanosecs(*jsnCdrsCfg.Dedup_window); err != nil {
+			return
+		}
+	}
+	if jsnCdrsCfg.Dedup_newer_field != nil {
+		cdrscfg.DedupNewerField = *jsnCdrsCfg.Dedup_newer_field
+	}
 	if jsnCdrsCfg.Ees_conns != nil {
 		cdrscfg.EEsConns = make([]string, len(*jsnCdrsCfg.Ees_conns))
 		for idx, connID := range *jsnCdrsCfg.Ees_conns {
@@ -141,6 +164,14 @@ func (cdrscfg *CdrsCfg) loadFromJsonCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCdrsCfg.Archive != nil {
+		if cdrscfg.Archive == nil {
+			cdrscfg.Archive = new(CdrsArchiveCfg)
+		}
+		if err = cdrscfg.Archive.loadFromJsonCfg(jsnCdrsCfg.Archive); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
@@ -211,8 +242,12 @@ func (cdrscfg *CdrsCfg) AsMapInterface() map[string]interface{} {
 			schedulerConns[i] = item
 		}
 	}
+	dedupFields := make([]string, len(cdrscfg.DedupFields))
+	for i, item := range cdrscfg.DedupFields {
+		dedupFields[i] = item
+	}
 
-	return map[string]interface{}{
+	mp := map[string]interface{}{
 		utils.EnabledCfg:          cdrscfg.Enabled,
 		utils.ExtraFieldsCfg:      extraFields,
 		utils.StoreCdrsCfg:        cdrscfg.StoreCdrs,
@@ -224,5 +259,106 @@ func (cdrscfg *CdrsCfg) AsMapInterface() map[string]interface{} {
 		utils.StatSConnsCfg:       statSConns,
 		utils.OnlineCDRExportsCfg: onlineCDRExports,
 		utils.SchedulerConnsCfg:   schedulerConns,
+		utils.DedupPolicyCfg:      cdrscfg.DedupPolicy,
+		utils.DedupFieldsCfg:      dedupFields,
+		utils.DedupWindowCfg:      cdrscfg.DedupWindow.String(),
+		utils.DedupNewerFieldCfg:  cdrscfg.DedupNewerField,
+	}
+	if cdrscfg.Archive != nil {
+		mp[utils.ArchiveCfg] = cdrscfg.Archive.AsMapInterface()
+	}
+	return mp
+}
+
+// CdrsArchiveCfg moves the old CDRs out of StorDB into compressed archive files
+type CdrsArchiveCfg struct {
+	RunInterval time.Duration // interval between the archive runs, 0 to disable them
+	ArchivePath string
+	Format      string // <*csv|*json>
+	MaxFileSize int64  // size in bytes of the records, before compression, after which the archive files are rolled, 0 for no limit
+	Rules       []*CdrsArchiveRule
+}
+
+func (arcCfg *CdrsArchiveCfg) loadFromJsonCfg(jsnCfg *CdrsArchiveJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Run_interval != nil {
+		if arcCfg.RunInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Run_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Archive_path != nil {
+		arcCfg.ArchivePath = *jsnCfg.Archive_path
+	}
+	if jsnCfg.Format != nil {
+		arcCfg.Format = *jsnCfg.Format
+	}
+	if jsnCfg.Max_file_size != nil {
+		arcCfg.MaxFileSize = *jsnCfg.Max_file_size
+	}
+	if jsnCfg.Rules != nil {
+		arcCfg.Rules = make([]*CdrsArchiveRule, len(*jsnCfg.Rules))
+		for i, jsnRule := range *jsnCfg.Rules {
+			arcCfg.Rules[i] = new(CdrsArchiveRule)
+			if err = arcCfg.Rules[i].loadFromJsonCfg(jsnRule); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (arcCfg *CdrsArchiveCfg) AsMapInterface() map[string]interface{} {
+	rules := make([]map[string]interface{}, len(arcCfg.Rules))
+	for i, rule := range arcCfg.Rules {
+		rules[i] = rule.AsMapInterface()
+	}
+	return map[string]interface{}{
+		utils.RunIntervalCfg: arcCfg.RunInterval.String(),
+		utils.ArchivePathCfg: arcCfg.ArchivePath,
+		utils.FormatCfg:      arcCfg.Format,
+		utils.MaxFileSizeCfg: arcCfg.MaxFileSize,
+		utils.RulesCfg:       rules,
+	}
+}
+
+// CdrsArchiveRule selects the CDRs to be archived
+type CdrsArchiveRule struct {
+	Tenant    string // empty to match all tenants
+	FilterIDs []string
+	MaxAge    time.Duration // CDRs answered before now-MaxAge are archived
+}
+
+func (rule *CdrsArchiveRule) loadFromJsonCfg(jsnCfg *CdrsArchiveRuleJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Tenant != nil {
+		rule.Tenant = *jsnCfg.Tenant
+	}
+	if jsnCfg.Filters != nil {
+		rule.FilterIDs = make([]string, len(*jsnCfg.Filters))
+		for i, fltrID := range *jsnCfg.Filters {
+			rule.FilterIDs[i] = fltrID
+		}
+	}
+	if jsnCfg.Max_age != nil {
+		if rule.MaxAge, err = utils.ParseDurationWithNanosecs(*jsnCfg.Max_age); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (rule *CdrsArchiveRule) AsMapInterface() map[string]interface{} {
+	fltrIDs := make([]string, len(rule.FilterIDs))
+	for i, fltrID := range rule.FilterIDs {
+		fltrIDs[i] = fltrID
+	}
+	return map[string]interface{}{
+		utils.TenantCfg:  rule.Tenant,
+		utils.FiltersCfg: fltrIDs,
+		utils.MaxAgeCfg:  rule.MaxAge.String(),
 	}
 }
@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -48,6 +49,9 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 	"thresholds_conns": [],					// address where to reach the thresholds service, empty to disable thresholds functionality: <""|*internal|x.y.z.y:1234>
 	"stats_conns": [],						// address where to reach the stat service, empty to disable stats functionality: <""|*internal|x.y.z.y:1234>
 	"online_cdr_exports":[],				// list of CDRE profiles to use for real-time CDR exports
+	"dedup_policy": "*drop",
+	"dedup_fields": ["OriginID"],
+	"dedup_window": "10s",
 	},
 }`
 	expected = CdrsCfg{
@@ -58,6 +62,9 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns: []string{},
 		ThresholdSConns: []string{},
 		StatSConns:      []string{},
+		DedupPolicy:     utils.MetaDrop,
+		DedupFields:     []string{utils.OriginID},
+		DedupWindow:     10 * time.Second,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -122,6 +129,10 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"stats_conns":          []string{},
 		"online_cdr_exports":   []string{},
 		"scheduler_conns":      []string{},
+		"dedup_policy":         "",
+		"dedup_fields":         []string{},
+		"dedup_window":         "0s",
+		"dedup_newer_field":    "",
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -146,6 +157,19 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 			"stats_conns": ["*internal"],						
 			"online_cdr_exports":["http_localhost", "amqp_localhost", "http_test_file", "amqp_test_file","aws_test_file","sqs_test_file","kafka_localhost","s3_test_file"],
 			"scheduler_conns": ["*internal"],				
+			"dedup_policy": "*update_if_newer",
+			"dedup_fields": ["OriginID", "RunID"],
+			"dedup_window": "1h",
+			"dedup_newer_field": "AnswerTime",
+			"archive": {
+				"run_interval": "24h",
+				"archive_path": "/tmp",
+				"format": "*json",
+				"max_file_size": 1024,
+				"rules": [
+					{"tenant": "cgrates.org", "filters": ["*string:~*req.Account:1001"], "max_age": "2160h"},
+				],
+			},
 		},
 	}`
 	eMap = map[string]interface{}{
@@ -160,6 +184,23 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"stats_conns":          []string{"*internal"},
 		"online_cdr_exports":   []string{"http_localhost", "amqp_localhost", "http_test_file", "amqp_test_file", "aws_test_file", "sqs_test_file", "kafka_localhost", "s3_test_file"},
 		"scheduler_conns":      []string{"*internal"},
+		"dedup_policy":         "*update_if_newer",
+		"dedup_fields":         []string{"OriginID", "RunID"},
+		"dedup_window":         "1h0m0s",
+		"dedup_newer_field":    "AnswerTime",
+		"archive": map[string]interface{}{
+			"run_interval":  "24h0m0s",
+			"archive_path":  "/tmp",
+			"format":        "*json",
+			"max_file_size": int64(1024),
+			"rules": []map[string]interface{}{
+				{
+					"tenant":  "cgrates.org",
+					"filters": []string{"*string:~*req.Account:1001"},
+					"max_age": "2160h0m0s",
+				},
+			},
+		},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -155,6 +155,7 @@ func NewDefaultCGRConfig() (cfg *CGRConfig, err error) {
 	cfg.ralsCfg.BalanceRatingSubject = make(map[string]string)
 	cfg.schedulerCfg = new(SchedulerCfg)
 	cfg.cdrsCfg = new(CdrsCfg)
+	cfg.cdrsCfg.Archive = new(CdrsArchiveCfg)
 	cfg.CdreProfiles = make(map[string]*CdreCfg)
 	cfg.analyzerSCfg = new(AnalyzerSCfg)
 	cfg.sessionSCfg = new(SessionSCfg)
@@ -182,6 +183,8 @@ func NewDefaultCGRConfig() (cfg *CGRConfig, err error) {
 	cfg.eesCfg = new(EEsCfg)
 	cfg.eesCfg.Cache = make(map[string]*CacheParamCfg)
 	cfg.rateSCfg = new(RateSCfg)
+	cfg.billRunSCfg = new(BillRunSCfg)
+	cfg.rpcAuthzCfg = new(RPCAuthzCfg)
 	cfg.sipAgentCfg = new(SIPAgentCfg)
 
 	cfg.ConfigReloads = make(map[string]chan struct{})
@@ -306,17 +309,21 @@ type CGRConfig struct {
 	ersCfg           *ERsCfg           // EventReader config
 	eesCfg           *EEsCfg           // EventExporter config
 	rateSCfg         *RateSCfg         // RateS config
+	billRunSCfg      *BillRunSCfg      // BillRunS config
+	rpcAuthzCfg      *RPCAuthzCfg      // RPC authorization config
 	sipAgentCfg      *SIPAgentCfg      // SIPAgent config
 }
 
 var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 	utils.MetaResources, utils.MetaFilters, utils.MetaStats,
 	utils.MetaRoutes, utils.MetaThresholds, utils.MetaChargers,
-	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaRateProfiles})
+	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaRateProfiles,
+	utils.MetaLookupTables, utils.MetaActionPlans})
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
-	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaJSON, utils.META_NONE})
+	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaJSON, utils.META_NONE,
+	utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap, utils.MetaS3jsonMap})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.META_NONE, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
@@ -370,7 +377,8 @@ func (cfg *CGRConfig) loadFromJsonCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTlsCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
-		cfg.loadRateSCfg, cfg.loadSIPAgentCfg} {
+		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadBillRunSCfg,
+		cfg.loadRPCAuthzCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -606,7 +614,7 @@ func (cfg *CGRConfig) loadAttributeSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	if jsnAttributeSCfg, err = jsnCfg.AttributeServJsonCfg(); err != nil {
 		return
 	}
-	return cfg.attributeSCfg.loadFromJsonCfg(jsnAttributeSCfg)
+	return cfg.attributeSCfg.loadFromJsonCfg(jsnAttributeSCfg, cfg.generalCfg.RSRSep)
 }
 
 // loadChargerSCfg loads the ChargerS section of the configuration
@@ -770,6 +778,24 @@ func (cfg *CGRConfig) loadRateSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.rateSCfg.loadFromJsonCfg(jsnRateCfg)
 }
 
+// loadBillRunSCfg loads the billruns section of the configuration
+func (cfg *CGRConfig) loadBillRunSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnBillRunCfg *BillRunSJsonCfg
+	if jsnBillRunCfg, err = jsnCfg.BillRunSCfgJson(); err != nil {
+		return
+	}
+	return cfg.billRunSCfg.loadFromJsonCfg(jsnBillRunCfg)
+}
+
+// loadRPCAuthzCfg loads the rpc_authz section of the configuration
+func (cfg *CGRConfig) loadRPCAuthzCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnRPCAuthzCfg *RPCAuthzJsonCfg
+	if jsnRPCAuthzCfg, err = jsnCfg.RPCAuthzCfgJson(); err != nil {
+		return
+	}
+	return cfg.rpcAuthzCfg.loadFromJsonCfg(jsnRPCAuthzCfg)
+}
+
 // loadSIPAgentCfg loads the sip_agent section of the configuration
 func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnSIPAgentCfg *SIPAgentJsonCfg
@@ -1035,6 +1061,20 @@ func (cfg *CGRConfig) RateSCfg() *RateSCfg {
 	return cfg.rateSCfg
 }
 
+// BillRunSCfg reads the BillRunS configuration
+func (cfg *CGRConfig) BillRunSCfg() *BillRunSCfg {
+	cfg.lks[BillRunSJson].RLock()
+	defer cfg.lks[BillRunSJson].RUnlock()
+	return cfg.billRunSCfg
+}
+
+// RPCAuthzCfg reads the RPC authorization configuration
+func (cfg *CGRConfig) RPCAuthzCfg() *RPCAuthzCfg {
+	cfg.lks[RPCAuthzJson].RLock()
+	defer cfg.lks[RPCAuthzJson].RUnlock()
+	return cfg.rpcAuthzCfg
+}
+
 // SIPAgentCfg reads the Apier configuration
 func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	cfg.lks[SIPAgentJson].Lock()
@@ -1267,6 +1307,8 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		RPCConnsJsonName:   cfg.loadRPCConns,
 		RateSJson:          cfg.loadRateSCfg,
 		SIPAgentJson:       cfg.loadSIPAgentCfg,
+		BillRunSJson:       cfg.loadBillRunSCfg,
+		RPCAuthzJson:       cfg.loadRPCAuthzCfg,
 	}
 }
 
@@ -1461,7 +1503,7 @@ func (cfg *CGRConfig) reloadSections(sections ...string) (err error) {
 	subsystemsThatNeedDataDB := utils.NewStringSet([]string{DATADB_JSN, SCHEDULER_JSN,
 		RALS_JSN, CDRS_JSN, SessionSJson, ATTRIBUTE_JSN,
 		ChargerSCfgJson, RESOURCES_JSON, STATS_JSON, THRESHOLDS_JSON,
-		RouteSJson, LoaderJson, DispatcherSJson, RateSJson})
+		RouteSJson, LoaderJson, DispatcherSJson, RateSJson, BillRunSJson})
 	subsystemsThatNeedStorDB := utils.NewStringSet([]string{STORDB_JSN, RALS_JSN, CDRS_JSN, ApierS})
 	needsDataDB := false
 	needsStorDB := false
@@ -1490,6 +1532,7 @@ func (cfg *CGRConfig) reloadSections(sections ...string) (err error) {
 		case STORDB_JSN: // reloaded before
 		case LISTEN_JSN:
 		case TlsCfgJson: // nothing to reload
+		case RPCAuthzJson: // nothing to reload
 		case HTTP_JSN:
 		case SCHEDULER_JSN:
 			cfg.rldChans[SCHEDULER_JSN] <- struct{}{}
@@ -1540,6 +1583,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) (err error) {
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
 		case RateSJson:
 			cfg.rldChans[RateSJson] <- struct{}{}
+		case BillRunSJson:
+			cfg.rldChans[BillRunSJson] <- struct{}{}
 		}
 		return
 	}
@@ -1590,7 +1635,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) map[string]interface{} {
 		utils.DiameterAgentCfg: cfg.diameterAgentCfg.AsMapInterface(separator),
 		utils.RadiusAgentCfg:   cfg.radiusAgentCfg.AsMapInterface(separator),
 		utils.DnsAgentCfg:      cfg.dnsAgentCfg.AsMapInterface(separator),
-		utils.AttributeSCfg:    cfg.attributeSCfg.AsMapInterface(),
+		utils.AttributeSCfg:    cfg.attributeSCfg.AsMapInterface(cfg.generalCfg.RSRSep),
 		utils.ChargerSCfg:      cfg.chargerSCfg.AsMapInterface(),
 		utils.ResourceSCfg:     cfg.resourceSCfg.AsMapInterface(),
 		utils.StatsCfg:         cfg.statsCfg.AsMapInterface(),
@@ -48,6 +48,7 @@ const CGRATES_CFG_JSON = `
 	"connect_timeout": "1s",								// consider connection unsuccessful on timeout, 0 to disable the feature
 	"reply_timeout": "2s",									// consider connection down for replies taking longer than this value
 	"locking_timeout": "0",									// timeout internal locks to avoid deadlocks
+	"locking_backend": "*internal",							// where the locks are kept, *datadb to share them with the engines using the same data_db <*internal|*datadb>
 	"digest_separator": ",",								// separator to use in replies containing data digests
 	"digest_equal": ":",									// equal symbol used in case of digests
 	"rsr_separator": ";",									// separator used within RSR fields
@@ -73,6 +74,11 @@ const CGRATES_CFG_JSON = `
 	"query_timeout":"10s",
 	"remote_conns":[],
 	"replication_conns":[],
+	"internal_db_dump_path": "",			// directory persisting the *internal data_db, empty to disable the persistence
+	"internal_db_snapshot_interval": "1h",	// interval between two snapshots of the *internal data_db, compacting the change log, 0 to snapshot only at shutdown
+	"internal_db_sync_writes": false,		// sync the *internal data_db change log to disk on each write
+	"replication_queue_path": "",			// directory used to queue the replication requests <""|$dir>, empty for synchronous replication
+	"replication_retry_interval": "1s",		// base interval between replication retries, increased on consecutive failures
 	"items":{
 		"*accounts":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 					
 		"*reverse_destinations": {"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false},
@@ -98,6 +104,7 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_profiles":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 
 		"*dispatcher_hosts":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 
 		"*rate_profiles":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false},
+		"*lookup_tables":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false},
 		"*load_ids":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 
 		"*indexes":{"remote":false, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 
 	},
@@ -118,8 +125,13 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields":[],				// prefix indexes on cdrs table to speed up queries, used in case of *internal
 	"query_timeout":"10s",
 	"sslmode":"disable",					// sslmode in case of *postgres
+	"internal_db_dump_path": "",			// directory persisting the *internal stor_db, empty to disable the persistence
+	"internal_db_snapshot_interval": "1h",	// interval between two snapshots of the *internal stor_db, compacting the change log, 0 to snapshot only at shutdown
+	"internal_db_sync_writes": false,		// sync the *internal stor_db change log to disk on each write
 	"items":{
 		"session_costs": {"limit": -1, "ttl": "", "static_ttl": false}, 
+		"audit_records": {"limit": -1, "ttl": "", "static_ttl": false},
+		"action_executions": {"limit": -1, "ttl": "", "static_ttl": false},
 		"cdrs": {"limit": -1, "ttl": "", "static_ttl": false}, 		
 		"tp_timings":{"limit": -1, "ttl": "", "static_ttl": false}, 					
 		"tp_destinations": {"limit": -1, "ttl": "", "static_ttl": false},
@@ -178,10 +190,24 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"rpc_authz": {								// role based authorization of the RPC calls on all listeners
+	"enabled": false,							// enables the authorization: <true|false>
+	"default_role": "",							// role of the connections not authenticated, empty to deny them
+												// cgr-loader and the engine to engine connections(rpc_conns, *localhost) do not authenticate
+												// so the default role needs to allow their calls, the *internal ones are not checked
+	"roles": {},								// allowed method patterns per role (eg: {"readonly": ["*.Get*"], "sessions": ["SessionSv1.*"]})
+	"tokens": {},								// API tokens used with AuthSv1.Authenticate or HTTP Bearer and their role (eg: {"token1": "readonly"})
+	"users": {},								// HTTP basic authentication users and their role, checked against http auth_users (eg: {"username1": "sessions"})
+},
+
+
 "schedulers": {
 	"enabled": false,				// start Scheduler service: <true|false>
 	"cdrs_conns": [],				// connections to CDRs for *cdrlog actions <""|*internal|$rpc_conns_id>
 	"filters": [],					// only execute actions matching these filters
+	"leader_election": false,		// elect over the DataDB the only scheduler executing the actions in the cluster <true|false>
+	"leader_lease_ttl": "10s",		// leadership lease, renewed by the leader, taken over by another scheduler on expiry
+	"execution_history": false,		// record the executions of the ActionPlans into StorDB, needed to catch up the missed runs <true|false>
 },
 
 
@@ -211,6 +237,7 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// control dispatcher profile caching
 		"*dispatcher_hosts": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control dispatcher hosts caching
 		"*rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// control rate profile caching
+		"*lookup_tables": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control lookup table entries caching
 		"*resource_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control resource filter indexes caching
 		"*stat_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 					// control stat filter indexes caching
 		"*threshold_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control threshold filter indexes caching
@@ -233,8 +260,12 @@ const CGRATES_CFG_JSON = `
 		"*rpc_connections": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// RPC connections caching
 		"*uch": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},									// User cache
 		"*stir": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},									// stirShaken cache keys
+		"*http_lookups": {"limit": -1, "ttl": "10m", "static_ttl": false, "replicate": false},							// replies of the *http_lookup attributes
 	},
 	"replication_conns": [],
+	"remote_invalidation": false,					// broadcast the cache invalidations over the DataDB to the engines sharing it
+	"precache_conns": [],							// warm up the precached partitions from these peer engines instead of DataDB <""|$rpc_conns_id>
+	"precache_chunk_size": 1000,					// number of items requested at once from the peer engine
 },
 
 
@@ -281,6 +312,17 @@ const CGRATES_CFG_JSON = `
 	"online_cdr_exports":[],				// list of CDRE profiles to use for real-time CDR exports
 	"scheduler_conns": [],					// connections to SchedulerS in case of *dynaprepaid request
 	"ees_conns": [],						// connections to EventExporter
+	"dedup_policy": "*none",				// handling of the duplicated CDRs, *none returns error: <*none|*drop|*update_if_newer|*merge>
+	"dedup_fields": ["CGRID", "RunID"],		// event fields building the key used to detect the duplicates
+	"dedup_window": "0s",					// events are considered duplicates only within this interval since first seen, 0 to rely on *cdr_ids cache TTL
+	"dedup_newer_field": "",				// field compared by *update_if_newer, empty to consider the last received CDR as newer
+	"archive": {
+		"run_interval": "0s",							// interval between the archive runs, 0 to disable them
+		"archive_path": "/var/spool/cgrates/cdrs_archive",	// path where the archive files and their index are written
+		"format": "*csv",								// format of the compressed archive files <*csv|*json>
+		"max_file_size": 104857600,						// size in bytes of the records, before compression, after which a new archive file is started, 0 for no limit
+		"rules": [],									// CDRs older than max_age, matching tenant and filters are archived, eg: {"tenant": "cgrates.org", "filters": [], "max_age": "2160h"}
+	},
 },
 
 
@@ -358,6 +400,7 @@ const CGRATES_CFG_JSON = `
 	"cache": {
 		"*file_csv": {"limit": -1, "ttl": "5s", "static_ttl": false},
 	},
+	"failed_exports_dir": "*none",			// directory where the exports failing all the retries or still retried on shutdown are stored, <*none|$dir>
 	"exporters": [
 		{
 			"id": "*default",									// identifier of the EventReader profile
@@ -371,6 +414,8 @@ const CGRATES_CFG_JSON = `
 			"attribute_context": "",							// context used to discover matching Attribute profiles
 			"synchronous": false,								// block processing until export has a result
 			"attempts": 1,										// export attempts
+			"retries": 0,										// export retries done in background before storing the event as failed, not applied to the file exporters
+			"retry_interval": "1s",								// base interval of the fibonacci backoff between retries
 			"field_separator": ",",								// separator used in case of csv files
 			"fields":[											// import fields template, tag will match internally CDR field, in case of .csv value will be represented by index of the field value
 				{"tag": "CGRID", "path": "*exp.CGRID", "type": "*variable", "value": "~*req.CGRID"},
@@ -583,6 +628,18 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"process_runs": 1,						// number of run loops when processing event
+	"http_lookups": {						// external HTTP services queried by the *http_lookup attributes, indexed on ID
+		// "CRM_CUSTOMER": {
+		// 	"url": "http://127.0.0.1:8080/customers/;~*req.Account",	// URL of the service, built out of the event with the values path escaped
+		// 	"method": "GET",						// HTTP method used to query the service <GET|POST>
+		// 	"query_params": {},						// query parameters appended to the URL, built out of the event
+		// 	"body": "",								// JSON body sent with POST, built out of the event with the values JSON escaped
+		// 	"reply_path": "",						// path of the value within the JSON reply, the whole reply if empty
+		// 	"timeout": "2s",						// maximum duration to wait for the reply
+		// 	"fallback": "",							// value used when the service cannot be queried, error if empty
+		// 	"fallback_ttl": "10s",					// duration the fallback is used before querying the service again, 0 to query on each event
+		// },
+	},
 },
 
 
@@ -639,6 +696,7 @@ const CGRATES_CFG_JSON = `
 	"resources_conns": [],					// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"thresholds_conns": [],					// connections to ThresholdS for route quarantine notifications, empty to disable: <""|*internal|$rpc_conns_id>
 	"default_ratio":1						// default ratio used in case of *load strategy
 },
 
@@ -649,9 +707,11 @@ const CGRATES_CFG_JSON = `
 		"enabled": false,									// starts as service: <true|false>.
 		"tenant": "",										// tenant used in filterS.Pass
 		"dry_run": false,									// do not send the CDRs to CDRS, just parse them
+		"transactional": false,								// validate all the files before writing and rollback the DataDB changes on errors, needed by *action_plans
 		"run_delay": 0,										// sleep interval in seconds between consecutive runs, 0 to use automation via inotify
 		"lock_filename": ".cgr.lck",						// Filename containing concurrency lock in case of delayed processing
 		"caches_conns": ["*internal"],
+		"scheduler_conns": [],								// connections to SchedulerS for reloads after loading *action_plans, empty to disable: <""|*internal|$rpc_conns_id>
 		"field_separator": ",",								// separator used in case of csv files
 		"tp_in_dir": "/var/spool/cgrates/loader/in",		// absolute path towards the directory where the TPs are stored
 		"tp_out_dir": "/var/spool/cgrates/loader/out",		// absolute path towards the directory where processed TPs will be moved
@@ -758,6 +818,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RouteBlocker", "path": "RouteBlocker", "type": "*variable", "value": "~13"},
 					{"tag": "RouteParameters", "path": "RouteParameters", "type": "*variable", "value": "~14"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~15"},
+					{"tag": "QuarantineRules", "path": "QuarantineRules", "type": "*variable", "value": "~16"},
+					{"tag": "QuarantineCooldown", "path": "QuarantineCooldown", "type": "*variable", "value": "~17"},
 				],
 			},
 			{
@@ -771,6 +833,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RunID", "path": "RunID", "type": "*variable", "value": "~4"},
 					{"tag": "AttributeIDs", "path": "AttributeIDs", "type": "*variable", "value": "~5"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~6"},
+					{"tag": "Opts", "path": "Opts", "type": "*variable", "value": "~7"},
+					{"tag": "Blocker", "path": "Blocker", "type": "*variable", "value": "~8"},
 				],
 			},
 			{
@@ -827,6 +891,16 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RateBlocker", "path": "RateBlocker", "type": "*variable", "value": "~17"},
 				],
 			},
+			{
+				"type": "*lookup_tables",						// data source type
+				"file_name": "LookupTables.csv",				// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~0", "mandatory": true},
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~1", "mandatory": true},
+					{"tag": "Key", "path": "Key", "type": "*variable", "value": "~2", "mandatory": true},
+					{"tag": "Value", "path": "Value", "type": "*variable", "value": "~3"},
+				],
+			},
 		],
 	},
 ],
@@ -920,6 +994,12 @@ const CGRATES_CFG_JSON = `
 	"caches_conns":["*internal"],
 	"scheduler_conns": [],					// connections to SchedulerS for reloads
 	"attributes_conns": [],					// connections to AttributeS for CDRExporter
+	"audit": false,							// record the administrative calls into StorDB: <true|false>
+											// only the calls received on the RPC listeners are recorded, the *internal ones are not
+	"audit_methods": [						// methods recorded by the audit trail, matched as patterns
+		"*.Set*", "*.Remove*", "*.Add*", "*.Load*", "*.Reload*", "*.Import*",
+		"APIerSv1.Debit*", "APIerSv1.ExecuteAction*", "CacheSv1.Clear", "CacheSv1.FlushCache",
+	],
 },
 
 
@@ -935,6 +1015,14 @@ const CGRATES_CFG_JSON = `
 	"rate_nested_fields": false,			// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 },
 
+"billruns": {
+	"enabled": false,						// starts the bill-run service: <true|false>
+	"cdrs_conns": ["*internal"],			// connections to CDRs used to query the rated CDRs: <""|*internal|$rpc_conns_id>
+	"export_path": "/var/spool/cgrates/billruns",	// path where the invoices are written
+	"html_template": "",					// path to the html/template file used by *html invoices, empty for the built-in one
+	"run_ids": ["*default"],				// RunIDs of the CDRs billed in the invoices, empty to bill all except *raw
+},
+
 "sip_agent": {							// SIP Agents, only used for redirections
 	"enabled": false,					// enables the SIP agent: <true|false>
 	"listen": "127.0.0.1:5060",			// address where to listen for SIP requests <x.y.z.y:1234>
@@ -326,6 +326,14 @@ func testCGRConfigReloadCDRs(t *testing.T) {
 		StoreCdrs:       true,
 		SchedulerConns:  []string{},
 		EEsConns:        []string{},
+		DedupPolicy:     utils.META_NONE,
+		DedupFields:     []string{utils.CGRID, utils.RunID},
+		Archive: &CdrsArchiveCfg{
+			ArchivePath: "/var/spool/cgrates/cdrs_archive",
+			Format:      utils.MetaCSV,
+			MaxFileSize: 104857600,
+			Rules:       []*CdrsArchiveRule{},
+		},
 	}
 	if !reflect.DeepEqual(expAttr, cfg.CdrsCfg()) {
 		t.Errorf("Expected %s , received: %s ", utils.ToJSON(expAttr), utils.ToJSON(cfg.CdrsCfg()))
@@ -60,6 +60,8 @@ const (
 	ERsJson            = "ers"
 	EEsJson            = "ees"
 	RateSJson          = "rates"
+	BillRunSJson       = "billruns"
+	RPCAuthzJson       = "rpc_authz"
 	RPCConnsJsonName   = "rpc_conns"
 	SIPAgentJson       = "sip_agent"
 )
@@ -69,7 +71,7 @@ var (
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, CDRE_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN,
 		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
-		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson}
+		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, BillRunSJson, RPCAuthzJson}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -508,6 +510,30 @@ func (self CgrJsonCfg) ApierCfgJson() (*ApierJsonCfg, error) {
 	return cfg, nil
 }
 
+func (self CgrJsonCfg) BillRunSCfgJson() (*BillRunSJsonCfg, error) {
+	rawCfg, hasKey := self[BillRunSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(BillRunSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) RPCAuthzCfgJson() (*RPCAuthzJsonCfg, error) {
+	rawCfg, hasKey := self[RPCAuthzJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(RPCAuthzJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (self CgrJsonCfg) RateCfgJson() (*RateSJsonCfg, error) {
 	rawCfg, hasKey := self[RateSJson]
 	if !hasKey {
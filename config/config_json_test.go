@@ -58,6 +58,7 @@ func TestDfGeneralJsonCfg(t *testing.T) {
 		Connect_timeout:      utils.StringPointer("1s"),
 		Reply_timeout:        utils.StringPointer("2s"),
 		Locking_timeout:      utils.StringPointer("0"),
+		Locking_backend:      utils.StringPointer(utils.MetaInternal),
 		Digest_separator:     utils.StringPointer(","),
 		Digest_equal:         utils.StringPointer(":"),
 		Rsr_separator:        utils.StringPointer(";"),
@@ -172,6 +173,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRateFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheLookupTables: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheReverseFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -211,8 +215,14 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheSTIR: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheHTTPLookups: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("10m"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 		},
-		Replication_conns: &[]string{},
+		Replication_conns:   &[]string{},
+		Remote_invalidation: utils.BoolPointer(false),
+		Precache_conns:      &[]string{},
+		Precache_chunk_size: utils.IntPointer(1000),
 	}
 
 	if gCfg, err := dfCgrJsonCfg.CacheJsonCfg(); err != nil {
@@ -250,6 +260,13 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 		Query_timeout:     utils.StringPointer("10s"),
 		Replication_conns: &[]string{},
 		Remote_conns:      &[]string{},
+
+		Internal_db_dump_path:         utils.StringPointer(""),
+		Internal_db_snapshot_interval: utils.StringPointer("1h"),
+		Internal_db_sync_writes:       utils.BoolPointer(false),
+
+		Replication_queue_path:     utils.StringPointer(""),
+		Replication_retry_interval: utils.StringPointer("1s"),
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
 				Replicate:  utils.BoolPointer(false),
@@ -383,6 +400,12 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Limit:      utils.IntPointer(-1),
 				Static_ttl: utils.BoolPointer(false)},
+			utils.MetaLookupTables: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Limit:      utils.IntPointer(-1),
+				Static_ttl: utils.BoolPointer(false)},
 			utils.MetaChargerProfiles: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -431,6 +454,10 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{},
 		Query_timeout:         utils.StringPointer("10s"),
 		Sslmode:               utils.StringPointer(utils.PostgressSSLModeDisable),
+
+		Internal_db_dump_path:         utils.StringPointer(""),
+		Internal_db_snapshot_interval: utils.StringPointer("1h"),
+		Internal_db_sync_writes:       utils.BoolPointer(false),
 		Items: &map[string]*ItemOptJson{
 			utils.TBLTPTimings: {
 				Ttl:        utils.StringPointer(utils.EmptyString),
@@ -492,6 +519,14 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Limit:      utils.IntPointer(-1),
 				Static_ttl: utils.BoolPointer(false)},
+			utils.AuditRecordsTBL: {
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Limit:      utils.IntPointer(-1),
+				Static_ttl: utils.BoolPointer(false)},
+			utils.ActionExecutionsTBL: {
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Limit:      utils.IntPointer(-1),
+				Static_ttl: utils.BoolPointer(false)},
 			utils.TBLTPActionPlans: {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Limit:      utils.IntPointer(-1),
@@ -568,9 +603,12 @@ func TestDfRalsJsonCfg(t *testing.T) {
 
 func TestDfSchedulerJsonCfg(t *testing.T) {
 	eCfg := &SchedulerJsonCfg{
-		Enabled:    utils.BoolPointer(false),
-		Cdrs_conns: &[]string{},
-		Filters:    &[]string{},
+		Enabled:           utils.BoolPointer(false),
+		Cdrs_conns:        &[]string{},
+		Filters:           &[]string{},
+		Leader_election:   utils.BoolPointer(false),
+		Leader_lease_ttl:  utils.StringPointer("10s"),
+		Execution_history: utils.BoolPointer(false),
 	}
 	if cfg, err := dfCgrJsonCfg.SchedulerJsonCfg(); err != nil {
 		t.Error(err)
@@ -593,6 +631,17 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{},
 		Scheduler_conns:      &[]string{},
 		Ees_conns:            &[]string{},
+		Dedup_policy:         utils.StringPointer(utils.META_NONE),
+		Dedup_fields:         &[]string{utils.CGRID, utils.RunID},
+		Dedup_window:         utils.StringPointer("0s"),
+		Dedup_newer_field:    utils.StringPointer(""),
+		Archive: &CdrsArchiveJsonCfg{
+			Run_interval:  utils.StringPointer("0s"),
+			Archive_path:  utils.StringPointer("/var/spool/cgrates/cdrs_archive"),
+			Format:        utils.StringPointer(utils.MetaCSV),
+			Max_file_size: utils.Int64Pointer(104857600),
+			Rules:         &[]*CdrsArchiveRuleJsonCfg{},
+		},
 	}
 	if cfg, err := dfCgrJsonCfg.CdrsJsonCfg(); err != nil {
 		t.Error(err)
@@ -1033,6 +1082,7 @@ func TestDfAttributeServJsonCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{},
 		Process_runs:          utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
+		Http_lookups:          &map[string]*HTTPLookupJsonCfg{},
 	}
 	if cfg, err := dfCgrJsonCfg.AttributeServJsonCfg(); err != nil {
 		t.Error(err)
@@ -1131,6 +1181,7 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Resources_conns:       &[]string{},
 		Stats_conns:           &[]string{},
 		Rals_conns:            &[]string{},
+		Thresholds_conns:      &[]string{},
 		Default_ratio:         utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
 	}
@@ -1148,9 +1199,11 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Enabled:         utils.BoolPointer(false),
 			Tenant:          utils.StringPointer(""),
 			Dry_run:         utils.BoolPointer(false),
+			Transactional:   utils.BoolPointer(false),
 			Run_delay:       utils.IntPointer(0),
 			Lock_filename:   utils.StringPointer(".cgr.lck"),
 			Caches_conns:    &[]string{utils.MetaInternal},
+			Scheduler_conns: &[]string{},
 			Field_separator: utils.StringPointer(","),
 			Tp_in_dir:       utils.StringPointer("/var/spool/cgrates/loader/in"),
 			Tp_out_dir:      utils.StringPointer("/var/spool/cgrates/loader/out"),
@@ -1474,6 +1527,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~15")},
+						{Tag: utils.StringPointer("QuarantineRules"),
+							Path:  utils.StringPointer("QuarantineRules"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~16")},
+						{Tag: utils.StringPointer("QuarantineCooldown"),
+							Path:  utils.StringPointer("QuarantineCooldown"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~17")},
 					},
 				},
 				{
@@ -1510,6 +1571,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~6")},
+						{Tag: utils.StringPointer("Opts"),
+							Path:  utils.StringPointer("Opts"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~7")},
+						{Tag: utils.StringPointer("Blocker"),
+							Path:  utils.StringPointer("Blocker"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~8")},
 					},
 				},
 				{
@@ -1680,6 +1749,31 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Value: utils.StringPointer("~17")},
 					},
 				},
+				{
+					Type:      utils.StringPointer(utils.MetaLookupTables),
+					File_name: utils.StringPointer(utils.LookupTablesCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.Key),
+							Path:      utils.StringPointer(utils.Key),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~2"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.Value),
+							Path:  utils.StringPointer(utils.Value),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~3")},
+					},
+				},
 			},
 		},
 	}
@@ -1851,6 +1945,9 @@ func TestDfApierCfg(t *testing.T) {
 		Caches_conns:     &[]string{utils.MetaInternal},
 		Scheduler_conns:  &[]string{},
 		Attributes_conns: &[]string{},
+		Audit:            utils.BoolPointer(false),
+		Audit_methods: &[]string{"*.Set*", "*.Remove*", "*.Add*", "*.Load*", "*.Reload*", "*.Import*",
+			"APIerSv1.Debit*", "APIerSv1.ExecuteAction*", "CacheSv1.Clear", "CacheSv1.FlushCache"},
 	}
 	if cfg, err := dfCgrJsonCfg.ApierCfgJson(); err != nil {
 		t.Error(err)
@@ -2014,6 +2111,7 @@ func TestDfEventExporterCfg(t *testing.T) {
 				Static_ttl: utils.BoolPointer(false),
 			},
 		},
+		Failed_exports_dir: utils.StringPointer(utils.META_NONE),
 		Exporters: &[]*EventExporterJsonCfg{
 			{
 				Id:                utils.StringPointer(utils.MetaDefault),
@@ -2028,6 +2126,8 @@ func TestDfEventExporterCfg(t *testing.T) {
 				Flags:             &[]string{},
 				Synchronous:       utils.BoolPointer(false),
 				Attempts:          utils.IntPointer(1),
+				Retries:           utils.IntPointer(0),
+				Retry_interval:    utils.StringPointer("1s"),
 				Fields:            &eContentFlds,
 			},
 		},
@@ -2039,6 +2139,36 @@ func TestDfEventExporterCfg(t *testing.T) {
 	}
 }
 
+func TestDfBillRunSJsonCfg(t *testing.T) {
+	eCfg := &BillRunSJsonCfg{
+		Enabled:       utils.BoolPointer(false),
+		Cdrs_conns:    &[]string{utils.MetaInternal},
+		Export_path:   utils.StringPointer("/var/spool/cgrates/billruns"),
+		Html_template: utils.StringPointer(""),
+		Run_ids:       &[]string{utils.MetaDefault},
+	}
+	if cfg, err := dfCgrJsonCfg.BillRunSCfgJson(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
+func TestDfRPCAuthzJsonCfg(t *testing.T) {
+	eCfg := &RPCAuthzJsonCfg{
+		Enabled:      utils.BoolPointer(false),
+		Default_role: utils.StringPointer(""),
+		Roles:        &map[string][]string{},
+		Tokens:       &map[string]string{},
+		Users:        &map[string]string{},
+	}
+	if cfg, err := dfCgrJsonCfg.RPCAuthzCfgJson(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
 func TestDfRateSJsonCfg(t *testing.T) {
 	eCfg := &RateSJsonCfg{
 		Enabled:                    utils.BoolPointer(false),
@@ -408,9 +408,10 @@ func TestCgrCfgJSONDefaultsRALs(t *testing.T) {
 
 func TestCgrCfgJSONDefaultsScheduler(t *testing.T) {
 	eSchedulerCfg := &SchedulerCfg{
-		Enabled:   false,
-		CDRsConns: []string{},
-		Filters:   []string{},
+		Enabled:        false,
+		CDRsConns:      []string{},
+		Filters:        []string{},
+		LeaderLeaseTTL: 10 * time.Second,
 	}
 	if !reflect.DeepEqual(cgrCfg.schedulerCfg, eSchedulerCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.schedulerCfg, eSchedulerCfg)
@@ -429,6 +430,14 @@ func TestCgrCfgJSONDefaultsCDRS(t *testing.T) {
 		StatSConns:      []string{},
 		SchedulerConns:  []string{},
 		EEsConns:        []string{},
+		DedupPolicy:     utils.META_NONE,
+		DedupFields:     []string{utils.CGRID, utils.RunID},
+		Archive: &CdrsArchiveCfg{
+			ArchivePath: "/var/spool/cgrates/cdrs_archive",
+			Format:      utils.MetaCSV,
+			MaxFileSize: 104857600,
+			Rules:       []*CdrsArchiveRule{},
+		},
 	}
 	if !reflect.DeepEqual(eCdrsCfg, cgrCfg.cdrsCfg) {
 		t.Errorf("Expecting: %+v , received: %+v", eCdrsCfg, cgrCfg.cdrsCfg)
@@ -691,6 +700,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheReverseFilterIndexes: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheLookupTables: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheDispatcherRoutes: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheDispatcherLoads: {Limit: -1,
@@ -715,8 +726,12 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(3 * time.Hour), StaticTTL: false},
 			utils.CacheSTIR: {Limit: -1,
 				TTL: time.Duration(3 * time.Hour), StaticTTL: false},
+			utils.CacheHTTPLookups: {Limit: -1,
+				TTL: time.Duration(10 * time.Minute), StaticTTL: false},
 		},
-		ReplicationConns: []string{},
+		ReplicationConns:  []string{},
+		PrecacheConns:     []string{},
+		PrecacheChunkSize: 1000,
 	}
 
 	if !reflect.DeepEqual(eCacheCfg, cgrCfg.CacheCfg()) {
@@ -856,6 +871,7 @@ func TestCgrCfgJSONDefaultRouteSCfg(t *testing.T) {
 		ResourceSConns:      []string{},
 		StatSConns:          []string{},
 		ResponderSConns:     []string{},
+		ThresholdSConns:     []string{},
 		DefaultRatio:        1,
 	}
 	if !reflect.DeepEqual(eSupplSCfg, cgrCfg.routeSCfg) {
@@ -1034,9 +1050,11 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 			Id:             utils.MetaDefault,
 			Enabled:        false,
 			DryRun:         false,
+			Transactional:  false,
 			RunDelay:       0,
 			LockFileName:   ".cgr.lck",
 			CacheSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)},
+			SchedulerConns: []string{},
 			FieldSeparator: ",",
 			TpInDir:        "/var/spool/cgrates/loader/in",
 			TpOutDir:       "/var/spool/cgrates/loader/out",
@@ -1443,6 +1461,16 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							Type:   utils.MetaVariable,
 							Value:  NewRSRParsersMustCompile("~15", true, utils.INFIELD_SEP),
 							Layout: time.RFC3339},
+						{Tag: "QuarantineRules",
+							Path:   "QuarantineRules",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~16", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "QuarantineCooldown",
+							Path:   "QuarantineCooldown",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~17", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
 					},
 				},
 				{
@@ -1486,6 +1514,16 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 							Type:   utils.MetaVariable,
 							Value:  NewRSRParsersMustCompile("~6", true, utils.INFIELD_SEP),
 							Layout: time.RFC3339},
+						{Tag: "Opts",
+							Path:   "Opts",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~7", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+						{Tag: "Blocker",
+							Path:   "Blocker",
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~8", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
 					},
 				},
 				{
@@ -1722,6 +1760,35 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 						},
 					},
 				},
+				{
+					Type:     utils.MetaLookupTables,
+					Filename: utils.LookupTablesCsv,
+					Fields: []*FCTemplate{
+						{Tag: utils.Tenant,
+							Path:      utils.Tenant,
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: utils.ID,
+							Path:      utils.ID,
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: utils.Key,
+							Path:      utils.Key,
+							Type:      utils.MetaVariable,
+							Value:     NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP),
+							Mandatory: true,
+							Layout:    time.RFC3339},
+						{Tag: utils.Value,
+							Path:   utils.Value,
+							Type:   utils.MetaVariable,
+							Value:  NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP),
+							Layout: time.RFC3339},
+					},
+				},
 			},
 		},
 	}
@@ -1885,6 +1952,9 @@ func TestCgrCfgJSONDefaultApierCfg(t *testing.T) {
 		CachesConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)},
 		SchedulerConns:  []string{},
 		AttributeSConns: []string{},
+		Audit:           false,
+		AuditMethods: []string{"*.Set*", "*.Remove*", "*.Add*", "*.Load*", "*.Reload*", "*.Import*",
+			"APIerSv1.Debit*", "APIerSv1.ExecuteAction*", "CacheSv1.Clear", "CacheSv1.FlushCache"},
 	}
 	if !reflect.DeepEqual(cgrCfg.apier, aCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.apier, aCfg)
@@ -1908,6 +1978,32 @@ func TestCgrCfgJSONDefaultRateCfg(t *testing.T) {
 	}
 }
 
+func TestCgrCfgJSONDefaultBillRunSCfg(t *testing.T) {
+	eCfg := &BillRunSCfg{
+		Enabled:      false,
+		CDRsConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)},
+		ExportPath:   "/var/spool/cgrates/billruns",
+		HTMLTemplate: "",
+		RunIDs:       []string{utils.MetaDefault},
+	}
+	if !reflect.DeepEqual(cgrCfg.billRunSCfg, eCfg) {
+		t.Errorf("received: %+v, expecting: %+v", cgrCfg.billRunSCfg, eCfg)
+	}
+}
+
+func TestCgrCfgJSONDefaultRPCAuthzCfg(t *testing.T) {
+	eCfg := &RPCAuthzCfg{
+		Enabled:     false,
+		DefaultRole: "",
+		Roles:       map[string][]string{},
+		Tokens:      map[string]string{},
+		Users:       map[string]string{},
+	}
+	if !reflect.DeepEqual(cgrCfg.rpcAuthzCfg, eCfg) {
+		t.Errorf("received: %+v, expecting: %+v", cgrCfg.rpcAuthzCfg, eCfg)
+	}
+}
+
 func TestCgrCfgV1GetConfigSection(t *testing.T) {
 	JSN_CFG := `
 {
@@ -2003,6 +2099,7 @@ func TestCgrCdfEventExporter(t *testing.T) {
 				StaticTTL: false,
 			},
 		},
+		FailedExportsDir: utils.META_NONE,
 		Exporters: []*EventExporterCfg{
 			&EventExporterCfg{
 				ID:            utils.MetaDefault,
@@ -2011,6 +2108,7 @@ func TestCgrCdfEventExporter(t *testing.T) {
 				Tenant:        nil,
 				ExportPath:    "/var/spool/cgrates/ees",
 				Attempts:      1,
+				RetryInterval: time.Second,
 				Timezone:      utils.EmptyString,
 				Filters:       []string{},
 				AttributeSIDs: []string{},
@@ -2285,15 +2383,16 @@ func TestCgrCfgEventReaderDefault(t *testing.T) {
 
 func TestCgrCfgEventExporterDefault(t *testing.T) {
 	eCfg := &EventExporterCfg{
-		ID:         utils.MetaDefault,
-		Type:       utils.META_NONE,
-		FieldSep:   ",",
-		Tenant:     nil,
-		ExportPath: "/var/spool/cgrates/ees",
-		Attempts:   1,
-		Timezone:   utils.EmptyString,
-		Filters:    nil,
-		Flags:      utils.FlagsWithParams{},
+		ID:            utils.MetaDefault,
+		Type:          utils.META_NONE,
+		FieldSep:      ",",
+		Tenant:        nil,
+		ExportPath:    "/var/spool/cgrates/ees",
+		Attempts:      1,
+		RetryInterval: time.Second,
+		Timezone:      utils.EmptyString,
+		Filters:       nil,
+		Flags:         utils.FlagsWithParams{},
 		contentFields: []*FCTemplate{
 			{
 				Tag:    utils.CGRID,
@@ -2629,6 +2728,7 @@ func TestGeneralCfg(t *testing.T) {
 			"connect_timeout": "1s",
 			"reply_timeout": "2s",
 			"locking_timeout": "0",
+			"locking_backend": "*internal",
 			"digest_separator": ",",
 			"digest_equal": ":",
 			"rsr_separator": ";",
@@ -2656,6 +2756,7 @@ func TestGeneralCfg(t *testing.T) {
 		"connect_timeout":      "1s",
 		"reply_timeout":        "2s",
 		"locking_timeout":      "0",
+		"locking_backend":      "*internal",
 		"digest_separator":     ",",
 		"digest_equal":         ":",
 		"rsr_separator":        ";",
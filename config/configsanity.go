@@ -106,6 +106,25 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
 			}
 		}
+		if !utils.IsSliceMember([]string{utils.META_NONE, utils.MetaDrop, utils.MetaMerge, utils.MetaUpdateIfNewer}, cfg.cdrsCfg.DedupPolicy) {
+			return fmt.Errorf("<%s> unsupported dedup policy %s", utils.CDRs, cfg.cdrsCfg.DedupPolicy)
+		}
+		if cfg.cdrsCfg.DedupPolicy != utils.META_NONE && len(cfg.cdrsCfg.DedupFields) == 0 {
+			return fmt.Errorf("<%s> dedup_fields cannot be empty for dedup policy %s", utils.CDRs, cfg.cdrsCfg.DedupPolicy)
+		}
+		if arcCfg := cfg.cdrsCfg.Archive; arcCfg != nil && len(arcCfg.Rules) != 0 {
+			if _, err := os.Stat(arcCfg.ArchivePath); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("<%s> nonexistent archive folder: %s", utils.CDRs, arcCfg.ArchivePath)
+			}
+			if arcCfg.Format != utils.MetaCSV && arcCfg.Format != utils.MetaJSON {
+				return fmt.Errorf("<%s> unsupported archive format %s", utils.CDRs, arcCfg.Format)
+			}
+			for _, rule := range arcCfg.Rules {
+				if rule.MaxAge <= 0 {
+					return fmt.Errorf("<%s> archive rule for tenant <%s> needs a positive max_age", utils.CDRs, rule.Tenant)
+				}
+			}
+		}
 		for prfl, cdre := range cfg.CdreProfiles {
 			for _, field := range cdre.Fields {
 				if field.Type != utils.META_NONE && field.Path == utils.EmptyString {
@@ -128,6 +147,9 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			if !posibleLoaderTypes.Has(data.Type) {
 				return fmt.Errorf("<%s> unsupported data type %s", utils.LoaderS, data.Type)
 			}
+			if data.Type == utils.MetaActionPlans && !ldrSCfg.Transactional {
+				return fmt.Errorf("<%s> data type %s is only supported by transactional loaders", utils.LoaderS, data.Type)
+			}
 
 			for _, field := range data.Fields {
 				if field.Type != utils.META_COMPOSED && field.Type != utils.MetaString && field.Type != utils.MetaVariable {
@@ -483,6 +505,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		for _, connID := range cfg.routeSCfg.ThresholdSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.ThresholdS, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
 	}
 	// Scheduler check connection with CDR Server
 	if cfg.schedulerCfg.Enabled {
@@ -494,6 +524,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SchedulerS, connID)
 			}
 		}
+		if cfg.schedulerCfg.LeaderElection {
+			if cfg.dataDbCfg.DataDbType == utils.INTERNAL {
+				return fmt.Errorf("<%s> leader_election cannot be enabled when DataDB is *internal", utils.SchedulerS)
+			}
+			if cfg.schedulerCfg.LeaderLeaseTTL <= 0 {
+				return fmt.Errorf("<%s> the leader_lease_ttl needs to be positive when leader_election is enabled", utils.SchedulerS)
+			}
+		}
 	}
 	// EventReader sanity checks
 	if cfg.ersCfg.Enabled {
@@ -520,10 +558,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if rdr.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for reader with ID: %s", utils.ERs, rdr.ID)
 				}
-			case utils.MetaKafkajsonMap:
+			case utils.MetaKafkajsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap:
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
+			case utils.MetaS3jsonMap:
+				if rdr.RunDelay < 0 {
+					return fmt.Errorf("<%s> the RunDelay field can not be smaller than zero for reader with ID: %s", utils.ERs, rdr.ID)
+				}
 			case utils.MetaFileXML, utils.MetaFileFWV, utils.MetaJSON:
 				for _, dir := range []string{rdr.ProcessedPath, rdr.SourcePath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
@@ -553,6 +595,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.EEs, connID)
 			}
 		}
+		if cfg.eesCfg.FailedExportsDir != utils.META_NONE {
+			if _, err := os.Stat(cfg.eesCfg.FailedExportsDir); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("<%s> nonexistent folder: %s for failed exports", utils.EEs, cfg.eesCfg.FailedExportsDir)
+			}
+		}
 		for _, exp := range cfg.eesCfg.Exporters {
 			if !possibleExporterTypes.Has(exp.Type) {
 				return fmt.Errorf("<%s> unsupported data type: %s for exporter with ID: %s", utils.EEs, exp.Type, exp.ID)
@@ -582,6 +629,45 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// BillRunS sanity checks
+	if cfg.billRunSCfg.Enabled {
+		for _, connID := range cfg.billRunSCfg.CDRsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.cdrsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.CDRs, utils.BillRunS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.BillRunS, connID)
+			}
+		}
+		if _, err := os.Stat(cfg.billRunSCfg.ExportPath); err != nil && os.IsNotExist(err) {
+			return fmt.Errorf("<%s> nonexistent folder: %s", utils.BillRunS, cfg.billRunSCfg.ExportPath)
+		}
+		if cfg.billRunSCfg.HTMLTemplate != utils.EmptyString {
+			if _, err := os.Stat(cfg.billRunSCfg.HTMLTemplate); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("<%s> nonexistent html template: %s", utils.BillRunS, cfg.billRunSCfg.HTMLTemplate)
+			}
+		}
+	}
+	// RPCAuthz sanity checks
+	if cfg.rpcAuthzCfg.Enabled {
+		if cfg.rpcAuthzCfg.DefaultRole != utils.EmptyString {
+			if _, has := cfg.rpcAuthzCfg.Roles[cfg.rpcAuthzCfg.DefaultRole]; !has {
+				return fmt.Errorf("<%s> role <%s> not defined", utils.RPCAuthz, cfg.rpcAuthzCfg.DefaultRole)
+			}
+		}
+		for _, roles := range []map[string]string{cfg.rpcAuthzCfg.Tokens, cfg.rpcAuthzCfg.Users} {
+			for _, role := range roles {
+				if _, has := cfg.rpcAuthzCfg.Roles[role]; !has {
+					return fmt.Errorf("<%s> role <%s> not defined", utils.RPCAuthz, role)
+				}
+			}
+		}
+		for user := range cfg.rpcAuthzCfg.Users {
+			if _, has := cfg.httpCfg.HTTPAuthUsers[user]; !has {
+				return fmt.Errorf("<%s> user <%s> not defined in %s %s", utils.RPCAuthz, user, utils.HttpCfg, utils.HTTPAuthUsersCfg)
+			}
+		}
+	}
 	// StorDB sanity checks
 	if cfg.storDbCfg.Type == utils.POSTGRES {
 		if !utils.IsSliceMember([]string{utils.PostgressSSLModeDisable, utils.PostgressSSLModeAllow,
@@ -606,6 +692,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if cfg.thresholdSCfg.Enabled == true && cfg.thresholdSCfg.StoreInterval != -1 {
 			return fmt.Errorf("<%s> the StoreInterval field needs to be -1 when DataBD is *internal, received : %d", utils.ThresholdS, cfg.thresholdSCfg.StoreInterval)
 		}
+		if cfg.generalCfg.LockingBackend == utils.MetaDataDB {
+			return fmt.Errorf("<%s> locking_backend cannot be %s when DataDB is *internal", utils.GeneralCfg, utils.MetaDataDB)
+		}
+		if cfg.cacheCfg.RemoteInvalidation {
+			return fmt.Errorf("<%s> remote_invalidation cannot be enabled when DataDB is *internal", utils.CacheCfg)
+		}
+	}
+	if !utils.IsSliceMember([]string{utils.MetaInternal, utils.MetaDataDB}, cfg.generalCfg.LockingBackend) {
+		return fmt.Errorf("<%s> unsupported locking_backend: %s", utils.GeneralCfg, cfg.generalCfg.LockingBackend)
 	}
 	for item, val := range cfg.dataDbCfg.Items {
 		if val.Remote == true && len(cfg.dataDbCfg.RmtConns) == 0 {
@@ -655,6 +750,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	for _, connID := range cfg.cacheCfg.PrecacheConns {
+		if _, has := cfg.rpcConns[connID]; !has {
+			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CacheS, connID)
+		}
+	}
+	if len(cfg.cacheCfg.PrecacheConns) != 0 && cfg.cacheCfg.PrecacheChunkSize <= 0 {
+		return fmt.Errorf("<%s> precache_chunk_size needs to be positive, received: %d", utils.CacheS, cfg.cacheCfg.PrecacheChunkSize)
+	}
 	for cacheID := range cfg.cacheCfg.Partitions {
 		if !utils.CachePartitions.Has(cacheID) {
 			return fmt.Errorf("<%s> partition <%s> not defined", utils.CacheS, cacheID)
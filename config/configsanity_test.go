@@ -125,6 +125,40 @@ func TestConfigSanityCDRServer(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.cdrsCfg.ThresholdSConns = []string{}
+	cfg.cdrsCfg.OnlineCDRExports = []string{}
+
+	cfg.cdrsCfg.DedupPolicy = "*wrong"
+	expected = "<CDRs> unsupported dedup policy *wrong"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.DedupPolicy = utils.MetaMerge
+	expected = "<CDRs> dedup_fields cannot be empty for dedup policy *merge"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.DedupPolicy = utils.META_NONE
+
+	cfg.cdrsCfg.Archive = &CdrsArchiveCfg{
+		ArchivePath: "/not/exist",
+		Format:      "*wrong",
+		Rules:       []*CdrsArchiveRule{{Tenant: "cgrates.org"}},
+	}
+	expected = "<CDRs> nonexistent archive folder: /not/exist"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.Archive.ArchivePath = "/tmp"
+	expected = "<CDRs> unsupported archive format *wrong"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.Archive.Format = utils.MetaCSV
+	expected = "<CDRs> archive rule for tenant <cgrates.org> needs a positive max_age"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityLoaders(t *testing.T) {
@@ -162,6 +196,23 @@ func TestConfigSanityLoaders(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "/",
+			TpOutDir: "/",
+			Data: []*LoaderDataType{
+				&LoaderDataType{
+					Type: utils.MetaActionPlans,
+				},
+			},
+		},
+	}
+	expected = "<LoaderS> data type *action_plans is only supported by transactional loaders"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.loaderCfg = LoaderSCfgs{
 		&LoaderSCfg{
 			Enabled:  true,
@@ -602,6 +653,18 @@ func TestConfigSanityRouteS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.routeSCfg.AttributeSConns = []string{}
+
+	cfg.routeSCfg.ThresholdSConns = []string{utils.MetaInternal}
+	expected = "<ThresholdS> not enabled but requested by <RouteS> component."
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.ThresholdSConns = []string{"test"}
+	expected = "<RouteS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityScheduler(t *testing.T) {
@@ -619,7 +682,18 @@ func TestConfigSanityScheduler(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
-
+	cfg.schedulerCfg.CDRsConns = []string{}
+	cfg.schedulerCfg.LeaderElection = true
+	cfg.dataDbCfg.DataDbType = utils.INTERNAL
+	expected = "<SchedulerS> leader_election cannot be enabled when DataDB is *internal"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.DataDbType = utils.REDIS
+	expected = "<SchedulerS> the leader_lease_ttl needs to be positive when leader_election is enabled"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityEventReader(t *testing.T) {
@@ -684,6 +758,23 @@ func TestConfigSanityEventReader(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:       "test4",
+		Type:     utils.MetaAMQPjsonMap,
+		RunDelay: 1,
+	}
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:       "test4",
+		Type:     utils.MetaS3jsonMap,
+		RunDelay: -1,
+	}
+	expected = "<ERs> the RunDelay field can not be smaller than zero for reader with ID: test4"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 	cfg.ersCfg.Readers[0] = &EventReaderCfg{
 		ID:            "test5",
 		Type:          utils.MetaFileXML,
@@ -711,6 +802,18 @@ func TestConfigSanityEventReader(t *testing.T) {
 	}
 }
 
+func TestConfigSanityEventExporter(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.eesCfg = &EEsCfg{
+		Enabled:          true,
+		FailedExportsDir: "/not/a/path",
+	}
+	expected := "<EEs> nonexistent folder: /not/a/path for failed exports"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityStorDB(t *testing.T) {
 	cfg, _ = NewDefaultCGRConfig()
 	cfg.storDbCfg = &StorDbCfg{
@@ -771,6 +874,25 @@ func TestConfigSanityDataDB(t *testing.T) {
 	}
 	cfg.thresholdSCfg.Enabled = false
 
+	cfg.generalCfg.LockingBackend = utils.MetaDataDB
+	expected = "<general> locking_backend cannot be *datadb when DataDB is *internal"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.LockingBackend = "*redis"
+	expected = "<general> unsupported locking_backend: *redis"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.LockingBackend = utils.MetaInternal
+
+	cfg.cacheCfg.RemoteInvalidation = true
+	expected = "<caches> remote_invalidation cannot be enabled when DataDB is *internal"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cacheCfg.RemoteInvalidation = false
+
 	cfg.dataDbCfg.Items = map[string]*ItemOpt{
 		"test1": &ItemOpt{
 			Remote: true,
@@ -848,6 +970,22 @@ func TestConfigSanityCacheS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err != nil {
 		t.Error(err)
 	}
+
+	cfg.cacheCfg.PrecacheConns = []string{"peer"}
+	expected := "<CacheS> connection with id: <peer> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcConns["peer"] = &RPCConn{}
+	cfg.cacheCfg.PrecacheChunkSize = 0
+	expected = "<CacheS> precache_chunk_size needs to be positive, received: 0"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cacheCfg.PrecacheChunkSize = 1000
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestConfigSanityFilterS(t *testing.T) {
@@ -887,3 +1025,61 @@ func TestConfigSanityFilterS(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 }
+
+func TestConfigSanityBillRunS(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.billRunSCfg.Enabled = true
+	cfg.billRunSCfg.CDRsConns = []string{utils.MetaInternal}
+
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != "<CDRs> not enabled but requested by <BillRunS> component." {
+		t.Error(err)
+	}
+	cfg.billRunSCfg.CDRsConns = []string{"test"}
+	expected := "<BillRunS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.billRunSCfg.CDRsConns = []string{}
+	cfg.billRunSCfg.ExportPath = "/not/exist"
+	expected = "<BillRunS> nonexistent folder: /not/exist"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.billRunSCfg.ExportPath = "/tmp"
+	cfg.billRunSCfg.HTMLTemplate = "/not/exist/invoice.html"
+	expected = "<BillRunS> nonexistent html template: /not/exist/invoice.html"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
+func TestConfigSanityRPCAuthz(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.rpcAuthzCfg.Enabled = true
+	cfg.rpcAuthzCfg.DefaultRole = "readonly"
+	expected := "<RPCAuthz> role <readonly> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcAuthzCfg.Roles = map[string][]string{"readonly": {"*.Get*"}}
+	cfg.rpcAuthzCfg.Tokens = map[string]string{"token1": "admin"}
+	expected = "<RPCAuthz> role <admin> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcAuthzCfg.Tokens = map[string]string{"token1": "readonly"}
+	cfg.rpcAuthzCfg.Users = map[string]string{"user1": "sessions"}
+	expected = "<RPCAuthz> role <sessions> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcAuthzCfg.Users = map[string]string{"user1": "readonly"}
+	expected = "<RPCAuthz> user <user1> not defined in http auth_users"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.httpCfg.HTTPAuthUsers = map[string]string{"user1": "cGFzcw=="}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
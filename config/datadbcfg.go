@@ -40,6 +40,13 @@ type DataDbCfg struct {
 	RmtConns           []string // Remote DataDB  connIDs
 	RplConns           []string // Replication connIDs
 	Items              map[string]*ItemOpt
+
+	InternalDBDumpPath         string        // directory used to persist the *internal DataDB, empty for no persistence
+	InternalDBSnapshotInterval time.Duration // interval between snapshots of the *internal DataDB
+	InternalDBSyncWrites       bool          // sync the change log on each write
+
+	ReplicationQueuePath     string        // directory used to queue the replication requests, empty for synchronous replication
+	ReplicationRetryInterval time.Duration // base interval between retries of a failed replication
 }
 
 //loadFromJsonCfg loads Database config from JsonCfg
@@ -99,6 +106,25 @@ func (dbcfg *DataDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnDbCfg.Internal_db_dump_path != nil {
+		dbcfg.InternalDBDumpPath = *jsnDbCfg.Internal_db_dump_path
+	}
+	if jsnDbCfg.Internal_db_snapshot_interval != nil {
+		if dbcfg.InternalDBSnapshotInterval, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Internal_db_snapshot_interval); err != nil {
+			return err
+		}
+	}
+	if jsnDbCfg.Internal_db_sync_writes != nil {
+		dbcfg.InternalDBSyncWrites = *jsnDbCfg.Internal_db_sync_writes
+	}
+	if jsnDbCfg.Replication_queue_path != nil {
+		dbcfg.ReplicationQueuePath = *jsnDbCfg.Replication_queue_path
+	}
+	if jsnDbCfg.Replication_retry_interval != nil {
+		if dbcfg.ReplicationRetryInterval, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Replication_retry_interval); err != nil {
+			return err
+		}
+	}
 	if jsnDbCfg.Items != nil {
 		for kJsn, vJsn := range *jsnDbCfg.Items {
 			val, has := dbcfg.Items[kJsn]
@@ -126,6 +152,13 @@ func (dbcfg *DataDbCfg) Clone() *DataDbCfg {
 		DataDbSentinelName: dbcfg.DataDbSentinelName,
 		QueryTimeout:       dbcfg.QueryTimeout,
 		Items:              dbcfg.Items,
+
+		InternalDBDumpPath:         dbcfg.InternalDBDumpPath,
+		InternalDBSnapshotInterval: dbcfg.InternalDBSnapshotInterval,
+		InternalDBSyncWrites:       dbcfg.InternalDBSyncWrites,
+
+		ReplicationQueuePath:     dbcfg.ReplicationQueuePath,
+		ReplicationRetryInterval: dbcfg.ReplicationRetryInterval,
 	}
 }
 
@@ -138,6 +171,14 @@ func (dbcfg *DataDbCfg) AsMapInterface() map[string]interface{} {
 	if dbcfg.QueryTimeout != 0 {
 		queryTimeout = dbcfg.QueryTimeout.String()
 	}
+	var snapshotInterval string = "0"
+	if dbcfg.InternalDBSnapshotInterval != 0 {
+		snapshotInterval = dbcfg.InternalDBSnapshotInterval.String()
+	}
+	var retryInterval string = "0"
+	if dbcfg.ReplicationRetryInterval != 0 {
+		retryInterval = dbcfg.ReplicationRetryInterval.String()
+	}
 	dbPort, _ := strconv.Atoi(dbcfg.DataDbPort)
 
 	return map[string]interface{}{
@@ -152,6 +193,13 @@ func (dbcfg *DataDbCfg) AsMapInterface() map[string]interface{} {
 		utils.RmtConnsCfg:           dbcfg.RmtConns,
 		utils.RplConnsCfg:           dbcfg.RplConns,
 		utils.ItemsCfg:              items,
+
+		utils.InternalDBDumpPathCfg:         dbcfg.InternalDBDumpPath,
+		utils.InternalDBSnapshotIntervalCfg: snapshotInterval,
+		utils.InternalDBSyncWritesCfg:       dbcfg.InternalDBSyncWrites,
+
+		utils.ReplicationQueuePathCfg:     dbcfg.ReplicationQueuePath,
+		utils.ReplicationRetryIntervalCfg: retryInterval,
 	}
 }
 
@@ -411,6 +411,11 @@ func TestDataDbCfgAsMapInterface(t *testing.T) {
 		"query_timeout":"10s",
 		"remote_conns":[],
 		"replication_conns":[],
+		"internal_db_dump_path": "/var/lib/cgrates/internal_db/datadb",
+		"internal_db_snapshot_interval": "30m",
+		"internal_db_sync_writes": true,
+		"replication_queue_path": "/var/spool/cgrates/replication",
+		"replication_retry_interval": "500ms",
 		"items":{
 			"*accounts":{"remote":true, "replicate":false, "limit": -1, "ttl": "", "static_ttl": false}, 					
 			"*reverse_destinations": {"remote":false, "replicate":false, "limit": 7, "ttl": "", "static_ttl": true},
@@ -418,16 +423,21 @@ func TestDataDbCfgAsMapInterface(t *testing.T) {
 	},		
 }`
 	eMap := map[string]interface{}{
-		"db_type":           "*redis",
-		"db_host":           "127.0.0.1",
-		"db_port":           6379,
-		"db_name":           "10",
-		"db_user":           "cgrates",
-		"db_password":       "",
-		"redis_sentinel":    "",
-		"query_timeout":     "10s",
-		"remote_conns":      []string{},
-		"replication_conns": []string{},
+		"db_type":                       "*redis",
+		"db_host":                       "127.0.0.1",
+		"db_port":                       6379,
+		"db_name":                       "10",
+		"db_user":                       "cgrates",
+		"db_password":                   "",
+		"redis_sentinel":                "",
+		"query_timeout":                 "10s",
+		"remote_conns":                  []string{},
+		"replication_conns":             []string{},
+		"internal_db_dump_path":         "/var/lib/cgrates/internal_db/datadb",
+		"internal_db_snapshot_interval": "30m0s",
+		"internal_db_sync_writes":       true,
+		"replication_queue_path":        "/var/spool/cgrates/replication",
+		"replication_retry_interval":    "500ms",
 		"items": map[string]interface{}{
 			"*accounts":             map[string]interface{}{"remote": true, "replicate": false, "limit": -1, "ttl": "", "static_ttl": false},
 			"*reverse_destinations": map[string]interface{}{"remote": false, "replicate": false, "limit": 7, "ttl": "", "static_ttl": true},
@@ -20,15 +20,17 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
 
 type EEsCfg struct {
-	Enabled         bool
-	AttributeSConns []string
-	Cache           map[string]*CacheParamCfg
-	Exporters       []*EventExporterCfg
+	Enabled          bool
+	AttributeSConns  []string
+	Cache            map[string]*CacheParamCfg
+	FailedExportsDir string // directory where the exports failing all the retries are stored, *none to disable
+	Exporters        []*EventExporterCfg
 }
 
 func (eeS *EEsCfg) loadFromJsonCfg(jsnCfg *EEsJsonCfg, sep string, dfltExpCfg *EventExporterCfg) (err error) {
@@ -47,6 +49,9 @@ func (eeS *EEsCfg) loadFromJsonCfg(jsnCfg *EEsJsonCfg, sep string, dfltExpCfg *E
 			eeS.Cache[kJsn] = val
 		}
 	}
+	if jsnCfg.Failed_exports_dir != nil {
+		eeS.FailedExportsDir = *jsnCfg.Failed_exports_dir
+	}
 	if jsnCfg.Attributes_conns != nil {
 		eeS.AttributeSConns = make([]string, len(*jsnCfg.Attributes_conns))
 		for i, fID := range *jsnCfg.Attributes_conns {
@@ -100,6 +105,7 @@ func (eeS *EEsCfg) Clone() (cln *EEsCfg) {
 	for idx, sConn := range eeS.AttributeSConns {
 		cln.AttributeSConns[idx] = sConn
 	}
+	cln.FailedExportsDir = eeS.FailedExportsDir
 	cln.Exporters = make([]*EventExporterCfg, len(eeS.Exporters))
 	for idx, exp := range eeS.Exporters {
 		cln.Exporters[idx] = exp.Clone()
@@ -113,9 +119,10 @@ func (eeS *EEsCfg) AsMapInterface(separator string) map[string]interface{} {
 		exporters[i] = item.AsMapInterface(separator)
 	}
 	return map[string]interface{}{
-		utils.EnabledCfg:         eeS.Enabled,
-		utils.AttributeSConnsCfg: eeS.AttributeSConns,
-		utils.ExportersCfg:       exporters,
+		utils.EnabledCfg:          eeS.Enabled,
+		utils.AttributeSConnsCfg:  eeS.AttributeSConns,
+		utils.FailedExportsDirCfg: eeS.FailedExportsDir,
+		utils.ExportersCfg:        exporters,
 	}
 }
 
@@ -131,6 +138,8 @@ type EventExporterCfg struct {
 	AttributeSCtx string   // context to use when querying AttributeS
 	Synchronous   bool
 	Attempts      int
+	Retries       int           // export retries before storing the event as failed
+	RetryInterval time.Duration // base of the fibonacci backoff between the retries
 	FieldSep      string
 	Fields        []*FCTemplate
 	headerFields  []*FCTemplate
@@ -185,6 +194,14 @@ func (eeC *EventExporterCfg) loadFromJsonCfg(jsnEec *EventExporterJsonCfg, separ
 	if jsnEec.Attempts != nil {
 		eeC.Attempts = *jsnEec.Attempts
 	}
+	if jsnEec.Retries != nil {
+		eeC.Retries = *jsnEec.Retries
+	}
+	if jsnEec.Retry_interval != nil {
+		if eeC.RetryInterval, err = utils.ParseDurationWithNanosecs(*jsnEec.Retry_interval); err != nil {
+			return
+		}
+	}
 	if jsnEec.Field_separator != nil {
 		eeC.FieldSep = *jsnEec.Field_separator
 	}
@@ -251,6 +268,8 @@ func (eeC *EventExporterCfg) Clone() (cln *EventExporterCfg) {
 	}
 	cln.Synchronous = eeC.Synchronous
 	cln.Attempts = eeC.Attempts
+	cln.Retries = eeC.Retries
+	cln.RetryInterval = eeC.RetryInterval
 	cln.FieldSep = eeC.FieldSep
 
 	cln.Fields = make([]*FCTemplate, len(eeC.Fields))
@@ -308,6 +327,8 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) map[string]interfa
 		utils.AttributeIDsCfg:     eeC.AttributeSIDs,
 		utils.SynchronousCfg:      eeC.Synchronous,
 		utils.AttemptsCfg:         eeC.Attempts,
+		utils.RetriesCfg:          eeC.Retries,
+		utils.RetryIntervalCfg:    eeC.RetryInterval.String(),
 		utils.FieldSeparatorCfg:   eeC.FieldSep,
 		utils.FieldsCfg:           fields,
 	}
@@ -151,6 +151,7 @@ func TestEventExporterSameID(t *testing.T) {
 				StaticTTL: false,
 			},
 		},
+		FailedExportsDir: utils.META_NONE,
 		Exporters: []*EventExporterCfg{
 			&EventExporterCfg{
 				ID:            utils.MetaDefault,
@@ -159,6 +160,7 @@ func TestEventExporterSameID(t *testing.T) {
 				Tenant:        nil,
 				ExportPath:    "/var/spool/cgrates/ees",
 				Attempts:      1,
+				RetryInterval: time.Second,
 				Timezone:      utils.EmptyString,
 				Filters:       []string{},
 				AttributeSIDs: []string{},
@@ -367,15 +369,16 @@ func TestEventExporterSameID(t *testing.T) {
 				trailerFields: []*FCTemplate{},
 			},
 			{
-				ID:         "file_exporter1",
-				Type:       utils.MetaFileCSV,
-				FieldSep:   ",",
-				Tenant:     nil,
-				Timezone:   utils.EmptyString,
-				Filters:    nil,
-				ExportPath: "/var/spool/cgrates/ees",
-				Attempts:   1,
-				Flags:      utils.FlagsWithParams{},
+				ID:            "file_exporter1",
+				Type:          utils.MetaFileCSV,
+				FieldSep:      ",",
+				Tenant:        nil,
+				Timezone:      utils.EmptyString,
+				Filters:       nil,
+				ExportPath:    "/var/spool/cgrates/ees",
+				Attempts:      1,
+				RetryInterval: time.Second,
+				Flags:         utils.FlagsWithParams{},
 				Fields: []*FCTemplate{
 					{Tag: "CustomTag2", Path: "*exp.CustomPath2", Type: utils.MetaVariable,
 						Value: NewRSRParsersMustCompile("CustomValue2", true, utils.INFIELD_SEP), Mandatory: true, Layout: time.RFC3339},
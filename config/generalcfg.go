@@ -47,6 +47,7 @@ type GeneralCfg struct {
 	ConnectTimeout    time.Duration // timeout for RPC connection attempts
 	ReplyTimeout      time.Duration // timeout replies if not reaching back
 	LockingTimeout    time.Duration // locking mechanism timeout to avoid deadlocks
+	LockingBackend    string        // where the locks are kept: <*internal|*datadb>
 	DigestSeparator   string        //
 	DigestEqual       string        //
 	RSRSep            string        // separator used to split RSRParser (by degault is used ";")
@@ -127,6 +128,9 @@ func (gencfg *GeneralCfg) loadFromJsonCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 			return err
 		}
 	}
+	if jsnGeneralCfg.Locking_backend != nil {
+		gencfg.LockingBackend = *jsnGeneralCfg.Locking_backend
+	}
 	if jsnGeneralCfg.Digest_separator != nil {
 		gencfg.DigestSeparator = *jsnGeneralCfg.Digest_separator
 	}
@@ -182,6 +186,7 @@ func (gencfg *GeneralCfg) AsMapInterface() map[string]interface{} {
 		utils.ConnectTimeoutCfg:    connectTimeout,
 		utils.ReplyTimeoutCfg:      replyTimeout,
 		utils.LockingTimeoutCfg:    lockingTimeout,
+		utils.LockingBackendCfg:    gencfg.LockingBackend,
 		utils.DigestSeparatorCfg:   gencfg.DigestSeparator,
 		utils.DigestEqualCfg:       gencfg.DigestEqual,
 		utils.RSRSepCfg:            gencfg.RSRSep,
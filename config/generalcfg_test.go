@@ -118,6 +118,7 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 			"connect_timeout": "1s",								
 			"reply_timeout": "2s",									
 			"locking_timeout": "0",									
+			"locking_backend": "*internal",
 			"digest_separator": ",",								
 			"digest_equal": ":",									
 			"rsr_separator": ";",									
@@ -145,6 +146,7 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 		"connect_timeout":      "1s",
 		"reply_timeout":        "2s",
 		"locking_timeout":      "0",
+		"locking_backend":      "*internal",
 		"digest_separator":     ",",
 		"digest_equal":         ":",
 		"rsr_separator":        ";",
@@ -42,6 +42,7 @@ type GeneralJsonCfg struct {
 	Connect_timeout      *string
 	Reply_timeout        *string
 	Locking_timeout      *string
+	Locking_backend      *string
 	Digest_separator     *string
 	Digest_equal         *string
 	Rsr_separator        *string
@@ -97,6 +98,13 @@ type DbJsonCfg struct {
 	Remote_conns          *[]string
 	Replication_conns     *[]string
 	Items                 *map[string]*ItemOptJson
+
+	Internal_db_dump_path         *string // Used only in case of *internal
+	Internal_db_snapshot_interval *string
+	Internal_db_sync_writes       *bool
+
+	Replication_queue_path     *string // Used only for data_db
+	Replication_retry_interval *string
 }
 
 type ItemOptJson struct {
@@ -133,9 +141,12 @@ type RalsJsonCfg struct {
 
 // Scheduler config section
 type SchedulerJsonCfg struct {
-	Enabled    *bool
-	Cdrs_conns *[]string
-	Filters    *[]string
+	Enabled           *bool
+	Cdrs_conns        *[]string
+	Filters           *[]string
+	Leader_election   *bool
+	Leader_lease_ttl  *string
+	Execution_history *bool
 }
 
 // Cdrs config section
@@ -152,6 +163,27 @@ type CdrsJsonCfg struct {
 	Online_cdr_exports   *[]string
 	Scheduler_conns      *[]string
 	Ees_conns            *[]string
+	Dedup_policy         *string
+	Dedup_fields         *[]string
+	Dedup_window         *string
+	Dedup_newer_field    *string
+	Archive              *CdrsArchiveJsonCfg
+}
+
+// Archive config of the CDRs section
+type CdrsArchiveJsonCfg struct {
+	Run_interval  *string
+	Archive_path  *string
+	Format        *string
+	Max_file_size *int64
+	Rules         *[]*CdrsArchiveRuleJsonCfg
+}
+
+// Archive rule config
+type CdrsArchiveRuleJsonCfg struct {
+	Tenant  *string
+	Filters *[]string
+	Max_age *string
 }
 
 // Cdre config section
@@ -199,10 +231,11 @@ type EventReaderJsonCfg struct {
 
 // EEsJsonCfg contains the configuration of EventExporterService
 type EEsJsonCfg struct {
-	Enabled          *bool
-	Attributes_conns *[]string
-	Cache            *map[string]*CacheParamJsonCfg
-	Exporters        *[]*EventExporterJsonCfg
+	Enabled            *bool
+	Attributes_conns   *[]string
+	Cache              *map[string]*CacheParamJsonCfg
+	Failed_exports_dir *string
+	Exporters          *[]*EventExporterJsonCfg
 }
 
 // EventExporterJsonCfg is the configuration of a single EventExporter
@@ -218,6 +251,8 @@ type EventExporterJsonCfg struct {
 	Attribute_context *string
 	Synchronous       *bool
 	Attempts          *int
+	Retries           *int
+	Retry_interval    *string
 	Field_separator   *string
 	Fields            *[]*FcTemplateJsonCfg
 }
@@ -315,8 +350,11 @@ type CacheParamJsonCfg struct {
 }
 
 type CacheJsonCfg struct {
-	Partitions        *map[string]*CacheParamJsonCfg
-	Replication_conns *[]string
+	Partitions          *map[string]*CacheParamJsonCfg
+	Replication_conns   *[]string
+	Remote_invalidation *bool
+	Precache_conns      *[]string
+	Precache_chunk_size *int
 }
 
 // SM-Kamailio config section
@@ -411,6 +449,19 @@ type AttributeSJsonCfg struct {
 	Prefix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Process_runs          *int
+	Http_lookups          *map[string]*HTTPLookupJsonCfg
+}
+
+// HTTPLookupJsonCfg is the config of an external HTTP lookup used by AttributeS
+type HTTPLookupJsonCfg struct {
+	Url          *string
+	Method       *string
+	Query_params *map[string]string
+	Body         *string
+	Reply_path   *string
+	Timeout      *string
+	Fallback     *string
+	Fallback_ttl *string
 }
 
 // ChargerSJsonCfg service config section
@@ -467,6 +518,7 @@ type RouteSJsonCfg struct {
 	Resources_conns       *[]string
 	Stats_conns           *[]string
 	Rals_conns            *[]string
+	Thresholds_conns      *[]string
 	Default_ratio         *int
 }
 
@@ -481,9 +533,11 @@ type LoaderJsonCfg struct {
 	Enabled         *bool
 	Tenant          *string
 	Dry_run         *bool
+	Transactional   *bool
 	Run_delay       *int
 	Lock_filename   *string
 	Caches_conns    *[]string
+	Scheduler_conns *[]string
 	Field_separator *string
 	Tp_in_dir       *string
 	Tp_out_dir      *string
@@ -598,6 +652,8 @@ type ApierJsonCfg struct {
 	Caches_conns     *[]string
 	Scheduler_conns  *[]string
 	Attributes_conns *[]string
+	Audit            *bool
+	Audit_methods    *[]string
 }
 
 type STIRJsonCfg struct {
@@ -620,6 +676,24 @@ type RateSJsonCfg struct {
 	Rate_nested_fields         *bool // applies when indexed fields is not defined
 }
 
+// BillRunSJsonCfg the bill-run service config section
+type BillRunSJsonCfg struct {
+	Enabled       *bool
+	Cdrs_conns    *[]string
+	Export_path   *string
+	Html_template *string
+	Run_ids       *[]string
+}
+
+// RPCAuthzJsonCfg the RPC authorization config section
+type RPCAuthzJsonCfg struct {
+	Enabled      *bool
+	Default_role *string
+	Roles        *map[string][]string
+	Tokens       *map[string]string
+	Users        *map[string]string
+}
+
 // SIPAgentJsonCfg
 type SIPAgentJsonCfg struct {
 	Enabled            *bool
@@ -51,9 +51,11 @@ type LoaderSCfg struct {
 	Enabled        bool
 	Tenant         RSRParsers
 	DryRun         bool
+	Transactional  bool
 	RunDelay       time.Duration
 	LockFileName   string
 	CacheSConns    []string
+	SchedulerConns []string
 	FieldSeparator string
 	TpInDir        string
 	TpOutDir       string
@@ -110,6 +112,9 @@ func (self *LoaderSCfg) loadFromJsonCfg(jsnCfg *LoaderJsonCfg, separator string)
 	if jsnCfg.Dry_run != nil {
 		self.DryRun = *jsnCfg.Dry_run
 	}
+	if jsnCfg.Transactional != nil {
+		self.Transactional = *jsnCfg.Transactional
+	}
 	if jsnCfg.Run_delay != nil {
 		self.RunDelay = time.Duration(*jsnCfg.Run_delay) * time.Second
 	}
@@ -127,6 +132,17 @@ func (self *LoaderSCfg) loadFromJsonCfg(jsnCfg *LoaderJsonCfg, separator string)
 			}
 		}
 	}
+	if jsnCfg.Scheduler_conns != nil {
+		self.SchedulerConns = make([]string, len(*jsnCfg.Scheduler_conns))
+		for idx, connID := range *jsnCfg.Scheduler_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				self.SchedulerConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler)
+			} else {
+				self.SchedulerConns[idx] = connID
+			}
+		}
+	}
 	if jsnCfg.Field_separator != nil {
 		self.FieldSeparator = *jsnCfg.Field_separator
 	}
@@ -167,12 +183,19 @@ func (self *LoaderSCfg) Clone() *LoaderSCfg {
 	clnLoader.Enabled = self.Enabled
 	clnLoader.Tenant = self.Tenant
 	clnLoader.DryRun = self.DryRun
+	clnLoader.Transactional = self.Transactional
 	clnLoader.RunDelay = self.RunDelay
 	clnLoader.LockFileName = self.LockFileName
 	clnLoader.CacheSConns = make([]string, len(self.CacheSConns))
 	for idx, connID := range self.CacheSConns {
 		clnLoader.CacheSConns[idx] = connID
 	}
+	if self.SchedulerConns != nil {
+		clnLoader.SchedulerConns = make([]string, len(self.SchedulerConns))
+		for idx, connID := range self.SchedulerConns {
+			clnLoader.SchedulerConns[idx] = connID
+		}
+	}
 	clnLoader.FieldSeparator = self.FieldSeparator
 	clnLoader.TpInDir = self.TpInDir
 	clnLoader.TpOutDir = self.TpOutDir
@@ -220,15 +243,25 @@ func (l *LoaderSCfg) AsMapInterface(separator string) map[string]interface{} {
 			cacheSConns[i] = item
 		}
 	}
+	schedulerConns := make([]string, len(l.SchedulerConns))
+	for i, item := range l.SchedulerConns {
+		if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler) {
+			schedulerConns[i] = utils.MetaInternal
+		} else {
+			schedulerConns[i] = item
+		}
+	}
 
 	return map[string]interface{}{
 		utils.IdCfg:             l.Id,
 		utils.EnabledCfg:        l.Enabled,
 		utils.TenantCfg:         strings.Join(tenant, utils.EmptyString),
 		utils.DryRunCfg:         l.DryRun,
+		utils.TransactionalCfg:  l.Transactional,
 		utils.RunDelayCfg:       runDelay,
 		utils.LockFileNameCfg:   l.LockFileName,
 		utils.CacheSConnsCfg:    cacheSConns,
+		utils.SchedulerConnsCfg: schedulerConns,
 		utils.FieldSeparatorCfg: l.FieldSeparator,
 		utils.TpInDirCfg:        l.TpInDir,
 		utils.TpOutDirCfg:       l.TpOutDir,
@@ -119,6 +119,7 @@ func TestLoaderCfgAsMapInterface(t *testing.T) {
 		"run_delay": 0,										
 		"lock_filename": ".cgr.lck",						
 		"caches_conns": ["*internal"],
+		"scheduler_conns": ["*internal"],
 		"field_separator": ",",								
 		"tp_in_dir": "/var/spool/cgrates/loader/in",		
 		"tp_out_dir": "/var/spool/cgrates/loader/out",		
@@ -141,9 +142,11 @@ func TestLoaderCfgAsMapInterface(t *testing.T) {
 		"enabled":         false,
 		"tenant":          "",
 		"dry_run":         false,
+		"transactional":   false,
 		"run_delay":       "0",
 		"lock_filename":   ".cgr.lck",
 		"caches_conns":    []string{"*internal"},
+		"scheduler_conns": []string{"*internal"},
 		"field_separator": ",",
 		"tp_in_dir":       "/var/spool/cgrates/loader/in",
 		"tp_out_dir":      "/var/spool/cgrates/loader/out",
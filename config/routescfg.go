@@ -34,6 +34,7 @@ type RouteSCfg struct {
 	ResourceSConns      []string
 	StatSConns          []string
 	ResponderSConns     []string
+	ThresholdSConns     []string
 	DefaultRatio        int
 	NestedFields        bool
 }
@@ -106,6 +107,17 @@ func (rts *RouteSCfg) loadFromJsonCfg(jsnCfg *RouteSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Thresholds_conns != nil {
+		rts.ThresholdSConns = make([]string, len(*jsnCfg.Thresholds_conns))
+		for idx, conn := range *jsnCfg.Thresholds_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if conn == utils.MetaInternal {
+				rts.ThresholdSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)
+			} else {
+				rts.ThresholdSConns[idx] = conn
+			}
+		}
+	}
 	if jsnCfg.Default_ratio != nil {
 		rts.DefaultRatio = *jsnCfg.Default_ratio
 	}
@@ -168,6 +180,15 @@ func (rts *RouteSCfg) AsMapInterface() map[string]interface{} {
 			statSConns[i] = item
 		}
 	}
+	thresholdSConns := make([]string, len(rts.ThresholdSConns))
+	for i, item := range rts.ThresholdSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)
+		if item == buf {
+			thresholdSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaThresholds, utils.EmptyString)
+		} else {
+			thresholdSConns[i] = item
+		}
+	}
 
 	return map[string]interface{}{
 		utils.EnabledCfg:             rts.Enabled,
@@ -178,6 +199,7 @@ func (rts *RouteSCfg) AsMapInterface() map[string]interface{} {
 		utils.ResourceSConnsCfg:      resourceSConns,
 		utils.StatSConnsCfg:          statSConns,
 		utils.RALsConnsCfg:           responderSConns,
+		utils.ThresholdSConnsCfg:     thresholdSConns,
 		utils.DefaultRatioCfg:        rts.DefaultRatio,
 		utils.NestedFieldsCfg:        rts.NestedFields,
 	}
@@ -44,6 +44,7 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 	"attributes_conns": [],					// address where to reach the AttributeS <""|127.0.0.1:2013>
 	"resources_conns": [],					// address where to reach the Resource service, empty to disable functionality: <""|*internal|x.y.z.y:1234>
 	"stats_conns": [],						// address where to reach the Stat service, empty to disable stats functionality: <""|*internal|x.y.z.y:1234>
+	"thresholds_conns": ["*internal"],
 	"default_ratio":1,
 },
 }`
@@ -52,6 +53,7 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns:     []string{},
 		ResourceSConns:      []string{},
 		StatSConns:          []string{},
+		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		DefaultRatio:        1,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
@@ -90,6 +92,7 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 		"resources_conns":       []string{},
 		"stats_conns":           []string{},
 		"rals_conns":            []string{},
+		"thresholds_conns":      []string{},
 		"default_ratio":         1,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
@@ -112,6 +115,7 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 			"resources_conns": ["*internal"],
 			"stats_conns": ["*internal"],
 			"rals_conns": ["*internal"],
+			"thresholds_conns": ["*internal"],
 			"default_ratio":1
 		},
 	}`
@@ -125,6 +129,7 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 		"resources_conns":       []string{"*internal"},
 		"stats_conns":           []string{"*internal"},
 		"rals_conns":            []string{"*internal"},
+		"thresholds_conns":      []string{"*internal"},
 		"default_ratio":         1,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
@@ -0,0 +1,77 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import "github.com/cgrates/cgrates/utils"
+
+// RPCAuthzCfg is the configuration of the role based authorization on the RPC listeners
+type RPCAuthzCfg struct {
+	Enabled     bool
+	DefaultRole string              // role of the connections not authenticated, empty to deny them
+	Roles       map[string][]string // role -> allowed method patterns
+	Tokens      map[string]string   // API token -> role
+	Users       map[string]string   // HTTP basic auth user -> role
+}
+
+func (aCfg *RPCAuthzCfg) loadFromJsonCfg(jsnCfg *RPCAuthzJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		aCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Default_role != nil {
+		aCfg.DefaultRole = *jsnCfg.Default_role
+	}
+	if jsnCfg.Roles != nil {
+		aCfg.Roles = make(map[string][]string, len(*jsnCfg.Roles))
+		for role, methods := range *jsnCfg.Roles {
+			aCfg.Roles[role] = methods
+		}
+	}
+	if jsnCfg.Tokens != nil {
+		aCfg.Tokens = *jsnCfg.Tokens
+	}
+	if jsnCfg.Users != nil {
+		aCfg.Users = *jsnCfg.Users
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (aCfg *RPCAuthzCfg) AsMapInterface() map[string]interface{} {
+	roles := make(map[string]interface{}, len(aCfg.Roles))
+	for role, methods := range aCfg.Roles {
+		roles[role] = methods
+	}
+	tokens := make(map[string]interface{}, len(aCfg.Tokens))
+	for token, role := range aCfg.Tokens {
+		tokens[token] = role
+	}
+	users := make(map[string]interface{}, len(aCfg.Users))
+	for user, role := range aCfg.Users {
+		users[user] = role
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:     aCfg.Enabled,
+		utils.DefaultRoleCfg: aCfg.DefaultRole,
+		utils.RolesCfg:       roles,
+		utils.TokensCfg:      tokens,
+		utils.UsersCfg:       users,
+	}
+}
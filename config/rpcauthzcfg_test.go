@@ -0,0 +1,83 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRPCAuthzCfgloadFromJsonCfg(t *testing.T) {
+	var aCfg, expected RPCAuthzCfg
+	if err := aCfg.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(aCfg, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, aCfg)
+	}
+	cfgJSONStr := `{
+"rpc_authz": {
+	"enabled": true,
+	"default_role": "readonly",
+	"roles": {
+		"readonly": ["*.Get*", "CoreSv1.Status"],
+		"sessions": ["SessionSv1.*"],
+	},
+	"tokens": {"token1": "sessions"},
+	"users": {"user1": "readonly"},
+},
+}`
+	expected = RPCAuthzCfg{
+		Enabled:     true,
+		DefaultRole: "readonly",
+		Roles: map[string][]string{
+			"readonly": {"*.Get*", "CoreSv1.Status"},
+			"sessions": {"SessionSv1.*"},
+		},
+		Tokens: map[string]string{"token1": "sessions"},
+		Users:  map[string]string{"user1": "readonly"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnACfg, err := jsnCfg.RPCAuthzCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = aCfg.loadFromJsonCfg(jsnACfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, aCfg) {
+		t.Errorf("Expected: %+v , recived: %+v", expected, aCfg)
+	}
+}
+
+func TestRPCAuthzCfgAsMapInterface(t *testing.T) {
+	aCfg := &RPCAuthzCfg{
+		Enabled: true,
+		Roles:   map[string][]string{"sessions": {"SessionSv1.*"}},
+		Tokens:  map[string]string{"token1": "sessions"},
+	}
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:     true,
+		utils.DefaultRoleCfg: "",
+		utils.RolesCfg:       map[string]interface{}{"sessions": []string{"SessionSv1.*"}},
+		utils.TokensCfg:      map[string]interface{}{"token1": "sessions"},
+		utils.UsersCfg:       map[string]interface{}{},
+	}
+	if rcv := aCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v , recived: %+v", eMap, rcv)
+	}
+}
@@ -18,15 +18,22 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 type SchedulerCfg struct {
-	Enabled   bool
-	CDRsConns []string
-	Filters   []string
+	Enabled        bool
+	CDRsConns      []string
+	Filters        []string
+	LeaderElection bool          // only the leader elected over DataDB executes the actions
+	LeaderLeaseTTL time.Duration // leadership lease, renewed by the leader
+	ExecHistory    bool          // record the executions of the ActionTimings into StorDB
 }
 
-func (schdcfg *SchedulerCfg) loadFromJsonCfg(jsnCfg *SchedulerJsonCfg) error {
+func (schdcfg *SchedulerCfg) loadFromJsonCfg(jsnCfg *SchedulerJsonCfg) (err error) {
 	if jsnCfg == nil {
 		return nil
 	}
@@ -50,13 +57,27 @@ func (schdcfg *SchedulerCfg) loadFromJsonCfg(jsnCfg *SchedulerJsonCfg) error {
 			schdcfg.Filters[i] = fltr
 		}
 	}
+	if jsnCfg.Leader_election != nil {
+		schdcfg.LeaderElection = *jsnCfg.Leader_election
+	}
+	if jsnCfg.Leader_lease_ttl != nil {
+		if schdcfg.LeaderLeaseTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Leader_lease_ttl); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Execution_history != nil {
+		schdcfg.ExecHistory = *jsnCfg.Execution_history
+	}
 	return nil
 }
 
 func (schdcfg *SchedulerCfg) AsMapInterface() map[string]interface{} {
 	return map[string]interface{}{
-		utils.EnabledCfg:   schdcfg.Enabled,
-		utils.CDRsConnsCfg: schdcfg.CDRsConns,
-		utils.FiltersCfg:   schdcfg.Filters,
+		utils.EnabledCfg:        schdcfg.Enabled,
+		utils.CDRsConnsCfg:      schdcfg.CDRsConns,
+		utils.FiltersCfg:        schdcfg.Filters,
+		utils.LeaderElectionCfg: schdcfg.LeaderElection,
+		utils.LeaderLeaseTTLCfg: schdcfg.LeaderLeaseTTL.String(),
+		utils.ExecHistoryCfg:    schdcfg.ExecHistory,
 	}
 }
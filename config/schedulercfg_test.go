@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -40,11 +41,17 @@ func TestSchedulerCfgloadFromJsonCfg(t *testing.T) {
 "schedulers": {
 	"enabled": true,				// start Scheduler service: <true|false>
 	"cdrs_conns": [],				// address where to reach CDR Server, empty to disable CDR capturing <*internal|x.y.z.y:1234>
+	"leader_election": true,
+	"leader_lease_ttl": "5s",
+	"execution_history": true,
 	},
 }`
 	expected = SchedulerCfg{
-		Enabled:   true,
-		CDRsConns: []string{},
+		Enabled:        true,
+		CDRsConns:      []string{},
+		LeaderElection: true,
+		LeaderLeaseTTL: 5 * time.Second,
+		ExecHistory:    true,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -67,9 +74,12 @@ func TestSchedulerCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		"enabled":    true,
-		"cdrs_conns": []string{},
-		"filters":    []string{},
+		"enabled":           true,
+		"cdrs_conns":        []string{},
+		"filters":           []string{},
+		"leader_election":   false,
+		"leader_lease_ttl":  "0s",
+		"execution_history": false,
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
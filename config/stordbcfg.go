@@ -42,6 +42,10 @@ type StorDbCfg struct {
 	QueryTimeout        time.Duration
 	SSLMode             string // for PostgresDB used to change default sslmode
 	Items               map[string]*ItemOpt
+
+	InternalDBDumpPath         string        // directory used to persist the *internal StorDB, empty for no persistence
+	InternalDBSnapshotInterval time.Duration // interval between snapshots of the *internal StorDB
+	InternalDBSyncWrites       bool          // sync the change log on each write
 }
 
 // loadFromJsonCfg loads StoreDb config from JsonCfg
@@ -94,6 +98,17 @@ func (dbcfg *StorDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 	if jsnDbCfg.Sslmode != nil {
 		dbcfg.SSLMode = *jsnDbCfg.Sslmode
 	}
+	if jsnDbCfg.Internal_db_dump_path != nil {
+		dbcfg.InternalDBDumpPath = *jsnDbCfg.Internal_db_dump_path
+	}
+	if jsnDbCfg.Internal_db_snapshot_interval != nil {
+		if dbcfg.InternalDBSnapshotInterval, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Internal_db_snapshot_interval); err != nil {
+			return err
+		}
+	}
+	if jsnDbCfg.Internal_db_sync_writes != nil {
+		dbcfg.InternalDBSyncWrites = *jsnDbCfg.Internal_db_sync_writes
+	}
 	if jsnDbCfg.Items != nil {
 		for kJsn, vJsn := range *jsnDbCfg.Items {
 			val := new(ItemOpt)
@@ -123,6 +138,10 @@ func (dbcfg *StorDbCfg) Clone() *StorDbCfg {
 		QueryTimeout:        dbcfg.QueryTimeout,
 		SSLMode:             dbcfg.SSLMode,
 		Items:               dbcfg.Items,
+
+		InternalDBDumpPath:         dbcfg.InternalDBDumpPath,
+		InternalDBSnapshotInterval: dbcfg.InternalDBSnapshotInterval,
+		InternalDBSyncWrites:       dbcfg.InternalDBSyncWrites,
 	}
 }
 
@@ -135,6 +154,10 @@ func (dbcfg *StorDbCfg) AsMapInterface() map[string]interface{} {
 	if dbcfg.QueryTimeout != 0 {
 		queryTimeout = dbcfg.QueryTimeout.String()
 	}
+	var snapshotInterval string = "0"
+	if dbcfg.InternalDBSnapshotInterval != 0 {
+		snapshotInterval = dbcfg.InternalDBSnapshotInterval.String()
+	}
 	dbPort, _ := strconv.Atoi(dbcfg.Port)
 
 	return map[string]interface{}{
@@ -152,5 +175,9 @@ func (dbcfg *StorDbCfg) AsMapInterface() map[string]interface{} {
 		utils.QueryTimeoutCfg:        queryTimeout,
 		utils.SSLModeCfg:             dbcfg.SSLMode,
 		utils.ItemsCfg:               items,
+
+		utils.InternalDBDumpPathCfg:         dbcfg.InternalDBDumpPath,
+		utils.InternalDBSnapshotIntervalCfg: snapshotInterval,
+		utils.InternalDBSyncWritesCfg:       dbcfg.InternalDBSyncWrites,
 	}
 }
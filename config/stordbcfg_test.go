@@ -159,19 +159,22 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 }`
 
 	eMap := map[string]interface{}{
-		"db_type":               "*mysql",
-		"db_host":               "127.0.0.1",
-		"db_port":               3306,
-		"db_name":               "cgrates",
-		"db_user":               "cgrates",
-		"db_password":           "",
-		"max_open_conns":        100,
-		"max_idle_conns":        10,
-		"conn_max_lifetime":     0,
-		"string_indexed_fields": []string{},
-		"prefix_indexed_fields": []string{},
-		"query_timeout":         "10s",
-		"sslmode":               "disable",
+		"db_type":                       "*mysql",
+		"db_host":                       "127.0.0.1",
+		"db_port":                       3306,
+		"db_name":                       "cgrates",
+		"db_user":                       "cgrates",
+		"db_password":                   "",
+		"max_open_conns":                100,
+		"max_idle_conns":                10,
+		"conn_max_lifetime":             0,
+		"string_indexed_fields":         []string{},
+		"prefix_indexed_fields":         []string{},
+		"query_timeout":                 "10s",
+		"sslmode":                       "disable",
+		"internal_db_dump_path":         "",
+		"internal_db_snapshot_interval": "0",
+		"internal_db_sync_writes":       false,
 		"items": map[string]interface{}{
 			"session_costs": map[string]interface{}{"limit": -1, "ttl": "", "static_ttl": false, "remote": false, "replicate": false},
 			"cdrs":          map[string]interface{}{"limit": -1, "ttl": "", "static_ttl": false, "remote": false, "replicate": false},
@@ -0,0 +1,64 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdRemoveLookupTable{
+		name:      "lookup_table_remove",
+		rpcMethod: utils.APIerSv1RemoveLookupTable,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdRemoveLookupTable struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantIDWithCache
+	*CommandExecuter
+}
+
+func (self *CmdRemoveLookupTable) Name() string {
+	return self.name
+}
+
+func (self *CmdRemoveLookupTable) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdRemoveLookupTable) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(utils.TenantIDWithCache)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdRemoveLookupTable) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdRemoveLookupTable) RpcResult() interface{} {
+	var s string
+	return &s
+}
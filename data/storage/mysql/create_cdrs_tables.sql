@@ -50,3 +50,33 @@ CREATE TABLE session_costs (
   KEY run_origin_idx (run_id, origin_id),
   KEY deleted_at_idx (deleted_at)
 );
+
+DROP TABLE IF EXISTS audit_records;
+CREATE TABLE audit_records (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  caller varchar(64) NOT NULL,
+  role varchar(64) NOT NULL,
+  remote_addr varchar(64) NOT NULL,
+  method varchar(128) NOT NULL,
+  args_digest varchar(40) NOT NULL,
+  result TEXT,
+  created_at TIMESTAMP(6) NULL,
+  PRIMARY KEY (`id`),
+  KEY created_at_idx (created_at),
+  KEY caller_idx (caller, created_at)
+);
+
+DROP TABLE IF EXISTS action_executions;
+CREATE TABLE action_executions (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  action_plan_id varchar(64) NOT NULL,
+  actions_id varchar(64) NOT NULL,
+  timing varchar(40) NOT NULL,
+  account_ids TEXT,
+  run_time TIMESTAMP(6) NULL,
+  error TEXT,
+  created_at TIMESTAMP(6) NULL,
+  PRIMARY KEY (`id`),
+  KEY created_at_idx (created_at),
+  KEY action_timing_idx (action_plan_id, actions_id, timing, created_at)
+);
@@ -10,7 +10,7 @@ CREATE TABLE `tp_timings` (
   `months` varchar(255) NOT NULL,
   `month_days` varchar(255) NOT NULL,
   `week_days` varchar(255) NOT NULL,
-  `time` varchar(32) NOT NULL,
+  `time` varchar(128) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -180,6 +180,7 @@ CREATE TABLE `tp_action_plans` (
   `actions_tag` varchar(64) NOT NULL,
   `timing_tag` varchar(64) NOT NULL,
   `weight` DECIMAL(8,2) NOT NULL,
+  `catch_up` varchar(8) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -364,6 +365,8 @@ CREATE TABLE tp_routes (
   `route_blocker` BOOLEAN NOT NULL,
   `route_parameters` varchar(64) NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `quarantine_rules` varchar(128) NOT NULL,
+  `quarantine_cooldown` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -413,6 +416,8 @@ CREATE TABLE tp_chargers (
   `run_id` varchar(64) NOT NULL,
   `attribute_ids` varchar(64) NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `opts` varchar(128) NOT NULL,
+  `blocker` BOOLEAN NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -57,3 +57,35 @@ DROP INDEX IF EXISTS run_origin_sessionscost_idx;
 CREATE INDEX run_origin_sessionscost_idx ON session_costs (run_id, origin_id);
 DROP INDEX IF EXISTS deleted_at_sessionscost_idx;
 CREATE INDEX deleted_at_sessionscost_idx ON session_costs (deleted_at);
+
+DROP TABLE IF EXISTS audit_records;
+CREATE TABLE audit_records (
+  id SERIAL PRIMARY KEY,
+  caller VARCHAR(64) NOT NULL,
+  role VARCHAR(64) NOT NULL,
+  remote_addr VARCHAR(64) NOT NULL,
+  method VARCHAR(128) NOT NULL,
+  args_digest VARCHAR(40) NOT NULL,
+  result TEXT,
+  created_at TIMESTAMP WITH TIME ZONE
+);
+DROP INDEX IF EXISTS created_at_auditrecords_idx;
+CREATE INDEX created_at_auditrecords_idx ON audit_records (created_at);
+DROP INDEX IF EXISTS caller_auditrecords_idx;
+CREATE INDEX caller_auditrecords_idx ON audit_records (caller, created_at);
+
+DROP TABLE IF EXISTS action_executions;
+CREATE TABLE action_executions (
+  id SERIAL PRIMARY KEY,
+  action_plan_id VARCHAR(64) NOT NULL,
+  actions_id VARCHAR(64) NOT NULL,
+  timing VARCHAR(40) NOT NULL,
+  account_ids TEXT,
+  run_time TIMESTAMP WITH TIME ZONE,
+  error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE
+);
+DROP INDEX IF EXISTS created_at_actionexecutions_idx;
+CREATE INDEX created_at_actionexecutions_idx ON action_executions (created_at);
+DROP INDEX IF EXISTS action_timing_actionexecutions_idx;
+CREATE INDEX action_timing_actionexecutions_idx ON action_executions (action_plan_id, actions_id, timing, created_at);
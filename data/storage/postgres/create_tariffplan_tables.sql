@@ -10,7 +10,7 @@ CREATE TABLE tp_timings (
   months VARCHAR(255) NOT NULL,
   month_days VARCHAR(255) NOT NULL,
   week_days VARCHAR(255) NOT NULL,
-  time VARCHAR(32) NOT NULL,
+  time VARCHAR(128) NOT NULL,
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE  (tpid, tag)
 );
@@ -175,6 +175,7 @@ CREATE TABLE tp_action_plans (
   actions_tag VARCHAR(64) NOT NULL,
   timing_tag VARCHAR(64) NOT NULL,
   weight NUMERIC(8,2) NOT NULL,
+  catch_up VARCHAR(8) NOT NULL,
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE  (tpid, tag, actions_tag, timing_tag)
 );
@@ -356,6 +357,8 @@ CREATE TABLE tp_routes (
   "route_blocker" BOOLEAN NOT NULL,
   "route_parameters" varchar(64) NOT NULL,
   "weight" decimal(8,2) NOT NULL,
+  "quarantine_rules" varchar(128) NOT NULL,
+  "quarantine_cooldown" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_routes_idx ON tp_routes (tpid);
@@ -403,6 +406,8 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
     "run_id" varchar(64) NOT NULL,
     "attribute_ids" varchar(64) NOT NULL,
     "weight" decimal(8,2) NOT NULL,
+    "opts" varchar(128) NOT NULL,
+    "blocker" BOOLEAN NOT NULL,
     "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_chargers_ids ON tp_chargers (tpid);
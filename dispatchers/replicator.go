@@ -563,6 +563,24 @@ func (dS *DispatcherService) ReplicatorSv1GetDispatcherHost(args *utils.TenantID
 	}, utils.MetaReplicator, routeID, utils.ReplicatorSv1GetDispatcherHost, args, reply)
 }
 
+func (dS *DispatcherService) ReplicatorSv1GetLookupTableValue(args *engine.LookupTableValueArgs, reply *string) (err error) {
+	tnt := utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if args.ArgDispatcher == nil {
+		return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ReplicatorSv1GetLookupTableValue, tnt,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	routeID := args.ArgDispatcher.RouteID
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.ID,
+	}, utils.MetaReplicator, routeID, utils.ReplicatorSv1GetLookupTableValue, args, reply)
+}
+
 func (dS *DispatcherService) ReplicatorSv1GetRateProfile(args *utils.TenantIDWithArgDispatcher, reply *engine.RateProfile) (err error) {
 	tnt := dS.cfg.GeneralCfg().DefaultTenant
 	if args.TenantID != nil && args.TenantID.Tenant != utils.EmptyString {
@@ -1134,6 +1152,31 @@ func (dS *DispatcherService) ReplicatorSv1SetDispatcherHost(args *engine.Dispatc
 		utils.ReplicatorSv1SetDispatcherHost, args, rpl)
 }
 
+func (dS *DispatcherService) ReplicatorSv1SetLookupTable(args *engine.LookupTableWithArgDispatcher, rpl *string) (err error) {
+	if args == nil {
+		args = &engine.LookupTableWithArgDispatcher{}
+	}
+	if args.LookupTable == nil {
+		args.LookupTable = &engine.LookupTable{}
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if args.ArgDispatcher == nil {
+			return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+		}
+		if err = dS.authorize(utils.ReplicatorSv1SetLookupTable, args.Tenant,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.Tenant}, utils.MetaReplicator, routeID,
+		utils.ReplicatorSv1SetLookupTable, args, rpl)
+}
+
 func (dS *DispatcherService) ReplicatorSv1RemoveThreshold(args *utils.TenantIDWithArgDispatcher, rpl *string) (err error) {
 	if args == nil {
 		args = &utils.TenantIDWithArgDispatcher{}
@@ -1640,6 +1683,28 @@ func (dS *DispatcherService) ReplicatorSv1RemoveDispatcherHost(args *utils.Tenan
 		utils.ReplicatorSv1RemoveDispatcherHost, args, rpl)
 }
 
+func (dS *DispatcherService) ReplicatorSv1RemoveLookupTable(args *utils.TenantIDWithArgDispatcher, rpl *string) (err error) {
+	if args == nil {
+		args = &utils.TenantIDWithArgDispatcher{}
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if args.ArgDispatcher == nil {
+			return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+		}
+		if err = dS.authorize(utils.ReplicatorSv1RemoveLookupTable, args.Tenant,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.Tenant}, utils.MetaReplicator, routeID,
+		utils.ReplicatorSv1RemoveLookupTable, args, rpl)
+}
+
 func (dS *DispatcherService) ReplicatorSv1RemoveRateProfile(args *utils.TenantIDWithArgDispatcher, rpl *string) (err error) {
 	if args == nil {
 		args = &utils.TenantIDWithArgDispatcher{}
@@ -1730,3 +1795,72 @@ func (dS *DispatcherService) ReplicatorSv1RemoveIndexes(args *utils.GetIndexesAr
 	return dS.Dispatch(&utils.CGREvent{Tenant: args.Tenant}, utils.MetaReplicator, routeID,
 		utils.ReplicatorSv1RemoveIndexes, args, reply)
 }
+
+// ReplicatorSv1GetChecksums .
+func (dS *DispatcherService) ReplicatorSv1GetChecksums(args *utils.StringWithApiKey, reply *map[string]string) (err error) {
+	if args == nil {
+		args = &utils.StringWithApiKey{}
+	}
+	args.TenantArg.Tenant = utils.FirstNonEmpty(args.TenantArg.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if args.ArgDispatcher == nil {
+			return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+		}
+		if err = dS.authorize(utils.ReplicatorSv1GetChecksums, args.TenantArg.Tenant,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.TenantArg.Tenant}, utils.MetaReplicator, routeID,
+		utils.ReplicatorSv1GetChecksums, args, reply)
+}
+
+// ReplicatorSv1Resync .
+func (dS *DispatcherService) ReplicatorSv1Resync(args *engine.ArgsReplicationResync, reply *map[string]map[string]*engine.ReplicationDiff) (err error) {
+	if args == nil {
+		args = &engine.ArgsReplicationResync{}
+	}
+	args.TenantArg.Tenant = utils.FirstNonEmpty(args.TenantArg.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if args.ArgDispatcher == nil {
+			return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+		}
+		if err = dS.authorize(utils.ReplicatorSv1Resync, args.TenantArg.Tenant,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: args.TenantArg.Tenant}, utils.MetaReplicator, routeID,
+		utils.ReplicatorSv1Resync, args, reply)
+}
+
+// ReplicatorSv1GetReplicationStats .
+func (dS *DispatcherService) ReplicatorSv1GetReplicationStats(args *utils.TenantWithArgDispatcher, reply *engine.ReplicationStats) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.TenantArg != nil && args.TenantArg.Tenant != utils.EmptyString {
+		tnt = args.TenantArg.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if args.ArgDispatcher == nil {
+			return utils.NewErrMandatoryIeMissing(utils.ArgDispatcherField)
+		}
+		if err = dS.authorize(utils.ReplicatorSv1GetReplicationStats, tnt,
+			args.APIKey, utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var routeID *string
+	if args.ArgDispatcher != nil {
+		routeID = args.ArgDispatcher.RouteID
+	}
+	return dS.Dispatch(&utils.CGREvent{Tenant: tnt}, utils.MetaReplicator, routeID,
+		utils.ReplicatorSv1GetReplicationStats, args, reply)
+}
@@ -43,6 +43,8 @@ func NewEventExporterS(cfg *config.CGRConfig, filterS *engine.FilterS,
 		filterS: filterS,
 		connMgr: connMgr,
 		eesChs:  make(map[string]*ltcache.Cache),
+
+		retryStop: make(chan struct{}),
 	}
 	eeS.setupCache(cfg.EEsNoLksCfg().Cache)
 	return
@@ -56,6 +58,11 @@ type EventExporterS struct {
 
 	eesChs map[string]*ltcache.Cache // map[eeType]*ltcache.Cache
 	eesMux sync.RWMutex              // protects the eesChs
+
+	fExpsMux sync.RWMutex // protects the failed exports files
+
+	retryStop chan struct{}  // closed on shutdown to stop waiting for the next retry
+	retryWg   sync.WaitGroup // the retries in progress
 }
 
 // ListenAndServe keeps the service alive
@@ -80,6 +87,12 @@ func (eeS *EventExporterS) ListenAndServe(exitChan chan bool, cfgRld chan struct
 // Shutdown is called to shutdown the service
 func (eeS *EventExporterS) Shutdown() (err error) {
 	utils.Logger.Info(fmt.Sprintf("<%s> shutdown <%s>", utils.CoreS, utils.EventExporterS))
+	select {
+	case <-eeS.retryStop: // already stopped
+	default:
+		close(eeS.retryStop)
+	}
+	eeS.retryWg.Wait()  // the pending retries are stored as failed exports
 	eeS.setupCache(nil) // cleanup exporters
 	return
 }
@@ -187,8 +200,8 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithOpts, rply *s
 		if eeCfg.Synchronous {
 			wg.Add(1) // wait for synchronous or file ones since these need to be done before continuing
 		}
-		go func(evict, sync bool) {
-			if err := ee.ExportEvent(cgrEv.CGREvent); err != nil {
+		go func(eeCfg *config.EventExporterCfg, evict, sync bool) {
+			if err := eeS.exportEvent(ee, eeCfg, cgrEv.CGREvent); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> with id <%s>, error: <%s>",
 						utils.EventExporterS, ee.ID(), err.Error()))
@@ -200,7 +213,7 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithOpts, rply *s
 			if sync {
 				wg.Done()
 			}
-		}(!hasCache, eeCfg.Synchronous)
+		}(eeCfg, !hasCache, eeCfg.Synchronous)
 	}
 	wg.Wait()
 	if withErr {
@@ -0,0 +1,303 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FailedExport is an event which could not be exported after all the retries
+type FailedExport struct {
+	ID         string
+	ExporterID string
+	CGREvent   *utils.CGREvent
+	Error      string
+	Attempts   int // number of export attempts done so far
+	FailedAt   time.Time
+}
+
+// ArgsFailedExports selects the failed exports, empty fields matching all
+type ArgsFailedExports struct {
+	ExporterIDs []string
+	IDs         []string
+}
+
+func (args *ArgsFailedExports) matches(fExp *FailedExport) bool {
+	if len(args.ExporterIDs) != 0 &&
+		!utils.IsSliceMember(args.ExporterIDs, fExp.ExporterID) {
+		return false
+	}
+	return len(args.IDs) == 0 ||
+		utils.IsSliceMember(args.IDs, fExp.ID)
+}
+
+// exportEvent exports the event, retrying it in the background on errors.
+// The file exporters are not retried since a failed write can be partially done.
+func (eeS *EventExporterS) exportEvent(ee EventExporter, eeCfg *config.EventExporterCfg,
+	cgrEv *utils.CGREvent) (err error) {
+	if err = ee.ExportEvent(cgrEv); err == nil {
+		return
+	}
+	if eeCfg.Retries == 0 ||
+		eeCfg.Type == utils.MetaFileCSV || eeCfg.Type == utils.MetaFileFWV {
+		eeS.addFailedExport(eeCfg.ID, cgrEv, err, 1)
+		return
+	}
+	eeS.retryWg.Add(1)
+	go eeS.retryExport(eeCfg, cgrEv, err, func() (EventExporter, error) {
+		return eeS.newRetryExporter(eeCfg.ID)
+	})
+	return
+}
+
+// retryExport exports the event with fibonacci backoff on a standalone exporter
+// so the metrics of the original one are not altered, storing the event
+// in the failed exports directory if all the retries fail or on shutdown
+func (eeS *EventExporterS) retryExport(eeCfg *config.EventExporterCfg,
+	cgrEv *utils.CGREvent, expErr error, newEE func() (EventExporter, error)) {
+	defer eeS.retryWg.Done()
+	ee, err := newEE()
+	if err != nil {
+		eeS.cfg.RLocks(config.EEsJson)
+		eeS.addFailedExport(eeCfg.ID, cgrEv, err, 1)
+		eeS.cfg.RUnlocks(config.EEsJson)
+		return
+	}
+	defer ee.OnEvicted(utils.EmptyString, nil) // so we can close ie the connection
+	err = expErr
+	fib := utils.Fib()
+	attempts := 1 // the first export was done by the caller
+	for attempts <= eeCfg.Retries {
+		select {
+		case <-time.After(eeCfg.RetryInterval * time.Duration(fib())):
+		case <-eeS.retryStop:
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> with id <%s>, error: <%s> after %d attempts, shutting down",
+					utils.EventExporterS, eeCfg.ID, err.Error(), attempts))
+			eeS.cfg.RLocks(config.EEsJson)
+			eeS.addFailedExport(eeCfg.ID, cgrEv, err, attempts)
+			eeS.cfg.RUnlocks(config.EEsJson)
+			return
+		}
+		attempts++
+		if err = ee.ExportEvent(cgrEv); err == nil {
+			return
+		}
+	}
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> with id <%s>, error: <%s> after %d attempts",
+			utils.EventExporterS, eeCfg.ID, err.Error(), attempts))
+	eeS.cfg.RLocks(config.EEsJson)
+	eeS.addFailedExport(eeCfg.ID, cgrEv, err, attempts)
+	eeS.cfg.RUnlocks(config.EEsJson)
+}
+
+// newRetryExporter builds a new exporter out of the current configuration
+func (eeS *EventExporterS) newRetryExporter(eeID string) (ee EventExporter, err error) {
+	eeS.cfg.RLocks(config.EEsJson)
+	defer eeS.cfg.RUnlocks(config.EEsJson)
+	for cfgIdx, eeCfg := range eeS.cfg.EEsNoLksCfg().Exporters {
+		if eeCfg.ID == eeID {
+			return NewEventExporter(eeS.cfg, cfgIdx, eeS.filterS, newEEMetrics())
+		}
+	}
+	return nil, fmt.Errorf("exporter <%s> not configured", eeID)
+}
+
+// addFailedExport stores the event in the failed exports directory if enabled
+// the caller needs to hold the EEs configuration lock
+func (eeS *EventExporterS) addFailedExport(eeID string, cgrEv *utils.CGREvent, err error, attempts int) {
+	if eeS.cfg.EEsNoLksCfg().FailedExportsDir == utils.META_NONE {
+		return
+	}
+	if errStore := eeS.storeFailedExport(&FailedExport{
+		ID:         utils.UUIDSha1Prefix(),
+		ExporterID: eeID,
+		CGREvent:   cgrEv,
+		Error:      err.Error(),
+		Attempts:   attempts,
+		FailedAt:   time.Now(),
+	}); errStore != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> storing failed export for exporter <%s>, error: <%s>",
+				utils.EventExporterS, eeID, errStore.Error()))
+	}
+}
+
+// failedExportPath returns the path of the file storing the failed export
+func (eeS *EventExporterS) failedExportPath(fExpID string) string {
+	return path.Join(eeS.cfg.EEsNoLksCfg().FailedExportsDir, fExpID+utils.JSNSuffix)
+}
+
+// storeFailedExport writes the failed export to its own file
+func (eeS *EventExporterS) storeFailedExport(fExp *FailedExport) (err error) {
+	var b []byte
+	if b, err = json.Marshal(fExp); err != nil {
+		return
+	}
+	eeS.fExpsMux.Lock()
+	err = ioutil.WriteFile(eeS.failedExportPath(fExp.ID), b, 0644)
+	eeS.fExpsMux.Unlock()
+	return
+}
+
+// getFailedExports reads the stored failed exports matching the arguments, oldest first
+func (eeS *EventExporterS) getFailedExports(args *ArgsFailedExports) (fExps []*FailedExport, err error) {
+	if eeS.cfg.EEsNoLksCfg().FailedExportsDir == utils.META_NONE {
+		return nil, errors.New("failed exports store is disabled")
+	}
+	eeS.fExpsMux.RLock()
+	defer eeS.fExpsMux.RUnlock()
+	var fis []os.FileInfo
+	if fis, err = ioutil.ReadDir(eeS.cfg.EEsNoLksCfg().FailedExportsDir); err != nil {
+		return
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), utils.JSNSuffix) {
+			continue
+		}
+		var b []byte
+		if b, err = ioutil.ReadFile(path.Join(eeS.cfg.EEsNoLksCfg().FailedExportsDir,
+			fi.Name())); err != nil {
+			return
+		}
+		fExp := new(FailedExport)
+		if err = json.Unmarshal(b, fExp); err != nil {
+			return nil, fmt.Errorf("decoding file <%s>: %s", fi.Name(), err.Error())
+		}
+		if args.matches(fExp) {
+			fExps = append(fExps, fExp)
+		}
+	}
+	sort.Slice(fExps, func(i, j int) bool {
+		return fExps[i].FailedAt.Before(fExps[j].FailedAt)
+	})
+	return
+}
+
+// removeFailedExport deletes the file of the failed export
+func (eeS *EventExporterS) removeFailedExport(fExpID string) (err error) {
+	eeS.fExpsMux.Lock()
+	err = os.Remove(eeS.failedExportPath(fExpID))
+	eeS.fExpsMux.Unlock()
+	return
+}
+
+// V1GetFailedExports returns the stored failed exports
+func (eeS *EventExporterS) V1GetFailedExports(args *ArgsFailedExports, reply *[]*FailedExport) (err error) {
+	eeS.cfg.RLocks(config.EEsJson)
+	defer eeS.cfg.RUnlocks(config.EEsJson)
+	var fExps []*FailedExport
+	if fExps, err = eeS.getFailedExports(args); err != nil {
+		return
+	}
+	if len(fExps) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = fExps
+	return
+}
+
+// V1ReplayFailedExports exports again the stored failed exports, once per event,
+// removing the successful ones from the store
+func (eeS *EventExporterS) V1ReplayFailedExports(args *ArgsFailedExports, reply *string) (err error) {
+	eeS.cfg.RLocks(config.EEsJson)
+	defer eeS.cfg.RUnlocks(config.EEsJson)
+	var fExps []*FailedExport
+	if fExps, err = eeS.getFailedExports(args); err != nil {
+		return
+	}
+	if len(fExps) == 0 {
+		return utils.ErrNotFound
+	}
+	cfgIdxs := make(map[string]int)
+	for cfgIdx, eeCfg := range eeS.cfg.EEsNoLksCfg().Exporters {
+		cfgIdxs[eeCfg.ID] = cfgIdx
+	}
+	ees := make(map[string]EventExporter) // one exporter per ID for the whole replay
+	defer func() {
+		for _, ee := range ees {
+			ee.OnEvicted(utils.EmptyString, nil) // so we can close ie the file
+		}
+	}()
+	var withErr bool
+	for _, fExp := range fExps {
+		ee, has := ees[fExp.ExporterID]
+		if !has {
+			cfgIdx, hasCfg := cfgIdxs[fExp.ExporterID]
+			if !hasCfg {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> replaying failed export <%s>, exporter <%s> not configured",
+						utils.EventExporterS, fExp.ID, fExp.ExporterID))
+				withErr = true
+				continue
+			}
+			if ee, err = NewEventExporter(eeS.cfg, cfgIdx, eeS.filterS, newEEMetrics()); err != nil {
+				return
+			}
+			ees[fExp.ExporterID] = ee
+		}
+		fExp.Attempts++
+		if errExp := ee.ExportEvent(fExp.CGREvent); errExp != nil {
+			withErr = true
+			fExp.Error = errExp.Error()
+			fExp.FailedAt = time.Now()
+			if err = eeS.storeFailedExport(fExp); err != nil {
+				return
+			}
+			continue
+		}
+		if err = eeS.removeFailedExport(fExp.ID); err != nil {
+			return
+		}
+	}
+	if withErr {
+		return utils.ErrPartiallyExecuted
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1PurgeFailedExports removes the stored failed exports without exporting them
+func (eeS *EventExporterS) V1PurgeFailedExports(args *ArgsFailedExports, reply *string) (err error) {
+	eeS.cfg.RLocks(config.EEsJson)
+	defer eeS.cfg.RUnlocks(config.EEsJson)
+	var fExps []*FailedExport
+	if fExps, err = eeS.getFailedExports(args); err != nil {
+		return
+	}
+	for _, fExp := range fExps {
+		if err = eeS.removeFailedExport(fExp.ID); err != nil {
+			return
+		}
+	}
+	*reply = utils.OK
+	return
+}
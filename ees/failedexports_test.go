@@ -0,0 +1,188 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// testFailingEE fails the first failures exports
+type testFailingEE struct {
+	failures int
+	exports  int
+}
+
+func (ee *testFailingEE) ID() string { return "testFailingEE" }
+
+func (ee *testFailingEE) ExportEvent(_ *utils.CGREvent) (err error) {
+	ee.exports++
+	if ee.exports <= ee.failures {
+		return errors.New("export failed")
+	}
+	return
+}
+
+func (ee *testFailingEE) OnEvicted(_ string, _ interface{}) {}
+
+func TestEEsFailedExports(t *testing.T) {
+	fExpsDir, err := ioutil.TempDir("", "TestEEsFailedExports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fExpsDir)
+	expDir, err := ioutil.TempDir("", "TestEEsFailedExportsCSV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(expDir)
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.EEsCfg().FailedExportsDir = fExpsDir
+	eeCfg := cfg.EEsCfg().Exporters[0].Clone()
+	eeCfg.ID = "csv_exporter"
+	eeCfg.Type = utils.MetaFileCSV
+	eeCfg.ExportPath = expDir
+	eeCfg.Retries = 1
+	eeCfg.RetryInterval = 0
+	cfg.EEsCfg().Exporters = append(cfg.EEsCfg().Exporters, eeCfg)
+	eeS := NewEventExporterS(cfg, &engine.FilterS{}, nil)
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.Account: "1001",
+		},
+	}
+	// succeeds on retry, using its own exporter
+	ee := &testFailingEE{}
+	eeS.retryWg.Add(1)
+	eeS.retryExport(eeCfg, cgrEv, errors.New("export failed"), func() (EventExporter, error) { return ee, nil })
+	if ee.exports != 1 {
+		t.Errorf("Expecting: 1 export, received: %d", ee.exports)
+	}
+	var fExps []*FailedExport
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{}, &fExps); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// fails all the retries
+	ee = &testFailingEE{failures: 1}
+	eeS.retryWg.Add(1)
+	eeS.retryExport(eeCfg, cgrEv, errors.New("export failed"), func() (EventExporter, error) { return ee, nil })
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{
+		ExporterIDs: []string{"csv_exporter"}}, &fExps); err != nil {
+		t.Fatal(err)
+	} else if len(fExps) != 1 {
+		t.Fatalf("Expecting one failed export, received: %s", utils.ToJSON(fExps))
+	} else if fExps[0].Attempts != 2 || fExps[0].Error != "export failed" ||
+		fExps[0].CGREvent.ID != "ev1" {
+		t.Errorf("Unexpected failed export: %s", utils.ToJSON(fExps[0]))
+	}
+	// the file exporters are not retried
+	ee = &testFailingEE{failures: 2}
+	if err := eeS.exportEvent(ee, eeCfg, cgrEv); err == nil {
+		t.Error("Expecting error")
+	} else if ee.exports != 1 {
+		t.Errorf("Expecting: 1 export, received: %d", ee.exports)
+	}
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{
+		ExporterIDs: []string{"csv_exporter"}}, &fExps); err != nil {
+		t.Fatal(err)
+	} else if len(fExps) != 2 {
+		t.Fatalf("Expecting two failed exports, received: %s", utils.ToJSON(fExps))
+	} else if fExps[1].Attempts != 1 {
+		t.Errorf("Unexpected failed export: %s", utils.ToJSON(fExps[1]))
+	}
+	var reply string
+	if err := eeS.V1ReplayFailedExports(&ArgsFailedExports{}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expecting: %s, received: %s", utils.OK, reply)
+	}
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{}, &fExps); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// exporter removed from configuration, replay keeps the event
+	ee = &testFailingEE{failures: 2}
+	unknownCfg := eeCfg.Clone()
+	unknownCfg.ID = "unknown"
+	if err := eeS.exportEvent(ee, unknownCfg, cgrEv); err == nil {
+		t.Error("Expecting error")
+	}
+	if err := eeS.V1ReplayFailedExports(&ArgsFailedExports{}, &reply); err != utils.ErrPartiallyExecuted {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrPartiallyExecuted, err)
+	}
+	if err := eeS.V1PurgeFailedExports(&ArgsFailedExports{
+		ExporterIDs: []string{"unknown"}}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{}, &fExps); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestEEsFailedExportsShutdown(t *testing.T) {
+	fExpsDir, err := ioutil.TempDir("", "TestEEsFailedExportsShutdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fExpsDir)
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.EEsCfg().FailedExportsDir = fExpsDir
+	eeCfg := cfg.EEsCfg().Exporters[0].Clone()
+	eeCfg.ID = "http_exporter"
+	eeCfg.Type = utils.MetaHTTPPost
+	eeCfg.Retries = 5
+	eeCfg.RetryInterval = time.Hour
+	cfg.EEsCfg().Exporters = append(cfg.EEsCfg().Exporters, eeCfg)
+	eeS := NewEventExporterS(cfg, &engine.FilterS{}, nil)
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+	}
+	ee := &testFailingEE{failures: 1}
+	if err := eeS.exportEvent(ee, eeCfg, cgrEv); err == nil {
+		t.Fatal("Expecting error")
+	}
+	done := make(chan struct{})
+	go func() {
+		eeS.Shutdown()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not interrupt the retry")
+	}
+	var fExps []*FailedExport
+	if err := eeS.V1GetFailedExports(&ArgsFailedExports{}, &fExps); err != nil {
+		t.Fatal(err)
+	} else if len(fExps) != 1 ||
+		fExps[0].ExporterID != "http_exporter" ||
+		fExps[0].Attempts != 1 ||
+		fExps[0].Error != "export failed" {
+		t.Errorf("Unexpected failed exports: %s", utils.ToJSON(fExps))
+	}
+}
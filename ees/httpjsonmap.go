@@ -165,7 +165,8 @@ func (httpJson *HTTPJsonMapEe) post(body interface{}, key string) (err error) {
 		err = engine.PostersCache.PostS3(httpJson.cgrCfg.EEsCfg().Exporters[httpJson.cfgIdx].ExportPath,
 			httpJson.cgrCfg.EEsCfg().Exporters[httpJson.cfgIdx].Attempts, body.([]byte), key)
 	}
-	if err != nil && httpJson.cgrCfg.GeneralCfg().FailedPostsDir != utils.META_NONE {
+	if err != nil && httpJson.cgrCfg.GeneralCfg().FailedPostsDir != utils.META_NONE &&
+		httpJson.cgrCfg.EEsCfg().FailedExportsDir == utils.META_NONE { // otherwise the event is stored by EEs
 		engine.AddFailedPost(httpJson.cgrCfg.EEsCfg().Exporters[httpJson.cfgIdx].ExportPath,
 			httpJson.cgrCfg.EEsCfg().Exporters[httpJson.cfgIdx].Type, utils.EventExporterS, body)
 	}
@@ -134,7 +134,8 @@ func (httpPost *HTTPPost) ExportEvent(cgrEv *utils.CGREvent) (err error) {
 	httpPost.dc[utils.PositiveExports].(utils.StringSet).Add(cgrEv.ID)
 	body = urlVals
 	if err = httpPost.httpPoster.Post(body, utils.EmptyString); err != nil &&
-		httpPost.cgrCfg.GeneralCfg().FailedPostsDir != utils.META_NONE {
+		httpPost.cgrCfg.GeneralCfg().FailedPostsDir != utils.META_NONE &&
+		httpPost.cgrCfg.EEsCfg().FailedExportsDir == utils.META_NONE { // otherwise the event is stored by EEs
 		engine.AddFailedPost(httpPost.cgrCfg.EEsCfg().Exporters[httpPost.cfgIdx].ExportPath,
 			httpPost.cgrCfg.EEsCfg().Exporters[httpPost.cfgIdx].Type, utils.EventExporterS, body)
 	}
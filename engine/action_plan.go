@@ -70,6 +70,16 @@ type ActionPlan struct {
 	Id            string // informative purpose only
 	AccountIDs    utils.StringMap
 	ActionTimings []*ActionTiming
+	CatchUp       string // policy for the runs missed while the scheduler was down: <""|*once|*all>
+}
+
+// CheckCatchUp returns an error if the policy for the missed runs is not supported
+func CheckCatchUp(catchUp string) error {
+	switch catchUp {
+	case utils.EmptyString, utils.MetaOnce, utils.MetaAll:
+		return nil
+	}
+	return fmt.Errorf("%s:CatchUp:%s", utils.ErrUnsupportedFormat.Error(), catchUp)
 }
 
 func (apl *ActionPlan) RemoveAccountID(accID string) (found bool) {
@@ -84,6 +94,7 @@ func (apl *ActionPlan) Clone() (interface{}, error) {
 	cln := &ActionPlan{
 		Id:         apl.Id,
 		AccountIDs: apl.AccountIDs.Clone(),
+		CatchUp:    apl.CatchUp,
 	}
 	if apl.ActionTimings != nil {
 		cln.ActionTimings = make([]*ActionTiming, len(apl.ActionTimings))
@@ -113,10 +124,47 @@ func (at *ActionTiming) GetNextStartTime(now time.Time) (t time.Time) {
 	if !at.stCache.IsZero() {
 		return at.stCache
 	}
+	cronExpr, loc := at.cronExpr()
+	if cronExpr == nil {
+		return
+	}
+	if loc != nil {
+		now = now.In(loc)
+	}
+	at.stCache = cronExpr.Next(now)
+	return at.stCache
+}
+
+// GetStartTimes returns the start times after tStart, up to and including tEnd
+func (at *ActionTiming) GetStartTimes(tStart, tEnd time.Time) (sTimes []time.Time) {
+	cronExpr, loc := at.cronExpr()
+	if cronExpr == nil {
+		return
+	}
+	if loc != nil {
+		tStart = tStart.In(loc)
+	}
+	for t := cronExpr.Next(tStart); !t.IsZero() && !t.After(tEnd); t = cronExpr.Next(t) {
+		sTimes = append(sTimes, t)
+	}
+	return
+}
+
+// cronExpr normalizes the timing and returns it as cron expression together with its time zone
+func (at *ActionTiming) cronExpr() (expr *cronexpr.Expression, loc *time.Location) {
 	i := at.Timing
 	if i == nil || i.Timing == nil {
 		return
 	}
+	if i.Timing.IsCron() {
+		var err error
+		if expr, loc, err = parseCron(i.Timing.StartTime); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> invalid cron timing <%s> for actions <%s>, error: %s",
+				utils.SchedulerS, i.Timing.StartTime, at.ActionsID, err.Error()))
+			return nil, nil
+		}
+		return
+	}
 	// Normalize
 	if i.Timing.StartTime == "" {
 		i.Timing.StartTime = "00:00:00"
@@ -127,8 +175,7 @@ func (at *ActionTiming) GetNextStartTime(now time.Time) (t time.Time) {
 	if len(i.Timing.Months) > 0 && len(i.Timing.MonthDays) == 0 {
 		i.Timing.MonthDays = append(i.Timing.MonthDays, 1)
 	}
-	at.stCache = cronexpr.MustParse(i.Timing.CronString()).Next(now)
-	return at.stCache
+	return cronexpr.MustParse(i.Timing.CronString()), nil
 }
 
 // To be deleted after the above solution proves reliable
@@ -313,6 +360,19 @@ func (at *ActionTiming) GetActionPlanID() string {
 	return at.actionPlanID
 }
 
+// GetTimingKey returns the key of the timing which, unlike the Uuid, is kept when the ActionPlan is reloaded
+func (at *ActionTiming) GetTimingKey() string {
+	if at.Timing == nil || at.Timing.Timing == nil {
+		return utils.EmptyString
+	}
+	t := at.Timing.Timing
+	return utils.Sha1(t.Years.Serialize(utils.INFIELD_SEP), utils.CONCATENATED_KEY_SEP,
+		t.Months.Serialize(utils.INFIELD_SEP), utils.CONCATENATED_KEY_SEP,
+		t.MonthDays.Serialize(utils.INFIELD_SEP), utils.CONCATENATED_KEY_SEP,
+		t.WeekDays.Serialize(utils.INFIELD_SEP), utils.CONCATENATED_KEY_SEP,
+		t.StartTime, utils.CONCATENATED_KEY_SEP, t.EndTime)
+}
+
 func (at *ActionTiming) getActions() (as []*Action, err error) {
 	if at.actions == nil {
 		at.actions, err = dm.GetActions(at.ActionsID, false, utils.NonTransactional)
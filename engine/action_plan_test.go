@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -91,6 +92,7 @@ func TestActionPlanClone(t *testing.T) {
 	at1 := &ActionPlan{
 		Id:         "test",
 		AccountIDs: utils.StringMap{"one": true, "two": true, "three": true},
+		CatchUp:    utils.MetaAll,
 		ActionTimings: []*ActionTiming{
 			&ActionTiming{
 				Uuid:      "Uuid_test1",
@@ -112,6 +114,68 @@ func TestActionPlanClone(t *testing.T) {
 	}
 }
 
+func TestActionTimingGetStartTimes(t *testing.T) {
+	at := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{
+		MonthDays: utils.MonthDays{1},
+		StartTime: "00:00:00",
+	}}}
+	tStart := time.Date(2020, 3, 1, 0, 0, 0, 0, time.Local)
+	tEnd := time.Date(2020, 6, 1, 0, 0, 0, 0, time.Local)
+	exp := []time.Time{
+		time.Date(2020, 4, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2020, 5, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2020, 6, 1, 0, 0, 0, 0, time.Local),
+	}
+	if rcv := at.GetStartTimes(tStart, tEnd); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %v, received: %v", exp, rcv)
+	}
+	if rcv := at.GetStartTimes(tEnd, tEnd); len(rcv) != 0 {
+		t.Errorf("Expecting no start times, received: %v", rcv)
+	}
+	at = &ActionTiming{Timing: &RateInterval{Timing: &RITiming{
+		Years:     utils.Years{2020},
+		Months:    utils.Months{time.April},
+		MonthDays: utils.MonthDays{15},
+		StartTime: "10:00:00",
+	}}}
+	exp = []time.Time{time.Date(2020, 4, 15, 10, 0, 0, 0, time.Local)}
+	if rcv := at.GetStartTimes(tStart, time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %v, received: %v", exp, rcv)
+	}
+}
+
+func TestActionTimingGetNextStartTimeCron(t *testing.T) {
+	now := time.Date(2020, 7, 21, 10, 7, 30, 0, time.UTC)
+	for cron, exp := range map[string]time.Time{
+		"*/15 * * * *":                 time.Date(2020, 7, 21, 10, 15, 0, 0, time.UTC),
+		"30 9-17 * * 1-5":              time.Date(2020, 7, 21, 10, 30, 0, 0, time.UTC),
+		"0 0 L * *":                    time.Date(2020, 7, 31, 0, 0, 0, 0, time.UTC),
+		"0 8 1 */3 *":                  time.Date(2020, 10, 1, 8, 0, 0, 0, time.UTC),
+		"0 0 1 * * 2021":               time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		"TZ=Europe/Berlin 0 13 * * *":  time.Date(2020, 7, 21, 11, 0, 0, 0, time.UTC),
+		"CRON_TZ=Asia/Tokyo 0 0 * * *": time.Date(2020, 7, 21, 15, 0, 0, 0, time.UTC),
+	} {
+		at := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{StartTime: cron}}}
+		if rcv := at.GetNextStartTime(now); !rcv.Equal(exp) {
+			t.Errorf("For %q expecting: %v, received: %v", cron, exp, rcv)
+		}
+	}
+	at := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{StartTime: "TZ=Europe/Berlin 0 0 L * *"}}}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	exp := []time.Time{
+		time.Date(2020, 7, 31, 0, 0, 0, 0, berlin),
+		time.Date(2020, 8, 31, 0, 0, 0, 0, berlin),
+		time.Date(2020, 9, 30, 0, 0, 0, 0, berlin),
+	}
+	if rcv := at.GetStartTimes(now, time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %v, received: %v", exp, rcv)
+	}
+	at = &ActionTiming{Timing: &RateInterval{Timing: &RITiming{StartTime: "0 0 32 * *"}}}
+	if rcv := at.GetNextStartTime(now); !rcv.IsZero() {
+		t.Errorf("Expecting zero time for invalid cron, received: %v", rcv)
+	}
+}
+
 func TestActionTimingClone(t *testing.T) {
 	at := &ActionTiming{
 		Uuid:      "Uuid_test",
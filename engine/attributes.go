@@ -309,6 +309,48 @@ func (alS *AttributeService) processEvent(args *AttrArgsProcessEvent) (
 				return nil, err
 			}
 			substitute = strconv.Itoa(int(t.Unix()))
+		case utils.MetaLookup, utils.MetaPrefixLookup:
+			if len(attribute.Value) != 2 {
+				return nil, fmt.Errorf("invalid arguments <%s> to %s",
+					utils.ToJSON(attribute.Value), attribute.Type)
+			}
+			lkTblID, err := attribute.Value[0].ParseDataProvider(evNm, utils.NestingSep) // LookupTable ID
+			if err != nil {
+				return nil, err
+			}
+			key, err := attribute.Value[1].ParseDataProvider(evNm, utils.NestingSep)
+			if err != nil {
+				return nil, err
+			}
+			getLookupValue := alS.dm.GetLookupTableValue
+			if attribute.Type == utils.MetaPrefixLookup {
+				getLookupValue = alS.dm.GetLookupTablePrefixValue
+			}
+			if substitute, err = getLookupValue(args.Tenant, lkTblID, key,
+				true, true, utils.NonTransactional); err != nil {
+				if err != utils.ErrNotFound {
+					return nil, err
+				}
+				continue // key not in the table, leave the field untouched
+			}
+		case utils.MetaHTTPLookup:
+			if len(attribute.Value) == 0 || len(attribute.Value) > 2 {
+				return nil, fmt.Errorf("invalid arguments <%s> to %s",
+					utils.ToJSON(attribute.Value), utils.MetaHTTPLookup)
+			}
+			lkID, err := attribute.Value[0].ParseDataProvider(evNm, utils.NestingSep) // HTTPLookup ID
+			if err != nil {
+				return nil, err
+			}
+			var replyPath string
+			if len(attribute.Value) == 2 {
+				if replyPath, err = attribute.Value[1].ParseDataProvider(evNm, utils.NestingSep); err != nil {
+					return nil, err
+				}
+			}
+			if substitute, err = alS.httpLookup(lkID, replyPath, evNm); err != nil {
+				return nil, err
+			}
 		default: // backwards compatible in case that Type is empty
 			substitute, err = attribute.Value.ParseDataProvider(evNm, utils.NestingSep)
 		}
@@ -2387,3 +2387,113 @@ func TestProcessAttributeUnixTimeStamp(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
 	}
 }
+
+func TestProcessAttributeLookup(t *testing.T) {
+	defaultCfg, _ := config.NewDefaultCGRConfig()
+	defaultCfg.AttributeSCfg().ProcessRuns = 1
+	data := NewInternalDB(nil, nil, true, defaultCfg.DataDbCfg().Items)
+	dmAtr = NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	Cache.Clear(nil)
+	attrService, _ = NewAttributeService(dmAtr, &FilterS{dm: dmAtr, cfg: defaultCfg}, defaultCfg)
+	if err := dmAtr.SetLookupTable(&LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "PORTED",
+		Entries: map[string]string{
+			"4986517174963": "D0014986517174963",
+		},
+	}, false); err != nil {
+		t.Error(err)
+	}
+	if err := dmAtr.SetLookupTable(&LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "ZONES",
+		Entries: map[string]string{
+			"49":    "GERMANY",
+			"49151": "GERMANY_MOBILE",
+		},
+	}, false); err != nil {
+		t.Error(err)
+	}
+	attrPrf := &AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_LOOKUP",
+		Contexts: []string{utils.MetaSessionS},
+		Attributes: []*Attribute{
+			{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Destination,
+				Type:  utils.MetaLookup,
+				Value: config.NewRSRParsersMustCompile("PORTED;~*req.Destination", true, utils.INFIELD_SEP),
+			},
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "Zone",
+				Type:  utils.MetaPrefixLookup,
+				Value: config.NewRSRParsersMustCompile("ZONES;~*req.Called", true, utils.INFIELD_SEP),
+			},
+		},
+		Weight: 10,
+	}
+	if err := dmAtr.SetAttributeProfile(attrPrf, true); err != nil {
+		t.Error(err)
+	}
+	ev := &AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeLookup",
+			Event: map[string]interface{}{
+				utils.Destination: "4986517174963",
+				"Called":          "4915112345",
+			},
+		},
+	}
+	rcv, err := attrService.processEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eRply := &AttrSProcessEventReply{
+		MatchedProfiles: []string{"ATTR_LOOKUP"},
+		AlteredFields: []string{utils.MetaReq + utils.NestingSep + utils.Destination,
+			utils.MetaReq + utils.NestingSep + "Zone"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeLookup",
+			Event: map[string]interface{}{
+				utils.Destination: "D0014986517174963",
+				"Called":          "4915112345",
+				"Zone":            "GERMANY_MOBILE",
+			},
+		},
+	}
+	if !reflect.DeepEqual(eRply, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
+	}
+	// keys missing from the tables leave the event untouched
+	ev = &AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeLookup",
+			Event: map[string]interface{}{
+				utils.Destination: "4986517174964",
+				"Called":          "331234",
+			},
+		},
+	}
+	if rcv, err = attrService.processEvent(ev); err != nil {
+		t.Fatal(err)
+	}
+	eRply = &AttrSProcessEventReply{
+		MatchedProfiles: []string{"ATTR_LOOKUP"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeLookup",
+			Event: map[string]interface{}{
+				utils.Destination: "4986517174964",
+				"Called":          "331234",
+			},
+		},
+	}
+	if !reflect.DeepEqual(eRply, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
+	}
+}
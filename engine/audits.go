@@ -0,0 +1,118 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewAuditS returns the audit trail recording the administrative calls into StorDB
+func NewAuditS(cfg *config.CGRConfig, storDB AuditStorage) *AuditS {
+	return &AuditS{
+		cfg:      cfg,
+		storDB:   storDB,
+		recChan:  make(chan *utils.AuditRecord, 1000),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// AuditS stores the calls received by the RPC listeners out of the request path
+// the calls over *internal connections do not pass through the listeners so they are not recorded
+type AuditS struct {
+	sync.RWMutex // protects storDB
+	cfg          *config.CGRConfig
+	storDB       AuditStorage
+	recChan      chan *utils.AuditRecord
+	stopChan     chan struct{}
+}
+
+// AuditMethod implements utils.RPCAuditor
+func (aS *AuditS) AuditMethod(method string) bool {
+	return utils.RPCMethodMatches(aS.cfg.ApierCfg().AuditMethods, method)
+}
+
+// Audit implements utils.RPCAuditor queueing the record to be stored
+func (aS *AuditS) Audit(rec *utils.AuditRecord) {
+	select {
+	case aS.recChan <- rec:
+	case <-aS.stopChan: // connections opened before the shutdown
+		utils.Logger.Warning(fmt.Sprintf("<%s> stopped, not recording the call to <%s> from <%s>",
+			utils.AuditS, rec.Method, rec.RemoteAddr))
+	}
+}
+
+// SetStorDB is used on StorDB reload
+func (aS *AuditS) SetStorDB(storDB AuditStorage) {
+	aS.Lock()
+	aS.storDB = storDB
+	aS.Unlock()
+}
+
+func (aS *AuditS) storeRecord(rec *utils.AuditRecord) {
+	aS.RLock()
+	storDB := aS.storDB
+	aS.RUnlock()
+	if err := storDB.SetAuditRecord(rec); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed storing the call to <%s> by <%s> from <%s>, error: %s",
+			utils.AuditS, rec.Method, rec.Caller, rec.RemoteAddr, err.Error()))
+	}
+}
+
+// ListenAndServe stores the queued records until shutdown
+func (aS *AuditS) ListenAndServe() {
+	for {
+		select {
+		case rec := <-aS.recChan:
+			aS.storeRecord(rec)
+		case <-aS.stopChan:
+			for { // store what was queued before the shutdown
+				select {
+				case rec := <-aS.recChan:
+					aS.storeRecord(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Shutdown stops storing the records
+func (aS *AuditS) Shutdown() {
+	close(aS.stopChan)
+}
+
+// V1GetAuditRecords queries the audit trail
+func (aS *AuditS) V1GetAuditRecords(args *utils.AuditRecordsFilter, reply *[]*utils.AuditRecord) (err error) {
+	aS.RLock()
+	storDB := aS.storDB
+	aS.RUnlock()
+	var recs []*utils.AuditRecord
+	if recs, err = storDB.GetAuditRecords(args); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = recs
+	return
+}
@@ -0,0 +1,120 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditSAuditMethod(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	aS := NewAuditS(cfg, nil)
+	for method, exp := range map[string]bool{
+		utils.APIerSv1LoadTariffPlanFromFolder: true,
+		utils.APIerSv1SetBalance:               true,
+		"APIerSv1.RemoveAccount":               true,
+		"ReplicatorSv1.SetFilter":              true,
+		"CacheSv1.ReloadCache":                 true,
+		utils.APIerSv1GetCDRs:                  false,
+		utils.APIerSv1GetAuditRecords:          false,
+		"SessionSv1.AuthorizeEvent":            false,
+	} {
+		if rcv := aS.AuditMethod(method); rcv != exp {
+			t.Errorf("Method: %s expected: %v, received: %v", method, exp, rcv)
+		}
+	}
+}
+
+func TestAuditSGetAuditRecords(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	aS := NewAuditS(cfg, storDB)
+	go aS.ListenAndServe()
+	tNow := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	recs := []*utils.AuditRecord{
+		{
+			Caller:     "user1",
+			Role:       "admin",
+			RemoteAddr: "127.0.0.1:4321",
+			Method:     utils.APIerSv1SetBalance,
+			ArgsDigest: utils.Sha1("args1"),
+			Result:     utils.OK,
+			Time:       tNow.Add(time.Minute),
+		},
+		{
+			Caller:     "user2",
+			Role:       "admin",
+			RemoteAddr: "127.0.0.1:4322",
+			Method:     utils.APIerSv1LoadTariffPlanFromFolder,
+			ArgsDigest: utils.Sha1("args2"),
+			Result:     utils.ErrNotFound.Error(),
+			Time:       tNow,
+		},
+		{
+			Caller:     "user1",
+			Role:       "admin",
+			RemoteAddr: "127.0.0.1:4321",
+			Method:     utils.APIerSv1LoadTariffPlanFromFolder,
+			ArgsDigest: utils.Sha1("args3"),
+			Result:     utils.OK,
+			Time:       tNow.Add(2 * time.Minute),
+		},
+	}
+	for _, rec := range recs {
+		aS.Audit(rec)
+	}
+	aS.Shutdown() // the queued records are stored before ListenAndServe returns
+	for i := 0; i < 100 && len(storDB.db.GetItemIDs(utils.AuditRecordsTBL, utils.EmptyString)) != len(recs); i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	var reply []*utils.AuditRecord
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{}, &reply); err != nil {
+		t.Fatal(err)
+	} else if exp := []*utils.AuditRecord{recs[1], recs[0], recs[2]}; !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		Callers: []string{"user1"},
+		Methods: []string{utils.APIerSv1LoadTariffPlanFromFolder},
+	}, &reply); err != nil {
+		t.Error(err)
+	} else if exp := []*utils.AuditRecord{recs[2]}; !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	tEnd := tNow.Add(2 * time.Minute)
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		TimeStart: &tNow,
+		TimeEnd:   &tEnd,
+		Paginator: utils.Paginator{Limit: utils.IntPointer(1), Offset: utils.IntPointer(1)},
+	}, &reply); err != nil {
+		t.Error(err)
+	} else if exp := []*utils.AuditRecord{recs[0]}; !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		RemoteAddrs: []string{"127.0.0.1:1234"},
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
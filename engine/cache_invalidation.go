@@ -0,0 +1,182 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// cacheInvalidationMaxRetryDelay limits the delay between two subscribe attempts
+const cacheInvalidationMaxRetryDelay = time.Minute
+
+// CacheInvalidation is the message broadcasted to the engines sharing the DataDB when an item is written
+type CacheInvalidation struct {
+	NodeID  string // the engine instance which made the change, ignored by itself on receive
+	CacheID string
+	ItemID  string
+	GroupID string // remove the full group instead of one item
+}
+
+// CacheInvalidationBus broadcasts the cache invalidations between the engines, eg: over the common DataDB
+type CacheInvalidationBus interface {
+	// PublishCacheInvalidation sends the invalidation to all the subscribers
+	PublishCacheInvalidation(msg *CacheInvalidation) error
+	// SubscribeCacheInvalidations calls subscribed once listening and hndlr for each invalidation received,
+	// blocking until stop is closed or the subscription fails
+	SubscribeCacheInvalidations(subscribed func(), hndlr func(*CacheInvalidation), stop chan struct{}) error
+}
+
+// cacheInvalidator publishes the local writes and drops the items written by the other engines from Cache
+type cacheInvalidator struct {
+	nodeID string
+	bus    CacheInvalidationBus
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// listen keeps the subscription active until stopped
+func (inv *cacheInvalidator) listen() {
+	defer close(inv.done)
+	fib := utils.Fib()
+	var delay time.Duration
+	var lost bool
+	for {
+		err := inv.bus.SubscribeCacheInvalidations(func() {
+			if lost { // invalidations were missed while disconnected
+				clearDataDBCache()
+			}
+			lost = false
+			fib = utils.Fib()
+			delay = 0
+		}, inv.handle, inv.stop)
+		select {
+		case <-inv.stop:
+			return
+		default:
+		}
+		lost = true
+		if delay < cacheInvalidationMaxRetryDelay { // stop growing once capped so the multiplication cannot overflow
+			if delay = time.Duration(fib()) * time.Second; delay > cacheInvalidationMaxRetryDelay {
+				delay = cacheInvalidationMaxRetryDelay
+			}
+		}
+		errMsg := "subscription closed"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> lost cache invalidations subscription, error: %s, retrying in %s",
+			utils.DataManager, errMsg, delay))
+		select {
+		case <-inv.stop:
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// handle removes the invalidated item from Cache
+func (inv *cacheInvalidator) handle(msg *CacheInvalidation) {
+	if msg.NodeID == inv.nodeID { // our own write
+		return
+	}
+	if msg.GroupID != utils.EmptyString {
+		Cache.RemoveGroup(msg.CacheID, msg.GroupID, true, utils.NonTransactional)
+		return
+	}
+	if err := Cache.Remove(msg.CacheID, msg.ItemID, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed removing <%s> from cache <%s>, error: %s",
+			utils.DataManager, msg.ItemID, msg.CacheID, err.Error()))
+	}
+}
+
+// clearDataDBCache drops all the cached DataDB items since they might be stale
+func clearDataDBCache() {
+	chIDs := make([]string, 0, len(utils.CacheInstanceToPrefix))
+	for chID := range utils.CacheInstanceToPrefix {
+		chIDs = append(chIDs, chID)
+	}
+	Cache.Clear(chIDs)
+}
+
+// StartCacheInvalidation shares the cache invalidations with the other engines over the bus
+func (dm *DataManager) StartCacheInvalidation(bus CacheInvalidationBus) {
+	go dm.setCacheInvalidator(bus).listen()
+}
+
+// PublishCacheInvalidations only sends the invalidations of the local writes, eg: for cgr-loader which has no cache to keep in sync
+func (dm *DataManager) PublishCacheInvalidations(bus CacheInvalidationBus) {
+	close(dm.setCacheInvalidator(bus).done)
+}
+
+func (dm *DataManager) setCacheInvalidator(bus CacheInvalidationBus) (inv *cacheInvalidator) {
+	dm.StopCacheInvalidation()
+	inv = &cacheInvalidator{
+		nodeID: utils.GenUUID(),
+		bus:    bus,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	dm.invMux.Lock()
+	dm.inv = inv
+	dm.invMux.Unlock()
+	return
+}
+
+// StopCacheInvalidation stops publishing and receiving the cache invalidations
+func (dm *DataManager) StopCacheInvalidation() {
+	dm.invMux.Lock()
+	inv := dm.inv
+	dm.inv = nil
+	dm.invMux.Unlock()
+	if inv != nil {
+		close(inv.stop)
+		<-inv.done
+	}
+}
+
+// invalidateCache tells the other engines to drop their cached copy of the item
+func (dm *DataManager) invalidateCache(chID, itmID string) {
+	dm.publishInvalidation(&CacheInvalidation{CacheID: chID, ItemID: itmID})
+}
+
+// invalidateCacheGroup tells the other engines to drop their cached copy of the group
+func (dm *DataManager) invalidateCacheGroup(chID, grpID string) {
+	dm.publishInvalidation(&CacheInvalidation{CacheID: chID, GroupID: grpID})
+}
+
+// publishInvalidation is best effort since the item is already written in DataDB
+func (dm *DataManager) publishInvalidation(msg *CacheInvalidation) {
+	if dm == nil {
+		return
+	}
+	dm.invMux.RLock()
+	inv := dm.inv
+	dm.invMux.RUnlock()
+	if inv == nil {
+		return
+	}
+	msg.NodeID = inv.nodeID
+	if err := inv.bus.PublishCacheInvalidation(msg); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed publishing cache invalidation for <%s> on <%s>, error: %s",
+			utils.DataManager, msg.ItemID+msg.GroupID, msg.CacheID, err.Error()))
+	}
+}
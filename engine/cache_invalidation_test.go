@@ -0,0 +1,188 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// testInvalidationBus mocks a CacheInvalidationBus in memory
+type testInvalidationBus struct {
+	sync.Mutex
+	hndlrs    map[chan struct{}]func(*CacheInvalidation)
+	failFirst bool // the first subscribe fails
+	subs      chan struct{}
+}
+
+func newTestInvalidationBus() *testInvalidationBus {
+	return &testInvalidationBus{
+		hndlrs: make(map[chan struct{}]func(*CacheInvalidation)),
+		subs:   make(chan struct{}, 10),
+	}
+}
+
+func (bus *testInvalidationBus) PublishCacheInvalidation(msg *CacheInvalidation) error {
+	bus.Lock()
+	defer bus.Unlock()
+	for _, hndlr := range bus.hndlrs {
+		hndlr(msg)
+	}
+	return nil
+}
+
+func (bus *testInvalidationBus) SubscribeCacheInvalidations(subscribed func(),
+	hndlr func(*CacheInvalidation), stop chan struct{}) error {
+	bus.Lock()
+	if bus.failFirst {
+		bus.failFirst = false
+		bus.Unlock()
+		return errors.New("connection refused")
+	}
+	bus.hndlrs[stop] = hndlr
+	bus.Unlock()
+	subscribed()
+	bus.subs <- struct{}{}
+	<-stop
+	bus.Lock()
+	delete(bus.hndlrs, stop)
+	bus.Unlock()
+	return nil
+}
+
+func (bus *testInvalidationBus) waitSubscribed(t *testing.T) {
+	select {
+	case <-bus.subs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for subscription")
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	bus := newTestInvalidationBus()
+	dm1 := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	dm2 := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	dm1.StartCacheInvalidation(bus)
+	bus.waitSubscribed(t)
+	dm2.StartCacheInvalidation(bus)
+	bus.waitSubscribed(t)
+	defer dm1.StopCacheInvalidation()
+	defer dm2.StopCacheInvalidation()
+
+	tmg := &utils.TPTiming{ID: "TMG_INV", Years: utils.Years{}, Months: utils.Months{},
+		MonthDays: utils.MonthDays{}, WeekDays: utils.WeekDays{}, StartTime: "00:00:00"}
+	Cache.Set(utils.CacheTimings, tmg.ID, tmg, nil, true, utils.NonTransactional)
+	if err := dm1.SetTiming(tmg); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := Cache.Get(utils.CacheTimings, tmg.ID); has {
+		t.Errorf("expecting %s to be removed from cache", tmg.ID)
+	}
+
+	// own writes are ignored by the handler
+	msg := &CacheInvalidation{NodeID: dm1.inv.nodeID, CacheID: utils.CacheTimings, ItemID: tmg.ID}
+	Cache.Set(utils.CacheTimings, tmg.ID, tmg, nil, true, utils.NonTransactional)
+	dm1.inv.handle(msg)
+	if _, has := Cache.Get(utils.CacheTimings, tmg.ID); !has {
+		t.Errorf("expecting %s to remain in cache", tmg.ID)
+	}
+	dm2.inv.handle(msg)
+	if _, has := Cache.Get(utils.CacheTimings, tmg.ID); has {
+		t.Errorf("expecting %s to be removed from cache", tmg.ID)
+	}
+
+	// removing all the indexes of a context drops the group
+	tntCtx := "cgrates.org:*sessions"
+	Cache.Set(utils.CacheAttributeFilterIndexes, utils.ConcatenatedKey(tntCtx, "*string:~*req.Account:1001"),
+		utils.StringSet{"ATTR1": {}}, []string{tntCtx}, true, utils.NonTransactional)
+	if err := dm1.RemoveIndexes(utils.CacheAttributeFilterIndexes, tntCtx, utils.EmptyString); err != nil &&
+		err != utils.ErrNotFound {
+		t.Fatal(err)
+	}
+	if Cache.tCache.HasGroup(utils.CacheAttributeFilterIndexes, tntCtx) {
+		t.Errorf("expecting group %s to be removed from cache", tntCtx)
+	}
+
+	// without invalidation nothing is published
+	dm2.StopCacheInvalidation()
+	dm1.StopCacheInvalidation()
+	Cache.Set(utils.CacheTimings, tmg.ID, tmg, nil, true, utils.NonTransactional)
+	if err := dm1.SetTiming(tmg); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := Cache.Get(utils.CacheTimings, tmg.ID); !has {
+		t.Errorf("expecting %s to remain in cache", tmg.ID)
+	}
+	Cache.Clear(nil)
+}
+
+func TestCacheInvalidationResubscribe(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	bus := newTestInvalidationBus()
+	bus.failFirst = true
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	Cache.Set(utils.CacheRatingPlans, "RP_INV", "stale", nil, true, utils.NonTransactional)
+	Cache.Set(utils.CacheUCH, "UCH_INV", "keep", nil, true, utils.NonTransactional)
+	dm.StartCacheInvalidation(bus)
+	bus.waitSubscribed(t) // after the retry
+	defer dm.StopCacheInvalidation()
+	if _, has := Cache.Get(utils.CacheRatingPlans, "RP_INV"); has {
+		t.Error("expecting the DataDB items to be cleared after missing invalidations")
+	}
+	if _, has := Cache.Get(utils.CacheUCH, "UCH_INV"); !has {
+		t.Error("expecting the other partitions to be kept")
+	}
+	Cache.Clear(nil)
+}
+
+func TestCacheInvalidationPublishOnly(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	bus := newTestInvalidationBus()
+	ldrDM := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	dm.StartCacheInvalidation(bus)
+	bus.waitSubscribed(t)
+	defer dm.StopCacheInvalidation()
+	ldrDM.PublishCacheInvalidations(bus)
+	bus.Lock()
+	if len(bus.hndlrs) != 1 {
+		t.Errorf("expecting only the engine to subscribe, received: %d", len(bus.hndlrs))
+	}
+	bus.Unlock()
+	tmg := &utils.TPTiming{ID: "TMG_LDR", Years: utils.Years{}, Months: utils.Months{},
+		MonthDays: utils.MonthDays{}, WeekDays: utils.WeekDays{}, StartTime: "00:00:00"}
+	Cache.Set(utils.CacheTimings, tmg.ID, tmg, nil, true, utils.NonTransactional)
+	if err := ldrDM.SetTiming(tmg); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := Cache.Get(utils.CacheTimings, tmg.ID); has {
+		t.Errorf("expecting %s to be removed from cache", tmg.ID)
+	}
+	ldrDM.StopCacheInvalidation()
+	Cache.Clear(nil)
+}
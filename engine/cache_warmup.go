@@ -0,0 +1,266 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// cacheExportTTL is the time an export is kept in between two chunk requests
+const cacheExportTTL = 10 * time.Minute
+
+// peerCacheItem is one cached item as transferred between engines
+type peerCacheItem struct {
+	ItemID   string
+	Value    interface{}
+	GroupIDs []string
+}
+
+// peerStringSet transports an utils.StringSet since gob cannot encode empty structs
+type peerStringSet []string
+
+// cacheExport is the snapshot of item IDs exported to a peer, chunk by chunk
+type cacheExport struct {
+	cacheID string
+	itmIDs  []string
+	timer   *time.Timer
+}
+
+// V1GetCacheChunk exports one chunk of a partition so a peer can warm up its cache
+func (chS *CacheS) V1GetCacheChunk(args *utils.ArgsGetCacheChunk, reply *utils.CacheChunk) (err error) {
+	if _, has := chS.pcItems[args.CacheID]; !has {
+		return fmt.Errorf("unknown cacheID: %s", args.CacheID)
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = chS.cfg.CacheCfg().PrecacheChunkSize
+	}
+	expID, itmIDs, err := chS.getCacheExport(args.CacheID, args.ExportID)
+	if err != nil {
+		return
+	}
+	start, end := args.Offset, args.Offset+limit
+	if start > len(itmIDs) {
+		start = len(itmIDs)
+	}
+	if end > len(itmIDs) {
+		end = len(itmIDs)
+	}
+	itms := make([]*peerCacheItem, 0, end-start)
+	grpItms := make(map[string]utils.StringSet)
+	for _, itmID := range itmIDs[start:end] {
+		val, has := chS.tCache.Get(args.CacheID, itmID)
+		if !has { // removed in the meantime
+			continue
+		}
+		if ss, isSet := val.(utils.StringSet); isSet {
+			val = peerStringSet(ss.AsSlice())
+		} else if val, err = exportCacheValue(val); err != nil {
+			chS.removeCacheExport(expID)
+			return
+		}
+		itms = append(itms, &peerCacheItem{
+			ItemID:   itmID,
+			Value:    val,
+			GroupIDs: chS.itemGroupIDs(args.CacheID, itmID, grpItms),
+		})
+	}
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(itms); err != nil {
+		chS.removeCacheExport(expID)
+		return
+	}
+	done := end == len(itmIDs)
+	if done {
+		chS.removeCacheExport(expID)
+	}
+	*reply = utils.CacheChunk{
+		ExportID: expID,
+		Items:    buf.Bytes(),
+		Next:     end,
+		Done:     done,
+	}
+	return
+}
+
+// exportCacheValue copies the items updated in place by their services,
+// under the same locks, so they are not encoded while being changed
+func exportCacheValue(val interface{}) (cln interface{}, err error) {
+	var lkID string
+	switch v := val.(type) {
+	case *Resource:
+		lkID = utils.ResourcesPrefix + v.TenantID()
+	case *StatQueue:
+		lkID = utils.StatQueuePrefix + v.TenantID()
+	case *Threshold:
+		tCln := *v // no locks used by ThresholdS, only values inside
+		return &tCln, nil
+	default:
+		return val, nil
+	}
+	_, err = guardian.Guardian.Guard(func() (_ interface{}, err error) {
+		if sq, isSQ := val.(*StatQueue); isSQ {
+			sq.RLock()
+			defer sq.RUnlock()
+		}
+		var buf bytes.Buffer
+		if err = gob.NewEncoder(&buf).Encode(val); err != nil {
+			return
+		}
+		cln = reflect.New(reflect.TypeOf(val).Elem()).Interface()
+		err = gob.NewDecoder(&buf).Decode(cln)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID)
+	return
+}
+
+// getCacheExport returns the item IDs of the export, creating a new one if expID is empty
+func (chS *CacheS) getCacheExport(cacheID, expID string) (string, []string, error) {
+	chS.expMux.Lock()
+	defer chS.expMux.Unlock()
+	if expID != utils.EmptyString {
+		exp, has := chS.exports[expID]
+		if !has || exp.cacheID != cacheID {
+			return expID, nil, utils.ErrNotFound
+		}
+		exp.timer.Reset(cacheExportTTL)
+		return expID, exp.itmIDs, nil
+	}
+	exp := &cacheExport{
+		cacheID: cacheID,
+		itmIDs:  chS.tCache.GetItemIDs(cacheID, utils.EmptyString),
+	}
+	sort.Strings(exp.itmIDs)
+	expID = utils.GenUUID()
+	exp.timer = time.AfterFunc(cacheExportTTL, func() { chS.removeCacheExport(expID) })
+	chS.exports[expID] = exp
+	return expID, exp.itmIDs, nil
+}
+
+func (chS *CacheS) removeCacheExport(expID string) {
+	chS.expMux.Lock()
+	if exp, has := chS.exports[expID]; has {
+		exp.timer.Stop()
+		delete(chS.exports, expID)
+	}
+	chS.expMux.Unlock()
+}
+
+// itemGroupIDs finds the groups of an item by checking the prefixes of its ID
+// since the groups (eg: tenant:context for indexes) are not exposed by ltcache
+func (chS *CacheS) itemGroupIDs(cacheID, itmID string, grpItms map[string]utils.StringSet) (grpIDs []string) {
+	for idx := strings.Index(itmID, utils.CONCATENATED_KEY_SEP); idx != -1; {
+		grpID := itmID[:idx]
+		itms, has := grpItms[grpID]
+		if !has {
+			itms = utils.NewStringSet(chS.tCache.GetGroupItemIDs(cacheID, grpID))
+			grpItms[grpID] = itms
+		}
+		if itms.Has(itmID) {
+			grpIDs = append(grpIDs, grpID)
+		}
+		nextIdx := strings.Index(itmID[idx+1:], utils.CONCATENATED_KEY_SEP)
+		if nextIdx == -1 {
+			break
+		}
+		idx += nextIdx + 1
+	}
+	return
+}
+
+// precacheFromPeers warms up the partition from the first peer having it ready,
+// returning false if the partition needs to be loaded from DataDB
+func (chS *CacheS) precacheFromPeers(cacheID string) bool {
+	if chS.dm == nil || chS.dm.connMgr == nil ||
+		len(chS.cfg.CacheCfg().PrecacheConns) == 0 {
+		return false
+	}
+	for _, connID := range chS.cfg.CacheCfg().PrecacheConns {
+		err := chS.precacheFromPeer(connID, cacheID)
+		if err == nil {
+			return true
+		}
+		chS.tCache.Clear([]string{cacheID}) // do not keep partial data
+		utils.Logger.Warning(fmt.Sprintf("<%s> could not precache <%s> from peer <%s>, error: %s",
+			utils.CacheS, cacheID, connID, err.Error()))
+	}
+	return false
+}
+
+// precacheFromPeer pulls the partition chunk by chunk from the peer
+func (chS *CacheS) precacheFromPeer(connID, cacheID string) (err error) {
+	conns := []string{connID}
+	var status map[string]string
+	if err = chS.dm.connMgr.Call(conns, nil, utils.CacheSv1PrecacheStatus,
+		&utils.AttrCacheIDsWithArgDispatcher{CacheIDs: []string{cacheID}}, &status); err != nil {
+		return
+	}
+	if status[cacheID] != utils.MetaReady {
+		return fmt.Errorf("partition not ready on peer, status: <%s>", status[cacheID])
+	}
+	args := &utils.ArgsGetCacheChunk{
+		CacheID: cacheID,
+		Limit:   chS.cfg.CacheCfg().PrecacheChunkSize,
+	}
+	for {
+		var chunk utils.CacheChunk
+		if err = chS.dm.connMgr.Call(conns, nil, utils.CacheSv1GetCacheChunk,
+			args, &chunk); err != nil {
+			return
+		}
+		var itms []*peerCacheItem
+		if err = gob.NewDecoder(bytes.NewReader(chunk.Items)).Decode(&itms); err != nil {
+			return
+		}
+		for _, itm := range itms {
+			if err = importPeerCacheValue(itm); err != nil {
+				return
+			}
+			chS.tCache.Set(cacheID, itm.ItemID, itm.Value, itm.GroupIDs,
+				true, utils.NonTransactional)
+		}
+		if chunk.Done {
+			return
+		}
+		args.ExportID, args.Offset = chunk.ExportID, chunk.Next
+	}
+}
+
+// importPeerCacheValue rebuilds the value as it would be cached after reading it from DataDB
+func importPeerCacheValue(itm *peerCacheItem) (err error) {
+	switch val := itm.Value.(type) {
+	case peerStringSet:
+		itm.Value = utils.NewStringSet(val)
+	case interface{ Compile() error }:
+		if err = val.Compile(); err != nil {
+			return fmt.Errorf("compiling <%s>: %s", itm.ItemID, err.Error())
+		}
+	}
+	return
+}
@@ -0,0 +1,176 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func newTestWarmupCacheS(connID string, peer *CacheS) (chS *CacheS) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.CacheCfg().PrecacheConns = []string{connID}
+	cfg.CacheCfg().PrecacheChunkSize = 2
+	for _, cacheID := range []string{utils.CacheDestinations, utils.CacheFilters,
+		utils.CacheAttributeFilterIndexes} {
+		cfg.CacheCfg().Partitions[cacheID].Precache = true
+	}
+	peerChan := make(chan rpcclient.ClientConnector, 1)
+	peerChan <- peer
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(),
+		NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{connID: peerChan}))
+	chS = NewCacheS(cfg, dm)
+	return
+}
+
+func TestCacheSPrecacheFromPeer(t *testing.T) {
+	peerCfg, _ := config.NewDefaultCGRConfig()
+	peer := NewCacheS(peerCfg, nil)
+	if err := peer.Precache(); err != nil { // nothing to precache, marks the partitions as ready
+		t.Fatal(err)
+	}
+	dst := &Destination{Id: "DST_1001", Prefixes: []string{"1001", "1002"}}
+	peer.Set(utils.CacheDestinations, "DST_1001", dst, nil, true, utils.NonTransactional)
+	peer.Set(utils.CacheDestinations, "DST_MISSING", nil, nil, true, utils.NonTransactional)
+	peer.Set(utils.CacheDestinations, "DST_1003", &Destination{Id: "DST_1003", Prefixes: []string{"1003"}},
+		nil, true, utils.NonTransactional)
+	fltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_RSR",
+		Rules: []*FilterRule{{
+			Type:   utils.MetaRSR,
+			Values: []string{"~Tenant(~^cgr.*\\.org$)"},
+		}},
+	}
+	if err := fltr.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	peer.Set(utils.CacheFilters, fltr.TenantID(), fltr, nil, true, utils.NonTransactional)
+	tntCtx := "cgrates.org:*sessions"
+	idxKey := utils.ConcatenatedKey(tntCtx, "*string:~*req.Account:1001")
+	peer.Set(utils.CacheAttributeFilterIndexes, idxKey, utils.StringSet{"ATTR_1001": {}},
+		[]string{tntCtx}, true, utils.NonTransactional)
+
+	chS := newTestWarmupCacheS("*peerCacheS", peer)
+	if err := chS.Precache(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, has := chS.Get(utils.CacheDestinations, "DST_1001"); !has {
+		t.Error("expecting DST_1001 to be precached")
+	} else if !reflect.DeepEqual(dst, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(dst), utils.ToJSON(rcv))
+	}
+	if rcv, has := chS.Get(utils.CacheDestinations, "DST_MISSING"); !has || rcv != nil {
+		t.Errorf("expecting nil DST_MISSING to be precached, received: %v, %v", rcv, has)
+	}
+	if _, has := chS.Get(utils.CacheDestinations, "DST_1003"); !has {
+		t.Error("expecting DST_1003 to be precached")
+	}
+	if rcv, has := chS.Get(utils.CacheFilters, fltr.TenantID()); !has {
+		t.Error("expecting the filter to be precached")
+	} else if rcv.(*Filter).Rules[0].rsrFields == nil {
+		t.Error("expecting the filter to be compiled")
+	}
+	eIdx := utils.StringSet{"ATTR_1001": {}}
+	if rcv, has := chS.Get(utils.CacheAttributeFilterIndexes, idxKey); !has {
+		t.Error("expecting the index to be precached")
+	} else if !reflect.DeepEqual(eIdx, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eIdx), utils.ToJSON(rcv))
+	}
+	if rcv := chS.tCache.GetGroupItemIDs(utils.CacheAttributeFilterIndexes, tntCtx); !reflect.DeepEqual([]string{idxKey}, rcv) {
+		t.Errorf("expecting group items: %+v, received: %+v", []string{idxKey}, rcv)
+	}
+	if len(peer.exports) != 0 {
+		t.Errorf("expecting the exports to be removed, received: %+v", peer.exports)
+	}
+}
+
+func TestCacheSPrecacheFromPeerNotReady(t *testing.T) {
+	peerCfg, _ := config.NewDefaultCGRConfig()
+	peer := NewCacheS(peerCfg, nil) // still precaching
+	peer.Set(utils.CacheDestinations, "DST_PEER", &Destination{Id: "DST_PEER"}, nil, true, utils.NonTransactional)
+	chS := newTestWarmupCacheS("*peerCacheSNotReady", peer)
+	if err := chS.Precache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := chS.Get(utils.CacheDestinations, "DST_PEER"); has {
+		t.Error("not expecting items from a peer which is not ready")
+	}
+}
+
+func TestCacheSV1GetCacheChunk(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	chS := NewCacheS(cfg, nil)
+	for _, id := range []string{"TM_3", "TM_1", "TM_2"} {
+		chS.Set(utils.CacheTimings, id, &utils.TPTiming{ID: id}, nil, true, utils.NonTransactional)
+	}
+	var chunk utils.CacheChunk
+	if err := chS.V1GetCacheChunk(&utils.ArgsGetCacheChunk{CacheID: "unknown"}, &chunk); err == nil {
+		t.Error("expecting error for unknown partition")
+	}
+	args := &utils.ArgsGetCacheChunk{CacheID: utils.CacheTimings, Limit: 2}
+	if err := chS.V1GetCacheChunk(args, &chunk); err != nil {
+		t.Fatal(err)
+	} else if chunk.Done || chunk.Next != 2 || chunk.ExportID == utils.EmptyString {
+		t.Errorf("unexpected chunk: %+v", chunk)
+	}
+	if err := chS.V1GetCacheChunk(&utils.ArgsGetCacheChunk{CacheID: utils.CacheTimings,
+		ExportID: "unknown", Offset: 2}, &chunk); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	args.ExportID, args.Offset = chunk.ExportID, chunk.Next
+	if err := chS.V1GetCacheChunk(args, &chunk); err != nil {
+		t.Fatal(err)
+	} else if !chunk.Done || chunk.Next != 3 {
+		t.Errorf("unexpected chunk: %+v", chunk)
+	}
+	if len(chS.exports) != 0 {
+		t.Errorf("expecting the export to be removed, received: %+v", chS.exports)
+	}
+}
+
+func TestCacheSExportCacheValue(t *testing.T) {
+	r := &Resource{Tenant: "cgrates.org", ID: "RES1",
+		Usages: map[string]*ResourceUsage{"RU1": {Tenant: "cgrates.org", ID: "RU1", Units: 1}}}
+	cln, err := exportCacheValue(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rCln, canCast := cln.(*Resource)
+	if !canCast || rCln == r {
+		t.Fatalf("expecting a copy of the resource, received: %+v", cln)
+	}
+	if !reflect.DeepEqual(r.Usages, rCln.Usages) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(r.Usages), utils.ToJSON(rCln.Usages))
+	}
+	r.Usages["RU2"] = &ResourceUsage{Tenant: "cgrates.org", ID: "RU2", Units: 1}
+	if len(rCln.Usages) != 1 {
+		t.Errorf("expecting the copy to be independent, received: %s", utils.ToJSON(rCln.Usages))
+	}
+	tmg := &utils.TPTiming{ID: "TM_1"}
+	if val, err := exportCacheValue(tmg); err != nil {
+		t.Error(err)
+	} else if val != tmg {
+		t.Errorf("expecting the same value, received: %+v", val)
+	}
+}
@@ -66,6 +66,20 @@ func init() {
 	// CDRs
 	gob.Register(new(EventCost))
 
+	// Cache warm-up from peers
+	gob.Register(new(Destination))
+	gob.Register(new(RatingPlan))
+	gob.Register(new(RatingProfile))
+	gob.Register(Actions{})
+	gob.Register(new(ActionPlan))
+	gob.Register(ActionTriggers{})
+	gob.Register(new(SharedGroup))
+	gob.Register(new(utils.TPTiming))
+	gob.Register(new(AttributeProfile))
+	gob.Register(new(ChargerProfile))
+	gob.Register(new(DispatcherProfile))
+	gob.Register(peerStringSet{})
+
 	// StatMetrics
 	gob.Register(new(StatASR))
 	gob.Register(new(StatACD))
@@ -111,6 +125,7 @@ func NewCacheS(cfg *config.CGRConfig, dm *DataManager) (c *CacheS) {
 		dm:      dm,
 		pcItems: make(map[string]chan struct{}),
 		tCache:  ltcache.NewTransCache(tCache),
+		exports: make(map[string]*cacheExport),
 	}
 	for cacheID := range cfg.CacheCfg().Partitions {
 		c.pcItems[cacheID] = make(chan struct{})
@@ -124,6 +139,9 @@ type CacheS struct {
 	dm      *DataManager
 	pcItems map[string]chan struct{} // signal precaching
 	tCache  *ltcache.TransCache
+
+	expMux  sync.Mutex
+	exports map[string]*cacheExport // partitions exported to peers
 }
 
 // Set is an exported method from TransCache
@@ -155,6 +173,11 @@ func (chS *CacheS) Remove(chID, itmID string, commit bool, transID string) (err
 	return chS.ReplicateRemove(chID, itmID)
 }
 
+// RemoveGroup is an exported method from TransCache
+func (chS *CacheS) RemoveGroup(chID, grpID string, commit bool, transID string) {
+	chS.tCache.RemoveGroup(chID, grpID, commit, transID)
+}
+
 // Clear is an exported method from TransCache
 func (chS *CacheS) Clear(chIDs []string) {
 	chS.tCache.Clear(chIDs)
@@ -197,6 +220,11 @@ func (chS *CacheS) Precache() (err error) {
 		}
 		wg.Add(1)
 		go func(cacheID string) {
+			if chS.precacheFromPeers(cacheID) {
+				close(chS.pcItems[cacheID])
+				wg.Done()
+				return
+			}
 			errCache := chS.dm.CacheDataFromDB(
 				utils.CacheInstanceToPrefix[cacheID], nil,
 				false)
@@ -774,7 +774,7 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool)
 	if cd.PerformRounding {
 		cc.Round()
 		roundIncrements := cc.GetRoundIncrements()
-		if len(roundIncrements) != 0 {
+		if len(roundIncrements) != 0 && !dryRun {
 			rcd := cc.CreateCallDescriptor()
 			rcd.Increments = roundIncrements
 			rcd.refundRounding()
@@ -792,6 +792,9 @@ func (cd *CallDescriptor) Debit() (cc *CallCost, err error) {
 		if err != nil {
 			return nil, err
 		}
+		if cd.DryRun {
+			cd.refundDryRun(account)
+		}
 		acntIDs, sgerr := account.GetUniqueSharedGroupMembers(cd)
 		if sgerr != nil {
 			return nil, sgerr
@@ -921,6 +924,28 @@ func (cd *CallDescriptor) refundIncrements() (acnt *Account, err error) {
 
 }
 
+// refundDryRun adds back in memory the Increments debited out of the account, without
+// counting the units, so a dry run debit sees the balances as before the Increments
+func (cd *CallDescriptor) refundDryRun(account *Account) {
+	cd.Increments.Decompress()
+	for _, increment := range cd.Increments {
+		if increment.BalanceInfo == nil ||
+			increment.BalanceInfo.AccountID != account.ID {
+			continue
+		}
+		if increment.BalanceInfo.Unit != nil && increment.BalanceInfo.Unit.UUID != "" {
+			if balance := account.BalanceMap[cd.ToR].GetBalance(increment.BalanceInfo.Unit.UUID); balance != nil {
+				balance.AddValue(float64(increment.Duration.Nanoseconds()))
+			}
+		}
+		if increment.BalanceInfo.Monetary != nil && increment.BalanceInfo.Monetary.UUID != "" {
+			if balance := account.BalanceMap[utils.MONETARY].GetBalance(increment.BalanceInfo.Monetary.UUID); balance != nil {
+				balance.AddValue(increment.Cost)
+			}
+		}
+	}
+}
+
 func (cd *CallDescriptor) RefundIncrements() (acnt *Account, err error) {
 	// get account list for locking
 	// all must be locked in order to use cache
@@ -1665,6 +1665,37 @@ func TestCDRefundIncrements(t *testing.T) {
 	}
 }
 
+func TestCDRefundDryRun(t *testing.T) {
+	ub := &Account{
+		ID: "test:refdry",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: Balances{
+				&Balance{Uuid: "moneya", Value: 100},
+			},
+			utils.VOICE: Balances{
+				&Balance{Uuid: "minutea", Value: 10 * float64(time.Second)},
+			},
+		},
+	}
+	dm.SetAccount(ub)
+	acnt, _ := dm.GetAccount(ub.ID)
+	cd := &CallDescriptor{ToR: utils.VOICE, Increments: Increments{
+		&Increment{Cost: 2, Duration: 3 * time.Second, BalanceInfo: &DebitInfo{
+			Unit:     &UnitInfo{UUID: "minutea"},
+			Monetary: &MonetaryInfo{UUID: "moneya"}, AccountID: ub.ID}},
+		&Increment{Cost: 5, BalanceInfo: &DebitInfo{
+			Monetary: &MonetaryInfo{UUID: "moneya"}, AccountID: "test:other"}},
+	}}
+	cd.refundDryRun(acnt)
+	if acnt.BalanceMap[utils.MONETARY][0].GetValue() != 102 ||
+		acnt.BalanceMap[utils.VOICE][0].GetValue() != 13*float64(time.Second) {
+		t.Error("Error refunding in dry run: ", utils.ToIJSON(acnt.BalanceMap))
+	}
+	if ub, _ = dm.GetAccount(ub.ID); ub.BalanceMap[utils.MONETARY][0].GetValue() != 100 {
+		t.Error("not expecting the stored account to change: ", utils.ToIJSON(ub.BalanceMap))
+	}
+}
+
 func TestCDRefundIncrementsZeroValue(t *testing.T) {
 	ub := &Account{
 		ID: "test:ref",
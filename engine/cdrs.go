@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -91,10 +92,20 @@ type CDRServer struct {
 	filterS    *FilterS
 	connMgr    *ConnManager
 	storDBChan chan StorDB
+	dedupStats CDRsDedupStats
+	dedupMux   sync.RWMutex
+	archiveMux sync.RWMutex // one archive run at a time
 }
 
 // ListenAndServe listen for storbd reload
 func (cdrS *CDRServer) ListenAndServe(stopChan chan struct{}) (err error) {
+	var archiveTick <-chan time.Time // nil channel blocks forever if the archive is disabled
+	if arcCfg := cdrS.cgrCfg.CdrsCfg().Archive; arcCfg != nil &&
+		arcCfg.RunInterval > 0 && len(arcCfg.Rules) != 0 {
+		tkr := time.NewTicker(arcCfg.RunInterval)
+		defer tkr.Stop()
+		archiveTick = tkr.C
+	}
 	for {
 		select {
 		case <-stopChan:
@@ -104,6 +115,14 @@ func (cdrS *CDRServer) ListenAndServe(stopChan chan struct{}) (err error) {
 				return
 			}
 			cdrS.cdrDb = stordb
+		case <-archiveTick:
+			go func() {
+				if _, err := cdrS.archiveCDRs(); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: <%s> archiving CDRs",
+							utils.CDRs, err.Error()))
+				}
+			}()
 		}
 	}
 }
@@ -231,18 +250,13 @@ func (cdrS *CDRServer) rateCDR(cdr *CDRWithArgDispatcher) ([]*CDR, error) {
 var reqTypes = utils.NewStringSet([]string{utils.META_PSEUDOPREPAID, utils.META_POSTPAID, utils.META_PREPAID,
 	utils.PSEUDOPREPAID, utils.POSTPAID, utils.PREPAID, utils.MetaDynaprepaid})
 
-// getCostFromRater will retrieve the cost from RALs
-func (cdrS *CDRServer) getCostFromRater(cdr *CDRWithArgDispatcher) (*CallCost, error) {
-	if len(cdrS.cgrCfg.CdrsCfg().RaterConns) == 0 {
-		return nil, utils.NewErrNotConnected(utils.RALService)
-	}
-	cc := new(CallCost)
-	var err error
+// newCallDescriptorFromCDR returns the CallDescriptor used to rate the CDR
+func newCallDescriptorFromCDR(cdr *CDR) *CallDescriptor {
 	timeStart := cdr.AnswerTime
 	if timeStart.IsZero() { // Fix for FreeSWITCH unanswered calls
 		timeStart = cdr.SetupTime
 	}
-	cd := &CallDescriptor{
+	return &CallDescriptor{
 		ToR:             cdr.ToR,
 		Tenant:          cdr.Tenant,
 		Category:        cdr.Category,
@@ -254,13 +268,28 @@ func (cdrS *CDRServer) getCostFromRater(cdr *CDRWithArgDispatcher) (*CallCost, e
 		DurationIndex:   cdr.Usage,
 		PerformRounding: true,
 	}
+}
+
+// getCostFromRater will retrieve the cost from RALs
+func (cdrS *CDRServer) getCostFromRater(cdr *CDRWithArgDispatcher) (*CallCost, error) {
+	return cdrS.getCostFromRaterWithCD(cdr, newCallDescriptorFromCDR(cdr.CDR))
+}
+
+// getCostFromRaterWithCD will retrieve the cost of the CDR from RALs using the given CallDescriptor,
+// the accounts are not created for *dynaprepaid in case of dry run
+func (cdrS *CDRServer) getCostFromRaterWithCD(cdr *CDRWithArgDispatcher, cd *CallDescriptor) (*CallCost, error) {
+	if len(cdrS.cgrCfg.CdrsCfg().RaterConns) == 0 {
+		return nil, utils.NewErrNotConnected(utils.RALService)
+	}
+	cc := new(CallCost)
+	var err error
 	if reqTypes.Has(cdr.RequestType) { // Prepaid - Cost can be recalculated in case of missing records from SM
 		err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().RaterConns, nil,
 			utils.ResponderDebit,
 			&CallDescriptorWithArgDispatcher{CallDescriptor: cd,
 				ArgDispatcher: cdr.ArgDispatcher}, cc)
 		if err != nil && err.Error() == utils.ErrAccountNotFound.Error() &&
-			cdr.RequestType == utils.MetaDynaprepaid {
+			cdr.RequestType == utils.MetaDynaprepaid && !cd.DryRun {
 			var reply string
 			// execute the actionPlan configured in RalS
 			if err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().SchedulerConns, nil,
@@ -462,28 +491,11 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 	}
 	// Check if the unique ID was not already processed
 	if !refund {
-		for _, cgrEv := range cgrEvs {
-			me := MapEvent(cgrEv.CGREvent.Event)
-			if !me.HasField(utils.CGRID) { // try to compute the CGRID if missing
-				me[utils.CGRID] = utils.Sha1(
-					me.GetStringIgnoreErrors(utils.OriginID),
-					me.GetStringIgnoreErrors(utils.OriginHost),
-				)
-			}
-			uID := utils.ConcatenatedKey(
-				me.GetStringIgnoreErrors(utils.CGRID),
-				me.GetStringIgnoreErrors(utils.RunID),
-			)
-			if Cache.HasItem(utils.CacheCDRIDs, uID) && !reRate {
-				utils.Logger.Warning(
-					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
-						utils.CDRs, utils.ErrExists, utils.ToJSON(cgrEv), utils.CacheS))
-				return nil, utils.ErrExists
-			}
-			if errCh := Cache.Set(utils.CacheCDRIDs, uID, true, nil,
-				cacheCommit(utils.NonTransactional), utils.NonTransactional); errCh != nil {
-				return nil, errCh
-			}
+		if cgrEvs, err = cdrS.dedupEvents(cgrEvs, reRate); err != nil {
+			return
+		}
+		if len(cgrEvs) == 0 { // all events were dropped as duplicates
+			return
 		}
 	}
 	// Populate CDR list out of events
@@ -547,12 +559,34 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 				}
 			}
 		}
+		var dupIdxs []int // duplicates dropped by the dedup_policy
 		for i, cdr := range cdrs {
 			if err = cdrS.cdrDb.SetCDR(cdr, false); err != nil {
-				if err != utils.ErrExists || !reRate {
+				if err != utils.ErrExists ||
+					(!reRate && cdrS.cgrCfg.CdrsCfg().DedupPolicy == utils.META_NONE) {
 					refundCDRCosts()
 					return
 				}
+				if !reRate { // duplicate CDR, apply the dedup_policy
+					var storedCDR *CDR
+					if storedCDR, err = cdrS.dedupStoredCDR(cdr); err != nil {
+						utils.Logger.Warning(
+							fmt.Sprintf("<%s> error: <%s> deduplicating CDR %+v",
+								utils.CDRs, err.Error(), cdr))
+						err = utils.ErrPartiallyExecuted
+						return
+					}
+					if storedCDR == nil {
+						dupIdxs = append(dupIdxs, i)
+						continue
+					}
+					cdrs[i] = storedCDR
+					cgrEvs[i] = &utils.CGREventWithArgDispatcher{
+						CGREvent:      storedCDR.AsCGREvent(),
+						ArgDispatcher: ev.ArgDispatcher,
+					}
+					continue
+				}
 				// CDR was found in StorDB
 				// reRate is allowed, refund the previous CDR
 				var prevCDRs []*CDR // only one should be returned
@@ -580,6 +614,14 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 				}
 			}
 		}
+		for i := len(dupIdxs) - 1; i >= 0; i-- { // remove the dropped duplicates
+			idx := dupIdxs[i]
+			cdrs = append(cdrs[:idx], cdrs[idx+1:]...)
+			cgrEvs = append(cgrEvs[:idx], cgrEvs[idx+1:]...)
+			if idx < len(procFlgs) {
+				procFlgs = append(procFlgs[:idx], procFlgs[idx+1:]...)
+			}
+		}
 	}
 	var partiallyExecuted bool // from here actions are optional and a general error is returned
 	if export {
@@ -1090,7 +1132,12 @@ func (cdrS *CDRServer) V1GetCDRs(args utils.RPCCDRsFilterWithArgDispatcher, cdrs
 		}
 		return err
 	}
-	qryCDRs, _, err := cdrS.cdrDb.GetCDRs(cdrsFltr, false)
+	var qryCDRs []*CDR
+	if args.Archived {
+		qryCDRs, err = cdrS.getCDRsWithArchives(cdrsFltr)
+	} else {
+		qryCDRs, _, err = cdrS.cdrDb.GetCDRs(cdrsFltr, false)
+	}
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
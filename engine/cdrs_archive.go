@@ -0,0 +1,673 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	archiveIndexFile = "index.json"
+	archivePageSize  = 1000
+)
+
+// CDRsArchive indexes one of the archive files
+type CDRsArchive struct {
+	File            string // file name inside the archive_path
+	Type            string // <*cdrs|*sm_costs>
+	Format          string // <*csv|*json>
+	Tenant          string // empty if the rule archived all the tenants
+	Count           int
+	AnswerTimeStart time.Time // oldest AnswerTime of the archived CDRs
+	AnswerTimeEnd   time.Time // newest AnswerTime of the archived CDRs
+	CreatedAt       time.Time
+}
+
+// matchesFilter checks if the CDRs archive could contain CDRs matching the filter
+func (arc *CDRsArchive) matchesFilter(fltr *utils.CDRsFilter) bool {
+	if arc.Type != utils.MetaCDRs {
+		return false
+	}
+	if arc.Tenant != utils.EmptyString && len(fltr.Tenants) != 0 &&
+		!utils.IsSliceMember(fltr.Tenants, arc.Tenant) {
+		return false
+	}
+	if fltr.AnswerTimeStart != nil && !fltr.AnswerTimeStart.IsZero() &&
+		arc.AnswerTimeEnd.Before(*fltr.AnswerTimeStart) {
+		return false
+	}
+	if fltr.AnswerTimeEnd != nil && !fltr.AnswerTimeEnd.IsZero() &&
+		arc.AnswerTimeStart.After(*fltr.AnswerTimeEnd) {
+		return false
+	}
+	return true
+}
+
+// sizeWriter counts the bytes written
+type sizeWriter struct {
+	w    io.Writer
+	size int64
+}
+
+func (sw *sizeWriter) Write(p []byte) (n int, err error) {
+	n, err = sw.w.Write(p)
+	sw.size += int64(n)
+	return
+}
+
+// archiveWriter writes the records into a compressed archive file
+type archiveWriter struct {
+	arc  *CDRsArchive
+	fd   *os.File
+	szW  *sizeWriter // bytes of the records before compression
+	gzW  *gzip.Writer
+	csvW *csv.Writer
+	jsnE *json.Encoder
+}
+
+func newArchiveWriter(arcPath, arcType, format, tnt string) (aw *archiveWriter, err error) {
+	tntName := tnt
+	if tntName == utils.EmptyString {
+		tntName = utils.META_ANY
+	}
+	ext := utils.CSVSuffix
+	if format == utils.MetaJSON {
+		ext = utils.JSNSuffix
+	}
+	now := time.Now()
+	aw = &archiveWriter{
+		arc: &CDRsArchive{
+			File: fmt.Sprintf("%s_%s_%s_%s%s.gz", arcType[1:], tntName,
+				now.Format("20060102150405"), utils.UUIDSha1Prefix(), ext),
+			Type:      arcType,
+			Format:    format,
+			Tenant:    tnt,
+			CreatedAt: now,
+		},
+	}
+	if aw.fd, err = os.Create(path.Join(arcPath, aw.arc.File)); err != nil {
+		return nil, err
+	}
+	aw.gzW = gzip.NewWriter(aw.fd)
+	aw.szW = &sizeWriter{w: aw.gzW}
+	if format == utils.MetaJSON {
+		aw.jsnE = json.NewEncoder(aw.szW)
+	} else {
+		aw.csvW = csv.NewWriter(aw.szW)
+	}
+	return
+}
+
+func (aw *archiveWriter) writeCDR(cdr *CDR) (err error) {
+	if aw.jsnE != nil {
+		err = aw.jsnE.Encode(cdr)
+	} else {
+		err = aw.csvW.Write(cdrAsArchiveRecord(cdr))
+	}
+	if err != nil {
+		return
+	}
+	if aw.arc.Count == 0 || cdr.AnswerTime.Before(aw.arc.AnswerTimeStart) {
+		aw.arc.AnswerTimeStart = cdr.AnswerTime
+	}
+	if cdr.AnswerTime.After(aw.arc.AnswerTimeEnd) {
+		aw.arc.AnswerTimeEnd = cdr.AnswerTime
+	}
+	aw.arc.Count++
+	return
+}
+
+func (aw *archiveWriter) writeSMCost(smCost *SMCost) (err error) {
+	if aw.jsnE != nil {
+		err = aw.jsnE.Encode(smCost)
+	} else {
+		err = aw.csvW.Write(smCostAsArchiveRecord(smCost))
+	}
+	if err != nil {
+		return
+	}
+	aw.arc.Count++
+	return
+}
+
+func (aw *archiveWriter) close() (err error) {
+	if aw.csvW != nil {
+		aw.csvW.Flush()
+		if err = aw.csvW.Error(); err != nil {
+			aw.fd.Close()
+			return
+		}
+	}
+	if err = aw.gzW.Close(); err != nil {
+		aw.fd.Close()
+		return
+	}
+	if err = aw.fd.Sync(); err != nil {
+		aw.fd.Close()
+		return
+	}
+	return aw.fd.Close()
+}
+
+// cdrAsArchiveRecord converts the CDR into a CSV record, complex fields are JSON encoded
+func cdrAsArchiveRecord(cdr *CDR) []string {
+	return []string{
+		cdr.CGRID,
+		cdr.RunID,
+		strconv.FormatInt(cdr.OrderID, 10),
+		cdr.OriginHost,
+		cdr.Source,
+		cdr.OriginID,
+		cdr.ToR,
+		cdr.RequestType,
+		cdr.Tenant,
+		cdr.Category,
+		cdr.Account,
+		cdr.Subject,
+		cdr.Destination,
+		cdr.SetupTime.Format(time.RFC3339Nano),
+		cdr.AnswerTime.Format(time.RFC3339Nano),
+		strconv.FormatInt(cdr.Usage.Nanoseconds(), 10),
+		utils.ToJSON(cdr.ExtraFields),
+		cdr.ExtraInfo,
+		strconv.FormatBool(cdr.Partial),
+		strconv.FormatBool(cdr.PreRated),
+		cdr.CostSource,
+		strconv.FormatFloat(cdr.Cost, 'f', -1, 64),
+		utils.ToJSON(cdr.CostDetails),
+	}
+}
+
+// cdrFromArchiveRecord is the reverse of cdrAsArchiveRecord
+func cdrFromArchiveRecord(rec []string) (cdr *CDR, err error) {
+	if len(rec) != 23 {
+		return nil, fmt.Errorf("invalid CDR archive record: %q", rec)
+	}
+	cdr = &CDR{
+		CGRID:       rec[0],
+		RunID:       rec[1],
+		OriginHost:  rec[3],
+		Source:      rec[4],
+		OriginID:    rec[5],
+		ToR:         rec[6],
+		RequestType: rec[7],
+		Tenant:      rec[8],
+		Category:    rec[9],
+		Account:     rec[10],
+		Subject:     rec[11],
+		Destination: rec[12],
+		ExtraInfo:   rec[17],
+		CostSource:  rec[20],
+	}
+	if cdr.OrderID, err = strconv.ParseInt(rec[2], 10, 64); err != nil {
+		return
+	}
+	if cdr.SetupTime, err = time.Parse(time.RFC3339Nano, rec[13]); err != nil {
+		return
+	}
+	if cdr.AnswerTime, err = time.Parse(time.RFC3339Nano, rec[14]); err != nil {
+		return
+	}
+	var usage int64
+	if usage, err = strconv.ParseInt(rec[15], 10, 64); err != nil {
+		return
+	}
+	cdr.Usage = time.Duration(usage)
+	if err = json.Unmarshal([]byte(rec[16]), &cdr.ExtraFields); err != nil {
+		return
+	}
+	if cdr.Partial, err = strconv.ParseBool(rec[18]); err != nil {
+		return
+	}
+	if cdr.PreRated, err = strconv.ParseBool(rec[19]); err != nil {
+		return
+	}
+	if cdr.Cost, err = strconv.ParseFloat(rec[21], 64); err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(rec[22]), &cdr.CostDetails)
+	return
+}
+
+// smCostAsArchiveRecord converts the SMCost into a CSV record
+func smCostAsArchiveRecord(smCost *SMCost) []string {
+	return []string{
+		smCost.CGRID,
+		smCost.RunID,
+		smCost.OriginHost,
+		smCost.OriginID,
+		smCost.CostSource,
+		strconv.FormatInt(smCost.Usage.Nanoseconds(), 10),
+		utils.ToJSON(smCost.CostDetails),
+	}
+}
+
+// readArchiveCDRs reads back the CDRs out of an archive file, passing them to hndlr in batches of archivePageSize
+// reading stops early if hndlr returns stop
+func readArchiveCDRs(fPath, format string, hndlr func(cdrs []*CDR) (stop bool, err error)) (err error) {
+	var fd *os.File
+	if fd, err = os.Open(fPath); err != nil {
+		return
+	}
+	defer fd.Close()
+	var gzR *gzip.Reader
+	if gzR, err = gzip.NewReader(fd); err != nil {
+		return
+	}
+	defer gzR.Close()
+	var jsnD *json.Decoder
+	var csvR *csv.Reader
+	if format == utils.MetaJSON {
+		jsnD = json.NewDecoder(gzR)
+	} else {
+		csvR = csv.NewReader(gzR)
+	}
+	cdrs := make([]*CDR, 0, archivePageSize)
+	for {
+		var cdr *CDR
+		if jsnD != nil {
+			err = jsnD.Decode(&cdr)
+		} else {
+			var rec []string
+			if rec, err = csvR.Read(); err == nil {
+				cdr, err = cdrFromArchiveRecord(rec)
+			}
+		}
+		if err != nil && err != io.EOF {
+			return
+		}
+		eof := err == io.EOF
+		err = nil
+		if !eof {
+			cdrs = append(cdrs, cdr)
+		}
+		if len(cdrs) == archivePageSize || (eof && len(cdrs) != 0) {
+			var stop bool
+			if stop, err = hndlr(cdrs); err != nil || stop {
+				return
+			}
+			cdrs = make([]*CDR, 0, archivePageSize)
+		}
+		if eof {
+			return
+		}
+	}
+}
+
+// getArchivesIndex reads the index out of the archive_path
+func getArchivesIndex(arcPath string) (arcs []*CDRsArchive, err error) {
+	var fd *os.File
+	if fd, err = os.Open(path.Join(arcPath, archiveIndexFile)); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer fd.Close()
+	err = json.NewDecoder(fd).Decode(&arcs)
+	return
+}
+
+// setArchivesIndex replaces the index inside the archive_path
+func setArchivesIndex(arcPath string, arcs []*CDRsArchive) (err error) {
+	tmpPath := path.Join(arcPath, archiveIndexFile+".tmp")
+	var fd *os.File
+	if fd, err = os.Create(tmpPath); err != nil {
+		return
+	}
+	if err = json.NewEncoder(fd).Encode(arcs); err == nil {
+		err = fd.Sync()
+	}
+	if errClose := fd.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	return os.Rename(tmpPath, path.Join(arcPath, archiveIndexFile))
+}
+
+// archiveCDRs moves the CDRs matching the archive rules out of StorDB.
+// The archives of the run are indexed at once, the CDRs being removed out of StorDB only afterwards.
+func (cdrS *CDRServer) archiveCDRs() (arcs []*CDRsArchive, err error) {
+	cdrS.archiveMux.Lock()
+	defer cdrS.archiveMux.Unlock()
+	arcCfg := cdrS.cgrCfg.CdrsCfg().Archive
+	if arcCfg == nil || len(arcCfg.Rules) == 0 {
+		return
+	}
+	var idx []*CDRsArchive
+	if idx, err = getArchivesIndex(arcCfg.ArchivePath); err != nil {
+		return
+	}
+	archived := make(map[string]utils.StringSet) // CGRIDs grouped by RunID, shared by the rules so a CDR is archived once
+	for _, rule := range arcCfg.Rules {
+		var ruleArcs []*CDRsArchive
+		ruleArcs, err = cdrS.archiveRule(arcCfg, rule, archived)
+		arcs = append(arcs, ruleArcs...)
+		if err != nil {
+			break
+		}
+	}
+	if len(arcs) == 0 {
+		return
+	}
+	if errIdx := setArchivesIndex(arcCfg.ArchivePath, append(idx, arcs...)); errIdx != nil {
+		removeArchiveFiles(arcCfg.ArchivePath, arcs)
+		return nil, errIdx
+	}
+	if errRm := cdrS.removeArchivedCDRs(archived); errRm != nil && err == nil {
+		err = errRm
+	}
+	return
+}
+
+// removeArchivedCDRs removes the archived CDRs, together with their SMCosts, out of StorDB
+func (cdrS *CDRServer) removeArchivedCDRs(archived map[string]utils.StringSet) (err error) {
+	for runID, cgrIDsSet := range archived {
+		cgrIDs := cgrIDsSet.AsSlice()
+		for i := 0; i < len(cgrIDs); i += archivePageSize {
+			end := i + archivePageSize
+			if end > len(cgrIDs) {
+				end = len(cgrIDs)
+			}
+			if _, _, err = cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{CGRIDs: cgrIDs[i:end],
+				RunIDs: []string{runID}}, true); err != nil && err != utils.ErrNotFound {
+				return
+			}
+			if err = cdrS.cdrDb.RemoveSMCosts(&utils.SMCostFilter{CGRIDs: cgrIDs[i:end],
+				RunIDs: []string{runID}}); err != nil && err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+		}
+	}
+	return
+}
+
+// archiveRule archives the CDRs, together with their SMCosts, matching one rule.
+// The files are kept open over the pages and rolled once reaching the max_file_size,
+// the CDRs archived are added to archived in order to be removed out of StorDB after indexing.
+func (cdrS *CDRServer) archiveRule(arcCfg *config.CdrsArchiveCfg, rule *config.CdrsArchiveRule,
+	archived map[string]utils.StringSet) (arcs []*CDRsArchive, err error) {
+	fltr := &utils.CDRsFilter{
+		AnswerTimeEnd: utils.TimePointer(time.Now().Add(-rule.MaxAge)),
+		OrderBy:       utils.OrderID,
+	}
+	if rule.Tenant != utils.EmptyString {
+		fltr.Tenants = []string{rule.Tenant}
+	}
+	ruleArchived := make(map[string][]string)
+	var cdrsW, smCostsW *archiveWriter
+	closeWriter := func(aw *archiveWriter) (err error) {
+		err = aw.close()
+		arcs = append(arcs, aw.arc)
+		return
+	}
+	defer func() {
+		for _, aw := range []*archiveWriter{cdrsW, smCostsW} {
+			if aw == nil {
+				continue
+			}
+			if errClose := closeWriter(aw); errClose != nil && err == nil {
+				err = errClose
+			}
+		}
+		if err != nil { // nothing is removed out of StorDB, drop the files of the rule
+			removeArchiveFiles(arcCfg.ArchivePath, arcs)
+			arcs = nil
+			return
+		}
+		for runID, cgrIDs := range ruleArchived {
+			if _, has := archived[runID]; !has {
+				archived[runID] = make(utils.StringSet)
+			}
+			archived[runID].AddSlice(cgrIDs)
+		}
+	}()
+	for offset := 0; ; offset += archivePageSize { // the CDRs stay in StorDB until the end of the run
+		fltr.Paginator = utils.Paginator{Limit: utils.IntPointer(archivePageSize)}
+		if offset != 0 {
+			fltr.Paginator.Offset = utils.IntPointer(offset)
+		}
+		var page []*CDR
+		if page, _, err = cdrS.cdrDb.GetCDRs(fltr, false); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+			}
+			return
+		}
+		for _, cdr := range page {
+			if archived[cdr.RunID].Has(cdr.CGRID) { // archived by a previous rule
+				continue
+			}
+			if len(rule.FilterIDs) != 0 {
+				var pass bool
+				if pass, err = cdrS.filterS.Pass(cdr.Tenant, rule.FilterIDs,
+					utils.MapStorage{utils.MetaReq: cdr.AsMapStringIface()}); err != nil {
+					return
+				}
+				if !pass {
+					continue
+				}
+			}
+			if cdrsW == nil {
+				if cdrsW, err = newArchiveWriter(arcCfg.ArchivePath, utils.MetaCDRs,
+					arcCfg.Format, rule.Tenant); err != nil {
+					return
+				}
+			}
+			if err = cdrsW.writeCDR(cdr); err != nil {
+				return
+			}
+			var smCosts []*SMCost
+			if smCosts, err = cdrS.cdrDb.GetSMCosts(cdr.CGRID, cdr.RunID,
+				utils.EmptyString, utils.EmptyString); err != nil && err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+			for _, smCost := range smCosts {
+				if smCostsW == nil {
+					if smCostsW, err = newArchiveWriter(arcCfg.ArchivePath, utils.MetaSMCosts,
+						arcCfg.Format, rule.Tenant); err != nil {
+						return
+					}
+				}
+				if err = smCostsW.writeSMCost(smCost); err != nil {
+					return
+				}
+			}
+			ruleArchived[cdr.RunID] = append(ruleArchived[cdr.RunID], cdr.CGRID)
+			if arcCfg.MaxFileSize <= 0 {
+				continue
+			}
+			if cdrsW.csvW != nil { // account the buffered records
+				cdrsW.csvW.Flush()
+			}
+			if smCostsW != nil && smCostsW.csvW != nil {
+				smCostsW.csvW.Flush()
+			}
+			if cdrsW.szW.size >= arcCfg.MaxFileSize {
+				aw := cdrsW
+				cdrsW = nil
+				if err = closeWriter(aw); err != nil {
+					return
+				}
+			}
+			if smCostsW != nil && smCostsW.szW.size >= arcCfg.MaxFileSize {
+				aw := smCostsW
+				smCostsW = nil
+				if err = closeWriter(aw); err != nil {
+					return
+				}
+			}
+		}
+		if len(page) < archivePageSize {
+			return
+		}
+	}
+}
+
+// removeArchiveFiles removes the files of archives which could not be completed
+func removeArchiveFiles(arcPath string, arcs []*CDRsArchive) {
+	for _, arc := range arcs {
+		if err := os.Remove(path.Join(arcPath, arc.File)); err != nil && !os.IsNotExist(err) {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed removing archive <%s>, error: %s",
+				utils.CDRs, arc.File, err.Error()))
+		}
+	}
+}
+
+// cloneCDRsFilter copies the filter since querying InternalDB consumes the indexed fields out of it
+func cloneCDRsFilter(fltr *utils.CDRsFilter) (cln *utils.CDRsFilter) {
+	f := *fltr
+	cln = &f
+	if fltr.ExtraFields != nil {
+		cln.ExtraFields = make(map[string]string, len(fltr.ExtraFields))
+		for k, v := range fltr.ExtraFields {
+			cln.ExtraFields[k] = v
+		}
+	}
+	if fltr.NotExtraFields != nil {
+		cln.NotExtraFields = make(map[string]string, len(fltr.NotExtraFields))
+		for k, v := range fltr.NotExtraFields {
+			cln.NotExtraFields[k] = v
+		}
+	}
+	return
+}
+
+// getCDRsWithArchives queries the CDRs inside StorDB together with the archived ones.
+// The archives are read in batches, keeping only the matching CDRs, and reading stops
+// once enough CDRs were found if the results are not ordered. The ordered queries need a limit,
+// only the first limit+offset CDRs being kept in memory while reading.
+func (cdrS *CDRServer) getCDRsWithArchives(fltr *utils.CDRsFilter) (cdrs []*CDR, err error) {
+	var offset, maxMatches int // maxMatches is 0 for no limit
+	if fltr.Paginator.Limit != nil && *fltr.Paginator.Limit > 0 {
+		if fltr.Paginator.Offset != nil && *fltr.Paginator.Offset > 0 {
+			offset = *fltr.Paginator.Offset
+		}
+		maxMatches = *fltr.Paginator.Limit + offset
+	}
+	if fltr.OrderBy != utils.EmptyString && maxMatches == 0 {
+		return nil, fmt.Errorf("ordering the archived CDRs requires a limit")
+	}
+	matchFltr := cloneCDRsFilter(fltr) // the ordering and pagination are applied on the merged results
+	matchFltr.Paginator = utils.Paginator{}
+	matchFltr.OrderBy = utils.EmptyString
+	matchFltr.Count = false
+	storFltr := cloneCDRsFilter(matchFltr)
+	if maxMatches != 0 {
+		storFltr.OrderBy = fltr.OrderBy
+		storFltr.Paginator.Limit = utils.IntPointer(maxMatches)
+	}
+	if cdrs, _, err = cdrS.cdrDb.GetCDRs(storFltr, false); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	err = nil
+	if arcCfg := cdrS.cgrCfg.CdrsCfg().Archive; arcCfg != nil &&
+		(maxMatches == 0 || fltr.OrderBy != utils.EmptyString || len(cdrs) < maxMatches) {
+		cdrS.archiveMux.RLock()
+		defer cdrS.archiveMux.RUnlock()
+		var arcs []*CDRsArchive
+		if arcs, err = getArchivesIndex(arcCfg.ArchivePath); err != nil {
+			return
+		}
+		addMatching := func(batch []*CDR) (stop bool, err error) {
+			var batchCDRs []*CDR
+			if batchCDRs, err = cdrS.filterCDRs(batch, cloneCDRsFilter(matchFltr)); err != nil {
+				return
+			}
+			cdrs = append(cdrs, batchCDRs...)
+			if fltr.OrderBy == utils.EmptyString {
+				return maxMatches != 0 && len(cdrs) >= maxMatches, nil
+			}
+			if len(cdrs) >= 2*maxMatches { // keep only the first CDRs as ordered
+				if cdrs, err = cdrS.filterCDRs(cdrs, &utils.CDRsFilter{OrderBy: fltr.OrderBy}); err != nil {
+					return
+				}
+				cdrs = cdrs[:maxMatches]
+			}
+			return
+		}
+		for _, arc := range arcs {
+			if !arc.matchesFilter(fltr) {
+				continue
+			}
+			if err = readArchiveCDRs(path.Join(arcCfg.ArchivePath, arc.File), arc.Format, addMatching); err != nil {
+				return
+			}
+			if fltr.OrderBy == utils.EmptyString && maxMatches != 0 && len(cdrs) >= maxMatches {
+				break
+			}
+		}
+	}
+	if fltr.OrderBy != utils.EmptyString {
+		if cdrs, err = cdrS.filterCDRs(cdrs, &utils.CDRsFilter{OrderBy: fltr.OrderBy}); err != nil {
+			return
+		}
+	}
+	if offset >= len(cdrs) {
+		return nil, utils.ErrNotFound
+	}
+	cdrs = cdrs[offset:]
+	if maxMatches != 0 && len(cdrs) > maxMatches-offset {
+		cdrs = cdrs[:maxMatches-offset]
+	}
+	return
+}
+
+// filterCDRs returns the CDRs matching the filter, ordered as requested by it, the pagination is left to the caller
+func (cdrS *CDRServer) filterCDRs(cdrs []*CDR, fltr *utils.CDRsFilter) (fltrd []*CDR, err error) {
+	if len(cdrs) == 0 {
+		return
+	}
+	fltrDB := NewInternalDB(nil, nil, false, cdrS.cgrCfg.StorDbCfg().Items)
+	for _, cdr := range cdrs {
+		if err = fltrDB.SetCDR(cdr, true); err != nil {
+			return
+		}
+	}
+	if fltrd, _, err = fltrDB.GetCDRs(fltr, false); err == utils.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+// V1ArchiveCDRs runs the archive rules on demand
+func (cdrS *CDRServer) V1ArchiveCDRs(ign *utils.TenantWithArgDispatcher, reply *[]*CDRsArchive) (err error) {
+	var arcs []*CDRsArchive
+	if arcs, err = cdrS.archiveCDRs(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = arcs
+	return
+}
@@ -0,0 +1,251 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCDRsArchiveCDRs(t *testing.T) {
+	for _, format := range []string{utils.MetaCSV, utils.MetaJSON} {
+		testCDRsArchiveCDRs(t, format)
+	}
+}
+
+func testCDRsArchiveCDRs(t *testing.T, format string) {
+	arcPath, err := ioutil.TempDir(utils.EmptyString, "cdrs_archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(arcPath)
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().Archive = &config.CdrsArchiveCfg{
+		ArchivePath: arcPath,
+		Format:      format,
+		Rules: []*config.CdrsArchiveRule{{
+			Tenant:    "cgrates.org",
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			MaxAge:    24 * time.Hour,
+		}},
+	}
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		cdrDb:   storDB,
+		dm:      dm,
+		filterS: &FilterS{dm: dm, cfg: cfg},
+	}
+	oldTime := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	for i, cdr := range []*CDR{
+		{CGRID: "cgrid1", Account: "1001", AnswerTime: oldTime},
+		{CGRID: "cgrid2", Account: "1002", AnswerTime: oldTime},
+		{CGRID: "cgrid3", Account: "1001", AnswerTime: time.Now()},
+	} {
+		cdr.RunID = utils.MetaDefault
+		cdr.OrderID = int64(i + 1)
+		cdr.OriginID = cdr.CGRID
+		cdr.Tenant = "cgrates.org"
+		cdr.SetupTime = cdr.AnswerTime
+		cdr.Usage = time.Minute
+		cdr.Cost = 1.5
+		cdr.ExtraFields = map[string]string{"Field1": "Val1"}
+		if err := storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storDB.SetSMCost(&SMCost{CGRID: "cgrid1", RunID: utils.MetaDefault,
+		OriginID: "cgrid1", Usage: time.Minute, CostDetails: NewBareEventCost()}); err != nil {
+		t.Fatal(err)
+	}
+
+	var arcs []*CDRsArchive
+	if err := cdrS.V1ArchiveCDRs(nil, &arcs); err != nil {
+		t.Fatal(err)
+	} else if len(arcs) != 2 {
+		t.Fatalf("expecting 2 archives, received: %s", utils.ToJSON(arcs))
+	}
+	for _, arc := range arcs {
+		if arc.Count != 1 || arc.Tenant != "cgrates.org" || arc.Format != format {
+			t.Errorf("unexpected archive: %s", utils.ToJSON(arc))
+		}
+		if _, err := os.Stat(path.Join(arcPath, arc.File)); err != nil {
+			t.Error(err)
+		}
+	}
+	if idx, err := getArchivesIndex(arcPath); err != nil {
+		t.Fatal(err)
+	} else if len(idx) != 2 {
+		t.Errorf("expecting the archives to be indexed, received: %s", utils.ToJSON(idx))
+	}
+	if _, err := storDB.GetSMCosts("cgrid1", utils.MetaDefault, utils.EmptyString, utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("expecting the SMCost to be archived, received: %v", err)
+	}
+
+	var cdrs []*CDR
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{}}, &cdrs); err != nil {
+		t.Fatal(err)
+	} else if cgrIDs := testCDRsCGRIDs(cdrs); !reflect.DeepEqual([]string{"cgrid2", "cgrid3"}, cgrIDs) {
+		t.Errorf("unexpected CDRs in StorDB: %v", cgrIDs)
+	}
+	cdrs = nil
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Archived: true}}, &cdrs); err != nil {
+		t.Fatal(err)
+	} else if cgrIDs := testCDRsCGRIDs(cdrs); !reflect.DeepEqual([]string{"cgrid1", "cgrid2", "cgrid3"}, cgrIDs) {
+		t.Errorf("unexpected CDRs together with archives: %v", cgrIDs)
+	}
+	cdrs = nil
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Archived: true, Accounts: []string{"1001"},
+			AnswerTimeEnd: "2020-07-22T00:00:00Z"}}, &cdrs); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 {
+		t.Fatalf("expecting one archived CDR, received: %s", utils.ToJSON(cdrs))
+	} else if cdrs[0].CGRID != "cgrid1" || cdrs[0].Usage != time.Minute ||
+		!cdrs[0].AnswerTime.Equal(oldTime) || cdrs[0].Cost != 1.5 ||
+		cdrs[0].ExtraFields["Field1"] != "Val1" {
+		t.Errorf("unexpected archived CDR: %s", utils.ToJSON(cdrs[0]))
+	}
+
+	cdrs = nil
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Archived: true, Accounts: []string{"1001"},
+			Paginator: utils.Paginator{Limit: utils.IntPointer(1)}}}, &cdrs); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 || cdrs[0].Account != "1001" {
+		t.Errorf("expecting one CDR of 1001, received: %s", utils.ToJSON(cdrs))
+	}
+
+	// nothing left to archive
+	arcs = nil
+	if err := cdrS.V1ArchiveCDRs(nil, &arcs); err != nil {
+		t.Fatal(err)
+	} else if len(arcs) != 0 {
+		t.Errorf("not expecting new archives, received: %s", utils.ToJSON(arcs))
+	}
+}
+
+func TestCDRsArchiveRollFiles(t *testing.T) {
+	arcPath, err := ioutil.TempDir(utils.EmptyString, "cdrs_archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(arcPath)
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().Archive = &config.CdrsArchiveCfg{
+		ArchivePath: arcPath,
+		Format:      utils.MetaCSV,
+		MaxFileSize: 1, // one CDR per file
+		Rules: []*config.CdrsArchiveRule{
+			{MaxAge: 24 * time.Hour},
+			{Tenant: "cgrates.org", MaxAge: 24 * time.Hour},
+		},
+	}
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	cdrS := &CDRServer{
+		cgrCfg: cfg,
+		cdrDb:  storDB,
+	}
+	oldTime := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	for i, cgrID := range []string{"cgrid1", "cgrid2", "cgrid3"} {
+		if err := storDB.SetCDR(&CDR{CGRID: cgrID, RunID: utils.MetaDefault, OrderID: int64(i + 1),
+			OriginID: cgrID, Tenant: "cgrates.org", Account: "1001",
+			SetupTime: oldTime, AnswerTime: oldTime}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var arcs []*CDRsArchive
+	if err := cdrS.V1ArchiveCDRs(nil, &arcs); err != nil {
+		t.Fatal(err)
+	} else if len(arcs) != 3 {
+		t.Fatalf("expecting 3 archives, received: %s", utils.ToJSON(arcs))
+	}
+	if idx, err := getArchivesIndex(arcPath); err != nil {
+		t.Fatal(err)
+	} else if len(idx) != 3 {
+		t.Errorf("expecting the archives to be indexed once, received: %s", utils.ToJSON(idx))
+	}
+	if _, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != utils.ErrNotFound {
+		t.Errorf("expecting the CDRs to be archived, received: %v", err)
+	}
+
+	var cdrs []*CDR
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Archived: true, OrderBy: utils.OrderID}}, &cdrs); err == nil {
+		t.Error("expecting the unbounded ordered query to be refused")
+	}
+	if err := cdrS.V1GetCDRs(utils.RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Archived: true, OrderBy: utils.OrderID,
+			Paginator: utils.Paginator{Limit: utils.IntPointer(1), Offset: utils.IntPointer(1)}}}, &cdrs); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 || cdrs[0].CGRID != "cgrid2" {
+		t.Errorf("expecting cgrid2, received: %s", utils.ToJSON(cdrs))
+	}
+}
+
+func testCDRsCGRIDs(cdrs []*CDR) (cgrIDs []string) {
+	for _, cdr := range cdrs {
+		cgrIDs = append(cgrIDs, cdr.CGRID)
+	}
+	sort.Strings(cgrIDs)
+	return
+}
+
+func TestCDRsArchiveRecord(t *testing.T) {
+	cdr := &CDR{
+		CGRID:       "cgrid1",
+		RunID:       utils.MetaDefault,
+		OrderID:     10,
+		OriginHost:  "127.0.0.1",
+		Source:      "test",
+		OriginID:    "origin1",
+		ToR:         utils.VOICE,
+		RequestType: utils.META_PREPAID,
+		Tenant:      "cgrates.org",
+		Category:    "call",
+		Account:     "1001",
+		Subject:     "1001",
+		Destination: "1002",
+		SetupTime:   time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC),
+		AnswerTime:  time.Date(2020, 7, 21, 10, 0, 5, 0, time.UTC),
+		Usage:       time.Minute,
+		ExtraFields: map[string]string{"Field1": "Val1"},
+		Cost:        1.25,
+	}
+	if rcv, err := cdrFromArchiveRecord(cdrAsArchiveRecord(cdr)); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(cdr, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(cdr), utils.ToJSON(rcv))
+	}
+	if _, err := cdrFromArchiveRecord([]string{"cgrid1"}); err == nil {
+		t.Error("expecting error for invalid record")
+	}
+}
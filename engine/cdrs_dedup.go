@@ -0,0 +1,300 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// cdrDedupEntry is cached for every processed event in order to detect the duplicates
+type cdrDedupEntry struct {
+	CGRID string
+	RunID string
+	Newer string // value of the dedup_newer_field
+	Time  time.Time
+}
+
+// CDRsDedupStats counts the duplicate CDRs detected by the CDRServer
+type CDRsDedupStats struct {
+	Duplicates int64 // all the duplicates detected
+	Dropped    int64
+	Updated    int64
+	Merged     int64
+}
+
+// countDuplicate increases the duplicate counters for the applied action
+func (cdrS *CDRServer) countDuplicate(action string) {
+	cdrS.dedupMux.Lock()
+	cdrS.dedupStats.Duplicates++
+	switch action {
+	case utils.MetaDrop:
+		cdrS.dedupStats.Dropped++
+	case utils.MetaUpdateIfNewer:
+		cdrS.dedupStats.Updated++
+	case utils.MetaMerge:
+		cdrS.dedupStats.Merged++
+	}
+	cdrS.dedupMux.Unlock()
+}
+
+// dedupKey builds the key identifying the event out of the dedup_fields
+func (cdrS *CDRServer) dedupKey(me MapEvent) string {
+	flds := cdrS.cgrCfg.CdrsCfg().DedupFields
+	if len(flds) == 0 {
+		flds = []string{utils.CGRID, utils.RunID}
+	}
+	vals := make([]string, len(flds))
+	for i, fld := range flds {
+		vals[i] = me.GetStringIgnoreErrors(fld)
+	}
+	return utils.ConcatenatedKey(vals...)
+}
+
+// dedupEvents checks the events against the ones already processed and applies the dedup_policy on duplicates
+// returns the events which should be processed further
+func (cdrS *CDRServer) dedupEvents(cgrEvs []*utils.CGREventWithArgDispatcher,
+	reRate bool) (evs []*utils.CGREventWithArgDispatcher, err error) {
+	cdrsCfg := cdrS.cgrCfg.CdrsCfg()
+	evs = make([]*utils.CGREventWithArgDispatcher, 0, len(cgrEvs))
+	for _, cgrEv := range cgrEvs {
+		me := MapEvent(cgrEv.CGREvent.Event)
+		if !me.HasField(utils.CGRID) { // try to compute the CGRID if missing
+			me[utils.CGRID] = utils.Sha1(
+				me.GetStringIgnoreErrors(utils.OriginID),
+				me.GetStringIgnoreErrors(utils.OriginHost),
+			)
+		}
+		uID := cdrS.dedupKey(me)
+		dEntry := &cdrDedupEntry{
+			CGRID: me.GetStringIgnoreErrors(utils.CGRID),
+			RunID: me.GetStringIgnoreErrors(utils.RunID),
+			Time:  time.Now(),
+		}
+		if cdrsCfg.DedupNewerField != utils.EmptyString {
+			dEntry.Newer = me.GetStringIgnoreErrors(cdrsCfg.DedupNewerField)
+		}
+		var process bool
+		// lock on uID so two simultaneous duplicates do not both pass the check
+		if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+			process, gErr = cdrS.dedupEvent(uID, me, dEntry, reRate)
+			return
+		}, cdrS.cgrCfg.GeneralCfg().LockingTimeout, utils.CacheCDRIDs+uID); err != nil {
+			if err == utils.ErrExists {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
+						utils.CDRs, utils.ErrExists, utils.ToJSON(cgrEv), utils.CacheS))
+			}
+			return nil, err
+		}
+		if process {
+			evs = append(evs, cgrEv)
+		}
+	}
+	return
+}
+
+// dedupEvent applies the dedup_policy on one event and records it as processed
+// returns false if the event is dropped as duplicate
+func (cdrS *CDRServer) dedupEvent(uID string, me MapEvent, dEntry *cdrDedupEntry, reRate bool) (process bool, err error) {
+	if !reRate {
+		if prevEntry, isDup := cdrS.getDedupEntry(uID); isDup {
+			switch cdrS.cgrCfg.CdrsCfg().DedupPolicy {
+			case utils.MetaDrop:
+				cdrS.countDuplicate(utils.MetaDrop)
+				return
+			case utils.MetaUpdateIfNewer:
+				if !isNewerValue(dEntry.Newer, prevEntry.Newer,
+					cdrS.cgrCfg.GeneralCfg().DefaultTimezone) {
+					cdrS.countDuplicate(utils.MetaDrop)
+					return
+				}
+				// point the event to the stored CDR so it gets updated
+				me[utils.CGRID] = prevEntry.CGRID
+				me[utils.RunID] = prevEntry.RunID
+				dEntry.CGRID, dEntry.RunID, dEntry.Time = prevEntry.CGRID, prevEntry.RunID, prevEntry.Time
+			case utils.MetaMerge:
+				me[utils.CGRID] = prevEntry.CGRID
+				me[utils.RunID] = prevEntry.RunID
+				dEntry = prevEntry
+			default:
+				cdrS.countDuplicate(utils.META_NONE)
+				return false, utils.ErrExists
+			}
+		}
+	}
+	if err = Cache.Set(utils.CacheCDRIDs, uID, dEntry, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional); err != nil {
+		return
+	}
+	return true, nil
+}
+
+// getDedupEntry returns the cached entry if the uID was processed within the dedup_window
+func (cdrS *CDRServer) getDedupEntry(uID string) (dEntry *cdrDedupEntry, has bool) {
+	itm, has := Cache.Get(utils.CacheCDRIDs, uID)
+	if !has {
+		return
+	}
+	if dEntry, has = itm.(*cdrDedupEntry); !has {
+		return
+	}
+	if dWindow := cdrS.cgrCfg.CdrsCfg().DedupWindow; dWindow != 0 &&
+		time.Since(dEntry.Time) > dWindow {
+		return nil, false
+	}
+	return
+}
+
+// dedupStoredCDR applies the dedup_policy on a CDR colliding with an already stored one
+// returns the CDR which was stored or nil if the CDR was dropped
+func (cdrS *CDRServer) dedupStoredCDR(cdr *CDR) (storedCDR *CDR, err error) {
+	var prevCDRs []*CDR // only one should be returned
+	if prevCDRs, _, err = cdrS.cdrDb.GetCDRs(
+		&utils.CDRsFilter{CGRIDs: []string{cdr.CGRID},
+			RunIDs: []string{cdr.RunID}}, false); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	if len(prevCDRs) == 0 { // removed in the meantime, nothing to deduplicate against
+		if err = cdrS.cdrDb.SetCDR(cdr, true); err != nil {
+			return
+		}
+		return cdr, nil
+	}
+	cdrsCfg := cdrS.cgrCfg.CdrsCfg()
+	action := cdrsCfg.DedupPolicy
+	if action == utils.MetaUpdateIfNewer &&
+		!isNewerValue(cdrNewerValue(cdr, cdrsCfg.DedupNewerField),
+			cdrNewerValue(prevCDRs[0], cdrsCfg.DedupNewerField),
+			cdrS.cgrCfg.GeneralCfg().DefaultTimezone) {
+		action = utils.MetaDrop
+	}
+	switch action {
+	case utils.MetaDrop: // refund what we have charged for the duplicate
+		if err = cdrS.refundDuplicate(cdr.CostDetails, cdr.RequestType, cdr.ToR); err != nil {
+			return
+		}
+		cdrS.countDuplicate(utils.MetaDrop)
+		return
+	case utils.MetaUpdateIfNewer: // the new CDR replaces the stored one
+		if err = cdrS.refundDuplicate(prevCDRs[0].CostDetails, prevCDRs[0].RequestType, prevCDRs[0].ToR); err != nil {
+			return
+		}
+		storedCDR = cdr
+	case utils.MetaMerge: // the stored CDR keeps its cost
+		if err = cdrS.refundDuplicate(cdr.CostDetails, cdr.RequestType, cdr.ToR); err != nil {
+			return
+		}
+		storedCDR = mergeCDRs(prevCDRs[0], cdr)
+	default:
+		return nil, utils.ErrExists
+	}
+	if err = cdrS.cdrDb.SetCDR(storedCDR, true); err != nil {
+		return nil, err
+	}
+	cdrS.countDuplicate(action)
+	return
+}
+
+// refundDuplicate refunds the cost of a duplicate CDR, if the CDR was charged
+func (cdrS *CDRServer) refundDuplicate(ec *EventCost, reqType, tor string) (err error) {
+	if ec == nil {
+		return
+	}
+	_, err = cdrS.refundEventCost(ec, reqType, tor)
+	return
+}
+
+// cdrNewerValue returns the value of the dedup_newer_field out of CDR
+func cdrNewerValue(cdr *CDR, fldName string) string {
+	if fldName == utils.EmptyString {
+		return utils.EmptyString
+	}
+	return NewMapEvent(cdr.AsMapStringIface()).GetStringIgnoreErrors(fldName)
+}
+
+// isNewerValue compares the values of the dedup_newer_field as times, numbers or strings
+// the new value is considered newer if the field is not configured
+func isNewerValue(newVal, oldVal, timezone string) bool {
+	if newVal == utils.EmptyString && oldVal == utils.EmptyString {
+		return true
+	}
+	if newTime, err := utils.ParseTimeDetectLayout(newVal, timezone); err == nil {
+		if oldTime, err := utils.ParseTimeDetectLayout(oldVal, timezone); err == nil {
+			return newTime.After(oldTime)
+		}
+	}
+	if newFlt, err := utils.IfaceAsFloat64(newVal); err == nil {
+		if oldFlt, err := utils.IfaceAsFloat64(oldVal); err == nil {
+			return newFlt > oldFlt
+		}
+	}
+	return strings.Compare(newVal, oldVal) > 0
+}
+
+// mergeCDRs completes the stored CDR with the fields populated only by the late one
+func mergeCDRs(prevCDR, lateCDR *CDR) (merged *CDR) {
+	merged = prevCDR.Clone()
+	for _, flds := range [][2]*string{
+		{&merged.OriginHost, &lateCDR.OriginHost},
+		{&merged.Source, &lateCDR.Source},
+		{&merged.OriginID, &lateCDR.OriginID},
+		{&merged.ToR, &lateCDR.ToR},
+		{&merged.RequestType, &lateCDR.RequestType},
+		{&merged.Tenant, &lateCDR.Tenant},
+		{&merged.Category, &lateCDR.Category},
+		{&merged.Account, &lateCDR.Account},
+		{&merged.Subject, &lateCDR.Subject},
+		{&merged.Destination, &lateCDR.Destination},
+	} {
+		if *flds[0] == utils.EmptyString {
+			*flds[0] = *flds[1]
+		}
+	}
+	if merged.SetupTime.IsZero() {
+		merged.SetupTime = lateCDR.SetupTime
+	}
+	if merged.AnswerTime.IsZero() {
+		merged.AnswerTime = lateCDR.AnswerTime
+	}
+	if merged.Usage == 0 {
+		merged.Usage = lateCDR.Usage
+	}
+	if merged.ExtraFields == nil {
+		merged.ExtraFields = make(map[string]string)
+	}
+	for fldName, fldVal := range lateCDR.ExtraFields {
+		if _, has := merged.ExtraFields[fldName]; !has {
+			merged.ExtraFields[fldName] = fldVal
+		}
+	}
+	return
+}
+
+// V1GetDedupStats returns the counters of the duplicate CDRs detected
+func (cdrS *CDRServer) V1GetDedupStats(ign *utils.TenantWithArgDispatcher, reply *CDRsDedupStats) error {
+	cdrS.dedupMux.RLock()
+	*reply = cdrS.dedupStats
+	cdrS.dedupMux.RUnlock()
+	return nil
+}
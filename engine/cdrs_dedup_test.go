@@ -0,0 +1,257 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testDedupCDRServer(policy string, flds []string, newerFld string) (cdrS *CDRServer, storDB *InternalDB) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().DedupPolicy = policy
+	cfg.CdrsCfg().DedupFields = flds
+	cfg.CdrsCfg().DedupNewerField = newerFld
+	storDB = NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	Cache.Clear([]string{utils.CacheCDRIDs})
+	return &CDRServer{cgrCfg: cfg, cdrDb: storDB}, storDB
+}
+
+func testDedupEvent(originHost, answerTime, extra string) *utils.CGREventWithOpts {
+	ev := &utils.CGREventWithOpts{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "testDedup",
+			Event: map[string]interface{}{
+				utils.OriginID:    "dedup1",
+				utils.OriginHost:  originHost,
+				utils.RunID:       utils.MetaDefault,
+				utils.ToR:         utils.VOICE,
+				utils.RequestType: utils.META_NONE,
+				utils.Account:     "1001",
+				utils.Destination: "1002",
+				utils.AnswerTime:  answerTime,
+				utils.Usage:       "10s",
+			},
+		},
+	}
+	if extra != utils.EmptyString {
+		ev.Event["Extra"] = extra
+	}
+	return ev
+}
+
+func TestCDRsDedupNone(t *testing.T) {
+	cdrS, _ := testDedupCDRServer(utils.META_NONE, []string{utils.CGRID, utils.RunID}, utils.EmptyString)
+	ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	ev = testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != utils.ErrExists {
+		t.Errorf("expecting: %v, received: %v", utils.ErrExists, err)
+	}
+	var rply CDRsDedupStats
+	if err := cdrS.V1GetDedupStats(nil, &rply); err != nil {
+		t.Fatal(err)
+	} else if exp := (CDRsDedupStats{Duplicates: 1}); rply != exp {
+		t.Errorf("expecting: %+v, received: %+v", exp, rply)
+	}
+}
+
+func TestCDRsDedupDrop(t *testing.T) {
+	cdrS, storDB := testDedupCDRServer(utils.MetaDrop, []string{utils.OriginID}, utils.EmptyString)
+	ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	// same OriginID coming from a different host
+	ev = testDedupEvent("192.168.1.2", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if evs, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	} else if len(evs) != 0 {
+		t.Errorf("expecting the duplicate to be dropped, received: %s", utils.ToJSON(evs))
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 || cdrs[0].OriginHost != "192.168.1.1" {
+		t.Errorf("unexpected CDRs: %s", utils.ToJSON(cdrs))
+	}
+	var rply CDRsDedupStats
+	if err := cdrS.V1GetDedupStats(nil, &rply); err != nil {
+		t.Fatal(err)
+	} else if exp := (CDRsDedupStats{Duplicates: 1, Dropped: 1}); rply != exp {
+		t.Errorf("expecting: %+v, received: %+v", exp, rply)
+	}
+}
+
+func TestCDRsDedupEventsConcurrent(t *testing.T) {
+	cdrS, _ := testDedupCDRServer(utils.MetaDrop, []string{utils.OriginID}, utils.EmptyString)
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	var processed int
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+			evs, err := cdrS.dedupEvents([]*utils.CGREventWithArgDispatcher{{CGREvent: ev.CGREvent}}, false)
+			if err != nil {
+				t.Error(err)
+			}
+			mux.Lock()
+			processed += len(evs)
+			mux.Unlock()
+		}()
+	}
+	wg.Wait()
+	if processed != 1 {
+		t.Errorf("expecting only one event processed, received: %d", processed)
+	}
+}
+
+func TestCDRsDedupStoredCDRRemoved(t *testing.T) {
+	cdrS, storDB := testDedupCDRServer(utils.MetaUpdateIfNewer, []string{utils.OriginID}, utils.AnswerTime)
+	cdr := &CDR{CGRID: "cgrid1", RunID: utils.MetaDefault, OrderID: 1, OriginID: "dedup1"}
+	if storedCDR, err := cdrS.dedupStoredCDR(cdr); err != nil {
+		t.Fatal(err)
+	} else if storedCDR != cdr {
+		t.Errorf("expecting the CDR to be stored, received: %s", utils.ToJSON(storedCDR))
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 {
+		t.Errorf("unexpected CDRs: %s", utils.ToJSON(cdrs))
+	}
+}
+
+func TestCDRsDedupWindow(t *testing.T) {
+	cdrS, storDB := testDedupCDRServer(utils.MetaDrop, []string{utils.OriginID}, utils.EmptyString)
+	cdrS.cgrCfg.CdrsCfg().DedupWindow = time.Millisecond
+	ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	ev = testDedupEvent("192.168.1.2", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 2 {
+		t.Errorf("expecting both CDRs outside the window, received: %s", utils.ToJSON(cdrs))
+	}
+}
+
+func TestCDRsDedupUpdateIfNewer(t *testing.T) {
+	cdrS, storDB := testDedupCDRServer(utils.MetaUpdateIfNewer, []string{utils.OriginID}, utils.AnswerTime)
+	ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	// older event is dropped
+	ev = testDedupEvent("192.168.1.2", "2020-07-21T09:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	// newer event updates the stored CDR
+	ev = testDedupEvent("192.168.1.3", "2020-07-21T11:00:00Z", utils.EmptyString)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 {
+		t.Errorf("expecting one CDR, received: %s", utils.ToJSON(cdrs))
+	} else if cdrs[0].OriginHost != "192.168.1.3" ||
+		!cdrs[0].AnswerTime.Equal(time.Date(2020, 7, 21, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("expecting the CDR to be updated, received: %s", utils.ToJSON(cdrs[0]))
+	}
+	var rply CDRsDedupStats
+	if err := cdrS.V1GetDedupStats(nil, &rply); err != nil {
+		t.Fatal(err)
+	} else if exp := (CDRsDedupStats{Duplicates: 2, Dropped: 1, Updated: 1}); rply != exp {
+		t.Errorf("expecting: %+v, received: %+v", exp, rply)
+	}
+}
+
+func TestCDRsDedupMerge(t *testing.T) {
+	cdrS, storDB := testDedupCDRServer(utils.MetaMerge, []string{utils.OriginID}, utils.EmptyString)
+	ev := testDedupEvent("192.168.1.1", "2020-07-21T10:00:00Z", utils.EmptyString)
+	delete(ev.Event, utils.Destination)
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	ev = testDedupEvent("192.168.1.2", "2020-07-21T11:00:00Z", "extra")
+	if _, err := cdrS.processEvent(ev, false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 {
+		t.Errorf("expecting one CDR, received: %s", utils.ToJSON(cdrs))
+	} else if cdrs[0].OriginHost != "192.168.1.1" ||
+		cdrs[0].Destination != "1002" ||
+		cdrs[0].ExtraFields["Extra"] != "extra" ||
+		!cdrs[0].AnswerTime.Equal(time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expecting the CDRs to be merged, received: %s", utils.ToJSON(cdrs[0]))
+	}
+	var rply CDRsDedupStats
+	if err := cdrS.V1GetDedupStats(nil, &rply); err != nil {
+		t.Fatal(err)
+	} else if exp := (CDRsDedupStats{Duplicates: 1, Merged: 1}); rply != exp {
+		t.Errorf("expecting: %+v, received: %+v", exp, rply)
+	}
+}
+
+func TestCDRsIsNewerValue(t *testing.T) {
+	for _, tc := range []struct {
+		newVal, oldVal string
+		exp            bool
+	}{
+		{"", "", true},
+		{"2020-07-21T11:00:00Z", "2020-07-21T10:00:00Z", true},
+		{"2020-07-21T09:00:00Z", "2020-07-21T10:00:00Z", false},
+		{"10.5", "9", true},
+		{"b", "a", true},
+		{"a", "a", false},
+	} {
+		if rcv := isNewerValue(tc.newVal, tc.oldVal, utils.EmptyString); rcv != tc.exp {
+			t.Errorf("for %q>%q expecting: %v, received: %v", tc.newVal, tc.oldVal, tc.exp, rcv)
+		}
+	}
+}
+
+func TestCDRsMergeCDRs(t *testing.T) {
+	prevCDR := &CDR{CGRID: "cgrid1", OriginID: "dedup1", Account: "1001",
+		Cost: 10, ExtraFields: map[string]string{"Field1": "val1"}}
+	lateCDR := &CDR{CGRID: "cgrid2", OriginID: "dedup1", Account: "1002", Destination: "1003",
+		Usage: time.Minute, Cost: 20, ExtraFields: map[string]string{"Field1": "val2", "Field2": "val2"}}
+	exp := &CDR{CGRID: "cgrid1", OriginID: "dedup1", Account: "1001", Destination: "1003",
+		Usage: time.Minute, Cost: 10, ExtraFields: map[string]string{"Field1": "val1", "Field2": "val2"}}
+	if rcv := mergeCDRs(prevCDR, lateCDR); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// cdrsGroupByFields are the CDR fields the report can be grouped on
+var cdrsGroupByFields = utils.NewStringSet([]string{utils.Tenant, utils.Account,
+	utils.Subject, utils.Category, utils.RunID, utils.ToR, utils.RequestType,
+	utils.Destination, utils.OriginHost, utils.Source})
+
+// cdrsGroupByLayouts formats the AnswerTime for the periods of the report, always in UTC
+var cdrsGroupByLayouts = map[string]string{
+	utils.MetaMonthly: "2006-01",
+	utils.MetaDaily:   "2006-01-02",
+	utils.MetaHourly:  "2006-01-02T15",
+}
+
+// ArgCDRsReport requests the CDRs matching the filter aggregated by the GroupBy criteria
+type ArgCDRsReport struct {
+	utils.RPCCDRsFilter
+	GroupBy []string // <$Field|*prefix:$Field:$Length|*monthly|*daily|*hourly>
+	*utils.ArgDispatcher
+	*utils.TenantArg
+}
+
+// CDRsGroupBy is one of the criteria grouping the CDRs
+type CDRsGroupBy struct {
+	ID     string // the criteria as requested, keys the values in the report
+	Field  string // CDR field, AnswerTime for periods
+	Prefix int    // group on the first characters of the field, 0 for the whole value
+	Period string // <*monthly|*daily|*hourly>
+}
+
+// NewCDRsGroupBy parses the group criteria
+func NewCDRsGroupBy(grpID string) (grp *CDRsGroupBy, err error) {
+	grp = &CDRsGroupBy{ID: grpID}
+	if _, has := cdrsGroupByLayouts[grpID]; has {
+		grp.Field = utils.AnswerTime
+		grp.Period = grpID
+		return
+	}
+	if strings.HasPrefix(grpID, utils.MetaPrefix+utils.InInFieldSep) {
+		splt := strings.Split(grpID, utils.InInFieldSep)
+		if len(splt) != 3 {
+			return nil, fmt.Errorf("invalid group criteria: <%s>", grpID)
+		}
+		grp.Field = splt[1]
+		if grp.Prefix, err = strconv.Atoi(splt[2]); err != nil || grp.Prefix <= 0 {
+			return nil, fmt.Errorf("invalid prefix length for group criteria: <%s>", grpID)
+		}
+	} else {
+		grp.Field = grpID
+	}
+	if !cdrsGroupByFields.Has(grp.Field) {
+		return nil, fmt.Errorf("unsupported group field: <%s>", grp.Field)
+	}
+	return
+}
+
+// valueFromCDR returns the value of the group criteria out of CDR
+func (grp *CDRsGroupBy) valueFromCDR(cdr *CDR) string {
+	if grp.Period != utils.EmptyString {
+		return cdr.AnswerTime.UTC().Format(cdrsGroupByLayouts[grp.Period])
+	}
+	val := utils.IfaceAsString(cdr.AsMapStringIface()[grp.Field])
+	if grp.Prefix != 0 && len(val) > grp.Prefix {
+		val = val[:grp.Prefix]
+	}
+	return val
+}
+
+// CDRsReportGroup aggregates the CDRs with the same values of the group criteria
+type CDRsReportGroup struct {
+	GroupBy      map[string]string // value for each of the group criteria
+	Count        int64
+	Usage        time.Duration
+	Cost         float64 // sum of the rated CDRs only
+	AvgUsage     time.Duration
+	AvgCost      float64       // per rated CDR
+	Unrated      int64         // CDRs with negative cost (not rated), left out of Cost
+	UnratedUsage time.Duration // included in Usage
+}
+
+// addCDR adds the CDR to the totals of the group
+func (grp *CDRsReportGroup) addCDR(cdr *CDR) {
+	grp.Count++
+	grp.Usage += cdr.Usage
+	if cdr.Cost < 0 {
+		grp.Unrated++
+		grp.UnratedUsage += cdr.Usage
+		return
+	}
+	grp.Cost += cdr.Cost
+}
+
+// merge adds the totals of another group with the same values
+func (grp *CDRsReportGroup) merge(oGrp *CDRsReportGroup) {
+	grp.Count += oGrp.Count
+	grp.Usage += oGrp.Usage
+	grp.Cost += oGrp.Cost
+	grp.Unrated += oGrp.Unrated
+	grp.UnratedUsage += oGrp.UnratedUsage
+}
+
+// finalize computes the averages of the group
+func (grp *CDRsReportGroup) finalize(roundDec int) {
+	grp.Cost = utils.Round(grp.Cost, roundDec, utils.ROUNDING_MIDDLE)
+	if grp.Count == 0 {
+		return
+	}
+	grp.AvgUsage = time.Duration(int64(grp.Usage) / grp.Count)
+	if rated := grp.Count - grp.Unrated; rated > 0 {
+		grp.AvgCost = utils.Round(grp.Cost/float64(rated), roundDec, utils.ROUNDING_MIDDLE)
+	}
+}
+
+// groupCDRs aggregates the CDRs in memory, used by the StorDBs without native grouping
+func groupCDRs(cdrs []*CDR, grpBy []*CDRsGroupBy) (grps []*CDRsReportGroup) {
+	grpIdx := make(map[string]*CDRsReportGroup)
+	for _, cdr := range cdrs {
+		vals := make([]string, len(grpBy))
+		for i, grp := range grpBy {
+			vals[i] = grp.valueFromCDR(cdr)
+		}
+		key := utils.ConcatenatedKey(vals...)
+		rGrp, has := grpIdx[key]
+		if !has {
+			rGrp = &CDRsReportGroup{GroupBy: make(map[string]string)}
+			for i, grp := range grpBy {
+				rGrp.GroupBy[grp.ID] = vals[i]
+			}
+			grpIdx[key] = rGrp
+			grps = append(grps, rGrp)
+		}
+		rGrp.addCDR(cdr)
+	}
+	return
+}
+
+// V1GetCDRsReport returns the CDRs aggregated by the requested criteria
+func (cdrS *CDRServer) V1GetCDRsReport(args *ArgCDRsReport, reply *[]*CDRsReportGroup) (err error) {
+	grpBy := make([]*CDRsGroupBy, len(args.GroupBy))
+	for i, grpID := range args.GroupBy {
+		if grpBy[i], err = NewCDRsGroupBy(grpID); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	var cdrsFltr *utils.CDRsFilter
+	if cdrsFltr, err = args.RPCCDRsFilter.AsCDRsFilter(
+		cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		if err.Error() != utils.NotFoundCaps {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	pag := cdrsFltr.Paginator // the pagination applies on groups
+	cdrsFltr.Paginator = utils.Paginator{}
+	cdrsFltr.OrderBy = utils.EmptyString
+	var grps []*CDRsReportGroup
+	if grps, err = cdrS.cdrDb.GetCDRsReport(cdrsFltr, grpBy); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if len(grps) == 0 {
+		return utils.ErrNotFound
+	}
+	roundDec := cdrS.cgrCfg.GeneralCfg().RoundingDecimals
+	for _, grp := range grps {
+		grp.finalize(roundDec)
+	}
+	sort.Slice(grps, func(i, j int) bool {
+		for _, grp := range grpBy {
+			if grps[i].GroupBy[grp.ID] != grps[j].GroupBy[grp.ID] {
+				return grps[i].GroupBy[grp.ID] < grps[j].GroupBy[grp.ID]
+			}
+		}
+		return false
+	})
+	if pag.Offset != nil && *pag.Offset > 0 {
+		if *pag.Offset >= len(grps) {
+			return utils.ErrNotFound
+		}
+		grps = grps[*pag.Offset:]
+	}
+	if pag.Limit != nil && *pag.Limit > 0 && *pag.Limit < len(grps) {
+		grps = grps[:*pag.Limit]
+	}
+	*reply = grps
+	return
+}
@@ -0,0 +1,150 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewCDRsGroupBy(t *testing.T) {
+	for grpID, exp := range map[string]*CDRsGroupBy{
+		utils.Account:           {ID: utils.Account, Field: utils.Account},
+		"*prefix:Destination:3": {ID: "*prefix:Destination:3", Field: utils.Destination, Prefix: 3},
+		utils.MetaDaily:         {ID: utils.MetaDaily, Field: utils.AnswerTime, Period: utils.MetaDaily},
+		utils.MetaMonthly:       {ID: utils.MetaMonthly, Field: utils.AnswerTime, Period: utils.MetaMonthly},
+		utils.RunID:             {ID: utils.RunID, Field: utils.RunID},
+	} {
+		if rcv, err := NewCDRsGroupBy(grpID); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(exp, rcv) {
+			t.Errorf("expecting: %+v, received: %+v", exp, rcv)
+		}
+	}
+	for grpID, expErr := range map[string]string{
+		"Usage":                 "unsupported group field: <Usage>",
+		"*prefix:Destination":   "invalid group criteria: <*prefix:Destination>",
+		"*prefix:Destination:a": "invalid prefix length for group criteria: <*prefix:Destination:a>",
+		"*prefix:Usage:2":       "unsupported group field: <Usage>",
+	} {
+		if _, err := NewCDRsGroupBy(grpID); err == nil || err.Error() != expErr {
+			t.Errorf("expecting: %s, received: %v", expErr, err)
+		}
+	}
+}
+
+func TestCDRsV1GetCDRsReport(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	cdrS := &CDRServer{cgrCfg: cfg, cdrDb: storDB}
+	day1 := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 7, 22, 10, 0, 0, 0, time.UTC)
+	for i, cdr := range []*CDR{
+		{Account: "1001", Destination: "49151", AnswerTime: day1, Usage: time.Minute, Cost: 1},
+		{Account: "1001", Destination: "49152", AnswerTime: day1, Usage: 2 * time.Minute, Cost: 2},
+		{Account: "1001", Destination: "40721", AnswerTime: day2, Usage: 3 * time.Minute, Cost: 3},
+		{Account: "1002", Destination: "49151", AnswerTime: day2, Usage: 4 * time.Minute, Cost: 4.5},
+		{Account: "1002", Destination: "49152", AnswerTime: day2, Usage: 2 * time.Minute, Cost: -1}, // not rated
+	} {
+		cdr.CGRID = utils.Sha1("report", cdr.Account, cdr.Destination)
+		cdr.RunID = utils.MetaDefault
+		cdr.OriginID = cdr.CGRID
+		cdr.OrderID = int64(i + 1)
+		cdr.Tenant = "cgrates.org"
+		if err := storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rply []*CDRsReportGroup
+	if err := cdrS.V1GetCDRsReport(&ArgCDRsReport{
+		GroupBy: []string{utils.Account, utils.MetaDaily}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*CDRsReportGroup{
+		{GroupBy: map[string]string{utils.Account: "1001", utils.MetaDaily: "2020-07-21"},
+			Count: 2, Usage: 3 * time.Minute, Cost: 3, AvgUsage: 90 * time.Second, AvgCost: 1.5},
+		{GroupBy: map[string]string{utils.Account: "1001", utils.MetaDaily: "2020-07-22"},
+			Count: 1, Usage: 3 * time.Minute, Cost: 3, AvgUsage: 3 * time.Minute, AvgCost: 3},
+		{GroupBy: map[string]string{utils.Account: "1002", utils.MetaDaily: "2020-07-22"},
+			Count: 2, Usage: 6 * time.Minute, Cost: 4.5, AvgUsage: 3 * time.Minute, AvgCost: 4.5,
+			Unrated: 1, UnratedUsage: 2 * time.Minute},
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	rply = nil
+	if err := cdrS.V1GetCDRsReport(&ArgCDRsReport{
+		RPCCDRsFilter: utils.RPCCDRsFilter{Paginator: utils.Paginator{Limit: utils.IntPointer(1)}},
+		GroupBy:       []string{"*prefix:Destination:3"}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp = []*CDRsReportGroup{
+		{GroupBy: map[string]string{"*prefix:Destination:3": "407"},
+			Count: 1, Usage: 3 * time.Minute, Cost: 3, AvgUsage: 3 * time.Minute, AvgCost: 3},
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	// totals without grouping
+	rply = nil
+	if err := cdrS.V1GetCDRsReport(&ArgCDRsReport{
+		RPCCDRsFilter: utils.RPCCDRsFilter{Accounts: []string{"1001"}}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp = []*CDRsReportGroup{
+		{GroupBy: map[string]string{}, Count: 3, Usage: 6 * time.Minute, Cost: 6,
+			AvgUsage: 2 * time.Minute, AvgCost: 2},
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	if err := cdrS.V1GetCDRsReport(&ArgCDRsReport{
+		RPCCDRsFilter: utils.RPCCDRsFilter{Accounts: []string{"1003"}}}, &rply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	expErr := "SERVER_ERROR: unsupported group field: <Usage>"
+	if err := cdrS.V1GetCDRsReport(&ArgCDRsReport{GroupBy: []string{utils.Usage}}, &rply); err == nil || err.Error() != expErr {
+		t.Errorf("expecting: %s, received: %v", expErr, err)
+	}
+}
+
+func TestCDRsReportMySQLPeriod(t *testing.T) {
+	answerTime := time.Date(2020, 7, 21, 23, 30, 0, 0, time.UTC)
+	// the hour is grouped as stored in the DATETIME, in the local time of the engine
+	localHour := answerTime.Local().Format("2006-01-02 15")
+	for period, exp := range map[string]string{
+		utils.MetaHourly:  "2020-07-21T23",
+		utils.MetaDaily:   "2020-07-21",
+		utils.MetaMonthly: "2020-07",
+	} {
+		if rcv, err := new(MySQLStorage).answerTimePeriod(period, localHour); err != nil {
+			t.Error(err)
+		} else if rcv != exp {
+			t.Errorf("expecting: %s, received: %s", exp, rcv)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgRateCDRsReport selects the stored CDRs to be re-rated against the current tariffs
+type ArgRateCDRsReport struct {
+	utils.RPCCDRsFilter
+	Confirm bool // store the new costs, refunding and debiting the accounts
+	*utils.ArgDispatcher
+	*utils.TenantArg
+}
+
+// CDRCostDiff compares the stored cost of a CDR with the one out of current tariffs
+type CDRCostDiff struct {
+	CGRID       string
+	RunID       string
+	OriginID    string
+	Tenant      string
+	Account     string
+	Subject     string
+	Destination string
+	Usage       time.Duration
+	OldCost     float64
+	NewCost     float64
+	Difference  float64
+	Applied     bool   // the new cost was stored
+	Error       string // the CDR could not be re-rated
+}
+
+// CostDiffSummary aggregates the cost differences of multiple CDRs
+type CostDiffSummary struct {
+	CDRs       int // successfully re-rated
+	Changed    int
+	Applied    int
+	Errors     int
+	OldCost    float64
+	NewCost    float64
+	Difference float64
+}
+
+func (cs *CostDiffSummary) add(diff *CDRCostDiff, roundDec int) {
+	if diff.Error != utils.EmptyString {
+		cs.Errors++
+		return
+	}
+	cs.CDRs++
+	if diff.Difference != 0 {
+		cs.Changed++
+	}
+	if diff.Applied {
+		cs.Applied++
+	}
+	cs.OldCost = utils.Round(cs.OldCost+diff.OldCost, roundDec, utils.ROUNDING_MIDDLE)
+	cs.NewCost = utils.Round(cs.NewCost+diff.NewCost, roundDec, utils.ROUNDING_MIDDLE)
+	cs.Difference = utils.Round(cs.Difference+diff.Difference, roundDec, utils.ROUNDING_MIDDLE)
+}
+
+// RateCDRsReport is the old versus new cost report, per CDR and aggregated
+type RateCDRsReport struct {
+	CDRs       []*CDRCostDiff
+	Total      *CostDiffSummary
+	PerAccount map[string]*CostDiffSummary // indexed on tenant:account
+}
+
+// V1RateCDRsReport re-rates the CDRs out of StorDB against the current tariffs and
+// reports the cost differences without touching the stored costs or the balances unless confirmed
+func (cdrS *CDRServer) V1RateCDRsReport(arg *ArgRateCDRsReport, reply *RateCDRsReport) (err error) {
+	if len(cdrS.cgrCfg.CdrsCfg().RaterConns) == 0 {
+		return utils.NewErrNotConnected(utils.RALService)
+	}
+	var cdrFltr *utils.CDRsFilter
+	if cdrFltr, err = arg.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	cdrs, _, err := cdrS.cdrDb.GetCDRs(cdrFltr, false)
+	if err != nil {
+		return
+	}
+	roundDec := cdrS.cgrCfg.GeneralCfg().RoundingDecimals
+	rpl := &RateCDRsReport{
+		CDRs:       make([]*CDRCostDiff, 0, len(cdrs)),
+		Total:      new(CostDiffSummary),
+		PerAccount: make(map[string]*CostDiffSummary),
+	}
+	for _, cdr := range cdrs {
+		if cdr.RequestType == utils.META_NONE {
+			continue
+		}
+		diff := &CDRCostDiff{
+			CGRID:       cdr.CGRID,
+			RunID:       cdr.RunID,
+			OriginID:    cdr.OriginID,
+			Tenant:      cdr.Tenant,
+			Account:     cdr.Account,
+			Subject:     cdr.Subject,
+			Destination: cdr.Destination,
+			Usage:       cdr.Usage,
+			OldCost:     cdr.Cost,
+		}
+		if diff.OldCost < 0 { // rating failed previously
+			diff.OldCost = 0
+		}
+		var cc *CallCost
+		if cc, err = cdrS.getCostFromRaterWithCD(&CDRWithArgDispatcher{CDR: cdr,
+			ArgDispatcher: arg.ArgDispatcher}, newReRateDryRunCD(cdr)); err != nil {
+			diff.Error = err.Error()
+		} else {
+			diff.NewCost = utils.Round(cc.Cost, roundDec, utils.ROUNDING_MIDDLE)
+			diff.Difference = utils.Round(diff.NewCost-diff.OldCost, roundDec, utils.ROUNDING_MIDDLE)
+			if arg.Confirm && (diff.Difference != 0 || cdr.Cost < 0) {
+				if err = cdrS.reRateCDR(cdr, arg.ArgDispatcher); err != nil {
+					diff.Error = err.Error()
+				} else {
+					diff.Applied = true
+					diff.NewCost = utils.Round(cdr.Cost, roundDec, utils.ROUNDING_MIDDLE)
+					diff.Difference = utils.Round(diff.NewCost-diff.OldCost, roundDec, utils.ROUNDING_MIDDLE)
+				}
+			}
+		}
+		err = nil
+		rpl.CDRs = append(rpl.CDRs, diff)
+		rpl.Total.add(diff, roundDec)
+		acntID := utils.ConcatenatedKey(cdr.Tenant, cdr.Account)
+		if _, has := rpl.PerAccount[acntID]; !has {
+			rpl.PerAccount[acntID] = new(CostDiffSummary)
+		}
+		rpl.PerAccount[acntID].add(diff, roundDec)
+	}
+	*reply = *rpl
+	return
+}
+
+// newReRateDryRunCD returns the CallDescriptor used to price the CDR like reRateCDR does,
+// debiting the accounts in dry run after refunding in memory the stored cost
+func newReRateDryRunCD(cdr *CDR) (cd *CallDescriptor) {
+	cd = newCallDescriptorFromCDR(cdr)
+	cd.DryRun = true
+	if cdr.CostDetails == nil ||
+		!utils.AccountableRequestTypes.Has(cdr.RequestType) {
+		return
+	}
+	if rfndCD := cdr.CostDetails.AsRefundIncrements(cdr.ToR); rfndCD != nil {
+		cd.Increments = rfndCD.Increments
+	}
+	return
+}
+
+// reRateCDR refunds the stored cost of the CDR, charges it again out of current tariffs and updates it in StorDB
+func (cdrS *CDRServer) reRateCDR(cdr *CDR, argDsp *utils.ArgDispatcher) (err error) {
+	if _, err = cdrS.refundEventCost(cdr.CostDetails,
+		cdr.RequestType, cdr.ToR); err != nil {
+		return
+	}
+	cdr.CostDetails = nil
+	var cc *CallCost
+	if cc, err = cdrS.getCostFromRater(&CDRWithArgDispatcher{CDR: cdr,
+		ArgDispatcher: argDsp}); err != nil {
+		cdr.Cost = -1.0 // already refunded, mark the CDR for a later rating
+		cdr.ExtraInfo = err.Error()
+		if errSet := cdrS.cdrDb.SetCDR(cdr, true); errSet != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> updating CDR %+v",
+					utils.CDRs, errSet.Error(), cdr))
+		}
+		return
+	}
+	cdr.Cost = cc.Cost
+	cdr.ExtraInfo = utils.EmptyString
+	cdr.CostDetails = NewEventCostFromCallCost(cc, cdr.CGRID, cdr.RunID)
+	cdr.CostDetails.Compute()
+	return cdrS.cdrDb.SetCDR(cdr, true)
+}
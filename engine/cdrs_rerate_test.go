@@ -0,0 +1,161 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// testRerateRater mocks the Responder pricing the calls at 1 unit per second
+type testRerateRater struct {
+	sync.Mutex
+	methods []string
+	dryRuns []bool
+}
+
+func (rt *testRerateRater) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	rt.Lock()
+	cd := args.(*CallDescriptorWithArgDispatcher).CallDescriptor
+	rt.methods = append(rt.methods, serviceMethod)
+	rt.dryRuns = append(rt.dryRuns, cd.DryRun)
+	rt.Unlock()
+	switch serviceMethod {
+	case utils.ResponderGetCost, utils.ResponderDebit:
+		if cd.Account == "1003" {
+			return utils.ErrAccountNotFound
+		}
+		*reply.(*CallCost) = CallCost{
+			Category:    cd.Category,
+			Tenant:      cd.Tenant,
+			Subject:     cd.Subject,
+			Account:     cd.Account,
+			Destination: cd.Destination,
+			ToR:         cd.ToR,
+			Cost:        cd.TimeEnd.Sub(cd.TimeStart).Seconds(),
+		}
+		return nil
+	}
+	return utils.ErrNotImplemented
+}
+
+func TestCDRsV1RateCDRsReport(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().RaterConns = []string{"*rerateRater"}
+	rater := new(testRerateRater)
+	raterChan := make(chan rpcclient.ClientConnector, 1)
+	raterChan <- rater
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		cdrDb:   storDB,
+		guard:   nil,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{"*rerateRater": raterChan}),
+	}
+	aTime := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	for i, acnt := range []string{"1001", "1002", "1003"} {
+		cdr := &CDR{
+			CGRID:       utils.Sha1("rerate", acnt),
+			RunID:       utils.MetaDefault,
+			OrderID:     int64(i),
+			OriginID:    "rerate" + acnt,
+			ToR:         utils.VOICE,
+			RequestType: utils.META_POSTPAID,
+			Tenant:      "cgrates.org",
+			Category:    "call",
+			Account:     acnt,
+			Subject:     acnt,
+			Destination: "1099",
+			SetupTime:   aTime,
+			AnswerTime:  aTime,
+			Usage:       10 * time.Second,
+			Cost:        10,
+		}
+		if acnt == "1002" { // tariff changed for 1002
+			cdr.Cost = 12
+		}
+		if err := storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rply RateCDRsReport
+	if err := cdrS.V1RateCDRsReport(&ArgRateCDRsReport{}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	eTotal := &CostDiffSummary{CDRs: 2, Changed: 1, Errors: 1,
+		OldCost: 22, NewCost: 20, Difference: -2}
+	if !reflect.DeepEqual(eTotal, rply.Total) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eTotal), utils.ToJSON(rply.Total))
+	}
+	eAcnt := &CostDiffSummary{CDRs: 1, Changed: 1, OldCost: 12, NewCost: 10, Difference: -2}
+	if rcv := rply.PerAccount["cgrates.org:1002"]; !reflect.DeepEqual(eAcnt, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eAcnt), utils.ToJSON(rcv))
+	}
+	if len(rply.CDRs) != 3 {
+		t.Fatalf("expecting 3 CDRs, received: %s", utils.ToJSON(rply.CDRs))
+	}
+	for _, diff := range rply.CDRs {
+		if diff.Applied {
+			t.Errorf("not expecting the cost to be applied: %s", utils.ToJSON(diff))
+		}
+	}
+	if len(rater.methods) != 3 {
+		t.Errorf("expecting 3 calls to the rater, received: %+v", rater.methods)
+	}
+	for i, method := range rater.methods {
+		if method != utils.ResponderDebit || !rater.dryRuns[i] {
+			t.Errorf("expecting the CDRs to be debited in dry run, received: %s, dry run: %v", method, rater.dryRuns[i])
+		}
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1002"}}, false); err != nil {
+		t.Fatal(err)
+	} else if cdrs[0].Cost != 12 {
+		t.Errorf("not expecting the stored cost to change, received: %v", cdrs[0].Cost)
+	}
+
+	// confirm the new costs
+	rply = RateCDRsReport{}
+	if err := cdrS.V1RateCDRsReport(&ArgRateCDRsReport{Confirm: true,
+		RPCCDRsFilter: utils.RPCCDRsFilter{Accounts: []string{"1001", "1002"}}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	eTotal = &CostDiffSummary{CDRs: 2, Changed: 1, Applied: 1,
+		OldCost: 22, NewCost: 20, Difference: -2}
+	if !reflect.DeepEqual(eTotal, rply.Total) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eTotal), utils.ToJSON(rply.Total))
+	}
+	if cdrs, _, err := storDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1002"}}, false); err != nil {
+		t.Fatal(err)
+	} else if cdrs[0].Cost != 10 {
+		t.Errorf("expecting the new cost to be stored, received: %v", cdrs[0].Cost)
+	}
+
+	cdrS.cgrCfg.CdrsCfg().RaterConns = nil
+	if err := cdrS.V1RateCDRsReport(&ArgRateCDRsReport{}, &rply); err == nil ||
+		err.Error() != utils.NewErrNotConnected(utils.RALService).Error() {
+		t.Errorf("expecting not connected error, received: %v", err)
+	}
+}
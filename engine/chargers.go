@@ -96,6 +96,12 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(cgrEv *utils.CGREvent)
 		i++
 	}
 	cPs.Sort()
+	for i, cP := range cPs {
+		if cP.Blocker { // the lower weight profiles are not processed
+			cPs = cPs[:i+1]
+			break
+		}
+	}
 	return
 }
 
@@ -117,6 +123,12 @@ func (cS *ChargerService) processEvent(cgrEv *utils.CGREventWithOpts) (rply []*C
 	for i, cP := range cPs {
 		clonedEv := cgrEv.Clone()
 		opts := MapEvent(cgrEv.Opts).Clone()
+		if opts == nil && len(cP.Opts) != 0 {
+			opts = make(MapEvent)
+		}
+		for key, val := range cP.Opts {
+			opts[key] = val
+		}
 		clonedEv.Event[utils.RunID] = cP.RunID
 		rply[i] = &ChrgSProcessEventReply{
 			ChargerSProfile: cP.ID,
@@ -125,6 +137,9 @@ func (cS *ChargerService) processEvent(cgrEv *utils.CGREventWithOpts) (rply []*C
 			Opts:            opts,
 		}
 		if len(cP.AttributeIDs) == 1 && cP.AttributeIDs[0] == utils.META_NONE {
+			if err = rply[i].applyRunOpts(); err != nil {
+				return nil, err
+			}
 			continue // AttributeS disabled
 		}
 
@@ -149,10 +164,42 @@ func (cS *ChargerService) processEvent(cgrEv *utils.CGREventWithOpts) (rply []*C
 			rply[i].CGREvent = evReply.CGREvent
 			rply[i].Opts = opts
 		}
+		if err = rply[i].applyRunOpts(); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
+// applyRunOpts forces the event fields based on the opts of the run
+// so they cannot be overwritten by AttributeS
+func (rply *ChrgSProcessEventReply) applyRunOpts() (err error) {
+	if reqType, has := rply.Opts[utils.OptsRequestType]; has {
+		rply.setField(utils.RequestType, utils.IfaceAsString(reqType))
+	}
+	if subj, has := rply.Opts[utils.OptsRatingSubject]; has {
+		rply.setField(utils.Subject, utils.IfaceAsString(subj))
+	}
+	if infoIface, has := rply.Opts[utils.OptsInformational]; has {
+		var info bool
+		if info, err = utils.IfaceAsBool(infoIface); err != nil {
+			return
+		}
+		if info { // rated but not debited
+			rply.setField(utils.RequestType, utils.META_RATED)
+		}
+	}
+	return
+}
+
+// setField overwrites the event field and marks it as altered
+func (rply *ChrgSProcessEventReply) setField(fldName, val string) {
+	rply.CGREvent.Event[fldName] = val
+	if fldPath := utils.MetaReq + utils.NestingSep + fldName; !utils.IsSliceMember(rply.AlteredFields, fldPath) {
+		rply.AlteredFields = append(rply.AlteredFields, fldPath)
+	}
+}
+
 // V1ProcessEvent will process the event received via API and return list of events forked
 func (cS *ChargerService) V1ProcessEvent(args *utils.CGREventWithOpts,
 	reply *[]*ChrgSProcessEventReply) (err error) {
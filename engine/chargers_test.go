@@ -224,3 +224,107 @@ func TestChargerProcessEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v ", utils.ToJSON(rpl[0]), utils.ToJSON(rcv[0]))
 	}
 }
+
+func TestChargerProcessEventRunOpts(t *testing.T) {
+	defaultCfg, _ := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, defaultCfg.DataDbCfg().Items)
+	dm := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	cS, _ := NewChargerService(dm, &FilterS{dm: dm, cfg: defaultCfg}, defaultCfg, nil)
+	for _, cP := range []*ChargerProfile{
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CPP_RETAIL",
+			FilterIDs:    []string{"*string:~*req.Account:1001"},
+			RunID:        "retail",
+			AttributeIDs: []string{utils.META_NONE},
+			Opts:         map[string]interface{}{utils.OptsRequestType: utils.META_POSTPAID},
+			Weight:       30,
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CPP_PARTNER",
+			FilterIDs:    []string{"*string:~*req.Account:1001"},
+			RunID:        "partner",
+			AttributeIDs: []string{utils.META_NONE},
+			Opts: map[string]interface{}{
+				utils.OptsInformational: "true",
+				utils.OptsRatingSubject: "partner",
+			},
+			Blocker: true,
+			Weight:  20,
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CPP_WHOLESALE",
+			FilterIDs:    []string{"*string:~*req.Account:1001"},
+			RunID:        "wholesale",
+			AttributeIDs: []string{utils.META_NONE},
+			Weight:       10,
+		},
+	} {
+		if err := dm.SetChargerProfile(cP, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ev := &utils.CGREventWithOpts{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestChargerProcessEventRunOpts",
+			Event: map[string]interface{}{
+				utils.Account:     "1001",
+				utils.Subject:     "1001",
+				utils.RequestType: utils.META_PREPAID,
+			},
+		},
+		Opts: map[string]interface{}{"*context": "test"},
+	}
+	eRply := []*ChrgSProcessEventReply{
+		{
+			ChargerSProfile: "CPP_RETAIL",
+			AlteredFields:   []string{utils.MetaReqRunID, utils.MetaReq + utils.NestingSep + utils.RequestType},
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "TestChargerProcessEventRunOpts",
+				Event: map[string]interface{}{
+					utils.Account:     "1001",
+					utils.Subject:     "1001",
+					utils.RequestType: utils.META_POSTPAID,
+					utils.RunID:       "retail",
+				},
+			},
+			Opts: map[string]interface{}{
+				"*context":            "test",
+				utils.OptsRequestType: utils.META_POSTPAID,
+			},
+		},
+		{
+			ChargerSProfile: "CPP_PARTNER",
+			AlteredFields: []string{utils.MetaReqRunID, utils.MetaReq + utils.NestingSep + utils.Subject,
+				utils.MetaReq + utils.NestingSep + utils.RequestType},
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "TestChargerProcessEventRunOpts",
+				Event: map[string]interface{}{
+					utils.Account:     "1001",
+					utils.Subject:     "partner",
+					utils.RequestType: utils.META_RATED,
+					utils.RunID:       "partner",
+				},
+			},
+			Opts: map[string]interface{}{
+				"*context":              "test",
+				utils.OptsInformational: "true",
+				utils.OptsRatingSubject: "partner",
+			},
+		},
+	}
+	if rcv, err := cS.processEvent(ev); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(eRply, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eRply), utils.ToJSON(rcv))
+	}
+	// the input event is not altered by the runs
+	if ev.Event[utils.RequestType] != utils.META_PREPAID {
+		t.Errorf("Input event altered: %s", utils.ToJSON(ev.Event))
+	}
+}
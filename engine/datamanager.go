@@ -18,6 +18,7 @@ package engine
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
@@ -111,6 +112,14 @@ type DataManager struct {
 	cacheCfg *config.CacheCfg
 	connMgr  *ConnManager
 	ms       Marshaler
+
+	rplMux      sync.RWMutex
+	rplQueue    *replicationQueue // nil for synchronous replication
+	rplStatsMux sync.Mutex
+	rplStats    ReplicationStats
+
+	invMux sync.RWMutex
+	inv    *cacheInvalidator // nil if the cache invalidations are not shared
 }
 
 // DataDB exports access to dataDB
@@ -417,18 +426,16 @@ func (dm *DataManager) SetDestination(dest *Destination, transactionID string) (
 	if err = dm.dataDB.SetDestinationDrv(dest, transactionID); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheDestinations, dest.Id)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDestinations]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetDestination,
+		if err = dm.replicate(utils.ReplicatorSv1SetDestination,
 			&DestinationWithArgDispatcher{
 				Destination: dest,
 				TenantArg:   utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -443,16 +450,16 @@ func (dm *DataManager) RemoveDestination(destID string, transactionID string) (e
 	if err = dm.dataDB.RemoveDestinationDrv(destID, transactionID); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheDestinations, destID)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDestinations]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1RemoveDestination,
+		err = dm.replicate(utils.ReplicatorSv1RemoveDestination,
 			&utils.StringWithApiKey{
 				Arg:       destID,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -465,11 +472,18 @@ func (dm *DataManager) SetReverseDestination(dest *Destination, transactionID st
 	if err = dm.dataDB.SetReverseDestinationDrv(dest, transactionID); err != nil {
 		return
 	}
-	if config.CgrConfig().DataDbCfg().Items[utils.MetaReverseDestinations].Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetReverseDestination, dest, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+	for _, prfx := range dest.Prefixes {
+		dm.invalidateCache(utils.CacheReverseDestinations, prfx)
+	}
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaReverseDestinations]; itm.Replicate {
+		if err = dm.replicate(utils.ReplicatorSv1SetReverseDestination,
+			&DestinationWithArgDispatcher{
+				Destination: dest,
+				TenantArg:   utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+				ArgDispatcher: &utils.ArgDispatcher{
+					APIKey:  utils.StringPointer(itm.APIKey),
+					RouteID: utils.StringPointer(itm.RouteID),
+				}}); err != nil {
 			return
 		}
 	}
@@ -553,16 +567,13 @@ func (dm *DataManager) SetAccount(acc *Account) (err error) {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccounts]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetAccount,
+		if err = dm.replicate(utils.ReplicatorSv1SetAccount,
 			&AccountWithArgDispatcher{
 				Account: acc,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -578,16 +589,14 @@ func (dm *DataManager) RemoveAccount(id string) (err error) {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccounts]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveAccount,
+		err = dm.replicate(utils.ReplicatorSv1RemoveAccount,
 			&utils.StringWithApiKey{
 				Arg:       id,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -667,17 +676,15 @@ func (dm *DataManager) SetStatQueue(sq *StatQueue) (err error) {
 	if err = dm.dataDB.SetStatQueueDrv(ssq, sq); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheStatQueues, sq.TenantID())
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaStatQueues]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetStatQueue,
+		if err = dm.replicate(utils.ReplicatorSv1SetStatQueue,
 			&StoredStatQueueWithArgDispatcher{
 				StoredStatQueue: ssq,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -693,16 +700,15 @@ func (dm *DataManager) RemoveStatQueue(tenant, id string, transactionID string)
 	if err = dm.dataDB.RemStatQueueDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id))
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaStatQueues]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveStatQueue,
+		err = dm.replicate(utils.ReplicatorSv1RemoveStatQueue,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -772,22 +778,20 @@ func (dm *DataManager) SetFilter(fltr *Filter, withIndex bool) (err error) {
 	if err = dm.DataDB().SetFilterDrv(fltr); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheFilters, fltr.TenantID())
 	if withIndex {
 		if err = updateFilterIndex(dm, oldFlt, fltr); err != nil {
 			return
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaFilters]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetFilter,
+		if err = dm.replicate(utils.ReplicatorSv1SetFilter,
 			&FilterWithArgDispatcher{
 				Filter: fltr,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -823,19 +827,18 @@ func (dm *DataManager) RemoveFilter(tenant, id, transactionID string, withIndex
 	if err = dm.DataDB().RemoveFilterDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheFilters, utils.ConcatenatedKey(tenant, id))
 	if oldFlt == nil {
 		return utils.ErrNotFound
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaFilters]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveFilter,
+		err = dm.replicate(utils.ReplicatorSv1RemoveFilter,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -896,17 +899,15 @@ func (dm *DataManager) SetThreshold(th *Threshold) (err error) {
 	if err = dm.DataDB().SetThresholdDrv(th); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheThresholds, th.TenantID())
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaThresholds]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetThreshold,
+		if err = dm.replicate(utils.ReplicatorSv1SetThreshold,
 			&ThresholdWithArgDispatcher{
 				Threshold: th,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -921,15 +922,15 @@ func (dm *DataManager) RemoveThreshold(tenant, id, transactionID string) (err er
 	if err = dm.DataDB().RemoveThresholdDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id))
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaThresholds]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1RemoveThreshold,
+		err = dm.replicate(utils.ReplicatorSv1RemoveThreshold,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -996,6 +997,7 @@ func (dm *DataManager) SetThresholdProfile(th *ThresholdProfile, withIndex bool)
 	if err = dm.DataDB().SetThresholdProfileDrv(th); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheThresholdProfiles, th.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldTh != nil {
@@ -1007,16 +1009,13 @@ func (dm *DataManager) SetThresholdProfile(th *ThresholdProfile, withIndex bool)
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaThresholdProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetThresholdProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetThresholdProfile,
 			&ThresholdProfileWithArgDispatcher{
 				ThresholdProfile: th,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID)},
-			}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+			}); err != nil {
 			return
 		}
 	}
@@ -1036,6 +1035,7 @@ func (dm *DataManager) RemoveThresholdProfile(tenant, id,
 	if err = dm.DataDB().RemThresholdProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldTh == nil {
 		return utils.ErrNotFound
 	}
@@ -1049,14 +1049,13 @@ func (dm *DataManager) RemoveThresholdProfile(tenant, id,
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaThresholdProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1RemoveThresholdProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveThresholdProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1123,6 +1122,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 	if err = dm.DataDB().SetStatQueueProfileDrv(sqp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheStatQueueProfiles, sqp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldSts != nil {
@@ -1134,16 +1134,13 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaStatQueueProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetStatQueueProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetStatQueueProfile,
 			&StatQueueProfileWithArgDispatcher{
 				StatQueueProfile: sqp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1163,6 +1160,7 @@ func (dm *DataManager) RemoveStatQueueProfile(tenant, id,
 	if err = dm.DataDB().RemStatQueueProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldSts == nil {
 		return utils.ErrNotFound
 	}
@@ -1176,14 +1174,13 @@ func (dm *DataManager) RemoveStatQueueProfile(tenant, id,
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaStatQueueProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1RemoveStatQueueProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveStatQueueProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1244,20 +1241,18 @@ func (dm *DataManager) SetTiming(t *utils.TPTiming) (err error) {
 	if err = dm.DataDB().SetTimingDrv(t); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheTimings, t.ID)
 	if err = dm.CacheDataFromDB(utils.TimingsPrefix, []string{t.ID}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaTimings]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetTiming,
+		if err = dm.replicate(utils.ReplicatorSv1SetTiming,
 			&utils.TPTimingWithArgDispatcher{
 				TPTiming: t,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1272,14 +1267,20 @@ func (dm *DataManager) RemoveTiming(id, transactionID string) (err error) {
 	if err = dm.DataDB().RemoveTimingDrv(id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheTimings, id)
 	if errCh := Cache.Remove(utils.CacheTimings, id,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
-	if config.CgrConfig().DataDbCfg().Items[utils.MetaTimings].Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveTiming, id, &reply)
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaTimings]; itm.Replicate {
+		err = dm.replicate(utils.ReplicatorSv1RemoveTiming,
+			&utils.StringWithApiKey{
+				Arg:       id,
+				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+				ArgDispatcher: &utils.ArgDispatcher{
+					APIKey:  utils.StringPointer(itm.APIKey),
+					RouteID: utils.StringPointer(itm.RouteID),
+				}})
 	}
 	return
 }
@@ -1342,17 +1343,15 @@ func (dm *DataManager) SetResource(rs *Resource) (err error) {
 	if err = dm.DataDB().SetResourceDrv(rs); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheResources, rs.TenantID())
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaResources]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetResource,
+		if err = dm.replicate(utils.ReplicatorSv1SetResource,
 			&ResourceWithArgDispatcher{
 				Resource: rs,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1367,16 +1366,15 @@ func (dm *DataManager) RemoveResource(tenant, id, transactionID string) (err err
 	if err = dm.DataDB().RemoveResourceDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheResources, utils.ConcatenatedKey(tenant, id))
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaResources]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveResource,
+		err = dm.replicate(utils.ReplicatorSv1RemoveResource,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1442,6 +1440,7 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 	if err = dm.DataDB().SetResourceProfileDrv(rp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheResourceProfiles, rp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRes != nil {
@@ -1454,16 +1453,13 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 		Cache.Clear([]string{utils.CacheEventResources})
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaResourceProfile]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetResourceProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetResourceProfile,
 			&ResourceProfileWithArgDispatcher{
 				ResourceProfile: rp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1482,6 +1478,7 @@ func (dm *DataManager) RemoveResourceProfile(tenant, id, transactionID string, w
 	if err = dm.DataDB().RemoveResourceProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldRes == nil {
 		return utils.ErrNotFound
 	}
@@ -1495,14 +1492,12 @@ func (dm *DataManager) RemoveResourceProfile(tenant, id, transactionID string, w
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaResourceProfile]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveResourceProfile, &utils.TenantIDWithArgDispatcher{
-				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
-				ArgDispatcher: &utils.ArgDispatcher{
-					APIKey:  utils.StringPointer(itm.APIKey),
-					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+		err = dm.replicate(utils.ReplicatorSv1RemoveResourceProfile, &utils.TenantIDWithArgDispatcher{
+			TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+			ArgDispatcher: &utils.ArgDispatcher{
+				APIKey:  utils.StringPointer(itm.APIKey),
+				RouteID: utils.StringPointer(itm.RouteID),
+			}})
 	}
 	return
 }
@@ -1562,21 +1557,20 @@ func (dm *DataManager) RemoveActionTriggers(id, transactionID string) (err error
 	if err = dm.DataDB().RemoveActionTriggersDrv(id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActionTriggers, id)
 	if errCh := Cache.Remove(utils.CacheActionTriggers, id,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionTriggers]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveActionTriggers,
+		err = dm.replicate(utils.ReplicatorSv1RemoveActionTriggers,
 			&utils.StringWithApiKey{
 				Arg:       id,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1598,20 +1592,19 @@ func (dm *DataManager) SetActionTriggers(key string, attr ActionTriggers,
 	if err = dm.DataDB().SetActionTriggersDrv(key, attr); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActionTriggers, key)
 	if err = dm.CacheDataFromDB(utils.ACTION_TRIGGER_PREFIX, []string{key}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionTriggers]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1SetActionTriggers,
+		if err = dm.replicate(utils.ReplicatorSv1SetActionTriggers,
 			&SetActionTriggersArgWithArgDispatcher{
 				Attrs: attr, Key: key,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID)},
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
-			}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+			}); err != nil {
 			return
 		}
 	}
@@ -1674,22 +1667,20 @@ func (dm *DataManager) SetSharedGroup(sg *SharedGroup,
 	if err = dm.DataDB().SetSharedGroupDrv(sg); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheSharedGroups, sg.Id)
 	if err = dm.CacheDataFromDB(utils.SHARED_GROUP_PREFIX,
 		[]string{sg.Id}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaSharedGroups]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetSharedGroup,
+		if err = dm.replicate(utils.ReplicatorSv1SetSharedGroup,
 			&SharedGroupWithArgDispatcher{
 				SharedGroup: sg,
 				TenantArg:   utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1704,21 +1695,20 @@ func (dm *DataManager) RemoveSharedGroup(id, transactionID string) (err error) {
 	if err = dm.DataDB().RemoveSharedGroupDrv(id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheSharedGroups, id)
 	if errCh := Cache.Remove(utils.CacheSharedGroups, id,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaSharedGroups]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveSharedGroup,
+		err = dm.replicate(utils.ReplicatorSv1RemoveSharedGroup,
 			&utils.StringWithApiKey{
 				Arg:       id,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1788,20 +1778,19 @@ func (dm *DataManager) SetActions(key string, as Actions, transactionID string)
 	if err = dm.DataDB().SetActionsDrv(key, as); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActions, key)
 	if err = dm.CacheDataFromDB(utils.ACTION_PREFIX, []string{key}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActions]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1SetActions,
+		if err = dm.replicate(utils.ReplicatorSv1SetActions,
 			&SetActionsArgsWithArgDispatcher{
 				Key: key, Acs: as,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -1816,20 +1805,19 @@ func (dm *DataManager) RemoveActions(key, transactionID string) (err error) {
 	if err = dm.DataDB().RemoveActionsDrv(key); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActions, key)
 	if errCh := Cache.Remove(utils.CacheActions, key,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActions]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveActions, &utils.StringWithApiKey{
-				Arg:       key,
-				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
-				ArgDispatcher: &utils.ArgDispatcher{
-					APIKey:  utils.StringPointer(itm.APIKey),
-					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+		err = dm.replicate(utils.ReplicatorSv1RemoveActions, &utils.StringWithApiKey{
+			Arg:       key,
+			TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+			ArgDispatcher: &utils.ArgDispatcher{
+				APIKey:  utils.StringPointer(itm.APIKey),
+				RouteID: utils.StringPointer(itm.RouteID),
+			}})
 	}
 	return
 }
@@ -1878,19 +1866,17 @@ func (dm *DataManager) SetActionPlan(key string, ats *ActionPlan,
 	if err = dm.dataDB.SetActionPlanDrv(key, ats, overwrite, transactionID); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActionPlans, key)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionPlans]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetActionPlan, &SetActionPlanArgWithArgDispatcher{
-				Key:       key,
-				Ats:       ats,
-				Overwrite: overwrite,
-				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
-				ArgDispatcher: &utils.ArgDispatcher{
-					APIKey:  utils.StringPointer(itm.APIKey),
-					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+		if err = dm.replicate(utils.ReplicatorSv1SetActionPlan, &SetActionPlanArgWithArgDispatcher{
+			Key:       key,
+			Ats:       ats,
+			Overwrite: overwrite,
+			TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+			ArgDispatcher: &utils.ArgDispatcher{
+				APIKey:  utils.StringPointer(itm.APIKey),
+				RouteID: utils.StringPointer(itm.RouteID),
+			}}); err != nil {
 			return
 		}
 	}
@@ -1930,17 +1916,16 @@ func (dm *DataManager) RemoveActionPlan(key string, transactionID string) (err e
 	if err = dm.dataDB.RemoveActionPlanDrv(key, transactionID); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheActionPlans, key)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionPlans]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveActionPlan,
+		err = dm.replicate(utils.ReplicatorSv1RemoveActionPlan,
 			&utils.StringWithApiKey{
 				Arg:       key,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -1989,19 +1974,17 @@ func (dm *DataManager) SetAccountActionPlans(acntID string, aPlIDs []string, ove
 	if err = dm.dataDB.SetAccountActionPlansDrv(acntID, aPlIDs, overwrite); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheAccountActionPlans, acntID)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccountActionPlans]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetAccountActionPlans, &SetAccountActionPlansArgWithArgDispatcher{
-				AcntID:    acntID,
-				AplIDs:    aPlIDs,
-				Overwrite: overwrite,
-				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
-				ArgDispatcher: &utils.ArgDispatcher{
-					APIKey:  utils.StringPointer(itm.APIKey),
-					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+		if err = dm.replicate(utils.ReplicatorSv1SetAccountActionPlans, &SetAccountActionPlansArgWithArgDispatcher{
+			AcntID:    acntID,
+			AplIDs:    aPlIDs,
+			Overwrite: overwrite,
+			TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+			ArgDispatcher: &utils.ArgDispatcher{
+				APIKey:  utils.StringPointer(itm.APIKey),
+				RouteID: utils.StringPointer(itm.RouteID),
+			}}); err != nil {
 			return
 		}
 	}
@@ -2024,17 +2007,16 @@ func (dm *DataManager) RemAccountActionPlans(acntID string, apIDs []string) (err
 	if err = dm.dataDB.RemAccountActionPlansDrv(acntID, apIDs); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheAccountActionPlans, acntID)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccountActionPlans]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemAccountActionPlans,
+		err = dm.replicate(utils.ReplicatorSv1RemAccountActionPlans,
 			&RemAccountActionPlansArgsWithArgDispatcher{
 				AcntID: acntID, ApIDs: apIDs,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2095,21 +2077,19 @@ func (dm *DataManager) SetRatingPlan(rp *RatingPlan, transactionID string) (err
 	if err = dm.DataDB().SetRatingPlanDrv(rp); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRatingPlans, rp.Id)
 	if err = dm.CacheDataFromDB(utils.RATING_PLAN_PREFIX, []string{rp.Id}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingPlans]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetRatingPlan,
+		if err = dm.replicate(utils.ReplicatorSv1SetRatingPlan,
 			&RatingPlanWithArgDispatcher{
 				RatingPlan: rp,
 				TenantArg:  utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2124,21 +2104,20 @@ func (dm *DataManager) RemoveRatingPlan(key string, transactionID string) (err e
 	if err = dm.DataDB().RemoveRatingPlanDrv(key); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRatingPlans, key)
 	if errCh := Cache.Remove(utils.CacheRatingPlans, key,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingPlans]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveRatingPlan,
+		err = dm.replicate(utils.ReplicatorSv1RemoveRatingPlan,
 			&utils.StringWithApiKey{
 				Arg:       key,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2203,21 +2182,19 @@ func (dm *DataManager) SetRatingProfile(rpf *RatingProfile,
 	if err = dm.DataDB().SetRatingProfileDrv(rpf); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRatingProfiles, rpf.Id)
 	if err = dm.CacheDataFromDB(utils.RATING_PROFILE_PREFIX, []string{rpf.Id}, true); err != nil {
 		return
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetRatingProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetRatingProfile,
 			&RatingProfileWithArgDispatcher{
 				RatingProfile: rpf,
 				TenantArg:     utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2233,21 +2210,20 @@ func (dm *DataManager) RemoveRatingProfile(key string,
 	if err = dm.DataDB().RemoveRatingProfileDrv(key); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRatingProfiles, key)
 	if errCh := Cache.Remove(utils.CacheRatingProfiles, key,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveRatingProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveRatingProfile,
 			&utils.StringWithApiKey{
 				Arg:       key,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2325,6 +2301,7 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 	if err = dm.DataDB().SetRouteProfileDrv(rpp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheRouteProfiles, rpp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRpp != nil {
@@ -2336,16 +2313,13 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetRouteProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetRouteProfile,
 			&RouteProfileWithArgDispatcher{
 				RouteProfile: rpp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2364,6 +2338,7 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id, transactionID string, with
 	if err = dm.DataDB().RemoveRouteProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2377,15 +2352,13 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id, transactionID string, with
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveRouteProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveRouteProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2466,6 +2439,7 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 	if err = dm.DataDB().SetAttributeProfileDrv(ap); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheAttributeProfiles, ap.TenantID())
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2479,16 +2453,13 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAttributeProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetAttributeProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetAttributeProfile,
 			&AttributeProfileWithArgDispatcher{
 				AttributeProfile: ap,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2507,6 +2478,7 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, transactionID s
 	if err = dm.DataDB().RemoveAttributeProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldAttr == nil {
 		return utils.ErrNotFound
 	}
@@ -2522,15 +2494,13 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, transactionID s
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAttributeProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveAttributeProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveAttributeProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2597,6 +2567,7 @@ func (dm *DataManager) SetChargerProfile(cpp *ChargerProfile, withIndex bool) (e
 	if err = dm.DataDB().SetChargerProfileDrv(cpp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheChargerProfiles, cpp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldCpp != nil {
@@ -2608,16 +2579,13 @@ func (dm *DataManager) SetChargerProfile(cpp *ChargerProfile, withIndex bool) (e
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaChargerProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetChargerProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetChargerProfile,
 			&ChargerProfileWithArgDispatcher{
 				ChargerProfile: cpp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2637,6 +2605,7 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string,
 	if err = dm.DataDB().RemoveChargerProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldCpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2650,15 +2619,13 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string,
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaChargerProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveChargerProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveChargerProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2725,6 +2692,7 @@ func (dm *DataManager) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bo
 	if err = dm.DataDB().SetDispatcherProfileDrv(dpp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheDispatcherProfiles, dpp.TenantID())
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2738,16 +2706,13 @@ func (dm *DataManager) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bo
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetDispatcherProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetDispatcherProfile,
 			&DispatcherProfileWithArgDispatcher{
 				DispatcherProfile: dpp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2767,6 +2732,7 @@ func (dm *DataManager) RemoveDispatcherProfile(tenant, id string,
 	if err = dm.DataDB().RemoveDispatcherProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldDpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2782,15 +2748,13 @@ func (dm *DataManager) RemoveDispatcherProfile(tenant, id string,
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveDispatcherProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveDispatcherProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2853,17 +2817,15 @@ func (dm *DataManager) SetDispatcherHost(dpp *DispatcherHost) (err error) {
 	if err = dm.DataDB().SetDispatcherHostDrv(dpp); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheDispatcherHosts, dpp.TenantID())
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherHosts]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetDispatcherHost,
+		if err = dm.replicate(utils.ReplicatorSv1SetDispatcherHost,
 			&DispatcherHostWithArgDispatcher{
 				DispatcherHost: dpp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -2883,19 +2845,154 @@ func (dm *DataManager) RemoveDispatcherHost(tenant, id string,
 	if err = dm.DataDB().RemoveDispatcherHostDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id))
 	if oldDpp == nil {
 		return utils.ErrNotFound
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherHosts]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveDispatcherHost,
+		err = dm.replicate(utils.ReplicatorSv1RemoveDispatcherHost,
+			&utils.TenantIDWithArgDispatcher{
+				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+				ArgDispatcher: &utils.ArgDispatcher{
+					APIKey:  utils.StringPointer(itm.APIKey),
+					RouteID: utils.StringPointer(itm.RouteID),
+				}})
+	}
+	return
+}
+
+// GetLookupTableValue returns the value stored for the key in the lookup table
+// the missing keys are also cached so we do not query DataDB for each event
+func (dm *DataManager) GetLookupTableValue(tenant, id, key string, cacheRead, cacheWrite bool,
+	transactionID string) (value string, err error) {
+	itmID := lookupTableEntryKey(tenant, id, key)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheLookupTables, itmID); ok {
+			if x == nil {
+				return utils.EmptyString, utils.ErrNotFound
+			}
+			return x.(string), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	grpIDs := []string{utils.ConcatenatedKey(tenant, id)}
+	if value, err = dm.dataDB.GetLookupTableValueDrv(tenant, id, key); err != nil {
+		if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaLookupTables]; err == utils.ErrNotFound && itm.Remote {
+			if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RmtConns, nil,
+				utils.ReplicatorSv1GetLookupTableValue,
+				&LookupTableValueArgs{
+					Tenant: tenant,
+					ID:     id,
+					Key:    key,
+					ArgDispatcher: &utils.ArgDispatcher{
+						APIKey:  utils.StringPointer(itm.APIKey),
+						RouteID: utils.StringPointer(itm.RouteID),
+					}}, &value); err == nil {
+				err = dm.dataDB.SetLookupTableDrv(&LookupTable{Tenant: tenant, ID: id,
+					Entries: map[string]string{key: value}}, false)
+			}
+		}
+		if err != nil {
+			err = utils.CastRPCErr(err)
+			if err == utils.ErrNotFound && cacheWrite {
+				if errCh := Cache.Set(utils.CacheLookupTables, itmID, nil, grpIDs,
+					cacheCommit(transactionID), transactionID); errCh != nil {
+					return utils.EmptyString, errCh
+				}
+			}
+			return utils.EmptyString, err
+		}
+	}
+	if cacheWrite {
+		if err = Cache.Set(utils.CacheLookupTables, itmID, value, grpIDs,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return utils.EmptyString, err
+		}
+	}
+	return
+}
+
+// GetLookupTablePrefixValue returns the value of the longest key in the lookup table prefixing the given one
+// queried at once out of DataDB, the results are not cached since every key looked up would add its own entry
+// the prefixes are queried one by one only towards the remote DataDB, without caching the missing ones
+func (dm *DataManager) GetLookupTablePrefixValue(tenant, id, key string, cacheRead, cacheWrite bool,
+	transactionID string) (value string, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if value, err = dm.dataDB.GetLookupTablePrefixValueDrv(tenant, id, key); err != utils.ErrNotFound ||
+		!config.CgrConfig().DataDbCfg().Items[utils.MetaLookupTables].Remote {
+		return
+	}
+	for i := len(key); i > 0; i-- {
+		if value, err = dm.GetLookupTableValue(tenant, id, key[:i],
+			cacheRead, false, transactionID); err != utils.ErrNotFound {
+			return
+		}
+	}
+	return utils.EmptyString, utils.ErrNotFound
+}
+
+// GetLookupTable returns all the entries of the lookup table, not cached since the tables can be large
+func (dm *DataManager) GetLookupTable(tenant, id string) (lt *LookupTable, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetLookupTableDrv(tenant, id)
+}
+
+// SetLookupTable merges the entries into the lookup table
+// or replaces the existing ones if replace is true
+func (dm *DataManager) SetLookupTable(lt *LookupTable, replace bool) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().SetLookupTableDrv(lt, replace); err != nil {
+		return
+	}
+	// the new keys might be cached as missing
+	Cache.RemoveGroup(utils.CacheLookupTables, lt.TenantID(), true, utils.NonTransactional)
+	dm.invalidateCacheGroup(utils.CacheLookupTables, lt.TenantID())
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaLookupTables]; itm.Replicate {
+		err = dm.replicate(utils.ReplicatorSv1SetLookupTable,
+			&LookupTableWithArgDispatcher{
+				LookupTable: lt,
+				Replace:     replace,
+				ArgDispatcher: &utils.ArgDispatcher{
+					APIKey:  utils.StringPointer(itm.APIKey),
+					RouteID: utils.StringPointer(itm.RouteID),
+				}})
+	}
+	return
+}
+
+// RemoveLookupTable removes all the entries of the lookup table
+func (dm *DataManager) RemoveLookupTable(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	err = dm.DataDB().RemoveLookupTableDrv(tenant, id)
+	tntID := utils.ConcatenatedKey(tenant, id)
+	Cache.RemoveGroup(utils.CacheLookupTables, tntID, true, utils.NonTransactional)
+	dm.invalidateCacheGroup(utils.CacheLookupTables, tntID)
+	if err != nil {
+		return
+	}
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaLookupTables]; itm.Replicate {
+		err = dm.replicate(utils.ReplicatorSv1RemoveLookupTable,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -2954,18 +3051,18 @@ func (dm *DataManager) SetLoadIDs(loadIDs map[string]int64) (err error) {
 	if err = dm.DataDB().SetLoadIDsDrv(loadIDs); err != nil {
 		return
 	}
+	for key := range loadIDs {
+		dm.invalidateCache(utils.CacheLoadIDs, key)
+	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaLoadIDs]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetLoadIDs,
+		if err = dm.replicate(utils.ReplicatorSv1SetLoadIDs,
 			&utils.LoadIDsWithArgDispatcher{
 				LoadIDs:   loadIDs,
 				TenantArg: utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -3034,6 +3131,7 @@ func (dm *DataManager) SetRateProfile(rpp *RateProfile, withIndex bool) (err err
 	if err = dm.DataDB().SetRateProfileDrv(rpp); err != nil {
 		return err
 	}
+	dm.invalidateCache(utils.CacheRateProfiles, rpp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRpp != nil {
@@ -3072,16 +3170,13 @@ func (dm *DataManager) SetRateProfile(rpp *RateProfile, withIndex bool) (err err
 
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherProfiles]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetRateProfile,
+		if err = dm.replicate(utils.ReplicatorSv1SetRateProfile,
 			&RateProfileWithArgDispatcher{
 				RateProfile: rpp,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
+				}}); err != nil {
 			return
 		}
 	}
@@ -3101,6 +3196,7 @@ func (dm *DataManager) RemoveRateProfile(tenant, id string,
 	if err = dm.DataDB().RemoveRateProfileDrv(tenant, id); err != nil {
 		return
 	}
+	dm.invalidateCache(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id))
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
@@ -3117,15 +3213,13 @@ func (dm *DataManager) RemoveRateProfile(tenant, id string,
 		}
 	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRateProfiles]; itm.Replicate {
-		var reply string
-		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveRateProfile,
+		err = dm.replicate(utils.ReplicatorSv1RemoveRateProfile,
 			&utils.TenantIDWithArgDispatcher{
 				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID),
-				}}, &reply)
+				}})
 	}
 	return
 }
@@ -3139,6 +3233,13 @@ func (dm *DataManager) Reconnect(marshaller string, newcfg *config.DataDbCfg) (e
 	}
 	// ToDo: consider locking
 	dm.dataDB.Close()
+	if iDB, isInternal := d.(*InternalDB); isInternal &&
+		newcfg.InternalDBDumpPath != utils.EmptyString { // after close so we read the last snapshot
+		if err = iDB.EnablePersistence(newcfg.InternalDBDumpPath,
+			newcfg.InternalDBSnapshotInterval, newcfg.InternalDBSyncWrites); err != nil {
+			return
+		}
+	}
 	dm.dataDB = d
 	return
 }
@@ -3211,10 +3312,11 @@ func (dm *DataManager) SetIndexes(idxItmType, tntCtx string,
 		indexes, commit, transactionID); err != nil {
 		return
 	}
+	for idxKey := range indexes {
+		dm.invalidateCache(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey))
+	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaIndexes]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1SetIndexes,
+		err = dm.replicate(utils.ReplicatorSv1SetIndexes,
 			&utils.SetIndexesArg{
 				IdxItmType: idxItmType,
 				TntCtx:     tntCtx,
@@ -3223,9 +3325,7 @@ func (dm *DataManager) SetIndexes(idxItmType, tntCtx string,
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID)},
-			}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
-		}
+			})
 	}
 	return
 }
@@ -3238,10 +3338,13 @@ func (dm *DataManager) RemoveIndexes(idxItmType, tntCtx, idxKey string) (err err
 	if err = dm.DataDB().RemoveIndexesDrv(idxItmType, tntCtx, idxKey); err != nil {
 		return
 	}
+	if idxKey == utils.EmptyString {
+		dm.invalidateCacheGroup(idxItmType, tntCtx)
+	} else {
+		dm.invalidateCache(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey))
+	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaIndexes]; itm.Replicate {
-		var reply string
-		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
-			utils.ReplicatorSv1RemoveIndexes,
+		err = dm.replicate(utils.ReplicatorSv1RemoveIndexes,
 			&utils.GetIndexesArg{
 				IdxItmType: idxItmType,
 				TntCtx:     tntCtx,
@@ -3250,9 +3353,7 @@ func (dm *DataManager) RemoveIndexes(idxItmType, tntCtx, idxKey string) (err err
 				ArgDispatcher: &utils.ArgDispatcher{
 					APIKey:  utils.StringPointer(itm.APIKey),
 					RouteID: utils.StringPointer(itm.RouteID)},
-			}, &reply); err != nil {
-			err = utils.CastRPCErr(err)
-		}
+			})
 	}
 	return
 }
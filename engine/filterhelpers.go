@@ -39,7 +39,7 @@ func MatchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldI
 	}
 	// Guard will protect the function with automatic locking
 	lockID := utils.CacheInstanceToPrefix[cacheID] + itemIDPrefix
-	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+	if _, errGuard := guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
 		if !indexedSelects {
 			var keysWithID []string
 			if keysWithID, err = dm.DataDB().GetKeysForPrefix(utils.CacheIndexesToPrefix[cacheID]); err != nil {
@@ -97,7 +97,9 @@ func MatchingItemIDsForEvent(ev map[string]interface{}, stringFldIDs, prefixFldI
 			}
 		}
 		return
-	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockID)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockID); errGuard != nil {
+		return nil, errGuard
+	}
 	if len(itemIDs) == 0 {
 		return nil, utils.ErrNotFound
 	}
@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// httpLookupMaxReplySize limits the replies read out of the external services
+const httpLookupMaxReplySize = 1 << 20
+
+var (
+	// httpLookupClient is shared by all the *http_lookup attributes, the timeout is enforced per request
+	httpLookupClient = new(http.Client)
+
+	// httpLookupCalls are the requests in flight, indexed on their cache key
+	httpLookupCalls    = make(map[string]*httpLookupCall)
+	httpLookupCallsMux sync.Mutex
+)
+
+// httpLookupCall is one request in flight, the identical lookups arriving meanwhile wait for its reply
+type httpLookupCall struct {
+	wg   sync.WaitGroup
+	rply interface{}
+	err  error
+}
+
+// httpLookupFailure is cached instead of the reply when the request fails
+// so the fallback is used without querying the service for each event
+type httpLookupFailure struct {
+	err    error
+	expiry time.Time
+}
+
+func (fl *httpLookupFailure) Error() string {
+	return fl.err.Error()
+}
+
+// httpLookup queries the external service configured under lkID and returns the value found in the reply
+// replyPath overwrites the one from config when not empty
+func (alS *AttributeService) httpLookup(lkID, replyPath string, ev utils.DataProvider) (val string, err error) {
+	lkCfg, has := alS.cgrcfg.AttributeSCfg().HTTPLookups[lkID]
+	if !has {
+		return utils.EmptyString, fmt.Errorf("unknown http lookup <%s>", lkID)
+	}
+	if replyPath == utils.EmptyString {
+		replyPath = lkCfg.ReplyPath
+	}
+	if val, err = queryHTTPLookup(lkID, lkCfg, replyPath, ev); err != nil {
+		if lkCfg.Fallback == utils.EmptyString {
+			return
+		}
+		if _, cached := err.(*httpLookupFailure); cached { // already logged
+			return lkCfg.Fallback, nil
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> http lookup <%s> failed with error: <%s>, using fallback value <%s>",
+				utils.AttributeS, lkID, err.Error(), lkCfg.Fallback))
+		return lkCfg.Fallback, nil
+	}
+	return
+}
+
+// queryHTTPLookup builds the request out of the event and extracts the value at replyPath
+// the decoded replies are cached so multiple attributes can share the same request
+func queryHTTPLookup(lkID string, lkCfg *config.HTTPLookupCfg, replyPath string,
+	ev utils.DataProvider) (val string, err error) {
+	var lkURL, body string
+	if lkURL, err = parseHTTPLookupTemplate(lkCfg.URL, ev, url.PathEscape); err != nil {
+		return
+	}
+	if len(lkCfg.QueryParams) != 0 {
+		qry := make(url.Values, len(lkCfg.QueryParams))
+		for param, prsrs := range lkCfg.QueryParams {
+			var pVal string
+			if pVal, err = prsrs.ParseDataProvider(ev, utils.NestingSep); err != nil {
+				return
+			}
+			qry.Set(param, pVal)
+		}
+		sep := "?"
+		if strings.Contains(lkURL, "?") {
+			sep = "&"
+		}
+		lkURL += sep + qry.Encode()
+	}
+	if len(lkCfg.Body) != 0 {
+		if body, err = parseHTTPLookupTemplate(lkCfg.Body, ev, jsonEscape); err != nil {
+			return
+		}
+	}
+	cacheKey := utils.ConcatenatedKey(lkID, lkCfg.Method, lkURL, body)
+	rply, has := Cache.Get(utils.CacheHTTPLookups, cacheKey)
+	if fl, isFailure := rply.(*httpLookupFailure); has && isFailure {
+		if time.Now().Before(fl.expiry) {
+			return utils.EmptyString, fl
+		}
+		has = false
+	}
+	if !has {
+		if rply, err = doHTTPLookupOnce(cacheKey, lkCfg, lkURL, body); err != nil {
+			return
+		}
+	}
+	if replyPath == utils.EmptyString {
+		return utils.IfaceAsString(rply), nil
+	}
+	rplyMp, canCast := rply.(map[string]interface{})
+	if !canCast {
+		return utils.EmptyString, fmt.Errorf("reply is not a JSON object, cannot extract <%s>", replyPath)
+	}
+	var iface interface{}
+	if iface, err = utils.MapStorage(rplyMp).FieldAsInterface(
+		strings.Split(replyPath, utils.NestingSep)); err != nil {
+		return
+	}
+	return utils.IfaceAsString(iface), nil
+}
+
+// doHTTPLookupOnce sends a single request for the concurrent identical lookups and caches its result
+func doHTTPLookupOnce(cacheKey string, lkCfg *config.HTTPLookupCfg, lkURL, body string) (rply interface{}, err error) {
+	httpLookupCallsMux.Lock()
+	if call, has := httpLookupCalls[cacheKey]; has {
+		httpLookupCallsMux.Unlock()
+		call.wg.Wait()
+		return call.rply, call.err
+	}
+	call := new(httpLookupCall)
+	call.wg.Add(1)
+	httpLookupCalls[cacheKey] = call
+	httpLookupCallsMux.Unlock()
+	defer func() {
+		call.rply, call.err = rply, err
+		httpLookupCallsMux.Lock()
+		delete(httpLookupCalls, cacheKey)
+		httpLookupCallsMux.Unlock()
+		call.wg.Done()
+	}()
+	if rply, err = doHTTPLookup(lkCfg, lkURL, body); err != nil {
+		if lkCfg.Fallback != utils.EmptyString && lkCfg.FallbackTTL > 0 {
+			if errCh := Cache.Set(utils.CacheHTTPLookups, cacheKey,
+				&httpLookupFailure{err: err, expiry: time.Now().Add(lkCfg.FallbackTTL)}, nil,
+				true, utils.NonTransactional); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return
+	}
+	err = Cache.Set(utils.CacheHTTPLookups, cacheKey, rply, nil,
+		true, utils.NonTransactional)
+	return
+}
+
+// parseHTTPLookupTemplate composes the template escaping the values taken from the event
+func parseHTTPLookupTemplate(prsrs config.RSRParsers, ev utils.DataProvider,
+	escape func(string) string) (out string, err error) {
+	for _, prsr := range prsrs {
+		var val string
+		if val, err = prsr.ParseDataProvider(ev, utils.NestingSep); err != nil {
+			return
+		}
+		if strings.HasPrefix(prsr.Rules, utils.DynamicDataPrefix) { // constants are used as configured
+			val = escape(val)
+		}
+		out += val
+	}
+	return
+}
+
+// jsonEscape escapes the value so it can be placed within a JSON string
+func jsonEscape(val string) string {
+	b, _ := json.Marshal(val)
+	return string(b[1 : len(b)-1])
+}
+
+// doHTTPLookup sends the request and decodes the reply
+// a reply which is not JSON is returned as string
+func doHTTPLookup(lkCfg *config.HTTPLookupCfg, lkURL, body string) (rply interface{}, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lkCfg.Timeout)
+	defer cancel()
+	var req *http.Request
+	if req, err = http.NewRequest(lkCfg.Method, lkURL, strings.NewReader(body)); err != nil {
+		return
+	}
+	req = req.WithContext(ctx)
+	if body != utils.EmptyString {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	var resp *http.Response
+	if resp, err = httpLookupClient.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	var respBody []byte
+	if respBody, err = ioutil.ReadAll(io.LimitReader(resp.Body, httpLookupMaxReplySize+1)); err != nil {
+		return
+	}
+	if len(respBody) > httpLookupMaxReplySize {
+		return nil, fmt.Errorf("reply larger than %d bytes", httpLookupMaxReplySize)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code <%d> with reply <%s>", resp.StatusCode, string(respBody))
+	}
+	if err = json.Unmarshal(respBody, &rply); err != nil {
+		return string(respBody), nil
+	}
+	return
+}
@@ -0,0 +1,337 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestProcessAttributeHTTPLookup(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		switch r.URL.Path {
+		case "/customers/1001":
+			if r.URL.Query().Get("fields") != "tier" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"customer":{"tier":"gold","credit_class":"A"}}`))
+		case "/customers/1002":
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte(`{"customer":{"tier":"silver","credit_class":"B"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	defaultCfg, _ := config.NewDefaultCGRConfig()
+	defaultCfg.AttributeSCfg().ProcessRuns = 1
+	defaultCfg.AttributeSCfg().HTTPLookups = map[string]*config.HTTPLookupCfg{
+		"CRM_CUSTOMER": {
+			URL:    config.NewRSRParsersMustCompile(srv.URL+"/customers/;~*req.Account", true, utils.INFIELD_SEP),
+			Method: http.MethodGet,
+			QueryParams: map[string]config.RSRParsers{
+				"fields": config.NewRSRParsersMustCompile("tier", true, utils.INFIELD_SEP),
+			},
+			ReplyPath: "customer.tier",
+			Timeout:   20 * time.Millisecond,
+			Fallback:  "standard",
+		},
+	}
+	data := NewInternalDB(nil, nil, true, defaultCfg.DataDbCfg().Items)
+	dmAtr = NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	Cache.Clear(nil)
+	attrService, _ = NewAttributeService(dmAtr, &FilterS{dm: dmAtr, cfg: defaultCfg}, defaultCfg)
+	attrPrf := &AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_HTTP_LOOKUP",
+		Contexts: []string{utils.MetaSessionS},
+		Attributes: []*Attribute{
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "Tier",
+				Type:  utils.MetaHTTPLookup,
+				Value: config.NewRSRParsersMustCompile("CRM_CUSTOMER", true, utils.INFIELD_SEP),
+			},
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "CreditClass",
+				Type:  utils.MetaHTTPLookup,
+				Value: config.NewRSRParsersMustCompile("CRM_CUSTOMER;customer.credit_class", true, utils.INFIELD_SEP),
+			},
+		},
+		Weight: 10,
+	}
+	if err := dmAtr.SetAttributeProfile(attrPrf, true); err != nil {
+		t.Error(err)
+	}
+	ev := &AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeHTTPLookup",
+			Event: map[string]interface{}{
+				utils.Account: "1001",
+			},
+		},
+	}
+	rcv, err := attrService.processEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eRply := &AttrSProcessEventReply{
+		MatchedProfiles: []string{"ATTR_HTTP_LOOKUP"},
+		AlteredFields: []string{utils.MetaReq + utils.NestingSep + "Tier",
+			utils.MetaReq + utils.NestingSep + "CreditClass"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeHTTPLookup",
+			Event: map[string]interface{}{
+				utils.Account: "1001",
+				"Tier":        "gold",
+				"CreditClass": "A",
+			},
+		},
+	}
+	if !reflect.DeepEqual(eRply, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
+	}
+	// both attributes share the cached reply
+	if nrHits := atomic.LoadInt32(&hits); nrHits != 1 {
+		t.Errorf("Expecting 1 request, received: %d", nrHits)
+	}
+	if _, err = attrService.processEvent(&AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeHTTPLookup",
+			Event: map[string]interface{}{
+				utils.Account: "1001",
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	} else if nrHits := atomic.LoadInt32(&hits); nrHits != 1 {
+		t.Errorf("Expecting 1 request, received: %d", nrHits)
+	}
+
+	// timeout uses the fallback
+	ev = &AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestProcessAttributeHTTPLookup",
+			Event: map[string]interface{}{
+				utils.Account: "1002",
+			},
+		},
+	}
+	if rcv, err = attrService.processEvent(ev); err != nil {
+		t.Fatal(err)
+	}
+	eEv := map[string]interface{}{
+		utils.Account: "1002",
+		"Tier":        "standard",
+		"CreditClass": "standard",
+	}
+	if !reflect.DeepEqual(eEv, rcv.CGREvent.Event) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eEv), utils.ToJSON(rcv.CGREvent.Event))
+	}
+	if _, has := Cache.Get(utils.CacheHTTPLookups, utils.ConcatenatedKey("CRM_CUSTOMER",
+		http.MethodGet, srv.URL+"/customers/1002?fields=tier", utils.EmptyString)); has {
+		t.Error("failed lookups should not be cached")
+	}
+
+	// without fallback the error is returned
+	defaultCfg.AttributeSCfg().HTTPLookups["CRM_CUSTOMER"].Fallback = utils.EmptyString
+	ev.CGREvent.Event = map[string]interface{}{
+		utils.Account: "1003",
+	}
+	if _, err = attrService.processEvent(ev); err == nil {
+		t.Error("Expecting error for status code 404")
+	}
+}
+
+func TestQueryHTTPLookupRawReply(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("premium"))
+	}))
+	defer srv.Close()
+	Cache.Clear([]string{utils.CacheHTTPLookups})
+	lkCfg := &config.HTTPLookupCfg{
+		URL:     config.NewRSRParsersMustCompile(srv.URL, true, utils.INFIELD_SEP),
+		Method:  http.MethodPost,
+		Body:    config.NewRSRParsersMustCompile(`{"account":";~*req.Account;"}`, true, utils.INFIELD_SEP),
+		Timeout: time.Second,
+	}
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Account: "1001"}}
+	if rcv, err := queryHTTPLookup("CRM_RAW", lkCfg, utils.EmptyString, ev); err != nil {
+		t.Error(err)
+	} else if rcv != "premium" {
+		t.Errorf("Expecting: premium, received: %s", rcv)
+	}
+	if _, err := queryHTTPLookup("CRM_RAW", lkCfg, "customer.tier", ev); err == nil {
+		t.Error("Expecting error when extracting a path out of a raw reply")
+	}
+}
+
+func TestHTTPLookupFallbackTTL(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	Cache.Clear([]string{utils.CacheHTTPLookups})
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.AttributeSCfg().HTTPLookups = map[string]*config.HTTPLookupCfg{
+		"CRM_DOWN": {
+			URL:         config.NewRSRParsersMustCompile(srv.URL, true, utils.INFIELD_SEP),
+			Method:      http.MethodGet,
+			Timeout:     time.Second,
+			Fallback:    "standard",
+			FallbackTTL: 50 * time.Millisecond,
+		},
+	}
+	alS := &AttributeService{cgrcfg: cfg}
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Account: "1001"}}
+	for i := 0; i < 3; i++ {
+		if rcv, err := alS.httpLookup("CRM_DOWN", utils.EmptyString, ev); err != nil {
+			t.Error(err)
+		} else if rcv != "standard" {
+			t.Errorf("Expecting: standard, received: %s", rcv)
+		}
+	}
+	if nrHits := atomic.LoadInt32(&hits); nrHits != 1 {
+		t.Errorf("Expecting 1 request, received: %d", nrHits)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if rcv, err := alS.httpLookup("CRM_DOWN", utils.EmptyString, ev); err != nil {
+		t.Error(err)
+	} else if rcv != "standard" {
+		t.Errorf("Expecting: standard, received: %s", rcv)
+	}
+	if nrHits := atomic.LoadInt32(&hits); nrHits != 2 {
+		t.Errorf("Expecting 2 requests, received: %d", nrHits)
+	}
+}
+
+func TestQueryHTTPLookupEscape(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req map[string]string
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"path":    r.URL.EscapedPath(),
+			"account": req["account"],
+		})
+	}))
+	defer srv.Close()
+	Cache.Clear([]string{utils.CacheHTTPLookups})
+	lkCfg := &config.HTTPLookupCfg{
+		URL:     config.NewRSRParsersMustCompile(srv.URL+"/customers/;~*req.Account", true, utils.INFIELD_SEP),
+		Method:  http.MethodPost,
+		Body:    config.NewRSRParsersMustCompile(`{"account":";~*req.Account;"}`, true, utils.INFIELD_SEP),
+		Timeout: time.Second,
+	}
+	acnt := `10"01/../x`
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Account: acnt}}
+	if rcv, err := queryHTTPLookup("CRM_ESCAPE", lkCfg, "account", ev); err != nil {
+		t.Error(err)
+	} else if rcv != acnt {
+		t.Errorf("Expecting: %s, received: %s", acnt, rcv)
+	}
+	if rcv, err := queryHTTPLookup("CRM_ESCAPE", lkCfg, "path", ev); err != nil {
+		t.Error(err)
+	} else if exp := "/customers/10%2201%2F..%2Fx"; rcv != exp {
+		t.Errorf("Expecting: %s, received: %s", exp, rcv)
+	}
+}
+
+func TestQueryHTTPLookupConcurrent(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Write([]byte("premium"))
+	}))
+	defer srv.Close()
+	Cache.Clear([]string{utils.CacheHTTPLookups})
+	lkCfg := &config.HTTPLookupCfg{
+		URL:     config.NewRSRParsersMustCompile(srv.URL, true, utils.INFIELD_SEP),
+		Method:  http.MethodGet,
+		Timeout: time.Second,
+	}
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Account: "1001"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if rcv, err := queryHTTPLookup("CRM_CONCURRENT", lkCfg, utils.EmptyString, ev); err != nil {
+				t.Error(err)
+			} else if rcv != "premium" {
+				t.Errorf("Expecting: premium, received: %s", rcv)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // let the lookups wait on the first request
+	close(release)
+	wg.Wait()
+	if nrHits := atomic.LoadInt32(&hits); nrHits != 1 {
+		t.Errorf("Expecting 1 request, received: %d", nrHits)
+	}
+}
+
+func TestQueryHTTPLookupReplyTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", httpLookupMaxReplySize+1)))
+	}))
+	defer srv.Close()
+	Cache.Clear([]string{utils.CacheHTTPLookups})
+	lkCfg := &config.HTTPLookupCfg{
+		URL:     config.NewRSRParsersMustCompile(srv.URL, true, utils.INFIELD_SEP),
+		Method:  http.MethodGet,
+		Timeout: time.Second,
+	}
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Account: "1001"}}
+	if _, err := queryHTTPLookup("CRM_LARGE", lkCfg, utils.EmptyString, ev); err == nil {
+		t.Error("Expecting error for the reply exceeding the limit")
+	}
+}
@@ -31,7 +31,9 @@ type ChargerProfile struct {
 	FilterIDs          []string
 	ActivationInterval *utils.ActivationInterval // Activation interval
 	RunID              string
-	AttributeIDs       []string // perform data aliasing based on these Attributes
+	AttributeIDs       []string               // perform data aliasing based on these Attributes
+	Opts               map[string]interface{} // merged into the opts of the run
+	Blocker            bool                   // do not process the lower weight profiles
 	Weight             float64
 }
 
@@ -155,6 +155,17 @@ func (sSpls *SortedRoutes) SortLoadDistribution() {
 	})
 }
 
+// SortScore is part of sort interface,
+// sort descendent based on Score with fallback on Weight
+func (sSpls *SortedRoutes) SortScore() {
+	sort.Slice(sSpls.SortedRoutes, func(i, j int) bool {
+		if sSpls.SortedRoutes[i].SortingData[utils.Score].(float64) == sSpls.SortedRoutes[j].SortingData[utils.Score].(float64) {
+			return sSpls.SortedRoutes[i].SortingData[utils.Weight].(float64) > sSpls.SortedRoutes[j].SortingData[utils.Weight].(float64)
+		}
+		return sSpls.SortedRoutes[i].SortingData[utils.Score].(float64) > sSpls.SortedRoutes[j].SortingData[utils.Score].(float64)
+	})
+}
+
 // Digest returns list of routeIDs + parameters for easier outside access
 // format route1:route1params,route2:route2params
 func (sSpls *SortedRoutes) Digest() string {
@@ -202,6 +213,7 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher, err er
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaMulti] = NewMultiRouteSorter(lcrS)
 	return
 }
 
@@ -671,3 +671,41 @@ func TestLibRoutesSortLoadDistribution(t *testing.T) {
 			eIds, rcv)
 	}
 }
+
+func TestLibRoutesSortScore(t *testing.T) {
+	sSpls := &SortedRoutes{
+		SortedRoutes: []*SortedRoute{
+			&SortedRoute{
+				RouteID: "supplier1",
+				SortingData: map[string]interface{}{
+					utils.Weight: 10.0,
+					utils.Score:  1.5,
+				},
+			},
+			&SortedRoute{
+				RouteID: "supplier2",
+				SortingData: map[string]interface{}{
+					utils.Weight: 20.0,
+					utils.Score:  1.5,
+				},
+			},
+			&SortedRoute{
+				RouteID: "supplier3",
+				SortingData: map[string]interface{}{
+					utils.Weight: 5.0,
+					utils.Score:  2.0,
+				},
+			},
+		},
+	}
+	sSpls.SortScore()
+	rcv := make([]string, len(sSpls.SortedRoutes))
+	eIds := []string{"supplier3", "supplier2", "supplier1"}
+	for i, spl := range sSpls.SortedRoutes {
+		rcv[i] = spl.RouteID
+	}
+	if !reflect.DeepEqual(eIds, rcv) {
+		t.Errorf("Expecting: %+v, \n received: %+v",
+			eIds, rcv)
+	}
+}
@@ -188,17 +188,17 @@ VF,*debit,,,,*monetary,,,,,*unlimited,*any,"{""Method"":""*incremental"",""Param
 TOPUP_RST_GNR_1000,*topup_reset,"{""*voice"": 60.0,""*data"":1024.0,""*sms"":1.0}",,,*generic,,*any,,,*unlimited,,1000,20,false,false,10
 `
 	ActionPlansCSVContent = `
-MORE_MINUTES,MINI,ONE_TIME_RUN,10
-MORE_MINUTES,SHARED,ONE_TIME_RUN,10
-TOPUP10_AT,TOPUP10_AC,*asap,10
-TOPUP10_AT,TOPUP10_AC1,*asap,10
-TOPUP_SHARED0_AT,SE0,*asap,10
-TOPUP_SHARED10_AT,SE10,*asap,10
-TOPUP_EMPTY_AT,EE0,*asap,10
-POST_AT,NEG,*asap,10
-BLOCK_AT,BLOCK,*asap,10
-BLOCK_EMPTY_AT,BLOCK_EMPTY,*asap,10
-EXP_AT,EXP,*asap,10
+MORE_MINUTES,MINI,ONE_TIME_RUN,10,
+MORE_MINUTES,SHARED,ONE_TIME_RUN,10,*once
+TOPUP10_AT,TOPUP10_AC,*asap,10,
+TOPUP10_AT,TOPUP10_AC1,*asap,10,
+TOPUP_SHARED0_AT,SE0,*asap,10,
+TOPUP_SHARED10_AT,SE10,*asap,10,
+TOPUP_EMPTY_AT,EE0,*asap,10,
+POST_AT,NEG,*asap,10,
+BLOCK_AT,BLOCK,*asap,10,
+BLOCK_EMPTY_AT,BLOCK_EMPTY,*asap,10,
+EXP_AT,EXP,*asap,10,
 `
 
 	ActionTriggersCSVContent = `
@@ -255,11 +255,11 @@ cgrates.org,FLTR_DST_DE,*destinations,~*req.Destination,DST_DE,2014-07-29T15:00:
 cgrates.org,FLTR_DST_NL,*destinations,~*req.Destination,DST_NL,2014-07-29T15:00:00Z
 `
 	RoutesCSVContent = `
-#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],Sorting[4],SortingParameters[5],RouteID[6],RouteFilterIDs[7],RouteAccountIDs[8],RouteRatingPlanIDs[9],RouteResourceIDs[10],RouteStatIDs[11],RouteWeight[12],RouteBlocker[13],RouteParameters[14],Weight[15]
-cgrates.org,SPP_1,*string:~*req.Account:dan,2014-07-29T15:00:00Z,*least_cost,,supplier1,FLTR_ACNT_dan,Account1;Account1_1,RPL_1,ResGroup1,Stat1,10,true,param1,20
-cgrates.org,SPP_1,,,,,supplier1,,,RPL_2,ResGroup2,,10,,,
-cgrates.org,SPP_1,,,,,supplier1,FLTR_DST_DE,Account2,RPL_3,ResGroup3,Stat2,10,,,
-cgrates.org,SPP_1,,,,,supplier1,,,,ResGroup4,Stat3,10,,,
+#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],Sorting[4],SortingParameters[5],RouteID[6],RouteFilterIDs[7],RouteAccountIDs[8],RouteRatingPlanIDs[9],RouteResourceIDs[10],RouteStatIDs[11],RouteWeight[12],RouteBlocker[13],RouteParameters[14],Weight[15],QuarantineRules[16],QuarantineCooldown[17]
+cgrates.org,SPP_1,*string:~*req.Account:dan,2014-07-29T15:00:00Z,*least_cost,,supplier1,FLTR_ACNT_dan,Account1;Account1_1,RPL_1,ResGroup1,Stat1,10,true,param1,20,,
+cgrates.org,SPP_1,,,,,supplier1,,,RPL_2,ResGroup2,,10,,,,,
+cgrates.org,SPP_1,,,,,supplier1,FLTR_DST_DE,Account2,RPL_3,ResGroup3,Stat2,10,,,,,
+cgrates.org,SPP_1,,,,,supplier1,,,,ResGroup4,Stat3,10,,,,,
 `
 	AttributesCSVContent = `
 #Tenant,ID,Contexts,FilterIDs,ActivationInterval,AttributeFilterIDs,Path,Type,Value,Blocker,Weight
@@ -267,8 +267,8 @@ cgrates.org,ALS1,con1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*string:~*
 cgrates.org,ALS1,con2;con3,,,,*req.Field2,*variable,Sub2,true,20
 `
 	ChargersCSVContent = `
-#Tenant,ID,FilterIDs,ActivationInterval,RunID,AttributeIDs,Weight
-cgrates.org,Charger1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*rated,ATTR_1001_SIMPLEAUTH,20
+#Tenant,ID,FilterIDs,ActivationInterval,RunID,AttributeIDs,Weight,Opts,Blocker
+cgrates.org,Charger1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*rated,ATTR_1001_SIMPLEAUTH,20,,
 `
 	DispatcherCSVContent = `
 #Tenant,ID,FilterIDs,ActivationInterval,Strategy,Hosts,Weight
@@ -518,5 +518,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheUCH:                       {},
 		utils.CacheEventCharges:              {},
 		utils.CacheReverseFilterIndexes:      {},
+		utils.CacheLookupTables:              {},
+		utils.CacheHTTPLookups:               {},
 	}
 }
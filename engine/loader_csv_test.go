@@ -854,6 +854,7 @@ func TestLoadActionTimings(t *testing.T) {
 				ActionsID: "SHARED",
 			},
 		},
+		CatchUp: utils.MetaOnce,
 	}
 	if !reflect.DeepEqual(atm, expected) {
 		t.Errorf("Error loading action timing:\n%+v", atm.ActionTimings[1])
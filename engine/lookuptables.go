@@ -0,0 +1,60 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// lookupTableBatchSize limits the number of entries written to DataDB in one go
+const lookupTableBatchSize = 10000
+
+// LookupTable is a tenant scoped key/value mapping (e.g. number portability)
+// the entries are stored individually so we can handle millions of keys
+type LookupTable struct {
+	Tenant  string
+	ID      string
+	Entries map[string]string
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (lt *LookupTable) TenantID() string {
+	return utils.ConcatenatedKey(lt.Tenant, lt.ID)
+}
+
+// lookupTableEntryKey returns the cache key of one entry in the table
+func lookupTableEntryKey(tenant, id, key string) string {
+	return utils.ConcatenatedKey(tenant, id, key)
+}
+
+// LookupTableWithArgDispatcher is used in replicator when setting the entries of a LookupTable
+type LookupTableWithArgDispatcher struct {
+	*LookupTable
+	Replace bool // remove the entries which are not part of the LookupTable
+	*utils.ArgDispatcher
+}
+
+// LookupTableValueArgs are the arguments used to query one key out of a lookup table
+type LookupTableValueArgs struct {
+	Tenant string
+	ID     string
+	Key    string
+	Prefix bool // match the longest prefix of Key instead of the exact key
+	*utils.ArgDispatcher
+}
@@ -0,0 +1,139 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDMLookupTable(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	if _, err := dm.GetLookupTableValue("cgrates.org", "LKT1", "1001",
+		true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if x, has := Cache.Get(utils.CacheLookupTables, "cgrates.org:LKT1:1001"); !has || x != nil {
+		t.Errorf("Expected the missing key in cache, received: %v, %v", x, has)
+	}
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "LKT1",
+		Entries: map[string]string{
+			"1001": "ACC1",
+			"10":   "ACC_PREFIX",
+		},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	// setting the table drops the cached missing key
+	if val, err := dm.GetLookupTableValue("cgrates.org", "LKT1", "1001",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if val != "ACC1" {
+		t.Errorf("Expected ACC1, received: %s", val)
+	}
+	if val, err := dm.GetLookupTablePrefixValue("cgrates.org", "LKT1", "10025",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if val != "ACC_PREFIX" {
+		t.Errorf("Expected ACC_PREFIX, received: %s", val)
+	}
+	if val, err := dm.GetLookupTablePrefixValue("cgrates.org", "LKT1", "10015",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if val != "ACC1" {
+		t.Errorf("Expected ACC1, received: %s", val)
+	}
+	if _, err := dm.GetLookupTablePrefixValue("cgrates.org", "LKT1", "2001",
+		true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	// the prefixes looked up are not cached as missing
+	if _, has := Cache.Get(utils.CacheLookupTables, "cgrates.org:LKT1:200"); has {
+		t.Error("Not expecting the missing prefix in cache")
+	}
+	if err := dm.RemoveLookupTable("cgrates.org", "LKT1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := dm.GetLookupTableValue("cgrates.org", "LKT1", "1001",
+		true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := dm.RemoveLookupTable("cgrates.org", "LKT1"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDMLookupTableReplace(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	if _, err := dm.GetLookupTable("cgrates.org", "LKT1"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT1",
+		Entries: map[string]string{"1001": "ACC1", "1002": "ACC2"},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT1",
+		Entries: map[string]string{"1003": "ACC3"},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	exp := &LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT1",
+		Entries: map[string]string{"1001": "ACC1", "1002": "ACC2", "1003": "ACC3"},
+	}
+	if rcv, err := dm.GetLookupTable("cgrates.org", "LKT1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT1",
+		Entries: map[string]string{"1002": "ACC22"},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	exp.Entries = map[string]string{"1002": "ACC22"}
+	if rcv, err := dm.GetLookupTable("cgrates.org", "LKT1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, err := dm.GetLookupTableValue("cgrates.org", "LKT1", "1001",
+		true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
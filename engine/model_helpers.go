@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -225,6 +226,16 @@ func MapTPTimings(tps []*utils.ApierTPTiming) (map[string]*utils.TPTiming, error
 		if _, found := result[tp.ID]; found {
 			return nil, fmt.Errorf("duplicate timing tag: %s", tp.ID)
 		}
+		if utils.IsCronExpr(t.StartTime) {
+			if len(t.Years) != 0 || len(t.Months) != 0 ||
+				len(t.MonthDays) != 0 || len(t.WeekDays) != 0 {
+				return nil, fmt.Errorf("cron expression for timing tag: %s cannot be combined with years, months, month days or week days", tp.ID)
+			}
+			if err := CheckCronExpr(t.StartTime); err != nil {
+				return nil, fmt.Errorf("invalid cron expression <%s> for timing tag: %s, error: %s",
+					t.StartTime, tp.ID, err.Error())
+			}
+		}
 		result[tp.ID] = t
 	}
 	return result, nil
@@ -818,8 +829,9 @@ func (tps TpActionPlans) AsMapTPActionPlans() (map[string]*utils.TPActionPlan, e
 	result := make(map[string]*utils.TPActionPlan)
 	for _, tp := range tps {
 		as := &utils.TPActionPlan{
-			TPid: tp.Tpid,
-			ID:   tp.Tag,
+			TPid:    tp.Tpid,
+			ID:      tp.Tag,
+			CatchUp: tp.CatchUp,
 		}
 		a := &utils.TPActionTiming{
 			ActionsId: tp.ActionsTag,
@@ -831,6 +843,9 @@ func (tps TpActionPlans) AsMapTPActionPlans() (map[string]*utils.TPActionPlan, e
 			result[as.ID] = as
 		} else {
 			existing.ActionPlan = append(existing.ActionPlan, a)
+			if tp.CatchUp != utils.EmptyString {
+				existing.CatchUp = tp.CatchUp
+			}
 		}
 	}
 	return result, nil
@@ -863,19 +878,24 @@ func MapTPActionTimings(s []*utils.TPActionPlan) map[string][]*utils.TPActionTim
 
 func APItoModelActionPlan(a *utils.TPActionPlan) (result TpActionPlans) {
 	if a != nil {
-		for _, ap := range a.ActionPlan {
-			result = append(result, TpActionPlan{
+		for i, ap := range a.ActionPlan {
+			mdl := TpActionPlan{
 				Tpid:       a.TPid,
 				Tag:        a.ID,
 				ActionsTag: ap.ActionsId,
 				TimingTag:  ap.TimingId,
 				Weight:     ap.Weight,
-			})
+			}
+			if i == 0 {
+				mdl.CatchUp = a.CatchUp
+			}
+			result = append(result, mdl)
 		}
 		if len(a.ActionPlan) == 0 {
 			result = append(result, TpActionPlan{
-				Tpid: a.TPid,
-				Tag:  a.ID,
+				Tpid:    a.TPid,
+				Tag:     a.ID,
+				CatchUp: a.CatchUp,
 			})
 		}
 	}
@@ -1901,7 +1921,7 @@ func (tps TPRoutes) CSVHeader() (result []string) {
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
 		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.QuarantineRules, utils.QuarantineCooldown,
 	}
 }
 
@@ -1910,6 +1930,7 @@ func (tps TPRoutes) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 	mst := make(map[string]*utils.TPRouteProfile)
 	routeMap := make(map[string]map[string]*utils.TPRoute)
 	sortingParameterMap := make(map[string]utils.StringMap)
+	quarantineRuleMap := make(map[string]utils.StringMap)
 	for _, tp := range tps {
 		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		th, found := mst[tenID]
@@ -1973,6 +1994,17 @@ func (tps TPRoutes) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 				sortingParameterMap[tenID][sortingParam] = true
 			}
 		}
+		if tp.QuarantineRules != utils.EmptyString {
+			if _, has := quarantineRuleMap[tenID]; !has {
+				quarantineRuleMap[tenID] = make(utils.StringMap)
+			}
+			for _, qRule := range strings.Split(tp.QuarantineRules, utils.INFIELD_SEP) {
+				quarantineRuleMap[tenID][qRule] = true
+			}
+		}
+		if tp.QuarantineCooldown != utils.EmptyString {
+			th.QuarantineCooldown = tp.QuarantineCooldown
+		}
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
@@ -2010,6 +2042,9 @@ func (tps TPRoutes) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 		for sortingParam := range sortingParameterMap[tntID] {
 			result[i].SortingParameters = append(result[i].SortingParameters, sortingParam)
 		}
+		for qRule := range quarantineRuleMap[tntID] {
+			result[i].QuarantineRules = append(result[i].QuarantineRules, qRule)
+		}
 		i++
 	}
 	return
@@ -2040,6 +2075,8 @@ func APItoModelTPRoutes(st *utils.TPRouteProfile) (mdls TPRoutes) {
 				}
 				mdl.SortingParameters += val
 			}
+			mdl.QuarantineRules = strings.Join(st.QuarantineRules, utils.INFIELD_SEP)
+			mdl.QuarantineCooldown = st.QuarantineCooldown
 			if st.ActivationInterval != nil {
 				if st.ActivationInterval.ActivationTime != utils.EmptyString {
 					mdl.ActivationInterval = st.ActivationInterval.ActivationTime
@@ -2101,6 +2138,17 @@ func APItoRouteProfile(tpRp *utils.TPRouteProfile, timezone string) (rp *RoutePr
 	for i, stp := range tpRp.SortingParameters {
 		rp.SortingParameters[i] = stp
 	}
+	if len(tpRp.QuarantineRules) != 0 {
+		rp.QuarantineRules = make([]string, len(tpRp.QuarantineRules))
+		for i, qRule := range tpRp.QuarantineRules {
+			rp.QuarantineRules[i] = qRule
+		}
+	}
+	if tpRp.QuarantineCooldown != utils.EmptyString {
+		if rp.QuarantineCooldown, err = utils.ParseDurationWithNanosecs(tpRp.QuarantineCooldown); err != nil {
+			return nil, err
+		}
+	}
 	for i, fli := range tpRp.FilterIDs {
 		rp.FilterIDs[i] = fli
 	}
@@ -2156,6 +2204,15 @@ func RouteProfileToAPI(rp *RouteProfile) (tpRp *utils.TPRouteProfile) {
 	for i, fli := range rp.SortingParameters {
 		tpRp.SortingParameters[i] = fli
 	}
+	if len(rp.QuarantineRules) != 0 {
+		tpRp.QuarantineRules = make([]string, len(rp.QuarantineRules))
+		for i, qRule := range rp.QuarantineRules {
+			tpRp.QuarantineRules[i] = qRule
+		}
+	}
+	if rp.QuarantineCooldown != time.Duration(0) {
+		tpRp.QuarantineCooldown = rp.QuarantineCooldown.String()
+	}
 	if rp.ActivationInterval != nil {
 		if !rp.ActivationInterval.ActivationTime.IsZero() {
 			tpRp.ActivationInterval.ActivationTime = rp.ActivationInterval.ActivationTime.Format(time.RFC3339)
@@ -2380,7 +2437,7 @@ type TPChargers []*TPCharger
 // CSVHeader return the header for csv fields as a slice of string
 func (tps TPChargers) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
-		utils.RunID, utils.AttributeIDs, utils.Weight}
+		utils.RunID, utils.AttributeIDs, utils.Weight, utils.Opts, utils.Blocker}
 }
 
 func (tps TPChargers) AsTPChargers() (result []*utils.TPChargerProfile) {
@@ -2421,6 +2478,16 @@ func (tps TPChargers) AsTPChargers() (result []*utils.TPChargerProfile) {
 		if tp.RunID != utils.EmptyString {
 			tpCPP.RunID = tp.RunID
 		}
+		if tp.Blocker {
+			tpCPP.Blocker = tp.Blocker
+		}
+		if tp.Opts != utils.EmptyString {
+			for _, opt := range strings.Split(tp.Opts, utils.INFIELD_SEP) {
+				if !utils.IsSliceMember(tpCPP.Opts, opt) {
+					tpCPP.Opts = append(tpCPP.Opts, opt)
+				}
+			}
+		}
 		if tp.AttributeIDs != utils.EmptyString {
 			if _, has := attributeMap[(&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()]; !has {
 				attributeMap[(&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()] = make(utils.StringMap)
@@ -2457,11 +2524,13 @@ func APItoModelTPCharger(tpCPP *utils.TPChargerProfile) (mdls TPChargers) {
 		}
 		if min == 0 {
 			mdl := &TPCharger{
-				Tenant: tpCPP.Tenant,
-				Tpid:   tpCPP.TPid,
-				ID:     tpCPP.ID,
-				Weight: tpCPP.Weight,
-				RunID:  tpCPP.RunID,
+				Tenant:  tpCPP.Tenant,
+				Tpid:    tpCPP.TPid,
+				ID:      tpCPP.ID,
+				Weight:  tpCPP.Weight,
+				RunID:   tpCPP.RunID,
+				Opts:    strings.Join(tpCPP.Opts, utils.INFIELD_SEP),
+				Blocker: tpCPP.Blocker,
 			}
 			if tpCPP.ActivationInterval != nil {
 				if tpCPP.ActivationInterval.ActivationTime != utils.EmptyString {
@@ -2488,6 +2557,8 @@ func APItoModelTPCharger(tpCPP *utils.TPChargerProfile) (mdls TPChargers) {
 				if i == 0 {
 					mdl.Weight = tpCPP.Weight
 					mdl.RunID = tpCPP.RunID
+					mdl.Opts = strings.Join(tpCPP.Opts, utils.INFIELD_SEP)
+					mdl.Blocker = tpCPP.Blocker
 					if tpCPP.ActivationInterval != nil {
 						if tpCPP.ActivationInterval.ActivationTime != utils.EmptyString {
 							mdl.ActivationInterval = tpCPP.ActivationInterval.ActivationTime
@@ -2535,6 +2606,7 @@ func APItoChargerProfile(tpCPP *utils.TPChargerProfile, timezone string) (cpp *C
 		ID:           tpCPP.ID,
 		Weight:       tpCPP.Weight,
 		RunID:        tpCPP.RunID,
+		Blocker:      tpCPP.Blocker,
 		FilterIDs:    make([]string, len(tpCPP.FilterIDs)),
 		AttributeIDs: make([]string, len(tpCPP.AttributeIDs)),
 	}
@@ -2544,6 +2616,16 @@ func APItoChargerProfile(tpCPP *utils.TPChargerProfile, timezone string) (cpp *C
 	for i, attribute := range tpCPP.AttributeIDs {
 		cpp.AttributeIDs[i] = attribute
 	}
+	if len(tpCPP.Opts) != 0 {
+		cpp.Opts = make(map[string]interface{}, len(tpCPP.Opts))
+		for _, opt := range tpCPP.Opts {
+			optSplt := strings.SplitN(opt, utils.InInFieldSep, 2)
+			if len(optSplt) != 2 {
+				return nil, fmt.Errorf("invalid opt <%s> for ChargerProfile <%s>", opt, tpCPP.ID)
+			}
+			cpp.Opts[optSplt[0]] = optSplt[1]
+		}
+	}
 	if tpCPP.ActivationInterval != nil {
 		if cpp.ActivationInterval, err = tpCPP.ActivationInterval.AsActivationInterval(timezone); err != nil {
 			return nil, err
@@ -2560,6 +2642,7 @@ func ChargerProfileToAPI(chargerPrf *ChargerProfile) (tpCharger *utils.TPCharger
 		ActivationInterval: new(utils.TPActivationInterval),
 		RunID:              chargerPrf.RunID,
 		AttributeIDs:       make([]string, len(chargerPrf.AttributeIDs)),
+		Blocker:            chargerPrf.Blocker,
 		Weight:             chargerPrf.Weight,
 	}
 	for i, fli := range chargerPrf.FilterIDs {
@@ -2568,6 +2651,14 @@ func ChargerProfileToAPI(chargerPrf *ChargerProfile) (tpCharger *utils.TPCharger
 	for i, fli := range chargerPrf.AttributeIDs {
 		tpCharger.AttributeIDs[i] = fli
 	}
+	if len(chargerPrf.Opts) != 0 {
+		tpCharger.Opts = make([]string, 0, len(chargerPrf.Opts))
+		for key, val := range chargerPrf.Opts {
+			tpCharger.Opts = append(tpCharger.Opts,
+				key+utils.InInFieldSep+utils.IfaceAsString(val))
+		}
+		sort.Strings(tpCharger.Opts)
+	}
 	if chargerPrf.ActivationInterval != nil {
 		if !chargerPrf.ActivationInterval.ActivationTime.IsZero() {
 			tpCharger.ActivationInterval.ActivationTime = chargerPrf.ActivationInterval.ActivationTime.Format(time.RFC3339)
@@ -37,6 +37,46 @@ func TestModelHelperCsvLoad(t *testing.T) {
 	}
 }
 
+func TestMapTPTimingsCron(t *testing.T) {
+	tps := []*utils.ApierTPTiming{{
+		TPid:      "TEST_TPID",
+		ID:        "WORK_HOURS",
+		Years:     utils.META_ANY,
+		Months:    utils.META_ANY,
+		MonthDays: utils.META_ANY,
+		WeekDays:  utils.META_ANY,
+		Time:      "*/15 9-17 * * 1-5",
+	}}
+	exp := map[string]*utils.TPTiming{
+		"WORK_HOURS": {
+			ID:        "WORK_HOURS",
+			Years:     utils.Years{},
+			Months:    utils.Months{},
+			MonthDays: utils.MonthDays{},
+			WeekDays:  utils.WeekDays{},
+			StartTime: "*/15 9-17 * * 1-5",
+		},
+	}
+	if rcv, err := MapTPTimings(tps); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	tps[0].Time = "TZ=Mars/Olympus 0 0 1 * *"
+	if _, err := MapTPTimings(tps); err == nil {
+		t.Error("Expecting error for unknown time zone")
+	}
+	tps[0].Time = "0 0 32 * *"
+	if _, err := MapTPTimings(tps); err == nil {
+		t.Error("Expecting error for invalid cron expression")
+	}
+	tps[0].Time = "0 0 1 * *"
+	tps[0].Months = "1;6"
+	if _, err := MapTPTimings(tps); err == nil {
+		t.Error("Expecting error for cron expression combined with months")
+	}
+}
+
 func TestModelHelperCsvDump(t *testing.T) {
 	tpd := TpDestination{
 		Tag:    "TEST_DEST",
@@ -356,10 +396,11 @@ func TestTPActionTriggersAsExportSlice(t *testing.T) {
 				TimingId:  "ASAP",
 				Weight:    20.0},
 		},
+		CatchUp: utils.MetaOnce,
 	}
 	expectedSlc := [][]string{
-		{"PACKAGE_10", "TOPUP_RST_10", "ASAP", "10"},
-		{"PACKAGE_10", "TOPUP_RST_5", "ASAP", "20"},
+		{"PACKAGE_10", "TOPUP_RST_10", "ASAP", "10", utils.MetaOnce},
+		{"PACKAGE_10", "TOPUP_RST_5", "ASAP", "20", ""},
 	}
 	ms := APItoModelActionPlan(ap)
 	var slc [][]string
@@ -1921,6 +1962,74 @@ func TestModelAsTPChargers(t *testing.T) {
 	}
 }
 
+func TestModelAsTPChargersOpts(t *testing.T) {
+	models := TPChargers{
+		&TPCharger{
+			Tpid:         "TP1",
+			Tenant:       "cgrates.org",
+			ID:           "Partner",
+			RunID:        "partner",
+			AttributeIDs: "*none",
+			Opts:         "*informational:true;*ratingSubject:partner",
+			Blocker:      true,
+			Weight:       20,
+		},
+		&TPCharger{
+			Tpid:   "TP1",
+			Tenant: "cgrates.org",
+			ID:     "Partner",
+			Opts:   "*requestType:*rated",
+		},
+	}
+	expTP := &utils.TPChargerProfile{
+		TPid:         "TP1",
+		Tenant:       "cgrates.org",
+		ID:           "Partner",
+		RunID:        "partner",
+		AttributeIDs: []string{"*none"},
+		Opts:         []string{"*informational:true", "*ratingSubject:partner", "*requestType:*rated"},
+		Blocker:      true,
+		Weight:       20,
+	}
+	rcv := models.AsTPChargers()
+	if !reflect.DeepEqual(expTP, rcv[0]) {
+		t.Fatalf("Expecting : %+v, received: %+v", utils.ToJSON(expTP), utils.ToJSON(rcv[0]))
+	}
+	expCP := &ChargerProfile{
+		Tenant:       "cgrates.org",
+		ID:           "Partner",
+		FilterIDs:    []string{},
+		RunID:        "partner",
+		AttributeIDs: []string{"*none"},
+		Opts: map[string]interface{}{
+			utils.OptsInformational: "true",
+			utils.OptsRatingSubject: "partner",
+			utils.OptsRequestType:   utils.META_RATED,
+		},
+		Blocker: true,
+		Weight:  20,
+	}
+	cP, err := APItoChargerProfile(rcv[0], utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expCP, cP) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(expCP), utils.ToJSON(cP))
+	}
+	if tpCP := ChargerProfileToAPI(cP); !reflect.DeepEqual(expTP.Opts, tpCP.Opts) ||
+		!tpCP.Blocker {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(expTP), utils.ToJSON(tpCP))
+	}
+	if mdls := APItoModelTPCharger(expTP); len(mdls) != 1 ||
+		mdls[0].Opts != "*informational:true;*ratingSubject:partner;*requestType:*rated" ||
+		!mdls[0].Blocker {
+		t.Errorf("Received: %+v", utils.ToJSON(mdls))
+	}
+	rcv[0].Opts = []string{"*informational"}
+	if _, err := APItoChargerProfile(rcv[0], utils.EmptyString); err == nil {
+		t.Error("Expecting error for opt without value")
+	}
+}
+
 func TestAPItoDispatcherProfile(t *testing.T) {
 	tpDPP := &utils.TPDispatcherProfile{
 		TPid:       "TP1",
@@ -2964,3 +3073,60 @@ func TestAsTPRateProfile(t *testing.T) {
 		t.Errorf("Expecting: %+v,\nReceived: %+v", utils.ToJSON(eRprf), utils.ToJSON(rcv[0]))
 	}
 }
+
+func TestTPRoutesQuarantine(t *testing.T) {
+	mdl := TPRoutes{
+		&TpRoute{
+			Tpid:               "TP",
+			Tenant:             "cgrates.org",
+			ID:                 "RoutePrf",
+			Sorting:            utils.MetaWeight,
+			RouteID:            "route1",
+			RouteStatIDs:       "Stat1",
+			QuarantineRules:    "*lt:*asr:30",
+			QuarantineCooldown: "10m",
+			Weight:             10,
+		},
+		&TpRoute{
+			Tpid:            "TP",
+			Tenant:          "cgrates.org",
+			ID:              "RoutePrf",
+			RouteID:         "route2",
+			RouteStatIDs:    "Stat2",
+			QuarantineRules: "*gt:*pdd:6s",
+		},
+	}
+	tpRp := mdl.AsTPRouteProfile()[0]
+	sort.Strings(tpRp.QuarantineRules)
+	if exp := []string{"*gt:*pdd:6s", "*lt:*asr:30"}; !reflect.DeepEqual(exp, tpRp.QuarantineRules) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, tpRp.QuarantineRules)
+	}
+	if tpRp.QuarantineCooldown != "10m" {
+		t.Errorf("Expecting: 10m, received: %+v", tpRp.QuarantineCooldown)
+	}
+	rp, err := APItoRouteProfile(tpRp, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.QuarantineCooldown != 10*time.Minute {
+		t.Errorf("Expecting: 10m, received: %+v", rp.QuarantineCooldown)
+	}
+	if !reflect.DeepEqual(tpRp.QuarantineRules, rp.QuarantineRules) {
+		t.Errorf("Expecting: %+v, received: %+v", tpRp.QuarantineRules, rp.QuarantineRules)
+	}
+	rcvTp := RouteProfileToAPI(rp)
+	if rcvTp.QuarantineCooldown != "10m0s" ||
+		!reflect.DeepEqual(tpRp.QuarantineRules, rcvTp.QuarantineRules) {
+		t.Errorf("Received: %s", utils.ToJSON(rcvTp))
+	}
+	mdls := APItoModelTPRoutes(tpRp)
+	if mdls[0].QuarantineRules != "*gt:*pdd:6s;*lt:*asr:30" ||
+		mdls[0].QuarantineCooldown != "10m" ||
+		mdls[1].QuarantineRules != utils.EmptyString {
+		t.Errorf("Received: %s", utils.ToJSON(mdls))
+	}
+	tpRp.QuarantineCooldown = "a"
+	if _, err := APItoRouteProfile(tpRp, utils.EmptyString); err == nil {
+		t.Error("Expected error for invalid cooldown")
+	}
+}
@@ -127,6 +127,7 @@ type TpActionPlan struct {
 	ActionsTag string  `index:"1" re:"\w+\s*,\s*"`
 	TimingTag  string  `index:"2" re:"\w+\s*,\s*"|\*any`
 	Weight     float64 `index:"3" re:"\d+\.?\d*"`
+	CatchUp    string  `index:"4" re:""`
 	CreatedAt  time.Time
 }
 
@@ -338,6 +339,36 @@ func (t SessionCostsSQL) TableName() string {
 	return utils.SessionCostsTBL
 }
 
+type AuditRecordSQL struct {
+	ID         int64
+	Caller     string
+	Role       string
+	RemoteAddr string
+	Method     string
+	ArgsDigest string
+	Result     string
+	CreatedAt  time.Time
+}
+
+func (t AuditRecordSQL) TableName() string {
+	return utils.AuditRecordsTBL
+}
+
+type ActionExecutionSQL struct {
+	ID           int64
+	ActionPlanID string
+	ActionsID    string
+	Timing       string
+	AccountIDs   string
+	RunTime      time.Time
+	Error        string
+	CreatedAt    time.Time
+}
+
+func (t ActionExecutionSQL) TableName() string {
+	return utils.ActionExecutionsTBL
+}
+
 type TBLVersion struct {
 	ID      uint
 	Item    string
@@ -367,6 +398,8 @@ type TpRoute struct {
 	RouteBlocker       bool    `index:"13" re:""`
 	RouteParameters    string  `index:"14" re:""`
 	Weight             float64 `index:"15" re:"\d+\.?\d*"`
+	QuarantineRules    string  `index:"16" re:""`
+	QuarantineCooldown string  `index:"17" re:""`
 	CreatedAt          time.Time
 }
 
@@ -397,6 +430,8 @@ type TPCharger struct {
 	RunID              string  `index:"4" re:""`
 	AttributeIDs       string  `index:"5" re:""`
 	Weight             float64 `index:"6" re:"\d+\.?\d*"`
+	Opts               string  `index:"7" re:""`
+	Blocker            bool    `index:"8" re:""`
 	CreatedAt          time.Time
 }
 
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
+	"github.com/gorhill/cronexpr"
 )
 
 /*
@@ -49,10 +50,37 @@ type RITiming struct {
 	tag                string // loading validation only
 }
 
+// IsCron returns true if the StartTime holds a cron expression
+func (rit *RITiming) IsCron() bool {
+	return utils.IsCronExpr(rit.StartTime)
+}
+
+// parseCron returns the cron expression together with its time zone, nil for the local one
+func parseCron(cron string) (expr *cronexpr.Expression, loc *time.Location, err error) {
+	cronStr, tz := utils.SplitCronTZ(cron)
+	if tz != utils.EmptyString {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return
+		}
+	}
+	expr, err = cronexpr.Parse(cronStr)
+	return
+}
+
+// CheckCronExpr validates the cron expression of a timing
+func CheckCronExpr(cron string) (err error) {
+	_, _, err = parseCron(cron)
+	return
+}
+
 func (rit *RITiming) CronString() string {
 	if rit.cronString != "" {
 		return rit.cronString
 	}
+	if rit.IsCron() {
+		rit.cronString, _ = utils.SplitCronTZ(rit.StartTime)
+		return rit.cronString
+	}
 	var sec, min, hour, monthday, month, weekday, year string
 	switch rit.StartTime {
 	case "":
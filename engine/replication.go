@@ -0,0 +1,962 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	replicationTaskExt       = ".rpl"
+	replicationRejectedDir   = "rejected"
+	replicationMaxRetryDelay = time.Minute
+)
+
+// ReplicationStats contains the replication metrics of the DataManager
+type ReplicationStats struct {
+	Queued         bool          // replication requests are sent over the persisted queue
+	Pending        int           // requests waiting in the queue
+	Lag            time.Duration // age of the oldest request waiting in the queue
+	Replicated     uint64        // requests replicated successfully
+	Failed         uint64        // requests rejected by the replicas
+	Retries        uint64        // retries because of network errors
+	NeedsResync    bool          // a queued request was rejected, the replicas need ResyncReplication
+	LastError      string
+	LastReplicated time.Time
+}
+
+// replicationTask is one replication request as persisted in the queue
+type replicationTask struct {
+	Seq      uint64
+	Method   string
+	Args     []byte
+	QueuedAt time.Time
+
+	args   interface{}
+	stored bool // persisted on disk, ready to be sent
+}
+
+// replicationArgs returns a new argument for each replicated API, used to decode the persisted requests
+var replicationArgs = map[string]func() interface{}{
+	utils.ReplicatorSv1SetDestination:          func() interface{} { return new(DestinationWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveDestination:       func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetReverseDestination:   func() interface{} { return new(DestinationWithArgDispatcher) },
+	utils.ReplicatorSv1SetAccount:              func() interface{} { return new(AccountWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveAccount:           func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetStatQueue:            func() interface{} { return new(StoredStatQueueWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveStatQueue:         func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetFilter:               func() interface{} { return new(FilterWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveFilter:            func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetThreshold:            func() interface{} { return new(ThresholdWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveThreshold:         func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetThresholdProfile:     func() interface{} { return new(ThresholdProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveThresholdProfile:  func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetStatQueueProfile:     func() interface{} { return new(StatQueueProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveStatQueueProfile:  func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetTiming:               func() interface{} { return new(utils.TPTimingWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveTiming:            func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetResource:             func() interface{} { return new(ResourceWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveResource:          func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetResourceProfile:      func() interface{} { return new(ResourceProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveResourceProfile:   func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetActionTriggers:       func() interface{} { return new(SetActionTriggersArgWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveActionTriggers:    func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetSharedGroup:          func() interface{} { return new(SharedGroupWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveSharedGroup:       func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetActions:              func() interface{} { return new(SetActionsArgsWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveActions:           func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetActionPlan:           func() interface{} { return new(SetActionPlanArgWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveActionPlan:        func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetAccountActionPlans:   func() interface{} { return new(SetAccountActionPlansArgWithArgDispatcher) },
+	utils.ReplicatorSv1RemAccountActionPlans:   func() interface{} { return new(RemAccountActionPlansArgsWithArgDispatcher) },
+	utils.ReplicatorSv1SetRatingPlan:           func() interface{} { return new(RatingPlanWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveRatingPlan:        func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetRatingProfile:        func() interface{} { return new(RatingProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveRatingProfile:     func() interface{} { return new(utils.StringWithApiKey) },
+	utils.ReplicatorSv1SetRouteProfile:         func() interface{} { return new(RouteProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveRouteProfile:      func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetAttributeProfile:     func() interface{} { return new(AttributeProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveAttributeProfile:  func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetChargerProfile:       func() interface{} { return new(ChargerProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveChargerProfile:    func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetDispatcherProfile:    func() interface{} { return new(DispatcherProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveDispatcherProfile: func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetDispatcherHost:       func() interface{} { return new(DispatcherHostWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveDispatcherHost:    func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetLookupTable:          func() interface{} { return new(LookupTableWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveLookupTable:       func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetLoadIDs:              func() interface{} { return new(utils.LoadIDsWithArgDispatcher) },
+	utils.ReplicatorSv1SetRateProfile:          func() interface{} { return new(RateProfileWithArgDispatcher) },
+	utils.ReplicatorSv1RemoveRateProfile:       func() interface{} { return new(utils.TenantIDWithArgDispatcher) },
+	utils.ReplicatorSv1SetIndexes:              func() interface{} { return new(utils.SetIndexesArg) },
+	utils.ReplicatorSv1RemoveIndexes:           func() interface{} { return new(utils.GetIndexesArg) },
+}
+
+// replicate sends the request to the replication connections,
+// over the queue if one is started
+func (dm *DataManager) replicate(method string, args interface{}) (err error) {
+	dm.rplMux.RLock()
+	q := dm.rplQueue
+	dm.rplMux.RUnlock()
+	if q != nil {
+		return q.enqueue(method, args)
+	}
+	err = dm.callReplication(method, args)
+	dm.recordReplication(err)
+	return
+}
+
+// callReplication calls the method on the replication connections
+func (dm *DataManager) callReplication(method string, args interface{}) (err error) {
+	var reply string
+	if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
+		method, args, &reply); err == nil {
+		return
+	}
+	err = utils.CastRPCErr(err)
+	if err == utils.ErrNotFound &&
+		!strings.HasPrefix(method, utils.ReplicatorSv1+utils.NestingSep+"Set") {
+		err = nil // already removed on the replica
+	}
+	return
+}
+
+// recordReplication updates the replication metrics with the result of one request
+func (dm *DataManager) recordReplication(err error) {
+	dm.rplStatsMux.Lock()
+	if err != nil {
+		dm.rplStats.Failed++
+		dm.rplStats.LastError = err.Error()
+	} else {
+		dm.rplStats.Replicated++
+		dm.rplStats.LastReplicated = time.Now()
+	}
+	dm.rplStatsMux.Unlock()
+}
+
+// GetReplicationStats returns the replication metrics
+func (dm *DataManager) GetReplicationStats() (rs *ReplicationStats) {
+	dm.rplStatsMux.Lock()
+	stats := dm.rplStats
+	dm.rplStatsMux.Unlock()
+	rs = &stats
+	dm.rplMux.RLock()
+	q := dm.rplQueue
+	dm.rplMux.RUnlock()
+	if q == nil {
+		return
+	}
+	rs.Queued = true
+	q.Lock()
+	if rs.Pending = len(q.tasks); rs.Pending != 0 { // also counts the ones still being persisted
+		rs.Lag = time.Since(q.tasks[0].QueuedAt)
+	}
+	q.Unlock()
+	return
+}
+
+// StartReplicationQueue makes the replication asynchronous, persisting the requests
+// in dirPath until the replicas accept them. Requests left from a previous run are resent first.
+func (dm *DataManager) StartReplicationQueue(dirPath string, retryInterval time.Duration) (err error) {
+	dm.rplMux.Lock()
+	defer dm.rplMux.Unlock()
+	if dm.rplQueue != nil {
+		dm.rplQueue.close()
+	}
+	var q *replicationQueue
+	if q, err = newReplicationQueue(dm, dirPath, retryInterval); err != nil {
+		dm.rplQueue = nil
+		return
+	}
+	dm.rplQueue = q
+	go q.loop()
+	return
+}
+
+// StopReplicationQueue stops sending the queued requests, the pending ones remain on disk
+func (dm *DataManager) StopReplicationQueue() {
+	dm.rplMux.Lock()
+	if dm.rplQueue != nil {
+		dm.rplQueue.close()
+		dm.rplQueue = nil
+	}
+	dm.rplMux.Unlock()
+}
+
+func newReplicationQueue(dm *DataManager, dirPath string, retryInterval time.Duration) (q *replicationQueue, err error) {
+	if err = os.MkdirAll(dirPath, 0755); err != nil {
+		return
+	}
+	q = &replicationQueue{
+		dm:         dm,
+		dirPath:    dirPath,
+		retryIntvl: retryInterval,
+		ms:         NewCodecMsgpackMarshaler(),
+		wakeup:     make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	if q.retryIntvl <= 0 {
+		q.retryIntvl = time.Second
+	}
+	err = q.load()
+	return
+}
+
+// replicationQueue sends the replication requests in the order they were made,
+// so the updates of the same item reach the replicas in order
+type replicationQueue struct {
+	sync.Mutex
+	dm         *DataManager
+	dirPath    string
+	retryIntvl time.Duration
+	ms         Marshaler
+	tasks      []*replicationTask
+	seq        uint64
+	wakeup     chan struct{}
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// load reads the requests persisted by a previous run
+func (q *replicationQueue) load() (err error) {
+	var files []os.FileInfo
+	if files, err = ioutil.ReadDir(q.dirPath); err != nil { // sorted by name
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() ||
+			!strings.HasSuffix(file.Name(), replicationTaskExt) {
+			continue
+		}
+		seq, errSeq := strconv.ParseUint(strings.TrimSuffix(file.Name(), replicationTaskExt), 10, 64)
+		if errSeq != nil {
+			continue
+		}
+		if seq > q.seq {
+			q.seq = seq
+		}
+		task, errRead := q.readTask(path.Join(q.dirPath, file.Name()))
+		if errRead != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> ignoring replication request <%s>, error: %s",
+				utils.DataManager, file.Name(), errRead.Error()))
+			continue
+		}
+		task.stored = true
+		q.tasks = append(q.tasks, task)
+	}
+	if len(q.tasks) != 0 {
+		utils.Logger.Info(fmt.Sprintf("<%s> resuming %d queued replication requests",
+			utils.DataManager, len(q.tasks)))
+	}
+	return
+}
+
+func (q *replicationQueue) readTask(fPath string) (task *replicationTask, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(fPath); err != nil {
+		return
+	}
+	task = new(replicationTask)
+	if err = q.ms.Unmarshal(data, task); err != nil {
+		return
+	}
+	newArgs, has := replicationArgs[task.Method]
+	if !has {
+		return nil, utils.ErrPrefixNotErrNotImplemented(task.Method)
+	}
+	task.args = newArgs()
+	err = q.ms.Unmarshal(task.Args, task.args)
+	return
+}
+
+func (q *replicationQueue) taskPath(seq uint64) string {
+	return path.Join(q.dirPath, fmt.Sprintf("%020d%s", seq, replicationTaskExt))
+}
+
+// enqueue persists the request and wakes up the sender.
+// The place in the queue is reserved first so the file is written and synced outside the lock.
+func (q *replicationQueue) enqueue(method string, args interface{}) (err error) {
+	task := &replicationTask{
+		Method:   method,
+		QueuedAt: time.Now(),
+		args:     args,
+	}
+	if task.Args, err = q.ms.Marshal(args); err != nil {
+		return
+	}
+	q.Lock()
+	q.seq++
+	task.Seq = q.seq
+	q.tasks = append(q.tasks, task)
+	q.Unlock()
+	if err = q.writeTask(task); err != nil {
+		q.Lock()
+		for i, t := range q.tasks {
+			if t == task {
+				q.tasks = append(q.tasks[:i], q.tasks[i+1:]...)
+				break
+			}
+		}
+		q.Unlock()
+	} else {
+		q.Lock()
+		task.stored = true
+		q.Unlock()
+	}
+	select { // also when failed, the next request may be waiting for this one
+	case q.wakeup <- struct{}{}:
+	default:
+	}
+	return
+}
+
+// writeTask persists the request atomically
+func (q *replicationQueue) writeTask(task *replicationTask) (err error) {
+	var data []byte
+	if data, err = q.ms.Marshal(task); err != nil {
+		return
+	}
+	fPath := q.taskPath(task.Seq)
+	tmpPath := fPath + utils.TmpSuffix
+	var f *os.File
+	if f, err = os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	return os.Rename(tmpPath, fPath)
+}
+
+// loop sends the queued requests one by one, retrying on network errors
+func (q *replicationQueue) loop() {
+	defer close(q.done)
+	maxDelay := replicationMaxRetryDelay
+	if q.retryIntvl > maxDelay {
+		maxDelay = q.retryIntvl
+	}
+	fib := utils.Fib()
+	var delay time.Duration
+	for {
+		var task *replicationTask
+		q.Lock()
+		if len(q.tasks) != 0 && q.tasks[0].stored {
+			task = q.tasks[0]
+		}
+		q.Unlock()
+		if task == nil {
+			select {
+			case <-q.stop:
+				return
+			case <-q.wakeup:
+				continue
+			}
+		}
+		err := q.dm.callReplication(task.Method, task.args)
+		if utils.IsNetworkError(err) {
+			q.dm.rplStatsMux.Lock()
+			q.dm.rplStats.Retries++
+			q.dm.rplStats.LastError = err.Error()
+			q.dm.rplStatsMux.Unlock()
+			if delay < maxDelay { // stop growing once capped so the multiplication cannot overflow
+				if delay = time.Duration(fib()) * q.retryIntvl; delay > maxDelay {
+					delay = maxDelay
+				}
+			}
+			select {
+			case <-q.stop:
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+		fib = utils.Fib()
+		delay = 0
+		q.dm.recordReplication(err)
+		if err != nil {
+			q.reject(task, err)
+		} else if errRm := os.Remove(q.taskPath(task.Seq)); errRm != nil && !os.IsNotExist(errRm) {
+			utils.Logger.Warning(fmt.Sprintf("<%s> cannot remove replication request %d, error: %s",
+				utils.DataManager, task.Seq, errRm.Error()))
+		}
+		q.Lock()
+		q.tasks = q.tasks[1:]
+		q.Unlock()
+	}
+}
+
+// reject moves the request refused by the replicas out of the queue, keeping it for inspection,
+// and flags the replicas as out of sync until ResyncReplication repairs them
+func (q *replicationQueue) reject(task *replicationTask, err error) {
+	q.dm.rplStatsMux.Lock()
+	q.dm.rplStats.NeedsResync = true
+	q.dm.rplStatsMux.Unlock()
+	utils.Logger.Err(fmt.Sprintf("<%s> replication request <%s> rejected, error: %s, run ResyncReplication to repair the replicas",
+		utils.DataManager, task.Method, err.Error()))
+	rjctDir := path.Join(q.dirPath, replicationRejectedDir)
+	fPath := q.taskPath(task.Seq)
+	errMv := os.MkdirAll(rjctDir, 0755)
+	if errMv == nil {
+		errMv = os.Rename(fPath, path.Join(rjctDir, path.Base(fPath)))
+	}
+	if errMv != nil && !os.IsNotExist(errMv) {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cannot move rejected replication request %d, error: %s",
+			utils.DataManager, task.Seq, errMv.Error()))
+		os.Remove(fPath)
+	}
+}
+
+func (q *replicationQueue) close() {
+	close(q.stop)
+	<-q.done
+}
+
+// replicationItem describes how one item type is compared and repaired on the replicas
+type replicationItem struct {
+	prefix    string
+	setMethod string
+	remMethod string
+	get       func(dm *DataManager, id string) (interface{}, error)
+	setArgs   func(dm *DataManager, id string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error)
+	remArgs   func(id string, argDsp *utils.ArgDispatcher) interface{}
+}
+
+func rplStringArgs(id string, argDsp *utils.ArgDispatcher) interface{} {
+	return &utils.StringWithApiKey{
+		Arg:           id,
+		TenantArg:     utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant},
+		ArgDispatcher: argDsp,
+	}
+}
+
+func rplTenantIDArgs(id string, argDsp *utils.ArgDispatcher) interface{} {
+	return &utils.TenantIDWithArgDispatcher{
+		TenantID:      utils.NewTenantID(id),
+		ArgDispatcher: argDsp,
+	}
+}
+
+func rplDefaultTenant() utils.TenantArg {
+	return utils.TenantArg{Tenant: config.CgrConfig().GeneralCfg().DefaultTenant}
+}
+
+// replicationItems are the item types which can be compared and repaired with ResyncReplication
+var replicationItems = map[string]*replicationItem{
+	utils.MetaAccounts: {
+		prefix:    utils.ACCOUNT_PREFIX,
+		setMethod: utils.ReplicatorSv1SetAccount,
+		remMethod: utils.ReplicatorSv1RemoveAccount,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetAccountDrv(id)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &AccountWithArgDispatcher{Account: itm.(*Account), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaDestinations: {
+		prefix:    utils.DESTINATION_PREFIX,
+		setMethod: utils.ReplicatorSv1SetDestination,
+		remMethod: utils.ReplicatorSv1RemoveDestination,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetDestinationDrv(id, true, utils.NonTransactional)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &DestinationWithArgDispatcher{Destination: itm.(*Destination),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaRatingPlans: {
+		prefix:    utils.RATING_PLAN_PREFIX,
+		setMethod: utils.ReplicatorSv1SetRatingPlan,
+		remMethod: utils.ReplicatorSv1RemoveRatingPlan,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetRatingPlanDrv(id)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &RatingPlanWithArgDispatcher{RatingPlan: itm.(*RatingPlan),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaRatingProfiles: {
+		prefix:    utils.RATING_PROFILE_PREFIX,
+		setMethod: utils.ReplicatorSv1SetRatingProfile,
+		remMethod: utils.ReplicatorSv1RemoveRatingProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetRatingProfileDrv(id)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &RatingProfileWithArgDispatcher{RatingProfile: itm.(*RatingProfile),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaActions: {
+		prefix:    utils.ACTION_PREFIX,
+		setMethod: utils.ReplicatorSv1SetActions,
+		remMethod: utils.ReplicatorSv1RemoveActions,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetActionsDrv(id)
+		},
+		setArgs: func(_ *DataManager, id string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &SetActionsArgsWithArgDispatcher{Key: id, Acs: itm.(Actions),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaActionPlans: {
+		prefix:    utils.ACTION_PLAN_PREFIX,
+		setMethod: utils.ReplicatorSv1SetActionPlan,
+		remMethod: utils.ReplicatorSv1RemoveActionPlan,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetActionPlanDrv(id, true, utils.NonTransactional)
+		},
+		setArgs: func(_ *DataManager, id string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &SetActionPlanArgWithArgDispatcher{Key: id, Ats: itm.(*ActionPlan), Overwrite: true,
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaAccountActionPlans: {
+		prefix:    utils.AccountActionPlansPrefix,
+		setMethod: utils.ReplicatorSv1SetAccountActionPlans,
+		remMethod: utils.ReplicatorSv1RemAccountActionPlans,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			aplIDs, err := dm.dataDB.GetAccountActionPlansDrv(id, true, utils.NonTransactional)
+			if err != nil {
+				return nil, err
+			}
+			return &SetAccountActionPlansArgWithArgDispatcher{AcntID: id, AplIDs: aplIDs, Overwrite: true}, nil
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			args := *itm.(*SetAccountActionPlansArgWithArgDispatcher)
+			args.TenantArg = rplDefaultTenant()
+			args.ArgDispatcher = argDsp
+			return &args, nil
+		},
+		remArgs: func(id string, argDsp *utils.ArgDispatcher) interface{} {
+			return &RemAccountActionPlansArgsWithArgDispatcher{AcntID: id,
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}
+		},
+	},
+	utils.MetaActionTriggers: {
+		prefix:    utils.ACTION_TRIGGER_PREFIX,
+		setMethod: utils.ReplicatorSv1SetActionTriggers,
+		remMethod: utils.ReplicatorSv1RemoveActionTriggers,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetActionTriggersDrv(id)
+		},
+		setArgs: func(_ *DataManager, id string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &SetActionTriggersArgWithArgDispatcher{Key: id, Attrs: itm.(ActionTriggers),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaSharedGroups: {
+		prefix:    utils.SHARED_GROUP_PREFIX,
+		setMethod: utils.ReplicatorSv1SetSharedGroup,
+		remMethod: utils.ReplicatorSv1RemoveSharedGroup,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetSharedGroupDrv(id)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &SharedGroupWithArgDispatcher{SharedGroup: itm.(*SharedGroup),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaTimings: {
+		prefix:    utils.TimingsPrefix,
+		setMethod: utils.ReplicatorSv1SetTiming,
+		remMethod: utils.ReplicatorSv1RemoveTiming,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			return dm.dataDB.GetTimingDrv(id)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &utils.TPTimingWithArgDispatcher{TPTiming: itm.(*utils.TPTiming),
+				TenantArg: rplDefaultTenant(), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplStringArgs,
+	},
+	utils.MetaResourceProfile: {
+		prefix:    utils.ResourceProfilesPrefix,
+		setMethod: utils.ReplicatorSv1SetResourceProfile,
+		remMethod: utils.ReplicatorSv1RemoveResourceProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetResourceProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &ResourceProfileWithArgDispatcher{ResourceProfile: itm.(*ResourceProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaResources: {
+		prefix:    utils.ResourcesPrefix,
+		setMethod: utils.ReplicatorSv1SetResource,
+		remMethod: utils.ReplicatorSv1RemoveResource,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetResourceDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &ResourceWithArgDispatcher{Resource: itm.(*Resource), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaStatQueueProfiles: {
+		prefix:    utils.StatQueueProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetStatQueueProfile,
+		remMethod: utils.ReplicatorSv1RemoveStatQueueProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetStatQueueProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &StatQueueProfileWithArgDispatcher{StatQueueProfile: itm.(*StatQueueProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaStatQueues: {
+		prefix:    utils.StatQueuePrefix,
+		setMethod: utils.ReplicatorSv1SetStatQueue,
+		remMethod: utils.ReplicatorSv1RemoveStatQueue,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetStatQueueDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(dm *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			ssq, err := NewStoredStatQueue(itm.(*StatQueue), dm.ms)
+			if err != nil {
+				return nil, err
+			}
+			return &StoredStatQueueWithArgDispatcher{StoredStatQueue: ssq, ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaThresholdProfiles: {
+		prefix:    utils.ThresholdProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetThresholdProfile,
+		remMethod: utils.ReplicatorSv1RemoveThresholdProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetThresholdProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &ThresholdProfileWithArgDispatcher{ThresholdProfile: itm.(*ThresholdProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaThresholds: {
+		prefix:    utils.ThresholdPrefix,
+		setMethod: utils.ReplicatorSv1SetThreshold,
+		remMethod: utils.ReplicatorSv1RemoveThreshold,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetThresholdDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &ThresholdWithArgDispatcher{Threshold: itm.(*Threshold), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaFilters: {
+		prefix:    utils.FilterPrefix,
+		setMethod: utils.ReplicatorSv1SetFilter,
+		remMethod: utils.ReplicatorSv1RemoveFilter,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetFilterDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &FilterWithArgDispatcher{Filter: itm.(*Filter), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaRouteProfiles: {
+		prefix:    utils.RouteProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetRouteProfile,
+		remMethod: utils.ReplicatorSv1RemoveRouteProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetRouteProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &RouteProfileWithArgDispatcher{RouteProfile: itm.(*RouteProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaAttributeProfiles: {
+		prefix:    utils.AttributeProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetAttributeProfile,
+		remMethod: utils.ReplicatorSv1RemoveAttributeProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetAttributeProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &AttributeProfileWithArgDispatcher{AttributeProfile: itm.(*AttributeProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaChargerProfiles: {
+		prefix:    utils.ChargerProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetChargerProfile,
+		remMethod: utils.ReplicatorSv1RemoveChargerProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetChargerProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &ChargerProfileWithArgDispatcher{ChargerProfile: itm.(*ChargerProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaDispatcherProfiles: {
+		prefix:    utils.DispatcherProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetDispatcherProfile,
+		remMethod: utils.ReplicatorSv1RemoveDispatcherProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetDispatcherProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &DispatcherProfileWithArgDispatcher{DispatcherProfile: itm.(*DispatcherProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaDispatcherHosts: {
+		prefix:    utils.DispatcherHostPrefix,
+		setMethod: utils.ReplicatorSv1SetDispatcherHost,
+		remMethod: utils.ReplicatorSv1RemoveDispatcherHost,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetDispatcherHostDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &DispatcherHostWithArgDispatcher{DispatcherHost: itm.(*DispatcherHost), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+	utils.MetaRateProfiles: {
+		prefix:    utils.RateProfilePrefix,
+		setMethod: utils.ReplicatorSv1SetRateProfile,
+		remMethod: utils.ReplicatorSv1RemoveRateProfile,
+		get: func(dm *DataManager, id string) (interface{}, error) {
+			tntID := utils.NewTenantID(id)
+			return dm.dataDB.GetRateProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		setArgs: func(_ *DataManager, _ string, itm interface{}, argDsp *utils.ArgDispatcher) (interface{}, error) {
+			return &RateProfileWithArgDispatcher{RateProfile: itm.(*RateProfile), ArgDispatcher: argDsp}, nil
+		},
+		remArgs: rplTenantIDArgs,
+	},
+}
+
+// replicationChecksum returns the checksum of one item, independent of the DataDB type
+func replicationChecksum(itm interface{}) (sum string, err error) {
+	if sq, isSQ := itm.(*StatQueue); isSQ {
+		sq.RLock()
+		defer sq.RUnlock()
+	}
+	var data []byte
+	if data, err = json.Marshal(itm); err != nil {
+		return
+	}
+	return fmt.Sprintf("%x", sha1.Sum(data)), nil
+}
+
+// GetReplicationChecksums returns the checksums of all the items of one type, indexed on item ID
+func (dm *DataManager) GetReplicationChecksums(itemType string) (sums map[string]string, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	rplItm, has := replicationItems[itemType]
+	if !has {
+		return nil, utils.ErrPrefixNotErrNotImplemented(itemType)
+	}
+	var keys []string
+	if keys, err = dm.dataDB.GetKeysForPrefix(rplItm.prefix); err != nil {
+		return
+	}
+	sums = make(map[string]string)
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, rplItm.prefix)
+		var itm interface{}
+		if itm, err = rplItm.get(dm, id); err != nil {
+			if err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			}
+			return
+		}
+		if sums[id], err = replicationChecksum(itm); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ArgsReplicationResync are the arguments of ResyncReplication
+type ArgsReplicationResync struct {
+	ItemTypes []string // item types to compare, all the replicated ones if empty
+	DryRun    bool     // only report the differences without repairing them
+	utils.TenantArg
+	*utils.ArgDispatcher
+}
+
+// ReplicationDiff lists the item IDs found different on one replica
+type ReplicationDiff struct {
+	Missing   []string // present only in the local DataDB
+	Different []string // present in both but with different content
+	Extra     []string // present only on the replica
+}
+
+// ResyncReplication compares the checksums of the items with each replication connection
+// and, if not DryRun, repairs the differences. The reply is indexed on connection ID and item type.
+func (dm *DataManager) ResyncReplication(args *ArgsReplicationResync) (report map[string]map[string]*ReplicationDiff, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	rplConns := config.CgrConfig().DataDbCfg().RplConns
+	if len(rplConns) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing("replication_conns")
+	}
+	itmTypes := args.ItemTypes
+	if len(itmTypes) == 0 {
+		for itmType := range replicationItems {
+			if itm, has := config.CgrConfig().DataDbCfg().Items[itmType]; has && itm.Replicate {
+				itmTypes = append(itmTypes, itmType)
+			}
+		}
+		sort.Strings(itmTypes)
+	}
+	report = make(map[string]map[string]*ReplicationDiff)
+	for _, connID := range rplConns {
+		report[connID] = make(map[string]*ReplicationDiff)
+		for _, itmType := range itmTypes {
+			if report[connID][itmType], err = dm.resyncItemType(connID, itmType, args.DryRun); err != nil {
+				return nil, utils.ErrPrefix(err, itmType)
+			}
+		}
+	}
+	if !args.DryRun && len(args.ItemTypes) == 0 { // all the replicated items are in sync now
+		dm.rplStatsMux.Lock()
+		dm.rplStats.NeedsResync = false
+		dm.rplStatsMux.Unlock()
+	}
+	return
+}
+
+// resyncItemType compares and repairs one item type on one replication connection
+func (dm *DataManager) resyncItemType(connID, itmType string, dryRun bool) (diff *ReplicationDiff, err error) {
+	rplItm, has := replicationItems[itmType]
+	if !has {
+		return nil, utils.ErrPrefixNotErrNotImplemented(itmType)
+	}
+	var locSums map[string]string
+	if locSums, err = dm.GetReplicationChecksums(itmType); err != nil {
+		return
+	}
+	var rmtSums map[string]string
+	itmOpt := config.CgrConfig().DataDbCfg().Items[itmType]
+	argDsp := new(utils.ArgDispatcher)
+	if itmOpt != nil {
+		argDsp.APIKey = utils.StringPointer(itmOpt.APIKey)
+		argDsp.RouteID = utils.StringPointer(itmOpt.RouteID)
+	}
+	if err = dm.connMgr.Call([]string{connID}, nil, utils.ReplicatorSv1GetChecksums,
+		&utils.StringWithApiKey{
+			Arg:           itmType,
+			TenantArg:     rplDefaultTenant(),
+			ArgDispatcher: argDsp,
+		}, &rmtSums); err != nil {
+		return
+	}
+	diff = new(ReplicationDiff)
+	for id, sum := range locSums {
+		if rmtSum, has := rmtSums[id]; !has {
+			diff.Missing = append(diff.Missing, id)
+		} else if rmtSum != sum {
+			diff.Different = append(diff.Different, id)
+		}
+	}
+	for id := range rmtSums {
+		if _, has := locSums[id]; !has {
+			diff.Extra = append(diff.Extra, id)
+		}
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Different)
+	sort.Strings(diff.Extra)
+	if dryRun {
+		return
+	}
+	var reply string
+	for _, ids := range [][]string{diff.Missing, diff.Different} {
+		for _, id := range ids {
+			var itm, setArgs interface{}
+			if itm, err = rplItm.get(dm, id); err != nil {
+				if err == utils.ErrNotFound { // removed in the meantime
+					err = nil
+					continue
+				}
+				return
+			}
+			if setArgs, err = rplItm.setArgs(dm, id, itm, argDsp); err != nil {
+				return
+			}
+			if err = dm.connMgr.Call([]string{connID}, nil, rplItm.setMethod,
+				setArgs, &reply); err != nil {
+				return
+			}
+		}
+	}
+	for _, id := range diff.Extra {
+		if err = dm.connMgr.Call([]string{connID}, nil, rplItm.remMethod,
+			rplItm.remArgs(id, argDsp), &reply); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() {
+			return
+		}
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,248 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// testReplica mocks the ReplicatorSv1 of a replica using the DataDB of dm
+type testReplica struct {
+	sync.Mutex
+	dm      *DataManager
+	down    bool
+	methods []string
+}
+
+func (rpl *testReplica) Call(serviceMethod string, args interface{}, reply interface{}) (err error) {
+	rpl.Lock()
+	defer rpl.Unlock()
+	if rpl.down {
+		return utils.ErrDisconnected
+	}
+	rpl.methods = append(rpl.methods, serviceMethod)
+	switch serviceMethod {
+	case utils.ReplicatorSv1GetChecksums:
+		var sums map[string]string
+		if sums, err = rpl.dm.GetReplicationChecksums(args.(*utils.StringWithApiKey).Arg); err != nil {
+			return
+		}
+		*reply.(*map[string]string) = sums
+		return
+	case utils.ReplicatorSv1SetTiming:
+		err = rpl.dm.DataDB().SetTimingDrv(args.(*utils.TPTimingWithArgDispatcher).TPTiming)
+	case utils.ReplicatorSv1RemoveTiming:
+		err = rpl.dm.DataDB().RemoveTimingDrv(args.(*utils.StringWithApiKey).Arg)
+	default:
+		return utils.ErrNotImplemented
+	}
+	if err == nil {
+		*reply.(*string) = utils.OK
+	}
+	return
+}
+
+func (rpl *testReplica) setDown(down bool) {
+	rpl.Lock()
+	rpl.down = down
+	rpl.Unlock()
+}
+
+func newTestReplicationDMs(connID string) (src *DataManager, rpl *testReplica) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.DataDbCfg().RplConns = []string{connID}
+	cfg.DataDbCfg().Items[utils.MetaTimings].Replicate = true
+	config.SetCgrConfig(cfg)
+	rpl = &testReplica{
+		dm: NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil),
+	}
+	rplChan := make(chan rpcclient.ClientConnector, 1)
+	rplChan <- rpl
+	src = NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(),
+		NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{connID: rplChan}))
+	return
+}
+
+func TestReplicationQueue(t *testing.T) {
+	defer func() {
+		cfg, _ := config.NewDefaultCGRConfig()
+		config.SetCgrConfig(cfg)
+	}()
+	dir, err := ioutil.TempDir("", "replication_queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dm, rpl := newTestReplicationDMs("*rplQueueTest")
+	rpl.setDown(true)
+	if err = dm.StartReplicationQueue(dir, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	tmg := &utils.TPTiming{ID: "TMG1", Years: utils.Years{2020}, StartTime: "00:00:00"}
+	if err = dm.SetTiming(tmg); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.RemoveTiming("TMG2", utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if rs := dm.GetReplicationStats(); !rs.Queued || rs.Pending != 2 ||
+		rs.Retries == 0 || rs.Replicated != 0 || rs.Lag <= 0 {
+		t.Errorf("unexpected stats: %s", utils.ToJSON(rs))
+	}
+	dm.StopReplicationQueue()
+	if files, err := ioutil.ReadDir(dir); err != nil {
+		t.Error(err)
+	} else if len(files) != 2 {
+		t.Errorf("expected 2 queued requests on disk, received: %d", len(files))
+	}
+
+	// the requests survive the restart and are sent in order
+	rpl.setDown(false)
+	if err = dm.StartReplicationQueue(dir, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if dm.GetReplicationStats().Pending == 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	dm.StopReplicationQueue()
+	rs := dm.GetReplicationStats()
+	if rs.Queued || rs.Pending != 0 || rs.Replicated != 2 || rs.Failed != 0 {
+		t.Errorf("unexpected stats: %s", utils.ToJSON(rs))
+	}
+	if exp := []string{utils.ReplicatorSv1SetTiming, utils.ReplicatorSv1RemoveTiming}; !reflect.DeepEqual(exp, rpl.methods) {
+		t.Errorf("expected: %v, received: %v", exp, rpl.methods)
+	}
+	if rcv, err := rpl.dm.DataDB().GetTimingDrv(tmg.ID); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(tmg, rcv) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(tmg), utils.ToJSON(rcv))
+	}
+	if files, err := ioutil.ReadDir(dir); err != nil {
+		t.Error(err)
+	} else if len(files) != 0 {
+		t.Errorf("expected empty queue, received: %d files", len(files))
+	}
+}
+
+func TestReplicationQueueRejected(t *testing.T) {
+	defer func() {
+		cfg, _ := config.NewDefaultCGRConfig()
+		config.SetCgrConfig(cfg)
+	}()
+	dir, err := ioutil.TempDir("", "replication_queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dm, _ := newTestReplicationDMs("*rplQueueRejectTest")
+	if err = dm.StartReplicationQueue(dir, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	// not implemented by the replica
+	if err = dm.replicate(utils.ReplicatorSv1RemoveDestination,
+		&utils.StringWithApiKey{Arg: "DST1"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if dm.GetReplicationStats().Pending == 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	dm.StopReplicationQueue()
+	if rs := dm.GetReplicationStats(); rs.Pending != 0 || rs.Failed != 1 || !rs.NeedsResync {
+		t.Errorf("unexpected stats: %s", utils.ToJSON(rs))
+	}
+	if files, err := ioutil.ReadDir(path.Join(dir, replicationRejectedDir)); err != nil {
+		t.Error(err)
+	} else if len(files) != 1 {
+		t.Errorf("expected 1 rejected request, received: %d", len(files))
+	}
+	if _, err = dm.ResyncReplication(new(ArgsReplicationResync)); err != nil {
+		t.Fatal(err)
+	}
+	if rs := dm.GetReplicationStats(); rs.NeedsResync {
+		t.Errorf("unexpected stats: %s", utils.ToJSON(rs))
+	}
+}
+
+func TestReplicationResync(t *testing.T) {
+	defer func() {
+		cfg, _ := config.NewDefaultCGRConfig()
+		config.SetCgrConfig(cfg)
+	}()
+	dm, rpl := newTestReplicationDMs("*rplResyncTest")
+	for _, tmg := range []*utils.TPTiming{
+		{ID: "TMG_SAME", StartTime: "00:00:00"},
+		{ID: "TMG_DIFF", StartTime: "00:00:00"},
+		{ID: "TMG_MISSING", StartTime: "00:00:00"},
+	} {
+		if err := dm.DataDB().SetTimingDrv(tmg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tmg := range []*utils.TPTiming{
+		{ID: "TMG_SAME", StartTime: "00:00:00"},
+		{ID: "TMG_DIFF", StartTime: "12:00:00"},
+		{ID: "TMG_EXTRA", StartTime: "00:00:00"},
+	} {
+		if err := rpl.dm.DataDB().SetTimingDrv(tmg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exp := map[string]map[string]*ReplicationDiff{
+		"*rplResyncTest": {
+			utils.MetaTimings: {
+				Missing:   []string{"TMG_MISSING"},
+				Different: []string{"TMG_DIFF"},
+				Extra:     []string{"TMG_EXTRA"},
+			},
+		},
+	}
+	if rcv, err := dm.ResyncReplication(&ArgsReplicationResync{DryRun: true}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, err := dm.ResyncReplication(&ArgsReplicationResync{ItemTypes: []string{utils.MetaTimings}}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.ResyncReplication(&ArgsReplicationResync{DryRun: true}); err != nil {
+		t.Fatal(err)
+	} else if diff := rcv["*rplResyncTest"][utils.MetaTimings]; len(diff.Missing) != 0 ||
+		len(diff.Different) != 0 || len(diff.Extra) != 0 {
+		t.Errorf("replica not in sync after resync: %s", utils.ToJSON(diff))
+	}
+	if _, err := dm.ResyncReplication(&ArgsReplicationResync{ItemTypes: []string{"*unknown"}}); err == nil {
+		t.Error("expected error for unsupported item type")
+	}
+}
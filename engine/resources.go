@@ -273,7 +273,7 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 		return "", utils.ErrResourceUnavailable
 	}
 	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
-	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+	if _, errGuard := guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
 		// Simulate resource usage
 		for _, r := range rs {
 			r.removeExpiredUnits()
@@ -303,7 +303,9 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 		}
 		err = rs.recordUsage(ru)
 		return
-	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...); errGuard != nil && err == nil {
+		err = errGuard
+	}
 	return
 }
 
@@ -487,7 +489,7 @@ func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent,
 	}
 	evNm := utils.MapStorage{utils.MetaReq: ev.Event}
 	lockIDs := utils.PrefixSliceItems(rs.IDs(), utils.ResourcesPrefix)
-	guardian.Guardian.Guard(func() (gIface interface{}, gErr error) {
+	if _, errGuard := guardian.Guardian.Guard(func() (gIface interface{}, gErr error) {
 		for resName := range rIDs {
 			var rPrf *ResourceProfile
 			if rPrf, err = rS.dm.GetResourceProfile(ev.Tenant, resName,
@@ -525,7 +527,9 @@ func (rS *ResourceService) matchingResourcesForEvent(ev *utils.CGREvent,
 			matchingResources[rPrf.ID] = r
 		}
 		return
-	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...); errGuard != nil && err == nil {
+		err = errGuard
+	}
 	if err != nil {
 		if isCached {
 			if errCh := Cache.Remove(utils.CacheEventResources, evUUID,
@@ -0,0 +1,143 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// scoreParam is one term of the *multi score formula
+type scoreParam struct {
+	field  string  // key within SortingData (ie: Cost, Weight, ResourceUsage, *acd)
+	weight float64 // positive when higher values are better, negative otherwise
+}
+
+// newScoreParams parses the SortingParameters in the form field:weight
+func newScoreParams(params []string) (sParams []*scoreParam, err error) {
+	if len(params) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing(utils.SortingParameters)
+	}
+	sParams = make([]*scoreParam, len(params))
+	for i, param := range params {
+		idx := strings.LastIndex(param, utils.CONCATENATED_KEY_SEP)
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaMulti, param)
+		}
+		var weight float64
+		if weight, err = strconv.ParseFloat(param[idx+1:], 64); err != nil ||
+			weight == 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaMulti, param)
+		}
+		sParams[i] = &scoreParam{field: param[:idx], weight: weight}
+	}
+	return
+}
+
+// scoreValue returns the value of the field from SortingData,
+// the metrics not available in stats are considered missing
+func scoreValue(sRoute *SortedRoute, field string) (val float64, has bool) {
+	var iface interface{}
+	if iface, has = sRoute.SortingData[field]; !has {
+		return
+	}
+	var err error
+	if val, err = utils.IfaceAsFloat64(iface); err != nil {
+		return 0, false
+	}
+	switch field {
+	case utils.Cost, utils.Weight, utils.ResourceUsage:
+	default:
+		has = val != STATS_NA
+	}
+	return
+}
+
+// computeScores populates the Score within SortingData of each route
+// every field is normalized between the routes of the request to a value
+// between 0 (worst or missing) and 1 (best) and multiplied with the absolute weight
+func computeScores(sRoutes []*SortedRoute, sParams []*scoreParam) {
+	scores := make([]float64, len(sRoutes))
+	for _, sParam := range sParams {
+		vals := make([]float64, len(sRoutes))
+		hasVal := make([]bool, len(sRoutes))
+		minVal, maxVal := math.Inf(1), math.Inf(-1)
+		for i, sRoute := range sRoutes {
+			if vals[i], hasVal[i] = scoreValue(sRoute, sParam.field); !hasVal[i] {
+				continue
+			}
+			minVal = math.Min(minVal, vals[i])
+			maxVal = math.Max(maxVal, vals[i])
+		}
+		for i := range sRoutes {
+			if !hasVal[i] {
+				continue
+			}
+			norm := 1.0 // all routes have the same value
+			if maxVal != minVal {
+				if sParam.weight > 0 {
+					norm = (vals[i] - minVal) / (maxVal - minVal)
+				} else {
+					norm = (maxVal - vals[i]) / (maxVal - minVal)
+				}
+			}
+			scores[i] += math.Abs(sParam.weight) * norm
+		}
+	}
+	for i, sRoute := range sRoutes {
+		sRoute.SortingData[utils.Score] = scores[i]
+	}
+}
+
+func NewMultiRouteSorter(rS *RouteService) *MultiRouteSorter {
+	return &MultiRouteSorter{rS: rS,
+		sorting: utils.MetaMulti}
+}
+
+// MultiRouteSorter sorts routes based on a weighted score
+// computed out of cost, stats, resource usage and weight
+type MultiRouteSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (ms *MultiRouteSorter) SortRoutes(prflID string, routes []*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	var sParams []*scoreParam
+	if sParams, err = newScoreParams(extraOpts.sortingParameters); err != nil {
+		return
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting:      ms.sorting,
+		SortedRoutes: make([]*SortedRoute, 0)}
+	for _, route := range routes {
+		if srtSpl, pass, err := ms.rS.populateSortingData(ev, route, extraOpts); err != nil {
+			return nil, err
+		} else if pass && srtSpl != nil {
+			sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, srtSpl)
+		}
+	}
+	computeScores(sortedRoutes.SortedRoutes, sParams)
+	sortedRoutes.SortScore()
+	return
+}
@@ -0,0 +1,160 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewScoreParams(t *testing.T) {
+	exp := []*scoreParam{
+		{field: utils.Cost, weight: -2},
+		{field: utils.MetaASR, weight: 1},
+		{field: "*sum#~*req.Usage", weight: 0.5},
+	}
+	if rcv, err := newScoreParams([]string{"Cost:-2", "*asr:1", "*sum#~*req.Usage:0.5"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, err := newScoreParams(nil); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.SortingParameters).Error() {
+		t.Errorf("Expected error, received: %v", err)
+	}
+	for _, param := range []string{"Cost", ":1", "Cost:", "Cost:a", "Cost:0", "Cost:NaN"} {
+		if _, err := newScoreParams([]string{param}); err == nil ||
+			err.Error() != "invalid *multi sorting parameter: <"+param+">" {
+			t.Errorf("Expected error for <%s>, received: %v", param, err)
+		}
+	}
+}
+
+func TestComputeScores(t *testing.T) {
+	sRoutes := []*SortedRoute{
+		{
+			RouteID: "route1",
+			SortingData: map[string]interface{}{
+				utils.Weight:  10.0,
+				utils.Cost:    1.0,
+				utils.MetaASR: 50.0,
+			},
+		},
+		{
+			RouteID: "route2",
+			SortingData: map[string]interface{}{
+				utils.Weight:  20.0,
+				utils.Cost:    2.0,
+				utils.MetaASR: 90.0,
+			},
+		},
+		{
+			RouteID: "route3",
+			SortingData: map[string]interface{}{
+				utils.Weight:  10.0,
+				utils.Cost:    3.0,
+				utils.MetaASR: STATS_NA,
+			},
+		},
+		{
+			RouteID: "route4",
+			SortingData: map[string]interface{}{
+				utils.Weight: 30.0,
+			},
+		},
+	}
+	computeScores(sRoutes, []*scoreParam{
+		{field: utils.Cost, weight: -2},
+		{field: utils.MetaASR, weight: 1},
+	})
+	exp := []float64{2, 2, 0, 0}
+	for i, sRoute := range sRoutes {
+		if sRoute.SortingData[utils.Score] != exp[i] {
+			t.Errorf("Expecting score %v for %s, received: %v",
+				exp[i], sRoute.RouteID, sRoute.SortingData[utils.Score])
+		}
+	}
+}
+
+func TestMultiRouteSorterSortRoutes(t *testing.T) {
+	ms := NewMultiRouteSorter(new(RouteService))
+	routes := []*Route{
+		{ID: "route1", Weight: 10},
+		{ID: "route2", Weight: 30},
+		{ID: "route3", Weight: 20},
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "TestMulti", Event: map[string]interface{}{}}
+	if _, err := ms.SortRoutes("RP_MULTI", routes, ev,
+		&optsGetRoutes{sortingStragety: utils.MetaMulti}); err == nil {
+		t.Error("Expected error for missing sorting parameters")
+	}
+	exp := &SortedRoutes{
+		ProfileID: "RP_MULTI",
+		Sorting:   utils.MetaMulti,
+		SortedRoutes: []*SortedRoute{
+			{
+				RouteID: "route1",
+				SortingData: map[string]interface{}{
+					utils.Weight: 10.0,
+					utils.Score:  1.0,
+				},
+			},
+			{
+				RouteID: "route3",
+				SortingData: map[string]interface{}{
+					utils.Weight: 20.0,
+					utils.Score:  0.5,
+				},
+			},
+			{
+				RouteID: "route2",
+				SortingData: map[string]interface{}{
+					utils.Weight: 30.0,
+					utils.Score:  0.0,
+				},
+			},
+		},
+	}
+	if rcv, err := ms.SortRoutes("RP_MULTI", routes, ev, &optsGetRoutes{
+		sortingStragety:   utils.MetaMulti,
+		sortingParameters: []string{"Weight:-1"},
+	}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestRouteProfileCompileMulti(t *testing.T) {
+	rp := &RouteProfile{
+		Tenant:            "cgrates.org",
+		ID:                "RP_MULTI",
+		Sorting:           utils.MetaMulti,
+		SortingParameters: []string{"Cost:-1", "*acd"},
+	}
+	if err := rp.Compile(); err == nil {
+		t.Error("Expected error for invalid sorting parameters")
+	}
+	rp.SortingParameters = []string{"Cost:-1", "*acd:0.5"}
+	if err := rp.Compile(); err != nil {
+		t.Error(err)
+	}
+}
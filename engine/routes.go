@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -54,9 +55,12 @@ type RouteProfile struct {
 	Sorting            string                    // Sorting strategy
 	SortingParameters  []string
 	Routes             []*Route
+	QuarantineRules    []string      // quarantine the routes when their stats reach the limits, ie: *lt:*asr:30
+	QuarantineCooldown time.Duration // minimum time a route stays in quarantine
 	Weight             float64
 
-	cache map[string]interface{}
+	cache           map[string]interface{}
+	quarantineRules []*quarantineRule
 }
 
 // RouteProfileWithArgDispatcher is used in replicatorV1 for dispatcher
@@ -66,6 +70,12 @@ type RouteProfileWithArgDispatcher struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
+	if rp.Sorting == utils.MetaMulti {
+		// make sure the score formula is valid
+		if _, err := newScoreParams(rp.SortingParameters); err != nil {
+			return err
+		}
+	}
 	if rp.Sorting == utils.MetaLoad {
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
@@ -95,9 +105,84 @@ func (rp *RouteProfile) compileCacheParameters() error {
 	return nil
 }
 
+func (rp *RouteProfile) compileQuarantineRules() (err error) {
+	if len(rp.QuarantineRules) == 0 {
+		rp.quarantineRules = nil
+		return
+	}
+	rp.quarantineRules = make([]*quarantineRule, len(rp.QuarantineRules))
+	for i, qRule := range rp.QuarantineRules {
+		if rp.quarantineRules[i], err = newQuarantineRule(qRule); err != nil {
+			return
+		}
+	}
+	return
+}
+
 // Compile is a wrapper for convenience setting up the RouteProfile
-func (rp *RouteProfile) Compile() error {
-	return rp.compileCacheParameters()
+func (rp *RouteProfile) Compile() (err error) {
+	if err = rp.compileCacheParameters(); err != nil {
+		return
+	}
+	return rp.compileQuarantineRules()
+}
+
+// quarantineRule is the limit of a stat metric which puts a route into quarantine
+type quarantineRule struct {
+	rType    string // *lt, *lte, *gt or *gte
+	metricID string
+	value    float64
+}
+
+// newQuarantineRule parses the rule in the form type:metricID:value
+// the value can be also a duration and in this case it is compared in seconds
+func newQuarantineRule(rule string) (qr *quarantineRule, err error) {
+	ruleSplt := strings.SplitN(rule, utils.CONCATENATED_KEY_SEP, 2)
+	if len(ruleSplt) != 2 {
+		return nil, fmt.Errorf("invalid quarantine rule: <%s>", rule)
+	}
+	switch ruleSplt[0] {
+	case utils.MetaLessThan, utils.MetaLessOrEqual,
+		utils.MetaGreaterThan, utils.MetaGreaterOrEqual:
+	default:
+		return nil, fmt.Errorf("invalid quarantine rule: <%s>", rule)
+	}
+	idx := strings.LastIndex(ruleSplt[1], utils.CONCATENATED_KEY_SEP)
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid quarantine rule: <%s>", rule)
+	}
+	qr = &quarantineRule{
+		rType:    ruleSplt[0],
+		metricID: ruleSplt[1][:idx],
+	}
+	valStr := ruleSplt[1][idx+1:]
+	if qr.value, err = strconv.ParseFloat(valStr, 64); err != nil {
+		var dur time.Duration
+		if dur, err = time.ParseDuration(valStr); err != nil {
+			return nil, fmt.Errorf("invalid quarantine rule: <%s>", rule)
+		}
+		qr.value = dur.Seconds()
+	}
+	return
+}
+
+// breached checks the metrics against the limit
+// the metrics which are missing or not available are ignored
+func (qr *quarantineRule) breached(metrics map[string]float64) bool {
+	val, has := metrics[qr.metricID]
+	if !has || val == STATS_NA {
+		return false
+	}
+	switch qr.rType {
+	case utils.MetaLessThan:
+		return val < qr.value
+	case utils.MetaLessOrEqual:
+		return val <= qr.value
+	case utils.MetaGreaterThan:
+		return val > qr.value
+	default: // *gte
+		return val >= qr.value
+	}
 }
 
 // TenantID returns unique identifier of the LCRProfile in a multi-tenant environment
@@ -117,10 +202,11 @@ func (lps RouteProfiles) Sort() {
 func NewRouteService(dm *DataManager,
 	filterS *FilterS, cgrcfg *config.CGRConfig, connMgr *ConnManager) (rS *RouteService, err error) {
 	rS = &RouteService{
-		dm:      dm,
-		filterS: filterS,
-		cgrcfg:  cgrcfg,
-		connMgr: connMgr,
+		dm:          dm,
+		filterS:     filterS,
+		cgrcfg:      cgrcfg,
+		connMgr:     connMgr,
+		quarantines: make(map[string]*routeQuarantine),
 	}
 	if rS.sorter, err = NewRouteSortDispatcher(rS); err != nil {
 		return nil, err
@@ -135,6 +221,20 @@ type RouteService struct {
 	cgrcfg  *config.CGRConfig
 	sorter  RouteSortDispatcher
 	connMgr *ConnManager
+
+	// quarantines are kept in memory, hence lost on restart and not shared between the engines
+	quarantines map[string]*routeQuarantine // indexed on tenant:profileID:routeID
+	qPruned     time.Time                   // last time the stale quarantines were removed
+	qMux        sync.Mutex                  // protects quarantines and qPruned
+}
+
+// quarantinesPruneInterval is the minimum interval between removing the stale quarantines
+const quarantinesPruneInterval = time.Minute
+
+// routeQuarantine is the quarantine of one route
+type routeQuarantine struct {
+	tenant, profileID, routeID string
+	expiry                     time.Time
 }
 
 // ListenAndServe will initialize the service
@@ -437,13 +537,16 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the supplier have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
-			for _, metric := range extraOpts.sortingParameters {
-				if _, hasMetric := metricSupp[metric]; !hasMetric {
-					switch metric {
-					default:
-						sortedSpl.SortingData[metric] = -1.0
-					case utils.MetaPDD:
-						sortedSpl.SortingData[metric] = 10000000.0
+			//*multi strategy handles the missing metrics when computing the score
+			if extraOpts.sortingStragety != utils.MetaMulti {
+				for _, metric := range extraOpts.sortingParameters {
+					if _, hasMetric := metricSupp[metric]; !hasMetric {
+						switch metric {
+						default:
+							sortedSpl.SortingData[metric] = -1.0
+						case utils.MetaPDD:
+							sortedSpl.SortingData[metric] = 10000000.0
+						}
 					}
 				}
 			}
@@ -482,6 +585,142 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 	return sortedSpl, true, nil
 }
 
+// admittedRoutes returns the routes which are not in quarantine
+// a route is put in quarantine for QuarantineCooldown once one of its metrics breaches
+// the QuarantineRules and it is admitted back only after the cooldown when the metrics are within limits
+func (rpS *RouteService) admittedRoutes(rPrfl *RouteProfile, routes []*Route,
+	tnt string, argDsp *utils.ArgDispatcher) (admitted []*Route) {
+	rpS.pruneQuarantines()
+	admitted = make([]*Route, 0, len(routes))
+	for _, route := range routes {
+		if len(route.StatIDs) == 0 {
+			admitted = append(admitted, route)
+			continue
+		}
+		qKey := utils.ConcatenatedKey(tnt, rPrfl.ID, route.ID)
+		rpS.qMux.Lock()
+		q, inQuarantine := rpS.quarantines[qKey]
+		rpS.qMux.Unlock()
+		if inQuarantine && time.Now().Before(q.expiry) {
+			continue
+		}
+		metrics, err := rpS.statMetrics(route.StatIDs, tnt)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s getting metrics for route: <%s>, considering it admitted",
+					utils.RouteS, err.Error(), route.ID))
+		}
+		var breached bool
+		for _, qRule := range rPrfl.quarantineRules {
+			if breached = qRule.breached(metrics); breached {
+				break
+			}
+		}
+		rpS.qMux.Lock()
+		if rpS.quarantines == nil {
+			rpS.quarantines = make(map[string]*routeQuarantine)
+		}
+		_, inQuarantine = rpS.quarantines[qKey] // concurrent requests could have changed the state
+		if breached {
+			rpS.quarantines[qKey] = &routeQuarantine{tenant: tnt, profileID: rPrfl.ID,
+				routeID: route.ID, expiry: time.Now().Add(rPrfl.QuarantineCooldown)}
+		} else {
+			delete(rpS.quarantines, qKey)
+		}
+		rpS.qMux.Unlock()
+		if !breached {
+			admitted = append(admitted, route)
+		}
+		if breached != inQuarantine { // state changed
+			rpS.processThresholds(rPrfl, route, breached, metrics, tnt, argDsp)
+		}
+	}
+	return
+}
+
+// pruneQuarantines removes the expired quarantines of the routes which are not quarantined anymore
+// since their profile was removed or changed, at most once per quarantinesPruneInterval
+func (rpS *RouteService) pruneQuarantines() {
+	rpS.qMux.Lock()
+	if time.Since(rpS.qPruned) < quarantinesPruneInterval {
+		rpS.qMux.Unlock()
+		return
+	}
+	rpS.qPruned = time.Now()
+	expired := make(map[string]*routeQuarantine)
+	for qKey, q := range rpS.quarantines {
+		if rpS.qPruned.After(q.expiry) {
+			expired[qKey] = q
+		}
+	}
+	rpS.qMux.Unlock()
+	for qKey, q := range expired {
+		if rpS.isQuarantined(q) {
+			continue
+		}
+		rpS.qMux.Lock()
+		if rpS.quarantines[qKey] == q { // not renewed meanwhile
+			delete(rpS.quarantines, qKey)
+		}
+		rpS.qMux.Unlock()
+	}
+}
+
+// isQuarantined returns false if the route of the quarantine is not subject to quarantine anymore
+func (rpS *RouteService) isQuarantined(q *routeQuarantine) bool {
+	rPrfl, err := rpS.dm.GetRouteProfile(q.tenant, q.profileID, true, true, utils.NonTransactional)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s getting the profile: <%s> of the route: <%s> in quarantine",
+					utils.RouteS, err.Error(), utils.ConcatenatedKey(q.tenant, q.profileID), q.routeID))
+			return true
+		}
+		return false
+	}
+	if len(rPrfl.QuarantineRules) == 0 {
+		return false
+	}
+	for _, route := range rPrfl.Routes {
+		if route.ID == q.routeID {
+			return len(route.StatIDs) != 0
+		}
+	}
+	return false
+}
+
+// processThresholds informs ThresholdS about the quarantine state change of a route
+func (rpS *RouteService) processThresholds(rPrfl *RouteProfile, route *Route, quarantined bool,
+	metrics map[string]float64, tnt string, argDsp *utils.ArgDispatcher) {
+	if len(rpS.cgrcfg.RouteSCfg().ThresholdSConns) == 0 {
+		return
+	}
+	thEv := &ArgsProcessEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: tnt,
+			ID:     utils.GenUUID(),
+			Event: map[string]interface{}{
+				utils.EventType:   utils.RouteUpdate,
+				utils.ProfileID:   rPrfl.ID,
+				utils.RouteID:     route.ID,
+				utils.Quarantined: quarantined,
+			},
+		},
+		ArgDispatcher: argDsp,
+	}
+	for metricID, val := range metrics {
+		thEv.Event[metricID] = val
+	}
+	var tIDs []string
+	if err := rpS.connMgr.Call(rpS.cgrcfg.RouteSCfg().ThresholdSConns, nil,
+		utils.ThresholdSv1ProcessEvent, thEv, &tIDs); err != nil &&
+		err.Error() != utils.ErrNotFound.Error() {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing event %+v with %s.",
+				utils.RouteS, err.Error(), thEv, utils.ThresholdS))
+	}
+}
+
 // sortedRoutesForEvent will return the list of valid route IDs
 // for event based on filters and sorting algorithms
 func (rpS *RouteService) sortedRoutesForEvent(args *ArgsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
@@ -517,6 +756,9 @@ func (rpS *RouteService) sortedRoutesForEvent(args *ArgsGetRoutes) (sortedRoutes
 		route.lazyCheckRules = lazyCheckRules
 		routeNew = append(routeNew, route)
 	}
+	if len(rPrfl.quarantineRules) != 0 {
+		routeNew = rpS.admittedRoutes(rPrfl, routeNew, args.CGREvent.Tenant, args.ArgDispatcher)
+	}
 
 	sortedRoutes, err = rpS.sorter.SortSuppliers(rPrfl.ID, rPrfl.Sorting,
 		routeNew, args.CGREvent, extraOpts)
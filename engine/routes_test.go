@@ -19,11 +19,13 @@ package engine
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 var (
@@ -651,3 +653,220 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[2], sprf[0])
 	}
 }
+
+func TestNewQuarantineRule(t *testing.T) {
+	exp := &quarantineRule{rType: utils.MetaLessThan, metricID: utils.MetaASR, value: 30}
+	if rcv, err := newQuarantineRule("*lt:*asr:30"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	exp = &quarantineRule{rType: utils.MetaGreaterThan, metricID: utils.MetaPDD, value: 6}
+	if rcv, err := newQuarantineRule("*gt:*pdd:6s"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	for _, rule := range []string{"*lt", "*lt:*asr", "*lt::30", "*eq:*asr:30", "*lt:*asr:a"} {
+		if _, err := newQuarantineRule(rule); err == nil ||
+			err.Error() != "invalid quarantine rule: <"+rule+">" {
+			t.Errorf("Expected error for <%s>, received: %v", rule, err)
+		}
+	}
+}
+
+func TestQuarantineRuleBreached(t *testing.T) {
+	metrics := map[string]float64{utils.MetaASR: 30, utils.MetaPDD: STATS_NA}
+	for rule, exp := range map[string]bool{
+		"*lt:*asr:30":  false,
+		"*lte:*asr:30": true,
+		"*gt:*asr:20":  true,
+		"*gte:*asr:31": false,
+		"*gt:*pdd:6s":  false, // not available
+		"*lt:*acd:10":  false, // missing
+	} {
+		qr, err := newQuarantineRule(rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv := qr.breached(metrics); rcv != exp {
+			t.Errorf("Expecting %v for <%s>, received: %v", exp, rule, rcv)
+		}
+	}
+}
+
+// testQuarantineConn mocks StatS and ThresholdS for route quarantine
+type testQuarantineConn struct {
+	sync.Mutex
+	metrics map[string]map[string]float64
+	events  []*ArgsProcessEvent
+}
+
+func (qc *testQuarantineConn) setMetrics(statID string, metrics map[string]float64) {
+	qc.Lock()
+	qc.metrics[statID] = metrics
+	qc.Unlock()
+}
+
+func (qc *testQuarantineConn) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	qc.Lock()
+	defer qc.Unlock()
+	switch serviceMethod {
+	case utils.StatSv1GetQueueFloatMetrics:
+		metrics, has := qc.metrics[args.(*utils.TenantIDWithArgDispatcher).ID]
+		if !has {
+			return utils.ErrNotFound
+		}
+		rply := make(map[string]float64)
+		for k, v := range metrics {
+			rply[k] = v
+		}
+		*reply.(*map[string]float64) = rply
+		return nil
+	case utils.ThresholdSv1ProcessEvent:
+		qc.events = append(qc.events, args.(*ArgsProcessEvent))
+		*reply.(*[]string) = []string{}
+		return nil
+	}
+	return utils.ErrNotImplemented
+}
+
+func TestRouteServiceAdmittedRoutes(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().StatSConns = []string{"*quarantineConn"}
+	cfg.RouteSCfg().ThresholdSConns = []string{"*quarantineConn"}
+	qConn := &testQuarantineConn{metrics: make(map[string]map[string]float64)}
+	qChan := make(chan rpcclient.ClientConnector, 1)
+	qChan <- qConn
+	rpS := &RouteService{
+		cgrcfg:      cfg,
+		connMgr:     NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{"*quarantineConn": qChan}),
+		quarantines: make(map[string]*routeQuarantine),
+	}
+	rPrfl := &RouteProfile{
+		Tenant:             "cgrates.org",
+		ID:                 "ROUTE_QUARANTINE",
+		Sorting:            utils.MetaWeight,
+		QuarantineRules:    []string{"*lt:*asr:30", "*gt:*pdd:6s"},
+		QuarantineCooldown: 50 * time.Millisecond,
+		Routes: []*Route{
+			{ID: "route1", StatIDs: []string{"Stat1"}},
+			{ID: "route2", StatIDs: []string{"Stat2"}},
+			{ID: "route3"},
+		},
+	}
+	if err := rPrfl.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	routeIDs := func() (rIDs []string) {
+		rIDs = make([]string, 0)
+		for _, route := range rpS.admittedRoutes(rPrfl, rPrfl.Routes, "cgrates.org", nil) {
+			rIDs = append(rIDs, route.ID)
+		}
+		return
+	}
+	checkEvents := func(exp []bool) {
+		t.Helper()
+		qConn.Lock()
+		defer qConn.Unlock()
+		if len(qConn.events) != len(exp) {
+			t.Fatalf("Expecting %d events, received: %s", len(exp), utils.ToJSON(qConn.events))
+		}
+		for i, ev := range qConn.events {
+			if ev.Event[utils.EventType] != utils.RouteUpdate ||
+				ev.Event[utils.ProfileID] != "ROUTE_QUARANTINE" ||
+				ev.Event[utils.RouteID] != "route1" ||
+				ev.Event[utils.Quarantined] != exp[i] {
+				t.Errorf("Unexpected event: %s", utils.ToJSON(ev))
+			}
+		}
+	}
+	qConn.setMetrics("Stat1", map[string]float64{utils.MetaASR: 20, utils.MetaPDD: 3})
+	qConn.setMetrics("Stat2", map[string]float64{utils.MetaASR: 80, utils.MetaPDD: STATS_NA})
+	exp := []string{"route2", "route3"}
+	if rcv := routeIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	checkEvents([]bool{true})
+	// stats recovered but the route stays in quarantine for the cooldown
+	qConn.setMetrics("Stat1", map[string]float64{utils.MetaASR: 50, utils.MetaPDD: 3})
+	if rcv := routeIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	checkEvents([]bool{true})
+	time.Sleep(60 * time.Millisecond)
+	exp = []string{"route1", "route2", "route3"}
+	if rcv := routeIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	checkEvents([]bool{true, false})
+	// after the cooldown the route stays in quarantine if it still breaches the limits
+	qConn.setMetrics("Stat1", map[string]float64{utils.MetaASR: 50, utils.MetaPDD: 7})
+	exp = []string{"route2", "route3"}
+	if rcv := routeIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if rcv := routeIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	checkEvents([]bool{true, false, true})
+}
+
+func TestRouteServicePruneQuarantines(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	if err := dm.SetRouteProfile(&RouteProfile{
+		Tenant:             "cgrates.org",
+		ID:                 "ROUTE_QUARANTINE",
+		Sorting:            utils.MetaWeight,
+		QuarantineRules:    []string{"*lt:*asr:30"},
+		QuarantineCooldown: time.Minute,
+		Routes: []*Route{
+			{ID: "route1", StatIDs: []string{"Stat1"}},
+			{ID: "route2"},
+		},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	expired := time.Now().Add(-time.Second)
+	rpS := &RouteService{
+		dm: dm,
+		quarantines: map[string]*routeQuarantine{
+			"cgrates.org:ROUTE_QUARANTINE:route1": {tenant: "cgrates.org",
+				profileID: "ROUTE_QUARANTINE", routeID: "route1", expiry: expired},
+			"cgrates.org:ROUTE_QUARANTINE:route2": {tenant: "cgrates.org", // without StatIDs anymore
+				profileID: "ROUTE_QUARANTINE", routeID: "route2", expiry: expired},
+			"cgrates.org:ROUTE_QUARANTINE:route3": {tenant: "cgrates.org", // removed from the profile
+				profileID: "ROUTE_QUARANTINE", routeID: "route3", expiry: expired},
+			"cgrates.org:ROUTE_REMOVED:route1": {tenant: "cgrates.org",
+				profileID: "ROUTE_REMOVED", routeID: "route1", expiry: expired},
+			"cgrates.org:ROUTE_REMOVED:route2": {tenant: "cgrates.org", // still in cooldown
+				profileID: "ROUTE_REMOVED", routeID: "route2", expiry: time.Now().Add(time.Minute)},
+		},
+	}
+	rpS.pruneQuarantines()
+	exp := utils.NewStringSet([]string{"cgrates.org:ROUTE_QUARANTINE:route1", "cgrates.org:ROUTE_REMOVED:route2"})
+	rcv := utils.NewStringSet(nil)
+	for qKey := range rpS.quarantines {
+		rcv.Add(qKey)
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	// pruned at most once per interval
+	if err := dm.RemoveRouteProfile("cgrates.org", "ROUTE_QUARANTINE",
+		utils.NonTransactional, true); err != nil {
+		t.Fatal(err)
+	}
+	Cache.Clear(nil)
+	rpS.pruneQuarantines()
+	if len(rpS.quarantines) != 2 {
+		t.Errorf("Expecting 2 quarantines, received: %+v", rpS.quarantines)
+	}
+	rpS.qPruned = time.Time{}
+	rpS.pruneQuarantines()
+	if _, has := rpS.quarantines["cgrates.org:ROUTE_QUARANTINE:route1"]; has || len(rpS.quarantines) != 1 {
+		t.Errorf("Unexpected quarantines: %+v", rpS.quarantines)
+	}
+}
@@ -105,7 +105,7 @@ func (sS *StatService) storeStats() {
 			break // no more keys, backup completed
 		}
 		lkID := utils.StatQueuePrefix + sID
-		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		if _, errGuard := guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
 			if sqIf, ok := Cache.Get(utils.CacheStatQueues, sID); !ok || sqIf == nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> failed retrieving from cache stat queue with ID: %s",
@@ -114,7 +114,9 @@ func (sS *StatService) storeStats() {
 				failedSqIDs = append(failedSqIDs, sID) // record failure so we can schedule it for next backup
 			}
 			return
-		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID)
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID); errGuard != nil {
+			failedSqIDs = append(failedSqIDs, sID) // not stored without the lock
+		}
 		// randomize the CPU load and give up thread control
 		time.Sleep(time.Duration(rand.Intn(1000)) * time.Nanosecond)
 	}
@@ -189,10 +191,12 @@ func (sS *StatService) matchingStatQueuesForEvent(args *StatsArgsProcessEvent) (
 		}
 		var sq *StatQueue
 		lkID := utils.StatQueuePrefix + utils.ConcatenatedKey(sqPrfl.Tenant, sqPrfl.ID)
-		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		if _, errGuard := guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
 			sq, err = sS.dm.GetStatQueue(sqPrfl.Tenant, sqPrfl.ID, true, true, "")
 			return
-		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID)
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID); errGuard != nil && err == nil {
+			err = errGuard
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -249,10 +253,12 @@ func (sS *StatService) processEvent(args *StatsArgsProcessEvent) (statQueueIDs [
 	for _, sq := range matchSQs {
 		stsIDs = append(stsIDs, sq.ID)
 		lkID := utils.StatQueuePrefix + sq.TenantID()
-		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		if _, errGuard := guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
 			err = sq.ProcessEvent(args.CGREvent, sS.filterS)
 			return
-		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID)
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, lkID); errGuard != nil && err == nil {
+			err = errGuard
+		}
 		if err != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<StatS> Queue: %s, ignoring event: %s, error: %s",
@@ -133,10 +133,17 @@ type DataDB interface {
 	GetRateProfileDrv(string, string) (*RateProfile, error)
 	SetRateProfileDrv(*RateProfile) error
 	RemoveRateProfileDrv(string, string) error
+	GetLookupTableValueDrv(tenant, id, key string) (string, error)
+	GetLookupTablePrefixValueDrv(tenant, id, key string) (string, error)
+	GetLookupTableDrv(tenant, id string) (*LookupTable, error)
+	SetLookupTableDrv(lt *LookupTable, replace bool) error
+	RemoveLookupTableDrv(tenant, id string) error
 }
 
 type StorDB interface {
 	CdrStorage
+	AuditStorage
+	ActionExecutionStorage
 	LoadReader
 	LoadWriter
 }
@@ -149,6 +156,19 @@ type CdrStorage interface {
 	RemoveSMCost(*SMCost) error
 	RemoveSMCosts(qryFltr *utils.SMCostFilter) error
 	GetCDRs(*utils.CDRsFilter, bool) ([]*CDR, int64, error)
+	GetCDRsReport(*utils.CDRsFilter, []*CDRsGroupBy) ([]*CDRsReportGroup, error)
+}
+
+// AuditStorage keeps the audit trail of the administrative calls
+type AuditStorage interface {
+	SetAuditRecord(*utils.AuditRecord) error
+	GetAuditRecords(*utils.AuditRecordsFilter) ([]*utils.AuditRecord, error)
+}
+
+// ActionExecutionStorage keeps the execution history of the ActionPlans
+type ActionExecutionStorage interface {
+	SetActionExecution(*utils.ActionExecution) error
+	GetActionExecutions(*utils.ActionExecutionsFilter) ([]*utils.ActionExecution, error)
 }
 
 type LoadStorage interface {
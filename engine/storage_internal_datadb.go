@@ -191,6 +191,11 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 				TTL:       itemsCacheCfg[utils.CacheRateProfiles].TTL,
 				StaticTTL: itemsCacheCfg[utils.CacheRateProfiles].StaticTTL,
 			},
+			utils.CacheLookupTables: {
+				MaxItems:  itemsCacheCfg[utils.CacheLookupTables].Limit,
+				TTL:       itemsCacheCfg[utils.CacheLookupTables].TTL,
+				StaticTTL: itemsCacheCfg[utils.CacheLookupTables].StaticTTL,
+			},
 			utils.CacheRateProfilesFilterIndexes: {
 				MaxItems:  itemsCacheCfg[utils.MetaIndexes].Limit,
 				TTL:       itemsCacheCfg[utils.MetaIndexes].TTL,
@@ -294,6 +299,16 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 				TTL:       itemsCacheCfg[utils.SessionCostsTBL].TTL,
 				StaticTTL: itemsCacheCfg[utils.SessionCostsTBL].StaticTTL,
 			},
+			utils.AuditRecordsTBL: {
+				MaxItems:  itemsCacheCfg[utils.AuditRecordsTBL].Limit,
+				TTL:       itemsCacheCfg[utils.AuditRecordsTBL].TTL,
+				StaticTTL: itemsCacheCfg[utils.AuditRecordsTBL].StaticTTL,
+			},
+			utils.ActionExecutionsTBL: {
+				MaxItems:  itemsCacheCfg[utils.ActionExecutionsTBL].Limit,
+				TTL:       itemsCacheCfg[utils.ActionExecutionsTBL].TTL,
+				StaticTTL: itemsCacheCfg[utils.ActionExecutionsTBL].StaticTTL,
+			},
 			utils.TBLTPActionPlans: {
 				MaxItems:  itemsCacheCfg[utils.TBLTPActionPlans].Limit,
 				TTL:       itemsCacheCfg[utils.TBLTPActionPlans].TTL,
@@ -340,7 +355,7 @@ func newInternalDBCfg(itemsCacheCfg map[string]*config.ItemOpt, isDataDB bool) m
 
 type InternalDB struct {
 	tasks               []*Task
-	db                  *ltcache.TransCache
+	db                  *internalStore
 	mu                  sync.RWMutex
 	stringIndexedFields []string
 	prefixIndexedFields []string
@@ -354,7 +369,7 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string,
 	isDataDB bool, itemsCacheCfg map[string]*config.ItemOpt) (iDB *InternalDB) {
 	ms, _ := NewMarshaler(config.CgrConfig().GeneralCfg().DBDataEncoding)
 	iDB = &InternalDB{
-		db:                  ltcache.NewTransCache(newInternalDBCfg(itemsCacheCfg, isDataDB)),
+		db:                  newInternalStore(newInternalDBCfg(itemsCacheCfg, isDataDB)),
 		stringIndexedFields: stringIndexedFields,
 		prefixIndexedFields: prefixIndexedFields,
 		cnter:               utils.NewCounter(time.Now().UnixNano(), 0),
@@ -377,7 +392,17 @@ func (iDB *InternalDB) SetPrefixIndexedFields(prefixIndexedFields []string) {
 	iDB.indexedFieldsMutex.Unlock()
 }
 
-func (iDB *InternalDB) Close() {}
+// EnablePersistence restores the data dumped in dirPath and starts persisting the changes there,
+// compacting them into a snapshot each snapshotInterval (needs to be called before using the DB)
+func (iDB *InternalDB) EnablePersistence(dirPath string, snapshotInterval time.Duration,
+	syncWrites bool) (err error) {
+	return iDB.db.enableDump(dirPath, snapshotInterval, syncWrites)
+}
+
+// Close takes the last snapshot in case of persistence
+func (iDB *InternalDB) Close() {
+	iDB.db.closeDump()
+}
 
 func (iDB *InternalDB) Flush(_ string) error {
 	iDB.db.Clear(nil)
@@ -1325,6 +1350,61 @@ func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetLookupTableValueDrv(tenant, id, key string) (value string, err error) {
+	x, ok := iDB.db.Get(utils.CacheLookupTables, lookupTableEntryKey(tenant, id, key))
+	if !ok || x == nil {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	return x.(string), nil
+}
+
+func (iDB *InternalDB) GetLookupTablePrefixValueDrv(tenant, id, key string) (value string, err error) {
+	for i := len(key); i > 0; i-- {
+		if x, ok := iDB.db.Get(utils.CacheLookupTables, lookupTableEntryKey(tenant, id, key[:i])); ok && x != nil {
+			return x.(string), nil
+		}
+	}
+	return utils.EmptyString, utils.ErrNotFound
+}
+
+func (iDB *InternalDB) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	itmIDs := iDB.db.GetGroupItemIDs(utils.CacheLookupTables, utils.ConcatenatedKey(tenant, id))
+	if len(itmIDs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	lt = &LookupTable{Tenant: tenant, ID: id, Entries: make(map[string]string, len(itmIDs))}
+	keyPrfx := lookupTableEntryKey(tenant, id, utils.EmptyString)
+	for _, itmID := range itmIDs {
+		if x, ok := iDB.db.Get(utils.CacheLookupTables, itmID); ok && x != nil {
+			lt.Entries[strings.TrimPrefix(itmID, keyPrfx)] = x.(string)
+		}
+	}
+	return
+}
+
+func (iDB *InternalDB) SetLookupTableDrv(lt *LookupTable, replace bool) (err error) {
+	grpIDs := []string{lt.TenantID()}
+	if replace {
+		iDB.db.RemoveGroup(utils.CacheLookupTables, lt.TenantID(),
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	for key, value := range lt.Entries {
+		iDB.db.Set(utils.CacheLookupTables, lookupTableEntryKey(lt.Tenant, lt.ID, key), value, grpIDs,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) RemoveLookupTableDrv(tenant, id string) (err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if !iDB.db.HasGroup(utils.CacheLookupTables, tntID) {
+		return utils.ErrNotFound
+	}
+	iDB.db.RemoveGroup(utils.CacheLookupTables, tntID,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
 func (iDB *InternalDB) GetRateProfileDrv(tenant, id string) (rpp *RateProfile, err error) {
 	x, ok := iDB.db.Get(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id))
 	if !ok || x == nil {
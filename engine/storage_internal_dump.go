@@ -0,0 +1,569 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+const (
+	internalDBSnapshotFile  = "snapshot"
+	internalDBChangeLogFile = "changes.log"
+	internalDBRecordHdrLen  = 8 // payload length + crc32 of the payload
+
+	internalDBOpSet         = "set"
+	internalDBOpRemove      = "remove"
+	internalDBOpRemoveGroup = "remove_group"
+	internalDBOpClear       = "clear"
+)
+
+// internalDBItemTypes are the types of the items stored in each partition, used to decode them from disk
+var internalDBItemTypes = map[string]reflect.Type{
+	utils.TBLVersions:               reflect.TypeOf(Versions{}),
+	utils.CacheDestinations:         reflect.TypeOf(new(Destination)),
+	utils.CacheReverseDestinations:  reflect.TypeOf(utils.StringMap{}),
+	utils.CacheRatingPlans:          reflect.TypeOf(new(RatingPlan)),
+	utils.CacheRatingProfiles:       reflect.TypeOf(new(RatingProfile)),
+	utils.CacheActions:              reflect.TypeOf(Actions{}),
+	utils.CacheActionPlans:          reflect.TypeOf(new(ActionPlan)),
+	utils.CacheAccountActionPlans:   reflect.TypeOf([]string{}),
+	utils.CacheActionTriggers:       reflect.TypeOf(ActionTriggers{}),
+	utils.CacheSharedGroups:         reflect.TypeOf(new(SharedGroup)),
+	utils.CacheTimings:              reflect.TypeOf(new(utils.TPTiming)),
+	utils.CacheResourceProfiles:     reflect.TypeOf(new(ResourceProfile)),
+	utils.CacheResources:            reflect.TypeOf(new(Resource)),
+	utils.CacheStatQueueProfiles:    reflect.TypeOf(new(StatQueueProfile)),
+	utils.CacheThresholdProfiles:    reflect.TypeOf(new(ThresholdProfile)),
+	utils.CacheThresholds:           reflect.TypeOf(new(Threshold)),
+	utils.CacheRouteProfiles:        reflect.TypeOf(new(RouteProfile)),
+	utils.CacheAttributeProfiles:    reflect.TypeOf(new(AttributeProfile)),
+	utils.CacheChargerProfiles:      reflect.TypeOf(new(ChargerProfile)),
+	utils.CacheDispatcherProfiles:   reflect.TypeOf(new(DispatcherProfile)),
+	utils.CacheDispatcherHosts:      reflect.TypeOf(new(DispatcherHost)),
+	utils.CacheRateProfiles:         reflect.TypeOf(new(RateProfile)),
+	utils.CacheLookupTables:         reflect.TypeOf(utils.EmptyString),
+	utils.CacheLoadIDs:              reflect.TypeOf(map[string]int64{}),
+	utils.CacheAccounts:             reflect.TypeOf(new(Account)),
+	utils.CacheReverseFilterIndexes: reflect.TypeOf(utils.StringSet{}),
+
+	utils.CDRsTBL:               reflect.TypeOf(new(CDR)),
+	utils.SessionCostsTBL:       reflect.TypeOf(new(SMCost)),
+	utils.AuditRecordsTBL:       reflect.TypeOf(new(utils.AuditRecord)),
+	utils.ActionExecutionsTBL:   reflect.TypeOf(new(utils.ActionExecution)),
+	utils.TBLTPTimings:          reflect.TypeOf(new(utils.ApierTPTiming)),
+	utils.TBLTPDestinations:     reflect.TypeOf(new(utils.TPDestination)),
+	utils.TBLTPRates:            reflect.TypeOf(new(utils.TPRateRALs)),
+	utils.TBLTPDestinationRates: reflect.TypeOf(new(utils.TPDestinationRate)),
+	utils.TBLTPRatingPlans:      reflect.TypeOf(new(utils.TPRatingPlan)),
+	utils.TBLTPRatingProfiles:   reflect.TypeOf(new(utils.TPRatingProfile)),
+	utils.TBLTPSharedGroups:     reflect.TypeOf(new(utils.TPSharedGroups)),
+	utils.TBLTPActions:          reflect.TypeOf(new(utils.TPActions)),
+	utils.TBLTPActionPlans:      reflect.TypeOf(new(utils.TPActionPlan)),
+	utils.TBLTPActionTriggers:   reflect.TypeOf(new(utils.TPActionTriggers)),
+	utils.TBLTPAccountActions:   reflect.TypeOf(new(utils.TPAccountActions)),
+	utils.TBLTPResources:        reflect.TypeOf(new(utils.TPResourceProfile)),
+	utils.TBLTPStats:            reflect.TypeOf(new(utils.TPStatProfile)),
+	utils.TBLTPThresholds:       reflect.TypeOf(new(utils.TPThresholdProfile)),
+	utils.TBLTPFilters:          reflect.TypeOf(new(utils.TPFilterProfile)),
+	utils.TBLTPRoutes:           reflect.TypeOf(new(utils.TPRouteProfile)),
+	utils.TBLTPAttributes:       reflect.TypeOf(new(utils.TPAttributeProfile)),
+	utils.TBLTPChargers:         reflect.TypeOf(new(utils.TPChargerProfile)),
+	utils.TBLTPDispatchers:      reflect.TypeOf(new(utils.TPDispatcherProfile)),
+	utils.TBLTPDispatcherHosts:  reflect.TypeOf(new(utils.TPDispatcherHost)),
+	utils.TBLTPRateProfiles:     reflect.TypeOf(new(utils.TPRateProfile)),
+}
+
+func init() {
+	for idxItmType := range utils.CacheIndexesToPrefix {
+		internalDBItemTypes[idxItmType] = reflect.TypeOf(utils.StringSet{})
+	}
+}
+
+var errInternalDBCorruptedRecord = errors.New("corrupted record")
+
+// internalDBLiveItems are the partitions whose items are changed in place by their services
+// so the snapshot writes the value last persisted instead of encoding the live item
+var internalDBLiveItems = utils.NewStringSet([]string{utils.CacheResources,
+	utils.CacheStatQueues, utils.CacheThresholds})
+
+// internalDBRecord is one change of the InternalDB, as written on disk
+type internalDBRecord struct {
+	Op       string
+	ChID     string
+	ItmID    string // the group ID in case of remove_group
+	GroupIDs []string
+	ChIDs    []string // the partitions in case of clear
+	Value    []byte   // empty for nil values
+}
+
+// newInternalStore returns the storage used by InternalDB
+func newInternalStore(cfg map[string]*ltcache.CacheConfig) (s *internalStore) {
+	s = &internalStore{
+		TransCache: ltcache.NewTransCache(cfg),
+		partitions: make([]string, 0, len(cfg)),
+	}
+	for chID := range cfg {
+		s.partitions = append(s.partitions, chID)
+	}
+	sort.Strings(s.partitions)
+	return
+}
+
+// internalStore is the TransCache of InternalDB, mirroring the changes on disk once the persistence is enabled
+type internalStore struct {
+	*ltcache.TransCache
+	partitions []string
+	dmp        *internalDump // nil if the persistence is disabled
+}
+
+// Set stores the item, writing it also in the change log
+func (s *internalStore) Set(chID, itmID string, value interface{},
+	groupIDs []string, commit bool, transID string) {
+	dmp := s.dmp
+	if dmp == nil {
+		s.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+		return
+	}
+	b, err := dmp.encodeValue(chID, value) // encode outside the lock
+	dmp.Lock()
+	s.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+	if err == nil {
+		dmp.setGroups(chID, itmID, groupIDs)
+		dmp.setLiveValue(chID, itmID, b)
+		err = dmp.writeChange(&internalDBRecord{Op: internalDBOpSet,
+			ChID: chID, ItmID: itmID, GroupIDs: groupIDs, Value: b})
+	}
+	dmp.Unlock()
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed persisting item <%s> from <%s>, error: %s",
+			utils.MetaInternal, itmID, chID, err.Error()))
+	}
+}
+
+// Remove removes the item, writing it also in the change log
+func (s *internalStore) Remove(chID, itmID string, commit bool, transID string) {
+	dmp := s.dmp
+	if dmp == nil {
+		s.TransCache.Remove(chID, itmID, commit, transID)
+		return
+	}
+	dmp.Lock()
+	s.TransCache.Remove(chID, itmID, commit, transID)
+	dmp.setGroups(chID, itmID, nil)
+	delete(dmp.liveValues[chID], itmID)
+	err := dmp.writeChange(&internalDBRecord{Op: internalDBOpRemove,
+		ChID: chID, ItmID: itmID})
+	dmp.Unlock()
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed persisting removal of item <%s> from <%s>, error: %s",
+			utils.MetaInternal, itmID, chID, err.Error()))
+	}
+}
+
+// RemoveGroup removes the items within the group, writing it also in the change log
+func (s *internalStore) RemoveGroup(chID, grpID string, commit bool, transID string) {
+	dmp := s.dmp
+	if dmp == nil {
+		s.TransCache.RemoveGroup(chID, grpID, commit, transID)
+		return
+	}
+	dmp.Lock()
+	s.removeGroup(chID, grpID)
+	err := dmp.writeChange(&internalDBRecord{Op: internalDBOpRemoveGroup,
+		ChID: chID, ItmID: grpID})
+	dmp.Unlock()
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed persisting removal of group <%s> from <%s>, error: %s",
+			utils.MetaInternal, grpID, chID, err.Error()))
+	}
+}
+
+// Clear removes all the items from the partitions, writing it also in the change log
+func (s *internalStore) Clear(chIDs []string) {
+	dmp := s.dmp
+	if dmp == nil {
+		s.TransCache.Clear(chIDs)
+		return
+	}
+	dmp.Lock()
+	s.clear(chIDs)
+	err := dmp.writeChange(&internalDBRecord{Op: internalDBOpClear, ChIDs: chIDs})
+	dmp.Unlock()
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed persisting clear of <%+v>, error: %s",
+			utils.MetaInternal, chIDs, err.Error()))
+	}
+}
+
+// removeGroup removes the group without logging the change, dmp should be locked
+func (s *internalStore) removeGroup(chID, grpID string) {
+	for _, itmID := range s.TransCache.GetGroupItemIDs(chID, grpID) {
+		s.dmp.setGroups(chID, itmID, nil)
+		delete(s.dmp.liveValues[chID], itmID)
+	}
+	s.TransCache.RemoveGroup(chID, grpID, true, utils.NonTransactional)
+}
+
+// clear clears the partitions without logging the change, dmp should be locked
+func (s *internalStore) clear(chIDs []string) {
+	if chIDs == nil {
+		s.dmp.groups = make(map[string]map[string][]string)
+		s.dmp.liveValues = make(map[string]map[string][]byte)
+	}
+	for _, chID := range chIDs {
+		delete(s.dmp.groups, chID)
+		delete(s.dmp.liveValues, chID)
+	}
+	s.TransCache.Clear(chIDs)
+}
+
+// apply applies a change read from disk
+func (s *internalStore) apply(rec *internalDBRecord) (err error) {
+	switch rec.Op {
+	case internalDBOpSet:
+		var value interface{}
+		if value, err = s.dmp.decodeValue(rec.ChID, rec.Value); err != nil {
+			return
+		}
+		s.TransCache.Set(rec.ChID, rec.ItmID, value, rec.GroupIDs,
+			true, utils.NonTransactional)
+		s.dmp.setGroups(rec.ChID, rec.ItmID, rec.GroupIDs)
+		s.dmp.setLiveValue(rec.ChID, rec.ItmID, rec.Value)
+	case internalDBOpRemove:
+		s.TransCache.Remove(rec.ChID, rec.ItmID, true, utils.NonTransactional)
+		s.dmp.setGroups(rec.ChID, rec.ItmID, nil)
+		delete(s.dmp.liveValues[rec.ChID], rec.ItmID)
+	case internalDBOpRemoveGroup:
+		s.removeGroup(rec.ChID, rec.ItmID)
+	case internalDBOpClear:
+		s.clear(rec.ChIDs)
+	default:
+		return fmt.Errorf("unsupported operation <%s>", rec.Op)
+	}
+	return
+}
+
+// enableDump restores the data found in dirPath and starts persisting the changes there
+func (s *internalStore) enableDump(dirPath string, snapshotInterval time.Duration,
+	syncWrites bool) (err error) {
+	if s.dmp != nil {
+		return fmt.Errorf("persistence already enabled in <%s>", s.dmp.dirPath)
+	}
+	if err = os.MkdirAll(dirPath, 0755); err != nil {
+		return
+	}
+	dmp := &internalDump{
+		dirPath:    dirPath,
+		syncWrites: syncWrites,
+		ms:         NewCodecMsgpackMarshaler(), // independent of the configured encoding
+		groups:     make(map[string]map[string][]string),
+		liveValues: make(map[string]map[string][]byte),
+		stop:       make(chan struct{}),
+	}
+	s.dmp = dmp
+	defer func() {
+		if err != nil {
+			if dmp.log != nil {
+				dmp.log.Close()
+			}
+			s.dmp = nil
+		}
+	}()
+	// the snapshot is written atomically so it should be complete
+	if err = s.replayFile(path.Join(dirPath, internalDBSnapshotFile), true); err != nil {
+		return
+	}
+	if err = s.replayFile(path.Join(dirPath, internalDBChangeLogFile), false); err != nil {
+		return
+	}
+	if dmp.log, err = os.OpenFile(path.Join(dirPath, internalDBChangeLogFile),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return
+	}
+	if err = s.snapshot(dmp); err != nil { // start with an empty change log
+		return
+	}
+	if snapshotInterval > 0 {
+		go s.snapshotLoop(dmp, snapshotInterval)
+	}
+	return
+}
+
+// closeDump takes the last snapshot and stops the persistence
+func (s *internalStore) closeDump() {
+	if s.dmp == nil {
+		return
+	}
+	dmp := s.dmp
+	close(dmp.stop)
+	if err := s.snapshot(dmp); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed writing snapshot in <%s>, error: %s",
+			utils.MetaInternal, dmp.dirPath, err.Error()))
+	}
+	dmp.Lock()
+	dmp.log.Close()
+	s.dmp = nil
+	dmp.Unlock()
+}
+
+func (s *internalStore) snapshotLoop(dmp *internalDump, snapshotInterval time.Duration) {
+	for {
+		select {
+		case <-dmp.stop:
+			return
+		case <-time.After(snapshotInterval):
+		}
+		if err := s.snapshot(dmp); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed writing snapshot in <%s>, error: %s",
+				utils.MetaInternal, dmp.dirPath, err.Error()))
+		}
+	}
+}
+
+// snapshot writes all the items in a new snapshot file and truncates the change log.
+// The writes are blocked while the snapshot is taken so it matches the change log.
+func (s *internalStore) snapshot(dmp *internalDump) (err error) {
+	dmp.Lock()
+	defer dmp.Unlock()
+	tmpPath := path.Join(dmp.dirPath, internalDBSnapshotFile+utils.TmpSuffix)
+	var fl *os.File
+	if fl, err = os.Create(tmpPath); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			fl.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+	w := bufio.NewWriter(fl)
+	groups := make(map[string]map[string][]string) // also cleans the groups of the expired items
+	liveValues := make(map[string]map[string][]byte)
+	for _, chID := range s.partitions {
+		for _, itmID := range s.TransCache.GetItemIDs(chID, utils.EmptyString) {
+			value, has := s.TransCache.Get(chID, itmID)
+			if !has {
+				continue
+			}
+			rec := &internalDBRecord{Op: internalDBOpSet,
+				ChID: chID, ItmID: itmID, GroupIDs: dmp.groups[chID][itmID]}
+			if internalDBLiveItems.Has(chID) {
+				// the live item can be changed by its service while we encode it
+				if rec.Value, has = dmp.liveValues[chID][itmID]; !has {
+					continue // not persisted, ie. failed encoding
+				}
+				if _, has = liveValues[chID]; !has {
+					liveValues[chID] = make(map[string][]byte)
+				}
+				liveValues[chID][itmID] = rec.Value
+			} else if rec.Value, err = dmp.encodeValue(chID, value); err != nil {
+				return fmt.Errorf("encoding item <%s> from <%s>: %s", itmID, chID, err.Error())
+			}
+			if err = dmp.writeRecord(w, rec); err != nil {
+				return
+			}
+			if len(rec.GroupIDs) != 0 {
+				if _, has := groups[chID]; !has {
+					groups[chID] = make(map[string][]string)
+				}
+				groups[chID][itmID] = rec.GroupIDs
+			}
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	if err = fl.Sync(); err != nil {
+		return
+	}
+	if err = fl.Close(); err != nil {
+		return
+	}
+	if err = os.Rename(tmpPath, path.Join(dmp.dirPath, internalDBSnapshotFile)); err != nil {
+		return
+	}
+	dmp.groups = groups
+	dmp.liveValues = liveValues
+	// replaying the old change log over the new snapshot gives the same data
+	// so it is safe to stop between the rename and the truncate
+	return dmp.log.Truncate(0)
+}
+
+// replayFile applies the records found in the file, ignoring an incomplete
+// record at the end unless strict (ie. the engine stopped while writing it)
+func (s *internalStore) replayFile(fPath string, strict bool) (err error) {
+	var fl *os.File
+	if fl, err = os.Open(fPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return
+	}
+	defer fl.Close()
+	rdr := bufio.NewReader(fl)
+	var size int
+	hdr := make([]byte, internalDBRecordHdrLen)
+	for {
+		if _, err = io.ReadFull(rdr, hdr); err != nil {
+			break
+		}
+		b := make([]byte, binary.BigEndian.Uint32(hdr))
+		if _, err = io.ReadFull(rdr, b); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(b) != binary.BigEndian.Uint32(hdr[4:]) {
+			err = errInternalDBCorruptedRecord
+			break
+		}
+		rec := new(internalDBRecord)
+		if err = s.dmp.ms.Unmarshal(b, rec); err != nil {
+			return fmt.Errorf("decoding record from <%s> at offset %d: %s", fPath, size, err.Error())
+		}
+		if err = s.apply(rec); err != nil {
+			return fmt.Errorf("applying record from <%s> at offset %d: %s", fPath, size, err.Error())
+		}
+		size += len(hdr) + len(b)
+	}
+	if err == io.EOF {
+		return nil
+	}
+	if strict {
+		return fmt.Errorf("reading <%s> at offset %d: %s", fPath, size, err.Error())
+	}
+	utils.Logger.Warning(fmt.Sprintf("<%s> ignoring incomplete data from <%s> at offset %d: %s",
+		utils.MetaInternal, fPath, size, err.Error()))
+	return nil
+}
+
+// internalDump holds the persistence state of the internalStore
+type internalDump struct {
+	sync.Mutex // keeps the change log in the same order as the changes
+	dirPath    string
+	syncWrites bool
+	ms         Marshaler
+	log        *os.File
+	groups     map[string]map[string][]string // the groups of the items since ltcache does not return them
+	liveValues map[string]map[string][]byte   // the last value written for the internalDBLiveItems
+	stop       chan struct{}
+}
+
+func (dmp *internalDump) setGroups(chID, itmID string, groupIDs []string) {
+	if len(groupIDs) == 0 {
+		delete(dmp.groups[chID], itmID)
+		return
+	}
+	if _, has := dmp.groups[chID]; !has {
+		dmp.groups[chID] = make(map[string][]string)
+	}
+	dmp.groups[chID][itmID] = groupIDs
+}
+
+// setLiveValue keeps the encoded value of the items changed in place by their services
+func (dmp *internalDump) setLiveValue(chID, itmID string, b []byte) {
+	if !internalDBLiveItems.Has(chID) {
+		return
+	}
+	if _, has := dmp.liveValues[chID]; !has {
+		dmp.liveValues[chID] = make(map[string][]byte)
+	}
+	dmp.liveValues[chID][itmID] = b
+}
+
+// writeChange appends the record to the change log
+func (dmp *internalDump) writeChange(rec *internalDBRecord) (err error) {
+	if err = dmp.writeRecord(dmp.log, rec); err != nil || !dmp.syncWrites {
+		return
+	}
+	return dmp.log.Sync()
+}
+
+// writeRecord writes the record prefixed by its length and checksum
+func (dmp *internalDump) writeRecord(w io.Writer, rec *internalDBRecord) (err error) {
+	var b []byte
+	if b, err = dmp.ms.Marshal(rec); err != nil {
+		return
+	}
+	out := make([]byte, internalDBRecordHdrLen, internalDBRecordHdrLen+len(b))
+	binary.BigEndian.PutUint32(out, uint32(len(b)))
+	binary.BigEndian.PutUint32(out[4:], crc32.ChecksumIEEE(b))
+	_, err = w.Write(append(out, b...)) // one write so the record is not interleaved
+	return
+}
+
+// encodeValue marshals the item in order to be written on disk
+func (dmp *internalDump) encodeValue(chID string, value interface{}) (b []byte, err error) {
+	if value == nil {
+		return
+	}
+	if chID == utils.CacheStatQueues { // metrics are interfaces so we store them marshaled
+		var ssq *StoredStatQueue
+		if ssq, err = NewStoredStatQueue(value.(*StatQueue), dmp.ms); err != nil {
+			return
+		}
+		value = ssq
+	}
+	return dmp.ms.Marshal(value)
+}
+
+// decodeValue unmarshals the item read from disk into the type stored in the partition
+func (dmp *internalDump) decodeValue(chID string, b []byte) (value interface{}, err error) {
+	if len(b) == 0 {
+		return
+	}
+	switch chID {
+	case utils.CacheStatQueues:
+		ssq := new(StoredStatQueue)
+		if err = dmp.ms.Unmarshal(b, ssq); err != nil {
+			return
+		}
+		return ssq.AsStatQueue(dmp.ms)
+	case utils.CacheFilters:
+		fltr := new(Filter)
+		if err = dmp.ms.Unmarshal(b, fltr); err != nil {
+			return
+		}
+		if err = fltr.Compile(); err != nil {
+			return
+		}
+		return fltr, nil
+	}
+	typ, has := internalDBItemTypes[chID]
+	if !has {
+		return nil, fmt.Errorf("unsupported partition <%s>", chID)
+	}
+	v := reflect.New(typ)
+	if err = dmp.ms.Unmarshal(b, v.Interface()); err != nil {
+		return
+	}
+	return v.Elem().Interface(), nil
+}
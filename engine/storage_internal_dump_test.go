@@ -0,0 +1,175 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInternalDBPersistence(t *testing.T) {
+	dirPath, err := ioutil.TempDir(utils.EmptyString, "internal_datadb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+	iDB := NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	if err = iDB.EnablePersistence(dirPath, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: {{ID: "Balance1", Value: 10, Weight: 10}},
+		},
+	}
+	if err = iDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	asr, err := NewASR(0, utils.EmptyString, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = asr.AddEvent(&utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+		Event: map[string]interface{}{utils.AnswerTime: time.Now()}}); err != nil {
+		t.Fatal(err)
+	}
+	sq := &StatQueue{Tenant: "cgrates.org", ID: "SQ1",
+		SQItems:   []SQItem{{EventID: "cgrates.org:ev1"}},
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr},
+	}
+	if err = iDB.SetStatQueueDrv(nil, sq); err != nil {
+		t.Fatal(err)
+	}
+	eSQItems := []SQItem{{EventID: "cgrates.org:ev1"}}
+	// changed in place without being stored, the snapshot keeps the stored value
+	sq.SQItems = append(sq.SQItems, SQItem{EventID: "cgrates.org:ev2"})
+	fltr := &Filter{Tenant: "cgrates.org", ID: "FLTR_1",
+		Rules: []*FilterRule{{Type: utils.MetaString, Element: "~*req.Account", Values: []string{"1001"}}}}
+	if err = fltr.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetFilterDrv(fltr); err != nil {
+		t.Fatal(err)
+	}
+	idxs := map[string]utils.StringSet{
+		"*string:~*req.Account:1001": utils.NewStringSet([]string{"ATTR_1"}),
+		"*string:~*req.Account:1002": utils.NewStringSet([]string{"ATTR_2"}),
+	}
+	if err = iDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		idxs, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.RemoveIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		"*string:~*req.Account:1002"); err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+
+	// simulate a crash while writing the last change
+	if err = ioutil.WriteFile(path.Join(dirPath, internalDBChangeLogFile),
+		[]byte{0, 0, 0, 100, 1, 2}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	iDB = NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	if err = iDB.EnablePersistence(dirPath, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	} else if rcv.GetBalanceWithID(utils.MONETARY, "Balance1").GetValue() != 10 {
+		t.Errorf("Unexpected account: %s", utils.ToJSON(rcv))
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if rcv, err := iDB.GetStatQueueDrv("cgrates.org", "SQ1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSQItems, rcv.SQItems) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(eSQItems), utils.ToJSON(rcv.SQItems))
+	} else if rcv.SQMetrics[utils.MetaASR].GetValue() != asr.GetValue() {
+		t.Errorf("Expected: %v, received: %v", asr.GetValue(), rcv.SQMetrics[utils.MetaASR].GetValue())
+	}
+	if rcv, err := iDB.GetFilterDrv("cgrates.org", "FLTR_1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(fltr, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(fltr), utils.ToJSON(rcv))
+	}
+	eIdxs := map[string]utils.StringSet{
+		"*string:~*req.Account:1001": utils.NewStringSet([]string{"ATTR_1"}),
+	}
+	if rcv, err := iDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eIdxs, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(eIdxs), utils.ToJSON(rcv))
+	}
+	// the groups are restored as well
+	if err = iDB.RemoveIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := iDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestInternalStorDBPersistence(t *testing.T) {
+	dirPath, err := ioutil.TempDir(utils.EmptyString, "internal_stordb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+	iDB := NewInternalDB(nil, nil, false, config.CgrConfig().StorDbCfg().Items)
+	if err = iDB.EnablePersistence(dirPath, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	cdr := &CDR{CGRID: "CGRID1", RunID: utils.MetaDefault, OriginID: "Origin1",
+		ToR: utils.VOICE, Tenant: "cgrates.org", Account: "1001", Destination: "1002",
+		Usage: time.Minute, Cost: 0.6}
+	if err = iDB.SetCDR(cdr, false); err != nil {
+		t.Fatal(err)
+	}
+	// no Close so the data is restored from the change log only
+	iDB = NewInternalDB(nil, nil, false, config.CgrConfig().StorDbCfg().Items)
+	if err = iDB.EnablePersistence(dirPath, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if cdrs, _, err := iDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1001"}}, false); err != nil {
+		t.Error(err)
+	} else if len(cdrs) != 1 || !reflect.DeepEqual(cdr, cdrs[0]) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(cdr), utils.ToJSON(cdrs))
+	}
+}
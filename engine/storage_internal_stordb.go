@@ -1727,6 +1727,18 @@ func (iDB *InternalDB) GetCDRs(filter *utils.CDRsFilter, remove bool) (cdrs []*C
 	return
 }
 
+// GetCDRsReport aggregates in memory the CDRs matching the filter
+func (iDB *InternalDB) GetCDRsReport(filter *utils.CDRsFilter, grpBy []*CDRsGroupBy) (grps []*CDRsReportGroup, err error) {
+	var cdrs []*CDR
+	if cdrs, _, err = iDB.GetCDRs(filter, false); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	return groupCDRs(cdrs, grpBy), nil
+}
+
 func (iDB *InternalDB) GetSMCosts(cgrid, runid, originHost, originIDPrfx string) (smCosts []*SMCost, err error) {
 	var smMpIDs utils.StringMap
 	for _, fltrSlc := range []struct {
@@ -1797,3 +1809,142 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return err
 }
+
+// SetAuditRecord stores the record of an administrative call
+func (iDB *InternalDB) SetAuditRecord(rec *utils.AuditRecord) (err error) {
+	idxs := utils.NewStringSet(nil)
+	idxs.Add(utils.ConcatenatedKey(utils.Caller, rec.Caller))
+	idxs.Add(utils.ConcatenatedKey(utils.Method, rec.Method))
+	idxs.Add(utils.ConcatenatedKey(utils.RemoteAddr, rec.RemoteAddr))
+	iDB.db.Set(utils.AuditRecordsTBL, utils.GenUUID(), rec, idxs.AsSlice(),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// GetAuditRecords returns the audit trail ordered by time
+func (iDB *InternalDB) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) (recs []*utils.AuditRecord, err error) {
+	var recIDs utils.StringSet
+	for _, fltrSlc := range []struct {
+		key string
+		ids []string
+	}{
+		{utils.Caller, qryFltr.Callers},
+		{utils.Method, qryFltr.Methods},
+		{utils.RemoteAddr, qryFltr.RemoteAddrs},
+	} {
+		if len(fltrSlc.ids) == 0 {
+			continue
+		}
+		grpIDs := utils.NewStringSet(nil)
+		for _, id := range fltrSlc.ids {
+			grpIDs.AddSlice(iDB.db.GetGroupItemIDs(utils.AuditRecordsTBL, utils.ConcatenatedKey(fltrSlc.key, id)))
+		}
+		if recIDs == nil {
+			recIDs = grpIDs
+		} else {
+			recIDs.Intersect(grpIDs)
+		}
+	}
+	if recIDs == nil {
+		recIDs = utils.NewStringSet(iDB.db.GetItemIDs(utils.AuditRecordsTBL, utils.EmptyString))
+	}
+	for id := range recIDs {
+		x, ok := iDB.db.Get(utils.AuditRecordsTBL, id)
+		if !ok || x == nil {
+			continue
+		}
+		rec := x.(*utils.AuditRecord)
+		if qryFltr.TimeStart != nil && rec.Time.Before(*qryFltr.TimeStart) ||
+			qryFltr.TimeEnd != nil && !rec.Time.Before(*qryFltr.TimeEnd) {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].Time.Before(recs[j].Time)
+	})
+	if qryFltr.Offset != nil {
+		if *qryFltr.Offset >= len(recs) {
+			recs = nil
+		} else {
+			recs = recs[*qryFltr.Offset:]
+		}
+	}
+	if qryFltr.Limit != nil && *qryFltr.Limit < len(recs) {
+		recs = recs[:*qryFltr.Limit]
+	}
+	if len(recs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// SetActionExecution stores the execution of an ActionTiming
+func (iDB *InternalDB) SetActionExecution(exec *utils.ActionExecution) (err error) {
+	idxs := utils.NewStringSet(nil)
+	idxs.Add(utils.ConcatenatedKey(utils.ActionPlanID, exec.ActionPlanID))
+	idxs.Add(utils.ConcatenatedKey(utils.ActionsID, exec.ActionsID))
+	idxs.Add(utils.ConcatenatedKey(utils.Timing, exec.Timing))
+	iDB.db.Set(utils.ActionExecutionsTBL, utils.GenUUID(), exec, idxs.AsSlice(),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// GetActionExecutions returns the execution history, the most recent first
+func (iDB *InternalDB) GetActionExecutions(qryFltr *utils.ActionExecutionsFilter) (execs []*utils.ActionExecution, err error) {
+	var execIDs utils.StringSet
+	for _, fltrSlc := range []struct {
+		key string
+		ids []string
+	}{
+		{utils.ActionPlanID, qryFltr.ActionPlanIDs},
+		{utils.ActionsID, qryFltr.ActionsIDs},
+		{utils.Timing, qryFltr.Timings},
+	} {
+		if len(fltrSlc.ids) == 0 {
+			continue
+		}
+		grpIDs := utils.NewStringSet(nil)
+		for _, id := range fltrSlc.ids {
+			grpIDs.AddSlice(iDB.db.GetGroupItemIDs(utils.ActionExecutionsTBL, utils.ConcatenatedKey(fltrSlc.key, id)))
+		}
+		if execIDs == nil {
+			execIDs = grpIDs
+		} else {
+			execIDs.Intersect(grpIDs)
+		}
+	}
+	if execIDs == nil {
+		execIDs = utils.NewStringSet(iDB.db.GetItemIDs(utils.ActionExecutionsTBL, utils.EmptyString))
+	}
+	for id := range execIDs {
+		x, ok := iDB.db.Get(utils.ActionExecutionsTBL, id)
+		if !ok || x == nil {
+			continue
+		}
+		exec := x.(*utils.ActionExecution)
+		if qryFltr.Failed != nil && *qryFltr.Failed != (exec.Error != utils.EmptyString) ||
+			qryFltr.TimeStart != nil && exec.Time.Before(*qryFltr.TimeStart) ||
+			qryFltr.TimeEnd != nil && !exec.Time.Before(*qryFltr.TimeEnd) {
+			continue
+		}
+		execs = append(execs, exec)
+	}
+	sort.Slice(execs, func(i, j int) bool {
+		return execs[i].Time.After(execs[j].Time)
+	})
+	if qryFltr.Offset != nil {
+		if *qryFltr.Offset >= len(execs) {
+			execs = nil
+		} else {
+			execs = execs[*qryFltr.Offset:]
+		}
+	}
+	if qryFltr.Limit != nil && *qryFltr.Limit < len(execs) {
+		execs = execs[:*qryFltr.Limit]
+	}
+	if len(execs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
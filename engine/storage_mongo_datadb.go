@@ -75,7 +75,10 @@ const (
 	ColDpp  = "dispatcher_profiles"
 	ColDph  = "dispatcher_hosts"
 	ColRpp  = "rate_profiles"
+	ColLkt  = "lookup_tables"
 	ColLID  = "load_ids"
+	ColGlk  = "guardian_locks"
+	ColCin  = "cache_invalidations"
 )
 
 var (
@@ -104,6 +107,14 @@ var (
 	DestinationLow     = strings.ToLower(utils.Destination)
 	CostLow            = strings.ToLower(utils.COST)
 	CostSourceLow      = strings.ToLower(utils.CostSource)
+	CallerLow          = strings.ToLower(utils.Caller)
+	MethodLow          = strings.ToLower(utils.Method)
+	RemoteAddrLow      = strings.ToLower(utils.RemoteAddr)
+	TimeLow            = strings.ToLower(utils.Time)
+	ActionPlanIDLow    = strings.ToLower(utils.ActionPlanID)
+	ActionsIDLow       = strings.ToLower(utils.ActionsID)
+	TimingLow          = strings.ToLower(utils.Timing)
+	ErrorLow           = strings.ToLower(utils.Error)
 
 	tTime = reflect.TypeOf(time.Time{})
 )
@@ -295,6 +306,10 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColLkt:
+		if err = ms.enusureIndex(col, true, "tenant", "id", "key"); err != nil {
+			return
+		}
 		//StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
@@ -334,6 +349,20 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 			OriginIDLow); err != nil {
 			return
 		}
+	case utils.AuditRecordsTBL:
+		if err = ms.enusureIndex(col, false, TimeLow); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, CallerLow, TimeLow); err != nil {
+			return
+		}
+	case utils.ActionExecutionsTBL:
+		if err = ms.enusureIndex(col, false, TimeLow); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, ActionPlanIDLow, ActionsIDLow, TimingLow, TimeLow); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -352,7 +381,7 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
 			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp,
-			ColRpf, ColShg, ColAcc} {
+			ColRpf, ColShg, ColAcc, ColLkt} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -364,7 +393,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL,
+			utils.AuditRecordsTBL, utils.ActionExecutionsTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -2114,6 +2144,130 @@ func (ms *MongoStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetLookupTableValueDrv(tenant, id, key string) (value string, err error) {
+	var result struct{ Value string }
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColLkt).FindOne(sctx, bson.M{"tenant": tenant, "id": id, "key": key})
+		if err := cur.Decode(&result); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return result.Value, err
+}
+
+// GetLookupTablePrefixValueDrv queries all the prefixes of the key at once, returning the value of the longest one
+func (ms *MongoStorage) GetLookupTablePrefixValueDrv(tenant, id, key string) (value string, err error) {
+	prfxs := make([]string, len(key))
+	for i := range key {
+		prfxs[i] = key[:i+1]
+	}
+	var found bool
+	var lngKey string
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColLkt).Find(sctx, bson.M{"tenant": tenant, "id": id,
+			"key": bson.M{"$in": prfxs}})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var entry struct{ Key, Value string }
+			if err = cur.Decode(&entry); err != nil {
+				cur.Close(sctx)
+				return err
+			}
+			if !found || len(entry.Key) > len(lngKey) {
+				found, lngKey, value = true, entry.Key, entry.Value
+			}
+		}
+		return cur.Close(sctx)
+	})
+	if err == nil && !found {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MongoStorage) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	lt = &LookupTable{Tenant: tenant, ID: id, Entries: make(map[string]string)}
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColLkt).Find(sctx, bson.M{"tenant": tenant, "id": id})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var entry struct{ Key, Value string }
+			if err = cur.Decode(&entry); err != nil {
+				cur.Close(sctx)
+				return err
+			}
+			lt.Entries[entry.Key] = entry.Value
+		}
+		return cur.Close(sctx)
+	}); err != nil {
+		return nil, err
+	}
+	if len(lt.Entries) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// SetLookupTableDrv merges the entries into the table, one document per entry
+// on replace the entries are marked with the ID of this write and the ones not marked are removed after
+func (ms *MongoStorage) SetLookupTableDrv(lt *LookupTable, replace bool) (err error) {
+	wrID := utils.GenUUID()
+	mdls := make([]mongo.WriteModel, 0, lookupTableBatchSize)
+	for key, value := range lt.Entries {
+		mdls = append(mdls, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"tenant": lt.Tenant, "id": lt.ID, "key": key}).
+			SetUpdate(bson.M{"$set": bson.M{"value": value, "wrid": wrID}}).
+			SetUpsert(true))
+		if len(mdls) == lookupTableBatchSize {
+			if err = ms.setLookupTableEntries(mdls); err != nil {
+				return
+			}
+			mdls = mdls[:0]
+		}
+	}
+	if len(mdls) != 0 {
+		if err = ms.setLookupTableEntries(mdls); err != nil {
+			return
+		}
+	}
+	if !replace {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColLkt).DeleteMany(sctx,
+			bson.M{"tenant": lt.Tenant, "id": lt.ID, "wrid": bson.M{"$ne": wrID}})
+		return err
+	})
+}
+
+func (ms *MongoStorage) setLookupTableEntries(mdls []mongo.WriteModel) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColLkt).BulkWrite(sctx, mdls, options.BulkWrite().SetOrdered(false))
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColLkt).DeleteMany(sctx, bson.M{"tenant": tenant, "id": id})
+		if err != nil {
+			return err
+		}
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
 	fop := options.FindOne()
 	if itemIDPrefix != "" {
@@ -2314,3 +2468,147 @@ func (ms *MongoStorage) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err
 		return err
 	})
 }
+
+// isDuplicateKeyError returns true if the write failed because the _id is already used
+func isDuplicateKeyError(err error) bool {
+	switch mgoErr := err.(type) {
+	case mongo.WriteException:
+		for _, wErr := range mgoErr.WriteErrors {
+			if wErr.Code == 11000 {
+				return true
+			}
+		}
+	case mongo.CommandError:
+		return mgoErr.Code == 11000
+	}
+	return false
+}
+
+// AcquireLock implements guardian.LockBackend
+// the expiry is computed with the local clock so the engines need to be time synchronized
+func (ms *MongoStorage) AcquireLock(lkID, owner string, ttl time.Duration) (acquired bool, err error) {
+	now := time.Now()
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColGlk).UpdateOne(sctx,
+			bson.M{"_id": lkID, "expiry": bson.M{"$lt": now}}, // free or expired
+			bson.M{"$set": bson.M{"owner": owner, "expiry": now.Add(ttl)}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	}); err != nil {
+		if isDuplicateKeyError(err) { // owned by somebody else
+			err = nil
+		}
+		return
+	}
+	return true, nil
+}
+
+// RefreshLock implements guardian.LockBackend
+func (ms *MongoStorage) RefreshLock(lkID, owner string, ttl time.Duration) (owned bool, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		var rply *mongo.UpdateResult
+		if rply, err = ms.getCol(ColGlk).UpdateOne(sctx,
+			bson.M{"_id": lkID, "owner": owner},
+			bson.M{"$set": bson.M{"expiry": time.Now().Add(ttl)}},
+		); err != nil {
+			return
+		}
+		owned = rply.MatchedCount != 0
+		return
+	})
+	return
+}
+
+// ReleaseLock implements guardian.LockBackend
+func (ms *MongoStorage) ReleaseLock(lkID, owner string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColGlk).DeleteOne(sctx, bson.M{"_id": lkID, "owner": owner})
+		return
+	})
+}
+
+// mongoCacheInvalidation is the CacheInvalidation as stored in ColCin
+type mongoCacheInvalidation struct {
+	CacheInvalidation `bson:",inline"`
+	Created           time.Time // the documents expire after mongoCacheInvalidationTTL
+}
+
+const mongoCacheInvalidationTTL = 60 // seconds
+
+// PublishCacheInvalidation implements CacheInvalidationBus
+func (ms *MongoStorage) PublishCacheInvalidation(msg *CacheInvalidation) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCin).InsertOne(sctx,
+			&mongoCacheInvalidation{CacheInvalidation: *msg, Created: time.Now()})
+		return
+	})
+}
+
+// SubscribeCacheInvalidations implements CacheInvalidationBus
+// uses change streams so MongoDB needs to run as replica set
+func (ms *MongoStorage) SubscribeCacheInvalidations(subscribed func(),
+	hndlr func(*CacheInvalidation), stop chan struct{}) (err error) {
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCin).Indexes().CreateOne(sctx, mongo.IndexModel{
+			Keys:    bson.M{"created": 1},
+			Options: options.Index().SetExpireAfterSeconds(mongoCacheInvalidationTTL),
+		})
+		return
+	}); err != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ms.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	var cs *mongo.ChangeStream
+	if cs, err = ms.getCol(ColCin).Watch(ctx,
+		mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}); err != nil {
+		return
+	}
+	defer cs.Close(ms.ctx)
+	subscribed()
+	for cs.Next(ctx) {
+		var chg struct {
+			FullDocument mongoCacheInvalidation `bson:"fullDocument"`
+		}
+		if errDec := cs.Decode(&chg); errDec != nil {
+			utils.Logger.Warning(fmt.Sprintf("<MongoStorage> ignoring cache invalidation, error: %s", errDec.Error()))
+			continue
+		}
+		hndlr(&chg.FullDocument.CacheInvalidation)
+	}
+	select {
+	case <-stop:
+		return nil
+	default:
+	}
+	return cs.Err()
+}
+
+// GetLockOwner returns the owner of the lock together with its expiry
+func (ms *MongoStorage) GetLockOwner(lkID string) (owner string, expiry time.Time, err error) {
+	var lk struct {
+		Owner  string
+		Expiry time.Time
+	}
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColGlk).FindOne(sctx, bson.M{"_id": lkID, "expiry": bson.M{"$gte": time.Now()}})
+		if err := cur.Decode(&lk); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return
+	}
+	return lk.Owner, lk.Expiry, nil
+}
@@ -934,6 +934,101 @@ func (ms *MongoStorage) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
 	})
 }
 
+// SetAuditRecord stores the record of an administrative call
+func (ms *MongoStorage) SetAuditRecord(rec *utils.AuditRecord) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.AuditRecordsTBL).InsertOne(sctx, rec)
+		return err
+	})
+}
+
+// GetAuditRecords returns the audit trail ordered by time
+func (ms *MongoStorage) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) (recs []*utils.AuditRecord, err error) {
+	filters := bson.M{
+		CallerLow:     bson.M{"$in": qryFltr.Callers},
+		MethodLow:     bson.M{"$in": qryFltr.Methods},
+		RemoteAddrLow: bson.M{"$in": qryFltr.RemoteAddrs},
+		TimeLow:       bson.M{"$gte": qryFltr.TimeStart, "$lt": qryFltr.TimeEnd},
+	}
+	ms.cleanEmptyFilters(filters)
+	fop := options.Find().SetSort(bson.M{TimeLow: 1})
+	if qryFltr.Limit != nil {
+		fop = fop.SetLimit(int64(*qryFltr.Limit))
+	}
+	if qryFltr.Offset != nil {
+		fop = fop.SetSkip(int64(*qryFltr.Offset))
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.AuditRecordsTBL).Find(sctx, filters, fop)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var rec utils.AuditRecord
+			if err := cur.Decode(&rec); err != nil {
+				return err
+			}
+			recs = append(recs, &rec)
+		}
+		if len(recs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
+// SetActionExecution stores the execution of an ActionTiming
+func (ms *MongoStorage) SetActionExecution(exec *utils.ActionExecution) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.ActionExecutionsTBL).InsertOne(sctx, exec)
+		return err
+	})
+}
+
+// GetActionExecutions returns the execution history, the most recent first
+func (ms *MongoStorage) GetActionExecutions(qryFltr *utils.ActionExecutionsFilter) (execs []*utils.ActionExecution, err error) {
+	filters := bson.M{
+		ActionPlanIDLow: bson.M{"$in": qryFltr.ActionPlanIDs},
+		ActionsIDLow:    bson.M{"$in": qryFltr.ActionsIDs},
+		TimingLow:       bson.M{"$in": qryFltr.Timings},
+		TimeLow:         bson.M{"$gte": qryFltr.TimeStart, "$lt": qryFltr.TimeEnd},
+	}
+	ms.cleanEmptyFilters(filters)
+	if qryFltr.Failed != nil {
+		if *qryFltr.Failed {
+			filters[ErrorLow] = bson.M{"$ne": utils.EmptyString}
+		} else {
+			filters[ErrorLow] = utils.EmptyString
+		}
+	}
+	fop := options.Find().SetSort(bson.M{TimeLow: -1})
+	if qryFltr.Limit != nil {
+		fop = fop.SetLimit(int64(*qryFltr.Limit))
+	}
+	if qryFltr.Offset != nil {
+		fop = fop.SetSkip(int64(*qryFltr.Offset))
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.ActionExecutionsTBL).Find(sctx, filters, fop)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var exec utils.ActionExecution
+			if err := cur.Decode(&exec); err != nil {
+				return err
+			}
+			execs = append(execs, &exec)
+		}
+		if len(execs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
 func (ms *MongoStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	if cdr.OrderID == 0 {
 		cdr.OrderID = ms.cnter.Next()
@@ -985,19 +1080,19 @@ func (ms *MongoStorage) cleanEmptyFilters(filters bson.M) {
 	}
 }
 
-//  _, err := col(ColCDRs).UpdateAll(bson.M{CGRIDLow: bson.M{"$in": cgrIds}}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
-func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR, int64, error) {
+// cdrsFilter builds the query selecting the CDRs matching the filter
+func (ms *MongoStorage) cdrsFilter(qryFltr *utils.CDRsFilter) (bson.M, error) {
 	var minUsage, maxUsage *time.Duration
 	if len(qryFltr.MinUsage) != 0 {
 		if parsed, err := utils.ParseDurationWithNanosecs(qryFltr.MinUsage); err != nil {
-			return nil, 0, err
+			return nil, err
 		} else {
 			minUsage = &parsed
 		}
 	}
 	if len(qryFltr.MaxUsage) != 0 {
 		if parsed, err := utils.ParseDurationWithNanosecs(qryFltr.MaxUsage); err != nil {
-			return nil, 0, err
+			return nil, err
 		} else {
 			maxUsage = &parsed
 		}
@@ -1098,6 +1193,15 @@ func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	}
 	//file.WriteString(fmt.Sprintf("AFTER: %v\n", utils.ToIJSON(filters)))
 	//file.Close()
+	return filters, nil
+}
+
+//  _, err := col(ColCDRs).UpdateAll(bson.M{CGRIDLow: bson.M{"$in": cgrIds}}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR, int64, error) {
+	filters, err := ms.cdrsFilter(qryFltr)
+	if err != nil {
+		return nil, 0, err
+	}
 	if remove {
 		var chgd int64
 		err := ms.query(func(sctx mongo.SessionContext) (err error) {
@@ -1154,7 +1258,7 @@ func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	}
 	// Execute query
 	var cdrs []*CDR
-	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
 		cur, err := ms.getCol(ColCDRs).Find(sctx, filters, fop)
 		if err != nil {
 			return err
@@ -1177,6 +1281,79 @@ func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	return cdrs, 0, err
 }
 
+// mongoCDRsDateLayouts formats the AnswerTime for the periods of the report
+var mongoCDRsDateLayouts = map[string]string{
+	utils.MetaMonthly: "%Y-%m",
+	utils.MetaDaily:   "%Y-%m-%d",
+	utils.MetaHourly:  "%Y-%m-%dT%H",
+}
+
+// GetCDRsReport aggregates the CDRs matching the filter with the aggregation pipeline
+func (ms *MongoStorage) GetCDRsReport(qryFltr *utils.CDRsFilter, grpBy []*CDRsGroupBy) (grps []*CDRsReportGroup, err error) {
+	var filters bson.M
+	if filters, err = ms.cdrsFilter(qryFltr); err != nil {
+		return
+	}
+	grpID := bson.M{}
+	for i, grp := range grpBy {
+		fldPath := "$" + strings.ToLower(grp.Field)
+		var expr interface{} = fldPath
+		if grp.Period != utils.EmptyString {
+			expr = bson.M{"$dateToString": bson.M{"format": mongoCDRsDateLayouts[grp.Period], "date": fldPath, "timezone": "UTC"}}
+		} else if grp.Prefix != 0 {
+			expr = bson.M{"$substrCP": bson.A{fldPath, 0, grp.Prefix}}
+		}
+		grpID[fmt.Sprintf("g%d", i)] = expr
+	}
+	pipeline := bson.A{
+		bson.M{"$match": filters},
+		bson.M{"$group": bson.M{
+			"_id":    grpID,
+			"count":  bson.M{"$sum": 1},
+			UsageLow: bson.M{"$sum": "$" + UsageLow},
+			CostLow: bson.M{"$sum": bson.M{ // the negative costs mark unrated CDRs
+				"$cond": bson.A{bson.M{"$gte": bson.A{"$" + CostLow, 0}}, "$" + CostLow, 0}}},
+			"unrated": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$lt": bson.A{"$" + CostLow, 0}}, 1, 0}}},
+			"unrated_usage": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$lt": bson.A{"$" + CostLow, 0}}, "$" + UsageLow, 0}}},
+		}},
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColCDRs).Aggregate(sctx, pipeline)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var rslt struct {
+				ID           map[string]string `bson:"_id"`
+				Count        int64             `bson:"count"`
+				Usage        int64             `bson:"usage"`
+				Cost         float64           `bson:"cost"`
+				Unrated      int64             `bson:"unrated"`
+				UnratedUsage int64             `bson:"unrated_usage"`
+			}
+			if err = cur.Decode(&rslt); err != nil {
+				return err
+			}
+			grp := &CDRsReportGroup{
+				GroupBy:      make(map[string]string),
+				Count:        rslt.Count,
+				Usage:        time.Duration(rslt.Usage),
+				Cost:         rslt.Cost,
+				Unrated:      rslt.Unrated,
+				UnratedUsage: time.Duration(rslt.UnratedUsage),
+			}
+			for i, gb := range grpBy {
+				grp.GroupBy[gb.ID] = rslt.ID[fmt.Sprintf("g%d", i)]
+			}
+			grps = append(grps, grp)
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
 func (ms *MongoStorage) SetTPStats(tpSTs []*utils.TPStatProfile) (err error) {
 	if len(tpSTs) == 0 {
 		return
@@ -87,6 +87,20 @@ func (self *MySQLStorage) notExtraFieldsValueQry(field, value string) string {
 	return fmt.Sprintf(" extra_fields NOT LIKE '%%\"%s\":\"%s\"%%'", field, value)
 }
 
+// answerTimeFormatQry groups on the local hour since the DATETIME keeps the time of the engine (loc=Local),
+// answerTimePeriod moves it then to the UTC period
+func (self *MySQLStorage) answerTimeFormatQry(period string) string {
+	return "DATE_FORMAT(answer_time, '%Y-%m-%d %H')"
+}
+
+func (self *MySQLStorage) answerTimePeriod(period, val string) (string, error) {
+	t, err := time.ParseInLocation("2006-01-02 15", val, time.Local)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(cdrsGroupByLayouts[period]), nil
+}
+
 func (self *MySQLStorage) GetStorageType() string {
 	return utils.MYSQL
 }
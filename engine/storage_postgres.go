@@ -91,6 +91,21 @@ func (self *PostgresStorage) notExtraFieldsValueQry(field, value string) string
 	return fmt.Sprintf(" NOT (extra_fields ?'%s' AND (extra_fields ->> '%s') = '%s')", field, field, value)
 }
 
+func (self *PostgresStorage) answerTimeFormatQry(period string) string {
+	layout := `YYYY-MM-DD"T"HH24`
+	switch period {
+	case utils.MetaMonthly:
+		layout = "YYYY-MM"
+	case utils.MetaDaily:
+		layout = "YYYY-MM-DD"
+	}
+	return fmt.Sprintf("to_char(answer_time AT TIME ZONE 'UTC', '%s')", layout)
+}
+
+func (self *PostgresStorage) answerTimePeriod(period, val string) (string, error) {
+	return val, nil
+}
+
 func (self *PostgresStorage) GetStorageType() string {
 	return utils.POSTGRES
 }
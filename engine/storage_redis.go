@@ -33,6 +33,7 @@ import (
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
 	"github.com/mediocregopher/radix.v2/pool"
+	"github.com/mediocregopher/radix.v2/pubsub"
 	"github.com/mediocregopher/radix.v2/redis"
 	"github.com/mediocregopher/radix.v2/sentinel"
 )
@@ -78,6 +79,13 @@ const (
 	redis_HGET     = "HGET"
 	redis_RENAME   = "RENAME"
 	redis_HMSET    = "HMSET"
+	redis_EVAL     = "EVAL"
+	redis_PTTL     = "PTTL"
+	redis_PUBLISH  = "PUBLISH"
+
+	// refresh and release the lock only if we still own it
+	redis_luaRefreshLock = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) end return 0`
+	redis_luaReleaseLock = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
 )
 
 func NewRedisStorage(address string, db int, pass, mrshlerStr string,
@@ -1583,6 +1591,91 @@ func (rs *RedisStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (rs *RedisStorage) GetLookupTableValueDrv(tenant, id, key string) (value string, err error) {
+	if value, err = rs.Cmd(redis_HGET, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id), key).Str(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+	}
+	return
+}
+
+// GetLookupTablePrefixValueDrv queries all the prefixes of the key with one HMGET, returning the value of the longest one
+func (rs *RedisStorage) GetLookupTablePrefixValueDrv(tenant, id, key string) (value string, err error) {
+	if len(key) == 0 {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	args := make([]interface{}, 1, len(key)+1)
+	args[0] = utils.LookupTablePrefix + utils.ConcatenatedKey(tenant, id)
+	for i := len(key); i > 0; i-- {
+		args = append(args, key[:i])
+	}
+	var vals []*redis.Resp
+	if vals, err = rs.Cmd(redis_HMGET, args...).Array(); err != nil {
+		return
+	}
+	for _, val := range vals { // ordered from the longest prefix
+		if !val.IsType(redis.Nil) {
+			return val.Str()
+		}
+	}
+	return utils.EmptyString, utils.ErrNotFound
+}
+
+func (rs *RedisStorage) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	var mp map[string]string
+	if mp, err = rs.Cmd(redis_HGETALL, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id)).Map(); err != nil {
+		return
+	}
+	if len(mp) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return &LookupTable{Tenant: tenant, ID: id, Entries: mp}, nil
+}
+
+// SetLookupTableDrv merges the entries into the table, a hash per table
+// on replace the entries are written in a new hash which is renamed over the old one
+func (rs *RedisStorage) SetLookupTableDrv(lt *LookupTable, replace bool) (err error) {
+	dbKey := utils.LookupTablePrefix + lt.TenantID()
+	if replace && len(lt.Entries) == 0 {
+		return rs.Cmd(redis_DEL, dbKey).Err
+	}
+	wrKey := dbKey
+	if replace {
+		wrKey = dbKey + utils.InInFieldSep + utils.GenUUID()
+	}
+	mp := make(map[string]string)
+	for key, value := range lt.Entries {
+		mp[key] = value
+		if len(mp) == lookupTableBatchSize {
+			if err = rs.Cmd(redis_HMSET, wrKey, mp).Err; err != nil {
+				return
+			}
+			mp = make(map[string]string)
+		}
+	}
+	if len(mp) != 0 {
+		if err = rs.Cmd(redis_HMSET, wrKey, mp).Err; err != nil {
+			return
+		}
+	}
+	if replace {
+		err = rs.Cmd(redis_RENAME, wrKey, dbKey).Err
+	}
+	return
+}
+
+func (rs *RedisStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	var n int
+	if n, err = rs.Cmd(redis_DEL, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id)).Int(); err != nil {
+		return
+	}
+	if n == 0 {
+		return utils.ErrNotFound
+	}
+	return
+}
+
 func (rs *RedisStorage) GetStorageType() string {
 	return utils.REDIS
 }
@@ -1728,3 +1821,116 @@ func (rs *RedisStorage) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err
 	}
 	return rs.Cmd(redis_HDEL, utils.CacheInstanceToPrefix[idxItmType]+tntCtx, idxKey).Err
 }
+
+// AcquireLock implements guardian.LockBackend
+func (rs *RedisStorage) AcquireLock(lkID, owner string, ttl time.Duration) (acquired bool, err error) {
+	rply := rs.Cmd(redis_SET, utils.GuardianLockPrefix+lkID, owner, "NX", "PX", ttl.Nanoseconds()/1e6)
+	if rply.IsType(redis.Nil) { // owned by somebody else
+		return
+	}
+	if err = rply.Err; err != nil {
+		return
+	}
+	return true, nil
+}
+
+// RefreshLock implements guardian.LockBackend
+func (rs *RedisStorage) RefreshLock(lkID, owner string, ttl time.Duration) (owned bool, err error) {
+	var rply int
+	if rply, err = rs.Cmd(redis_EVAL, redis_luaRefreshLock, 1,
+		utils.GuardianLockPrefix+lkID, owner, ttl.Nanoseconds()/1e6).Int(); err != nil {
+		return
+	}
+	return rply == 1, nil
+}
+
+// ReleaseLock implements guardian.LockBackend
+func (rs *RedisStorage) ReleaseLock(lkID, owner string) (err error) {
+	return rs.Cmd(redis_EVAL, redis_luaReleaseLock, 1,
+		utils.GuardianLockPrefix+lkID, owner).Err
+}
+
+// PublishCacheInvalidation implements CacheInvalidationBus
+func (rs *RedisStorage) PublishCacheInvalidation(msg *CacheInvalidation) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(msg); err != nil {
+		return
+	}
+	return rs.Cmd(redis_PUBLISH, utils.CacheInvalidationChannel, result).Err
+}
+
+// SubscribeCacheInvalidations implements CacheInvalidationBus
+func (rs *RedisStorage) SubscribeCacheInvalidations(subscribed func(),
+	hndlr func(*CacheInvalidation), stop chan struct{}) (err error) {
+	var conn *redis.Client
+	if conn, err = rs.dedicatedConn(); err != nil {
+		return
+	}
+	defer conn.Close()             // not returned to pool since it stays in subscribe mode
+	conn.ReadTimeout = time.Second // check periodically for stop
+	subC := pubsub.NewSubClient(conn)
+	if err = subC.Subscribe(utils.CacheInvalidationChannel).Err; err != nil {
+		return
+	}
+	subscribed()
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		rply := subC.Receive()
+		if rply.Timeout() {
+			continue
+		}
+		if rply.Err != nil {
+			return rply.Err
+		}
+		if rply.Type != pubsub.Message {
+			continue
+		}
+		msg := new(CacheInvalidation)
+		if errUnm := rs.ms.Unmarshal([]byte(rply.Message), msg); errUnm != nil {
+			utils.Logger.Warning(fmt.Sprintf("<RedisStorage> ignoring cache invalidation <%s>, error: %s",
+				rply.Message, errUnm.Error()))
+			continue
+		}
+		hndlr(msg)
+	}
+}
+
+// dedicatedConn returns a connection outside the pool management, to be closed by the caller
+func (rs *RedisStorage) dedicatedConn() (conn *redis.Client, err error) {
+	if rs.sentinelName == utils.EmptyString {
+		return rs.dbPool.Get()
+	}
+	rs.sentinelMux.RLock()
+	defer rs.sentinelMux.RUnlock()
+	for _, sInst := range rs.sentinelInsts {
+		if sInst.conn == nil {
+			continue
+		}
+		if conn, err = sInst.conn.GetMaster(rs.sentinelName); err == nil {
+			return
+		}
+	}
+	return nil, errors.New("No sentinels active")
+}
+
+// GetLockOwner returns the owner of the lock together with its expiry
+func (rs *RedisStorage) GetLockOwner(lkID string) (owner string, expiry time.Time, err error) {
+	if owner, err = rs.Cmd(redis_GET, utils.GuardianLockPrefix+lkID).Str(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	var pttl int64
+	if pttl, err = rs.Cmd(redis_PTTL, utils.GuardianLockPrefix+lkID).Int64(); err != nil {
+		return
+	}
+	if pttl < 0 { // expired meanwhile
+		return utils.EmptyString, expiry, utils.ErrNotFound
+	}
+	return owner, time.Now().Add(time.Duration(pttl) * time.Millisecond), nil
+}
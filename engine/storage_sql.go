@@ -37,6 +37,8 @@ type SQLImpl interface {
 	extraFieldsValueQry(string, string) string
 	notExtraFieldsExistsQry(string) string
 	notExtraFieldsValueQry(string, string) string
+	answerTimeFormatQry(string) string
+	answerTimePeriod(string, string) (string, error) // converts the value grouped by answerTimeFormatQry to the UTC period
 }
 
 type SQLStorage struct {
@@ -894,6 +896,135 @@ func (self *SQLStorage) GetSMCosts(cgrid, runid, originHost, originIDPrefix stri
 	return smCosts, nil
 }
 
+// SetAuditRecord stores the record of an administrative call
+func (self *SQLStorage) SetAuditRecord(rec *utils.AuditRecord) error {
+	return self.db.Save(&AuditRecordSQL{
+		Caller:     rec.Caller,
+		Role:       rec.Role,
+		RemoteAddr: rec.RemoteAddr,
+		Method:     rec.Method,
+		ArgsDigest: rec.ArgsDigest,
+		Result:     rec.Result,
+		CreatedAt:  rec.Time,
+	}).Error
+}
+
+// GetAuditRecords returns the audit trail ordered by time
+func (self *SQLStorage) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) ([]*utils.AuditRecord, error) {
+	q := self.db.Table(utils.AuditRecordsTBL).Select("*")
+	if len(qryFltr.Callers) != 0 {
+		q = q.Where("caller in (?)", qryFltr.Callers)
+	}
+	if len(qryFltr.Methods) != 0 {
+		q = q.Where("method in (?)", qryFltr.Methods)
+	}
+	if len(qryFltr.RemoteAddrs) != 0 {
+		q = q.Where("remote_addr in (?)", qryFltr.RemoteAddrs)
+	}
+	if qryFltr.TimeStart != nil {
+		q = q.Where("created_at >= ?", qryFltr.TimeStart)
+	}
+	if qryFltr.TimeEnd != nil {
+		q = q.Where("created_at < ?", qryFltr.TimeEnd)
+	}
+	q = q.Order("created_at, id")
+	if qryFltr.Limit != nil {
+		q = q.Limit(*qryFltr.Limit)
+	}
+	if qryFltr.Offset != nil {
+		q = q.Offset(*qryFltr.Offset)
+	}
+	results := make([]*AuditRecordSQL, 0)
+	if err := q.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	recs := make([]*utils.AuditRecord, len(results))
+	for i, result := range results {
+		recs[i] = &utils.AuditRecord{
+			Caller:     result.Caller,
+			Role:       result.Role,
+			RemoteAddr: result.RemoteAddr,
+			Method:     result.Method,
+			ArgsDigest: result.ArgsDigest,
+			Result:     result.Result,
+			Time:       result.CreatedAt,
+		}
+	}
+	return recs, nil
+}
+
+// SetActionExecution stores the execution of an ActionTiming
+func (self *SQLStorage) SetActionExecution(exec *utils.ActionExecution) error {
+	return self.db.Save(&ActionExecutionSQL{
+		ActionPlanID: exec.ActionPlanID,
+		ActionsID:    exec.ActionsID,
+		Timing:       exec.Timing,
+		AccountIDs:   strings.Join(exec.AccountIDs, utils.INFIELD_SEP),
+		RunTime:      exec.RunTime,
+		Error:        exec.Error,
+		CreatedAt:    exec.Time,
+	}).Error
+}
+
+// GetActionExecutions returns the execution history, the most recent first
+func (self *SQLStorage) GetActionExecutions(qryFltr *utils.ActionExecutionsFilter) ([]*utils.ActionExecution, error) {
+	q := self.db.Table(utils.ActionExecutionsTBL).Select("*")
+	if len(qryFltr.ActionPlanIDs) != 0 {
+		q = q.Where("action_plan_id in (?)", qryFltr.ActionPlanIDs)
+	}
+	if len(qryFltr.ActionsIDs) != 0 {
+		q = q.Where("actions_id in (?)", qryFltr.ActionsIDs)
+	}
+	if len(qryFltr.Timings) != 0 {
+		q = q.Where("timing in (?)", qryFltr.Timings)
+	}
+	if qryFltr.Failed != nil {
+		if *qryFltr.Failed {
+			q = q.Where("error != ''")
+		} else {
+			q = q.Where("error = ''")
+		}
+	}
+	if qryFltr.TimeStart != nil {
+		q = q.Where("created_at >= ?", qryFltr.TimeStart)
+	}
+	if qryFltr.TimeEnd != nil {
+		q = q.Where("created_at < ?", qryFltr.TimeEnd)
+	}
+	q = q.Order("created_at DESC, id DESC")
+	if qryFltr.Limit != nil {
+		q = q.Limit(*qryFltr.Limit)
+	}
+	if qryFltr.Offset != nil {
+		q = q.Offset(*qryFltr.Offset)
+	}
+	results := make([]*ActionExecutionSQL, 0)
+	if err := q.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	execs := make([]*utils.ActionExecution, len(results))
+	for i, result := range results {
+		execs[i] = &utils.ActionExecution{
+			ActionPlanID: result.ActionPlanID,
+			ActionsID:    result.ActionsID,
+			Timing:       result.Timing,
+			RunTime:      result.RunTime,
+			Time:         result.CreatedAt,
+			Error:        result.Error,
+		}
+		if result.AccountIDs != utils.EmptyString {
+			execs[i].AccountIDs = strings.Split(result.AccountIDs, utils.INFIELD_SEP)
+		}
+	}
+	return execs, nil
+}
+
 func (self *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	tx := self.db.Begin()
 	cdrSql := cdr.AsCDRsql()
@@ -920,11 +1051,9 @@ func (self *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	return nil
 }
 
-// GetCDRs has ability to remove the selected CDRs, count them or simply return them
-// qryFltr.Unscoped will ignore soft deletes or delete records permanently
-func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR, int64, error) {
-	var cdrs []*CDR
-	q := self.db.Table(utils.CDRsTBL).Select("*")
+// cdrsFilterQuery builds the query selecting the CDRs matching the filter
+func (self *SQLStorage) cdrsFilterQuery(qryFltr *utils.CDRsFilter) (*gorm.DB, error) {
+	q := self.db.Table(utils.CDRsTBL)
 	if qryFltr.Unscoped {
 		q = q.Unscoped()
 	}
@@ -1087,36 +1216,10 @@ func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	if qryFltr.UpdatedAtEnd != nil && !qryFltr.UpdatedAtEnd.IsZero() {
 		q = q.Where("updated_at < ?", qryFltr.UpdatedAtEnd)
 	}
-	if qryFltr.OrderBy != "" {
-		var orderVal string
-		separateVals := strings.Split(qryFltr.OrderBy, utils.INFIELD_SEP)
-		switch separateVals[0] {
-		case utils.OrderID:
-			orderVal = "id"
-		case utils.AnswerTime:
-			orderVal = "answer_time"
-		case utils.SetupTime:
-			orderVal = "setup_time"
-		case utils.Usage:
-			if self.db.Dialect().GetName() == utils.MYSQL {
-				orderVal = "`usage`"
-			} else {
-				orderVal = "usage"
-			}
-		case utils.Cost:
-			orderVal = "cost"
-		default:
-			return nil, 0, fmt.Errorf("Invalid value : %s", separateVals[0])
-		}
-		if len(separateVals) == 2 && separateVals[1] == "desc" {
-			orderVal += " DESC"
-		}
-		q = q.Order(orderVal)
-	}
 	if len(qryFltr.MinUsage) != 0 {
 		minUsage, err := utils.ParseDurationWithNanosecs(qryFltr.MinUsage)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		if self.db.Dialect().GetName() == utils.MYSQL { // MySQL needs escaping for usage
 			q = q.Where("`usage` >= ?", minUsage.Nanoseconds())
@@ -1127,7 +1230,7 @@ func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	if len(qryFltr.MaxUsage) != 0 {
 		maxUsage, err := utils.ParseDurationWithNanosecs(qryFltr.MaxUsage)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		if self.db.Dialect().GetName() == utils.MYSQL { // MySQL needs escaping for usage
 			q = q.Where("`usage` < ?", maxUsage.Nanoseconds())
@@ -1152,6 +1255,44 @@ func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 			q = q.Where(fmt.Sprintf("( cost IS NULL OR cost < %f )", *qryFltr.MaxCost))
 		}
 	}
+	return q, nil
+}
+
+// GetCDRs has ability to remove the selected CDRs, count them or simply return them
+// qryFltr.Unscoped will ignore soft deletes or delete records permanently
+func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR, int64, error) {
+	var cdrs []*CDR
+	q, err := self.cdrsFilterQuery(qryFltr)
+	if err != nil {
+		return nil, 0, err
+	}
+	q = q.Select("*")
+	if qryFltr.OrderBy != "" {
+		var orderVal string
+		separateVals := strings.Split(qryFltr.OrderBy, utils.INFIELD_SEP)
+		switch separateVals[0] {
+		case utils.OrderID:
+			orderVal = "id"
+		case utils.AnswerTime:
+			orderVal = "answer_time"
+		case utils.SetupTime:
+			orderVal = "setup_time"
+		case utils.Usage:
+			if self.db.Dialect().GetName() == utils.MYSQL {
+				orderVal = "`usage`"
+			} else {
+				orderVal = "usage"
+			}
+		case utils.Cost:
+			orderVal = "cost"
+		default:
+			return nil, 0, fmt.Errorf("Invalid value : %s", separateVals[0])
+		}
+		if len(separateVals) == 2 && separateVals[1] == "desc" {
+			orderVal += " DESC"
+		}
+		q = q.Order(orderVal)
+	}
 	if qryFltr.Paginator.Limit != nil {
 		q = q.Limit(*qryFltr.Paginator.Limit)
 	}
@@ -1191,6 +1332,101 @@ func (self *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	return cdrs, 0, nil
 }
 
+// sqlCDRsColumns maps the CDR fields to the columns of the cdrs table
+var sqlCDRsColumns = map[string]string{
+	utils.Tenant:      "tenant",
+	utils.Account:     "account",
+	utils.Subject:     "subject",
+	utils.Category:    "category",
+	utils.RunID:       "run_id",
+	utils.ToR:         "tor",
+	utils.RequestType: "request_type",
+	utils.Destination: "destination",
+	utils.OriginHost:  "origin_host",
+	utils.Source:      "source",
+}
+
+// GetCDRsReport aggregates the CDRs matching the filter with GROUP BY
+func (self *SQLStorage) GetCDRsReport(qryFltr *utils.CDRsFilter, grpBy []*CDRsGroupBy) (grps []*CDRsReportGroup, err error) {
+	var q *gorm.DB
+	if q, err = self.cdrsFilterQuery(qryFltr); err != nil {
+		return
+	}
+	usageCol := "usage"
+	if self.db.Dialect().GetName() == utils.MYSQL { // MySQL needs escaping for usage
+		usageCol = "`usage`"
+	}
+	grpExprs := make([]string, len(grpBy))
+	for i, grp := range grpBy {
+		if grp.Period != utils.EmptyString {
+			grpExprs[i] = self.SQLImpl.answerTimeFormatQry(grp.Period)
+			continue
+		}
+		grpExprs[i] = sqlCDRsColumns[grp.Field]
+		if grp.Prefix != 0 {
+			grpExprs[i] = fmt.Sprintf("SUBSTR(%s, 1, %d)", grpExprs[i], grp.Prefix)
+		}
+	}
+	slctExprs := append(append([]string{}, grpExprs...),
+		"COUNT(*)", fmt.Sprintf("SUM(%s)", usageCol),
+		"SUM(CASE WHEN cost >= 0 THEN cost ELSE 0 END)", // the negative costs mark unrated CDRs
+		"SUM(CASE WHEN cost < 0 THEN 1 ELSE 0 END)",
+		fmt.Sprintf("SUM(CASE WHEN cost < 0 THEN %s ELSE 0 END)", usageCol))
+	q = q.Select(strings.Join(slctExprs, ", "))
+	if len(grpExprs) != 0 {
+		q = q.Group(strings.Join(grpExprs, ", "))
+	}
+	var rows *sql.Rows
+	if rows, err = q.Rows(); err != nil {
+		return
+	}
+	defer rows.Close()
+	grpIdx := make(map[string]*CDRsReportGroup) // the periods might merge groups
+	for rows.Next() {
+		vals := make([]sql.NullString, len(grpBy))
+		var cnt int64
+		var usage, cost, unrated, unratedUsage sql.NullFloat64 // SUM returns decimals for integer columns
+		dest := make([]interface{}, 0, len(grpBy)+5)
+		for i := range vals {
+			dest = append(dest, &vals[i])
+		}
+		dest = append(dest, &cnt, &usage, &cost, &unrated, &unratedUsage)
+		if err = rows.Scan(dest...); err != nil {
+			return
+		}
+		if cnt == 0 { // without GROUP BY one row is returned even if no CDR matches
+			continue
+		}
+		grp := &CDRsReportGroup{
+			GroupBy:      make(map[string]string),
+			Count:        cnt,
+			Usage:        time.Duration(usage.Float64),
+			Cost:         cost.Float64,
+			Unrated:      int64(unrated.Float64),
+			UnratedUsage: time.Duration(unratedUsage.Float64),
+		}
+		keyVals := make([]string, len(grpBy))
+		for i, gb := range grpBy {
+			keyVals[i] = vals[i].String
+			if gb.Period != utils.EmptyString {
+				if keyVals[i], err = self.SQLImpl.answerTimePeriod(gb.Period, keyVals[i]); err != nil {
+					return
+				}
+			}
+			grp.GroupBy[gb.ID] = keyVals[i]
+		}
+		key := utils.ConcatenatedKey(keyVals...)
+		if prevGrp, has := grpIdx[key]; has {
+			prevGrp.merge(grp)
+			continue
+		}
+		grpIdx[key] = grp
+		grps = append(grps, grp)
+	}
+	err = rows.Err()
+	return
+}
+
 func (self *SQLStorage) GetTPDestinations(tpid, id string) (uTPDsts []*utils.TPDestination, err error) {
 	var tpDests TpDestinations
 	q := self.db.Where("tpid = ?", tpid)
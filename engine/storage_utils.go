@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -57,6 +58,32 @@ func NewDataDBConn(dbType, host, port, name, user,
 	return
 }
 
+// NewLockBackend returns the DataDB as guardian.LockBackend so the locks can be shared between engines
+func NewLockBackend(d DataDB) (lkBknd guardian.LockBackend, err error) {
+	switch db := d.(type) {
+	case *RedisStorage:
+		lkBknd = db
+	case *MongoStorage:
+		lkBknd = db
+	default:
+		err = fmt.Errorf("unsupported locking backend for db_type <%s>", d.GetStorageType())
+	}
+	return
+}
+
+// NewCacheInvalidationBus returns the DataDB as CacheInvalidationBus so the cache invalidations can be shared between engines
+func NewCacheInvalidationBus(d DataDB) (bus CacheInvalidationBus, err error) {
+	switch db := d.(type) {
+	case *RedisStorage:
+		bus = db
+	case *MongoStorage:
+		bus = db
+	default:
+		err = fmt.Errorf("unsupported cache invalidation bus for db_type <%s>", d.GetStorageType())
+	}
+	return
+}
+
 // NewStorDBConn returns a StorDB(implements Storage interface) based on dbType
 func NewStorDBConn(dbType, host, port, name, user, pass, marshaler, sslmode string,
 	maxConn, maxIdleConn, connMaxLifetime int,
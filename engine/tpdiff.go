@@ -0,0 +1,312 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// tpDiffIgnoredFields are generated on each load so they are not compared
+var tpDiffIgnoredFields = utils.NewStringSet([]string{"Uuid", "UniqueID"})
+
+// TPFieldDiff is one field changed by the tariff plan
+type TPFieldDiff struct {
+	Field    string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// TPItemDiff holds the changed fields of one item
+type TPItemDiff struct {
+	ID     string
+	Fields []*TPFieldDiff
+}
+
+// TPDiff is the difference between the tariff plan and DataDB for one item type
+type TPDiff struct {
+	Added   []string      // present in the tariff plan, missing in DataDB
+	NotInTP []string      // present in DataDB, missing in the tariff plan; a load keeps them
+	Changed []*TPItemDiff // present in both with different content
+}
+
+// DiffWithDatabase compares the loaded tariff plan with the content of DataDB
+// without writing anything, returning the differences indexed on the cache partition.
+// Only the item types present in the tariff plan are compared.
+func (tpr *TpReader) DiffWithDatabase() (diffs map[string]*TPDiff, err error) {
+	if tpr.dm.dataDB == nil {
+		return nil, errors.New("no database connection")
+	}
+	tpItms := make(map[string]map[string]interface{}) // items in the tariff plan, indexed on cacheID and ID
+	addItm := func(cacheID, id string, itm interface{}) {
+		if _, has := tpItms[cacheID]; !has {
+			tpItms[cacheID] = make(map[string]interface{})
+		}
+		tpItms[cacheID][id] = itm
+	}
+	for id, dst := range tpr.destinations {
+		addItm(utils.CacheDestinations, id, dst)
+	}
+	for id, rp := range tpr.ratingPlans {
+		addItm(utils.CacheRatingPlans, id, rp)
+	}
+	for id, rpf := range tpr.ratingProfiles {
+		addItm(utils.CacheRatingProfiles, id, rpf)
+	}
+	for id, ap := range tpr.actionPlans {
+		addItm(utils.CacheActionPlans, id, ap)
+	}
+	for id, atrs := range tpr.actionsTriggers {
+		addItm(utils.CacheActionTriggers, id, atrs)
+	}
+	for id, sg := range tpr.sharedGroups {
+		addItm(utils.CacheSharedGroups, id, sg)
+	}
+	for id, acts := range tpr.actions {
+		addItm(utils.CacheActions, id, Actions(acts))
+	}
+	for tntID, tpFltr := range tpr.filters {
+		fltr, err := APItoFilter(tpFltr, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheFilters, tntID.TenantID(), fltr)
+	}
+	for tntID, tpRsp := range tpr.resProfiles {
+		rsp, err := APItoResource(tpRsp, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheResourceProfiles, tntID.TenantID(), rsp)
+	}
+	for tntID, tpST := range tpr.sqProfiles {
+		st, err := APItoStats(tpST, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheStatQueueProfiles, tntID.TenantID(), st)
+	}
+	for tntID, tpTH := range tpr.thProfiles {
+		th, err := APItoThresholdProfile(tpTH, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheThresholdProfiles, tntID.TenantID(), th)
+	}
+	for tntID, tpRp := range tpr.routeProfiles {
+		rp, err := APItoRouteProfile(tpRp, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheRouteProfiles, tntID.TenantID(), rp)
+	}
+	for tntID, tpAttr := range tpr.attributeProfiles {
+		attr, err := APItoAttributeProfile(tpAttr, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheAttributeProfiles, tntID.TenantID(), attr)
+	}
+	for tntID, tpCpp := range tpr.chargerProfiles {
+		cpp, err := APItoChargerProfile(tpCpp, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheChargerProfiles, tntID.TenantID(), cpp)
+	}
+	for tntID, tpDsp := range tpr.dispatcherProfiles {
+		dsp, err := APItoDispatcherProfile(tpDsp, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheDispatcherProfiles, tntID.TenantID(), dsp)
+	}
+	for tntID, tpDsh := range tpr.dispatcherHosts {
+		addItm(utils.CacheDispatcherHosts, tntID.TenantID(), APItoDispatcherHost(tpDsh))
+	}
+	for tntID, tpRpl := range tpr.rateProfiles {
+		rpl, err := APItoRateProfile(tpRpl, tpr.timezone)
+		if err != nil {
+			return nil, err
+		}
+		addItm(utils.CacheRateProfiles, tntID.TenantID(), rpl)
+	}
+	diffs = make(map[string]*TPDiff)
+	for cacheID, itms := range tpItms {
+		diff := new(TPDiff)
+		for id, itm := range itms {
+			var dbItm interface{}
+			if dbItm, err = tpr.getDBItem(cacheID, id); err != nil {
+				if err != utils.ErrNotFound {
+					return nil, err
+				}
+				err = nil
+				diff.Added = append(diff.Added, id)
+				continue
+			}
+			var flds []*TPFieldDiff
+			if flds, err = diffItemFields(dbItm, itm); err != nil {
+				return nil, err
+			}
+			if len(flds) != 0 {
+				diff.Changed = append(diff.Changed, &TPItemDiff{ID: id, Fields: flds})
+			}
+		}
+		prfx := utils.CacheInstanceToPrefix[cacheID]
+		var keys []string
+		if keys, err = tpr.dm.DataDB().GetKeysForPrefix(prfx); err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if id := key[len(prfx):]; itms[id] == nil {
+				diff.NotInTP = append(diff.NotInTP, id)
+			}
+		}
+		sort.Strings(diff.Added)
+		sort.Strings(diff.NotInTP)
+		sort.Slice(diff.Changed, func(i, j int) bool {
+			return diff.Changed[i].ID < diff.Changed[j].ID
+		})
+		diffs[cacheID] = diff
+	}
+	return
+}
+
+// getDBItem returns the item stored in DataDB, skipping the cache
+func (tpr *TpReader) getDBItem(cacheID, id string) (itm interface{}, err error) {
+	switch cacheID {
+	case utils.CacheDestinations:
+		return tpr.dm.GetDestination(id, true, utils.NonTransactional)
+	case utils.CacheRatingPlans:
+		return tpr.dm.GetRatingPlan(id, true, utils.NonTransactional)
+	case utils.CacheRatingProfiles:
+		return tpr.dm.GetRatingProfile(id, true, utils.NonTransactional)
+	case utils.CacheActionPlans:
+		return tpr.dm.GetActionPlan(id, true, utils.NonTransactional)
+	case utils.CacheActionTriggers:
+		return tpr.dm.GetActionTriggers(id, true, utils.NonTransactional)
+	case utils.CacheSharedGroups:
+		return tpr.dm.GetSharedGroup(id, true, utils.NonTransactional)
+	case utils.CacheActions:
+		return tpr.dm.GetActions(id, true, utils.NonTransactional)
+	}
+	tntID := utils.NewTenantID(id)
+	switch cacheID {
+	case utils.CacheFilters:
+		return tpr.dm.GetFilter(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheResourceProfiles:
+		return tpr.dm.GetResourceProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheStatQueueProfiles:
+		return tpr.dm.GetStatQueueProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheThresholdProfiles:
+		return tpr.dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheRouteProfiles:
+		return tpr.dm.GetRouteProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheAttributeProfiles:
+		return tpr.dm.GetAttributeProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheChargerProfiles:
+		return tpr.dm.GetChargerProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheDispatcherProfiles:
+		return tpr.dm.GetDispatcherProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheDispatcherHosts:
+		return tpr.dm.GetDispatcherHost(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.CacheRateProfiles:
+		return tpr.dm.GetRateProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	}
+	return nil, fmt.Errorf("unsupported item type: <%s>", cacheID)
+}
+
+// diffItemFields returns the field level differences between two items
+// using their JSON representation so the exported fields are compared
+func diffItemFields(oldItm, newItm interface{}) (flds []*TPFieldDiff, err error) {
+	var oldVal, newVal interface{}
+	var b []byte
+	if b, err = json.Marshal(oldItm); err != nil {
+		return
+	}
+	if err = json.Unmarshal(b, &oldVal); err != nil {
+		return
+	}
+	if b, err = json.Marshal(newItm); err != nil {
+		return
+	}
+	if err = json.Unmarshal(b, &newVal); err != nil {
+		return
+	}
+	appendFieldDiffs(utils.EmptyString, oldVal, newVal, &flds)
+	return
+}
+
+// appendFieldDiffs walks recursively the two values adding the differences to flds
+func appendFieldDiffs(fldPath string, oldVal, newVal interface{}, flds *[]*TPFieldDiff) {
+	if isEmptyDiffValue(oldVal) && isEmptyDiffValue(newVal) {
+		return
+	}
+	oldMp, oldIsMp := oldVal.(map[string]interface{})
+	newMp, newIsMp := newVal.(map[string]interface{})
+	if oldIsMp && newIsMp {
+		keys := make(utils.StringSet)
+		for key := range oldMp {
+			keys.Add(key)
+		}
+		for key := range newMp {
+			keys.Add(key)
+		}
+		for _, key := range keys.AsOrderedSlice() {
+			if tpDiffIgnoredFields.Has(key) {
+				continue
+			}
+			keyPath := key
+			if fldPath != utils.EmptyString {
+				keyPath = fldPath + utils.NestingSep + key
+			}
+			appendFieldDiffs(keyPath, oldMp[key], newMp[key], flds)
+		}
+		return
+	}
+	oldSl, oldIsSl := oldVal.([]interface{})
+	newSl, newIsSl := newVal.([]interface{})
+	if oldIsSl && newIsSl && len(oldSl) == len(newSl) {
+		for i := range oldSl {
+			appendFieldDiffs(fmt.Sprintf("%s[%d]", fldPath, i), oldSl[i], newSl[i], flds)
+		}
+		return
+	}
+	if !reflect.DeepEqual(oldVal, newVal) {
+		*flds = append(*flds, &TPFieldDiff{Field: fldPath, OldValue: oldVal, NewValue: newVal})
+	}
+}
+
+// isEmptyDiffValue considers nil and the empty containers as equal
+func isEmptyDiffValue(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
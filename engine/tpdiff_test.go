@@ -0,0 +1,133 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDiffItemFields(t *testing.T) {
+	oldFltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1001"},
+		}},
+	}
+	newFltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1002"},
+		}},
+	}
+	eFlds := []*TPFieldDiff{{Field: "Rules[0].Values[0]", OldValue: "1001", NewValue: "1002"}}
+	if flds, err := diffItemFields(oldFltr, newFltr); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eFlds, flds) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eFlds), utils.ToJSON(flds))
+	}
+	if flds, err := diffItemFields(oldFltr, oldFltr); err != nil {
+		t.Error(err)
+	} else if len(flds) != 0 {
+		t.Errorf("expecting no differences, received: %s", utils.ToJSON(flds))
+	}
+	// nil and empty containers are considered equal
+	if flds, err := diffItemFields(&ChargerProfile{AttributeIDs: []string{}},
+		&ChargerProfile{}); err != nil {
+		t.Error(err)
+	} else if len(flds) != 0 {
+		t.Errorf("expecting no differences, received: %s", utils.ToJSON(flds))
+	}
+}
+
+func TestTpReaderDiffWithDatabase(t *testing.T) {
+	data := NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	dmDiff := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	for _, fltr := range []*Filter{
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_CHANGED",
+			Rules: []*FilterRule{{
+				Type:    utils.MetaString,
+				Element: "~*req.Account",
+				Values:  []string{"1001"},
+			}},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "FLTR_REMOVED",
+			Rules: []*FilterRule{{
+				Type:    utils.MetaString,
+				Element: "~*req.Account",
+				Values:  []string{"1003"},
+			}},
+		},
+	} {
+		if err := dmDiff.SetFilter(fltr, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fltrsCSV := `
+#Tenant[0],ID[1],Type[2],Element[3],Values[4],ActivationInterval[5]
+cgrates.org,FLTR_CHANGED,*string,~*req.Account,1002,
+cgrates.org,FLTR_ADDED,*prefix,~*req.Destination,10,
+`
+	tpr, err := NewTpReader(data, NewStringCSVStorage(utils.CSV_SEP,
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", fltrsCSV,
+		"", "", "", "", "", ""), testTPID, "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tpr.LoadFilters(); err != nil {
+		t.Fatal(err)
+	}
+	eDiffs := map[string]*TPDiff{
+		utils.CacheFilters: {
+			Added:   []string{"cgrates.org:FLTR_ADDED"},
+			NotInTP: []string{"cgrates.org:FLTR_REMOVED"},
+			Changed: []*TPItemDiff{{
+				ID: "cgrates.org:FLTR_CHANGED",
+				Fields: []*TPFieldDiff{{
+					Field:    "Rules[0].Values[0]",
+					OldValue: "1001",
+					NewValue: "1002",
+				}},
+			}},
+		},
+	}
+	if diffs, err := tpr.DiffWithDatabase(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eDiffs, diffs) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eDiffs), utils.ToJSON(diffs))
+	}
+	// nothing should be written
+	if _, err := dmDiff.GetFilter("cgrates.org", "FLTR_ADDED",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
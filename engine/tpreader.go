@@ -235,6 +235,9 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 					return false, err
 				}
 			}
+			if utils.IsCronExpr(tm[rp.TimingId].StartTime) {
+				return false, fmt.Errorf("cron timing %s not supported by rating plans", rp.TimingId)
+			}
 
 			rp.SetTiming(tm[rp.TimingId])
 			tpdrm, err := tpr.lr.GetTPDestinationRates(tpr.tpid, rp.DestinationRatesId, nil)
@@ -305,6 +308,9 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 			if !exists {
 				return fmt.Errorf("could not get timing for tag %v", rplBnd.TimingId)
 			}
+			if utils.IsCronExpr(t.StartTime) {
+				return fmt.Errorf("cron timing %s not supported by rating plans", rplBnd.TimingId)
+			}
 			rplBnd.SetTiming(t)
 			drs, exists := tpr.destinationRates[rplBnd.DestinationRatesId]
 			if !exists {
@@ -592,6 +598,17 @@ func (tpr *TpReader) LoadActionPlans() (err error) {
 			tpr.actionPlans[atID] = actPln
 		}
 	}
+	for _, tp := range tps {
+		if tp.CatchUp == utils.EmptyString {
+			continue
+		}
+		if err = CheckCatchUp(tp.CatchUp); err != nil {
+			return fmt.Errorf("[ActionPlans] %s for tag: %v", err.Error(), tp.ID)
+		}
+		if actPln, has := tpr.actionPlans[tp.ID]; has {
+			actPln.CatchUp = tp.CatchUp
+		}
+	}
 	return nil
 }
 
@@ -768,6 +785,14 @@ func (tpr *TpReader) LoadAccountActionsFiltered(qriedAA *utils.TPAccountActions)
 				exitingAccountIds[id] = true
 				actionPlan.AccountIDs = exitingAccountIds
 			}
+			for _, tpAp := range tpap {
+				if tpAp.CatchUp != utils.EmptyString {
+					if err = CheckCatchUp(tpAp.CatchUp); err != nil {
+						return errors.New(err.Error() + " (ActionPlan): " + accountAction.ActionPlanId)
+					}
+					actionPlan.CatchUp = tpAp.CatchUp
+				}
+			}
 			// write tasks
 			for _, at := range actionPlan.ActionTimings {
 				if at.IsASAP() {
@@ -2543,6 +2568,16 @@ func (tpr *TpReader) ReloadScheduler(verbose bool) (err error) {
 	return
 }
 
+// PublishCacheInvalidations tells the engines sharing the DataDB to drop the items written by this reader from their cache
+func (tpr *TpReader) PublishCacheInvalidations() (err error) {
+	var bus CacheInvalidationBus
+	if bus, err = NewCacheInvalidationBus(tpr.dm.DataDB()); err != nil {
+		return
+	}
+	tpr.dm.PublishCacheInvalidations(bus)
+	return
+}
+
 func (tpr *TpReader) addDefaultTimings() {
 	tpr.timings[utils.ANY] = &utils.TPTiming{
 		ID:        utils.ANY,
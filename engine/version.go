@@ -38,8 +38,10 @@ var (
 		utils.Routes:         "cgr-migrator -exec=*routes",
 	}
 	storDBVers = map[string]string{
-		utils.CostDetails:   "cgr-migrator -exec=*cost_details",
-		utils.SessionSCosts: "cgr-migrator -exec=*sessions_costs",
+		utils.CostDetails:      "cgr-migrator -exec=*cost_details",
+		utils.SessionSCosts:    "cgr-migrator -exec=*sessions_costs",
+		utils.AuditRecords:     "cgr-migrator -exec=*audit_records",
+		utils.ActionExecutions: "cgr-migrator -exec=*action_executions",
 	}
 	allVers map[string]string // init will fill this with a merge of data+stor
 )
@@ -174,26 +176,28 @@ func CurrentStorDBVersions() Versions {
 		utils.CostDetails:        2,
 		utils.SessionSCosts:      3,
 		utils.CDRs:               2,
+		utils.AuditRecords:       1,
+		utils.ActionExecutions:   1,
 		utils.TpRatingPlans:      1,
 		utils.TpFilters:          1,
 		utils.TpDestinationRates: 1,
 		utils.TpActionTriggers:   1,
 		utils.TpAccountActionsV:  1,
-		utils.TpActionPlans:      1,
+		utils.TpActionPlans:      2,
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
-		utils.TpRoutes:           1,
+		utils.TpRoutes:           2,
 		utils.TpStats:            1,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
 		utils.TpResources:        1,
 		utils.TpRates:            1,
-		utils.TpTiming:           1,
+		utils.TpTiming:           2,
 		utils.TpResource:         1,
 		utils.TpDestinations:     1,
 		utils.TpRatingPlan:       1,
 		utils.TpRatingProfile:    1,
-		utils.TpChargers:         1,
+		utils.TpChargers:         2,
 		utils.TpDispatchers:      1,
 		utils.TpRateProfiles:     1,
 	}
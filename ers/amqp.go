@@ -0,0 +1,329 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/streadway/amqp"
+)
+
+const (
+	defaultQueueID      = "cgrates_cdrs"
+	defaultExchangeType = "direct"
+	defaultConsumerTag  = "cgrates"
+)
+
+// amqpQuery are the URL parameters passed to the AMQP library
+var amqpQuery = []string{"cacertfile", "certfile", "keyfile", "verify",
+	"server_name_indication", "auth_mechanism", "heartbeat",
+	"connection_timeout", "channel_max"}
+
+// NewAMQPER return a new amqp event reader
+func NewAMQPER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &AMQPER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	er = rdr
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// AMQPER implements EventReader interface for amqp message
+type AMQPER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL      string
+	queueID      string
+	exchange     string
+	exchangeType string
+	routingKey   string
+	consumerTag  string
+
+	deadLetterQueue    string // queue receiving the messages failing all the processing attempts
+	processingAttempts int
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+
+	conn *amqp.Connection
+}
+
+// Config returns the curent configuration
+func (rdr *AMQPER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to consume the amqp queue
+func (rdr *AMQPER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	if rdr.conn, err = amqp.Dial(rdr.dialURL); err != nil {
+		return
+	}
+	var msgs <-chan amqp.Delivery
+	if msgs, err = rdr.newConsumer(); err != nil {
+		rdr.conn.Close()
+		return
+	}
+	go func() {
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring amqp queue <%s>",
+					utils.ERs, rdr.queueID))
+			rdr.conn.Close() // closes also the deliveries channel
+			return
+		}
+	}()
+	go rdr.readLoop(msgs) // read until the connection is closed
+	return
+}
+
+// newConsumer declares the queue and starts consuming without automatic acknowledge
+func (rdr *AMQPER) newConsumer() (msgs <-chan amqp.Delivery, err error) {
+	var chn *amqp.Channel
+	if chn, err = rdr.conn.Channel(); err != nil {
+		return
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		if err = chn.Qos(concReq, 0, false); err != nil {
+			return
+		}
+	}
+	if rdr.exchange != utils.EmptyString {
+		if err = chn.ExchangeDeclare(
+			rdr.exchange,     // name
+			rdr.exchangeType, // type
+			true,             // durable
+			false,            // auto-delete
+			false,            // internal
+			false,            // no-wait
+			nil,              // args
+		); err != nil {
+			return
+		}
+	}
+	if _, err = chn.QueueDeclare(
+		rdr.queueID, // name
+		true,        // durable
+		false,       // auto-delete
+		false,       // exclusive
+		false,       // no-wait
+		nil,         // args
+	); err != nil {
+		return
+	}
+	if rdr.exchange != utils.EmptyString {
+		if err = chn.QueueBind(
+			rdr.queueID,    // queue
+			rdr.routingKey, // key
+			rdr.exchange,   // exchange
+			false,          // no-wait
+			nil,            // args
+		); err != nil {
+			return
+		}
+	}
+	return chn.Consume(
+		rdr.queueID,     // queue
+		rdr.consumerTag, // consumer
+		false,           // auto-ack
+		false,           // exclusive
+		false,           // no-local
+		false,           // no-wait
+		nil,             // args
+	)
+}
+
+func (rdr *AMQPER) readLoop(msgs <-chan amqp.Delivery) {
+	for msg := range msgs {
+		if rdr.Config().ConcurrentReqs != -1 {
+			<-rdr.cap // do not try to read if the limit is reached
+		}
+		go func(msg amqp.Delivery) {
+			if err := processWithAttempts(func() error { return rdr.processMessage(msg.Body) },
+				rdr.processingAttempts, rdr.rdrExit, msg.MessageId); err != nil {
+				rdr.rejectMessage(msg, err)
+			} else if err = msg.Ack(false); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> acknowledging message %s error: %s",
+						utils.ERs, msg.MessageId, err.Error()))
+			} else if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+				if err := engine.PostersCache.PostAMQP(rdr.Config().ProcessedPath,
+					rdr.cgrCfg.GeneralCfg().PosterAttempts, msg.Body); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> writing message %s error: %s",
+							utils.ERs, msg.MessageId, err.Error()))
+				}
+			}
+			if rdr.Config().ConcurrentReqs != -1 {
+				rdr.cap <- struct{}{}
+			}
+		}(msg)
+	}
+	select {
+	case <-rdr.rdrExit: // closed by us
+	default:
+		rdr.rdrErr <- errors.New("amqp deliveries channel closed")
+	}
+}
+
+// rejectMessage handles the message failing all the processing attempts: it is requeued if the
+// reader was stopped, otherwise sent to the dead letter queue or rejected without requeue so
+// the broker can dead-letter it
+func (rdr *AMQPER) rejectMessage(msg amqp.Delivery, err error) {
+	select {
+	case <-rdr.rdrExit: // not processed, deliver it again after restart
+		if err = msg.Nack(false, true); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> requeueing message %s error: %s",
+					utils.ERs, msg.MessageId, err.Error()))
+		}
+		return
+	default:
+	}
+	if errors.Is(err, errMalformedMessage) {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> rejecting malformed message %s, error: %s",
+				utils.ERs, msg.MessageId, err.Error()))
+	} else {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> rejecting message %s after %d processing attempts, error: %s",
+				utils.ERs, msg.MessageId, rdr.processingAttempts, err.Error()))
+	}
+	if rdr.deadLetterQueue == utils.EmptyString {
+		if err = msg.Nack(false, false); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> rejecting message %s error: %s",
+					utils.ERs, msg.MessageId, err.Error()))
+		}
+		return
+	}
+	if err = engine.PostersCache.PostAMQP(rdr.deadLetterURL(),
+		rdr.cgrCfg.GeneralCfg().PosterAttempts, msg.Body); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> writing message %s to dead letter queue <%s> error: %s",
+				utils.ERs, msg.MessageId, rdr.deadLetterQueue, err.Error()))
+		// requeue so the message is not lost
+		if err = msg.Nack(false, true); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> requeueing message %s error: %s",
+					utils.ERs, msg.MessageId, err.Error()))
+		}
+		return
+	}
+	if err = msg.Ack(false); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> acknowledging message %s error: %s",
+				utils.ERs, msg.MessageId, err.Error()))
+	}
+}
+
+// deadLetterURL returns the poster URL for the dead letter queue
+func (rdr *AMQPER) deadLetterURL() string {
+	u, _ := url.Parse(rdr.dialURL) // already parsed in setURL
+	qry := u.Query()
+	qry.Set(utils.QueueID, rdr.deadLetterQueue)
+	qry.Set(utils.AMQPRoutingKey, rdr.deadLetterQueue)
+	u.RawQuery = qry.Encode()
+	return u.String()
+}
+
+// processMessage dispatches the event to ERs and waits for the processing result
+func (rdr *AMQPER) processMessage(msg []byte) (err error) {
+	return processJSONMessage(msg, rdr.Config(), rdr.cgrCfg,
+		rdr.fltrS, rdr.rdrEvents, rdr.rdrExit)
+}
+
+func (rdr *AMQPER) setURL(dialURL string) (err error) {
+	var u *url.URL
+	if u, err = url.Parse(dialURL); err != nil {
+		return
+	}
+	qry := u.Query()
+	q := url.Values{}
+	for _, key := range amqpQuery {
+		if vals, has := qry[key]; has && len(vals) != 0 {
+			q.Add(key, vals[0])
+		}
+	}
+	rdr.dialURL = strings.Split(dialURL, "?")[0]
+	if len(q) != 0 {
+		rdr.dialURL += "?" + q.Encode()
+	}
+	rdr.queueID = defaultQueueID
+	if vals, has := qry[utils.QueueID]; has && len(vals) != 0 {
+		rdr.queueID = vals[0]
+	}
+	rdr.routingKey = rdr.queueID
+	if vals, has := qry[utils.AMQPRoutingKey]; has && len(vals) != 0 {
+		rdr.routingKey = vals[0]
+	}
+	if vals, has := qry[utils.AMQPExchange]; has && len(vals) != 0 {
+		rdr.exchange = vals[0]
+		rdr.exchangeType = defaultExchangeType
+	}
+	if vals, has := qry[utils.AMQPExchangeType]; has && len(vals) != 0 {
+		rdr.exchangeType = vals[0]
+	}
+	rdr.consumerTag = defaultConsumerTag
+	if vals, has := qry[utils.AMQPConsumerTag]; has && len(vals) != 0 {
+		rdr.consumerTag = vals[0]
+	}
+	if vals, has := qry[utils.DeadLetterQueue]; has && len(vals) != 0 {
+		rdr.deadLetterQueue = vals[0]
+	}
+	rdr.processingAttempts = defaultProcessingAttempts
+	if vals, has := qry[utils.KafkaProcessingAttempts]; has && len(vals) != 0 {
+		if rdr.processingAttempts, err = strconv.Atoi(vals[0]); err != nil {
+			return
+		}
+		if rdr.processingAttempts < 1 {
+			rdr.processingAttempts = 1
+		}
+	}
+	return
+}
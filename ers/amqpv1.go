@@ -0,0 +1,183 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	amqpv1 "pack.ag/amqp"
+)
+
+// NewAMQPv1ER return a new amqpv1 event reader
+func NewAMQPv1ER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &AMQPv1ER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	er = rdr
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// AMQPv1ER implements EventReader interface for amqpv1 message
+type AMQPv1ER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL string
+	queueID string
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+
+	client *amqpv1.Client
+}
+
+// Config returns the curent configuration
+func (rdr *AMQPv1ER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to consume the amqpv1 queue
+func (rdr *AMQPv1ER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	if rdr.client, err = amqpv1.Dial(rdr.dialURL); err != nil {
+		return
+	}
+	var ses *amqpv1.Session
+	if ses, err = rdr.client.NewSession(); err != nil {
+		rdr.client.Close()
+		return
+	}
+	lnkOpts := []amqpv1.LinkOption{amqpv1.LinkSourceAddress(rdr.queueID)}
+	if concReq := rdr.Config().ConcurrentReqs; concReq > 0 {
+		lnkOpts = append(lnkOpts, amqpv1.LinkCredit(uint32(concReq)))
+	}
+	var rcv *amqpv1.Receiver
+	if rcv, err = ses.NewReceiver(lnkOpts...); err != nil {
+		rdr.client.Close()
+		return
+	}
+	go func() {
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring amqpv1 queue <%s>",
+					utils.ERs, rdr.queueID))
+			rdr.client.Close() // unblocks the Receive
+			return
+		}
+	}()
+	go rdr.readLoop(rcv) // read until the connection is closed
+	return
+}
+
+func (rdr *AMQPv1ER) readLoop(rcv *amqpv1.Receiver) {
+	for {
+		if rdr.Config().ConcurrentReqs != -1 {
+			<-rdr.cap // do not try to read if the limit is reached
+		}
+		msg, err := rcv.Receive(context.Background())
+		if err != nil {
+			select {
+			case <-rdr.rdrExit: // closed by us
+			default:
+				rdr.rdrErr <- err
+			}
+			return
+		}
+		go func(msg *amqpv1.Message) {
+			body := msg.GetData()
+			if err := rdr.processMessage(body); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> processing message error: %s",
+						utils.ERs, err.Error()))
+				if errors.Is(err, errMalformedMessage) {
+					err = msg.Reject(nil)
+				} else {
+					err = msg.Release() // delivered again by the broker
+				}
+				if err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> rejecting message error: %s",
+							utils.ERs, err.Error()))
+				}
+			} else if err = msg.Accept(); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> accepting message error: %s",
+						utils.ERs, err.Error()))
+			} else if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+				if err := engine.PostersCache.PostAMQPv1(rdr.Config().ProcessedPath,
+					rdr.cgrCfg.GeneralCfg().PosterAttempts, body); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> writing message error: %s",
+							utils.ERs, err.Error()))
+				}
+			}
+			if rdr.Config().ConcurrentReqs != -1 {
+				rdr.cap <- struct{}{}
+			}
+		}(msg)
+	}
+}
+
+// processMessage dispatches the event to ERs and waits for the processing result
+func (rdr *AMQPv1ER) processMessage(msg []byte) (err error) {
+	return processJSONMessage(msg, rdr.Config(), rdr.cgrCfg,
+		rdr.fltrS, rdr.rdrEvents, rdr.rdrExit)
+}
+
+func (rdr *AMQPv1ER) setURL(dialURL string) (err error) {
+	var u *url.URL
+	if u, err = url.Parse(dialURL); err != nil {
+		return
+	}
+	qry := u.Query()
+	rdr.dialURL = strings.Split(dialURL, "?")[0]
+	rdr.queueID = "/" + defaultQueueID
+	if vals, has := qry[utils.QueueID]; has && len(vals) != 0 {
+		rdr.queueID = "/" + vals[0]
+	}
+	return
+}
@@ -33,6 +33,7 @@ type erEvent struct {
 	cgrEvent *utils.CGREvent
 	rdrCfg   *config.EventReaderCfg
 	opts     map[string]interface{}
+	rplyErr  chan error // optional, receives the processing result
 }
 
 // NewERService instantiates the ERService
@@ -88,11 +89,15 @@ func (erS *ERService) ListenAndServe(cfgRldChan chan struct{}) (err error) {
 		case <-erS.stopChan:
 			return
 		case erEv := <-erS.rdrEvents:
-			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg, erEv.opts); err != nil {
+			err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg, erEv.opts)
+			if err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
 						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
 			}
+			if erEv.rplyErr != nil { // reader waits for the result
+				erEv.rplyErr <- err
+			}
 		case <-cfgRldChan: // handle reload
 			cfgIDs := make(map[string]int)
 			pathReloaded := make(map[string]struct{})
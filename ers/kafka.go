@@ -20,14 +20,14 @@ package ers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/cgrates/cgrates/agents"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
@@ -36,9 +36,10 @@ import (
 )
 
 const (
-	defaultTopic   = "cgrates"
-	defaultGroupID = "cgrates"
-	defaultMaxWait = time.Millisecond
+	defaultTopic              = "cgrates"
+	defaultGroupID            = "cgrates"
+	defaultMaxWait            = time.Millisecond
+	defaultProcessingAttempts = 3
 )
 
 // NewKafkaER return a new kafka event reader
@@ -77,6 +78,10 @@ type KafkaER struct {
 	groupID string
 	maxWait time.Duration
 
+	manualCommit       bool   // commit the offsets only after the events are processed
+	deadLetterTopic    string // topic receiving the messages failing all the processing attempts
+	processingAttempts int
+
 	rdrEvents chan *erEvent // channel to dispatch the events created to
 	rdrExit   chan struct{}
 	rdrErr    chan error
@@ -111,6 +116,10 @@ func (rdr *KafkaER) Serve() (err error) {
 			return
 		}
 	}(r)
+	if rdr.manualCommit {
+		go rdr.readCommitLoop(r)
+		return
+	}
 	go rdr.readLoop(r) // read until the connection is closed
 	return
 }
@@ -152,33 +161,87 @@ func (rdr *KafkaER) readLoop(r *kafka.Reader) {
 	}
 }
 
-func (rdr *KafkaER) processMessage(msg []byte) (err error) {
-	var decodedMessage map[string]interface{}
-	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
-		return
+// readCommitLoop processes the messages one by one and commits their offsets
+// only after processing so the unprocessed messages are read again after a restart
+func (rdr *KafkaER) readCommitLoop(r *kafka.Reader) {
+	for {
+		msg, err := r.FetchMessage(context.Background())
+		if err != nil {
+			if err == io.EOF || rdr.isStopped() {
+				return
+			}
+			rdr.rdrErr <- err
+			return
+		}
+		if err = rdr.processMessageWithAttempts(msg); err != nil {
+			if rdr.isStopped() {
+				return // not processed, do not commit
+			}
+			if rdr.deadLetterTopic == utils.EmptyString {
+				if errors.Is(err, errMalformedMessage) {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> dropping malformed message %s, error: %s",
+							utils.ERs, string(msg.Key), err.Error()))
+				} else {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> dropping message %s after %d processing attempts, error: %s",
+							utils.ERs, string(msg.Key), rdr.processingAttempts, err.Error()))
+				}
+			} else if err = engine.PostersCache.PostKafka(rdr.deadLetterURL(),
+				rdr.cgrCfg.GeneralCfg().PosterAttempts, msg.Value, string(msg.Key)); err != nil {
+				if rdr.isStopped() {
+					return
+				}
+				// do not commit so the message is not lost
+				rdr.rdrErr <- fmt.Errorf("writing message %s to dead letter topic <%s> error: %s",
+					string(msg.Key), rdr.deadLetterTopic, err.Error())
+				return
+			}
+		}
+		if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+			if err = engine.PostersCache.PostKafka(rdr.Config().ProcessedPath,
+				rdr.cgrCfg.GeneralCfg().PosterAttempts, msg.Value, string(msg.Key)); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> writing message %s error: %s",
+						utils.ERs, string(msg.Key), err.Error()))
+			}
+		}
+		if err = r.CommitMessages(context.Background(), msg); err != nil {
+			if rdr.isStopped() {
+				return
+			}
+			rdr.rdrErr <- err
+			return
+		}
 	}
+}
 
-	agReq := agents.NewAgentRequest(
-		utils.MapStorage(decodedMessage), nil,
-		nil, nil, nil, rdr.Config().Tenant,
-		rdr.cgrCfg.GeneralCfg().DefaultTenant,
-		utils.FirstNonEmpty(rdr.Config().Timezone,
-			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
-		rdr.fltrS, nil, nil) // create an AgentRequest
-	var pass bool
-	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
-		agReq); err != nil || !pass {
-		return
-	}
-	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
-		return
-	}
-	rdr.rdrEvents <- &erEvent{
-		cgrEvent: config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep),
-		rdrCfg:   rdr.Config(),
-		opts:     config.NMAsMapInterface(agReq.Opts, utils.NestingSep),
+// processMessageWithAttempts waits for the event to be processed, retrying on errors
+// the messages which cannot be decoded are not retried
+func (rdr *KafkaER) processMessageWithAttempts(msg kafka.Message) (err error) {
+	return processWithAttempts(func() error { return rdr.processMessage(msg.Value) },
+		rdr.processingAttempts, rdr.rdrExit, string(msg.Key))
+}
+
+// isStopped returns true if the reader was stopped
+func (rdr *KafkaER) isStopped() bool {
+	select {
+	case <-rdr.rdrExit:
+		return true
+	default:
+		return false
 	}
-	return
+}
+
+// deadLetterURL returns the poster URL for the dead letter topic
+func (rdr *KafkaER) deadLetterURL() string {
+	return rdr.dialURL + "?" + url.Values{utils.KafkaTopic: []string{rdr.deadLetterTopic}}.Encode()
+}
+
+// processMessage dispatches the event to ERs and waits for the processing result
+func (rdr *KafkaER) processMessage(msg []byte) (err error) {
+	return processJSONMessage(msg, rdr.Config(), rdr.cgrCfg,
+		rdr.fltrS, rdr.rdrEvents, rdr.rdrExit)
 }
 
 func (rdr *KafkaER) setURL(dialURL string) (err error) {
@@ -199,7 +262,26 @@ func (rdr *KafkaER) setURL(dialURL string) (err error) {
 	}
 	rdr.maxWait = defaultMaxWait
 	if vals, has := qry[utils.KafkaMaxWait]; has && len(vals) != 0 {
-		rdr.maxWait, err = time.ParseDuration(vals[0])
+		if rdr.maxWait, err = time.ParseDuration(vals[0]); err != nil {
+			return
+		}
+	}
+	if vals, has := qry[utils.KafkaManualCommit]; has && len(vals) != 0 {
+		if rdr.manualCommit, err = strconv.ParseBool(vals[0]); err != nil {
+			return
+		}
+	}
+	if vals, has := qry[utils.KafkaDeadLetterTopic]; has && len(vals) != 0 {
+		rdr.deadLetterTopic = vals[0]
+	}
+	rdr.processingAttempts = defaultProcessingAttempts
+	if vals, has := qry[utils.KafkaProcessingAttempts]; has && len(vals) != 0 {
+		if rdr.processingAttempts, err = strconv.Atoi(vals[0]); err != nil {
+			return
+		}
+		if rdr.processingAttempts < 1 {
+			rdr.processingAttempts = 1
+		}
 	}
 	return
 }
@@ -19,8 +19,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ers
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	kafka "github.com/segmentio/kafka-go"
 )
 
 func TestKafkaSetURL(t *testing.T) {
@@ -73,3 +79,88 @@ func TestKafkaSetURL(t *testing.T) {
 		t.Errorf("Expected error received: %v", err)
 	}
 }
+
+func TestKafkaSetURLManualCommit(t *testing.T) {
+	k := new(KafkaER)
+	url := "localhost:2013?topic=cdrs&manual_commit=true&processing_attempts=5&dead_letter_topic=cdrs_failed"
+	if err := k.setURL(url); err != nil {
+		t.Fatal(err)
+	} else if !k.manualCommit {
+		t.Errorf("Expected manual commit")
+	} else if k.processingAttempts != 5 {
+		t.Errorf("Expected: %d ,received: %d", 5, k.processingAttempts)
+	} else if k.deadLetterTopic != "cdrs_failed" {
+		t.Errorf("Expected: %s ,received: %s", "cdrs_failed", k.deadLetterTopic)
+	} else if dlURL := k.deadLetterURL(); dlURL != "localhost:2013?topic=cdrs_failed" {
+		t.Errorf("Expected: %s ,received: %s", "localhost:2013?topic=cdrs_failed", dlURL)
+	}
+	k = new(KafkaER)
+	if err := k.setURL("localhost:2013"); err != nil {
+		t.Fatal(err)
+	} else if k.manualCommit {
+		t.Errorf("Expected manual commit disabled")
+	} else if k.processingAttempts != defaultProcessingAttempts {
+		t.Errorf("Expected: %d ,received: %d", defaultProcessingAttempts, k.processingAttempts)
+	}
+	k = new(KafkaER)
+	if err := k.setURL("localhost:2013?manual_commit=notbool"); err == nil {
+		t.Errorf("Expected error received: %v", err)
+	}
+}
+
+func TestKafkaProcessMessageWithAttempts(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	erS := NewERService(cfg, &engine.FilterS{}, stopChan, nil)
+	cfg.ERsCfg().Readers[0].Fields = nil
+	go erS.ListenAndServe(nil)
+	rdr := &KafkaER{
+		cgrCfg:             cfg,
+		cfgIdx:             0,
+		fltrS:              &engine.FilterS{},
+		rdrEvents:          erS.rdrEvents,
+		processingAttempts: 1,
+	}
+	msg := kafka.Message{Key: []byte("1"), Value: []byte(`{"Account":"1001"}`)}
+	// no request type within flags so the processing fails
+	if err := rdr.processMessageWithAttempts(msg); err == nil ||
+		err.Error() != "unsupported reqType: <>" {
+		t.Errorf("Expected error: %s, received: %v", "unsupported reqType: <>", err)
+	}
+	var err error
+	if cfg.ERsCfg().Readers[0].Flags, err = utils.FlagsWithParamsFromSlice(
+		[]string{utils.META_NONE}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rdr.processMessageWithAttempts(msg); err != nil {
+		t.Error(err)
+	}
+	msg.Value = []byte("notJSON")
+	rdr.processingAttempts = 3
+	start := time.Now()
+	if err := rdr.processMessageWithAttempts(msg); !errors.Is(err, errMalformedMessage) {
+		t.Errorf("Expected error: %v, received: %v", errMalformedMessage, err)
+	} else if time.Since(start) > time.Second {
+		t.Errorf("Expected the malformed message to not be retried")
+	}
+}
+
+func TestKafkaProcessMessageWithAttemptsStopped(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].Fields = nil
+	rdrExit := make(chan struct{})
+	close(rdrExit)
+	rdr := &KafkaER{
+		cgrCfg:             cfg,
+		cfgIdx:             0,
+		fltrS:              &engine.FilterS{},
+		rdrEvents:          make(chan *erEvent), // nobody is consuming the events
+		rdrExit:            rdrExit,
+		processingAttempts: 1,
+	}
+	msg := kafka.Message{Key: []byte("1"), Value: []byte(`{"Account":"1001"}`)}
+	if err := rdr.processMessageWithAttempts(msg); err != utils.ErrDisconnected {
+		t.Errorf("Expected error: %v, received: %v", utils.ErrDisconnected, err)
+	}
+}
@@ -19,13 +19,23 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/fsnotify/fsnotify"
 )
 
+// errMalformedMessage is returned for the messages which cannot be decoded
+// so the readers do not try to process them again
+var errMalformedMessage = errors.New("MALFORMED_MESSAGE")
+
 // watchDir sets up a watcher via inotify to be triggered on new files
 // sysID is the subsystem ID, f will be triggered on match
 func watchDir(dirPath string, f func(itmPath, itmID string) error,
@@ -65,3 +75,73 @@ func watchDir(dirPath string, f func(itmPath, itmID string) error,
 	}()
 	return
 }
+
+// processJSONMessage decodes the message and dispatches the event to ERs, waiting for
+// the processing result; it gives up with utils.ErrDisconnected once the reader is stopped
+func processJSONMessage(msg []byte, rdrCfg *config.EventReaderCfg, cgrCfg *config.CGRConfig,
+	fltrS *engine.FilterS, rdrEvents chan *erEvent, rdrExit chan struct{}) (err error) {
+	var decodedMessage map[string]interface{}
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return fmt.Errorf("%w: %s", errMalformedMessage, err)
+	}
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), nil,
+		nil, nil, nil, rdrCfg.Tenant,
+		cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdrCfg.Timezone,
+			cgrCfg.GeneralCfg().DefaultTimezone),
+		fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = fltrS.Pass(agReq.Tenant, rdrCfg.Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdrCfg.Fields); err != nil {
+		return
+	}
+	rplyErr := make(chan error, 1)
+	select {
+	case rdrEvents <- &erEvent{
+		cgrEvent: config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep),
+		rdrCfg:   rdrCfg,
+		opts:     config.NMAsMapInterface(agReq.Opts, utils.NestingSep),
+		rplyErr:  rplyErr,
+	}:
+	case <-rdrExit:
+		return utils.ErrDisconnected
+	}
+	select {
+	case err = <-rplyErr:
+	case <-rdrExit:
+		err = utils.ErrDisconnected
+	}
+	return
+}
+
+// processWithAttempts calls process until it succeeds or the attempts are exhausted,
+// waiting a fibonacci backoff between the attempts; the malformed messages are not retried
+func processWithAttempts(process func() error, attempts int,
+	rdrExit chan struct{}, msgID string) (err error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	fib := utils.Fib()
+	for i := 0; i < attempts; i++ {
+		if i != 0 {
+			select {
+			case <-time.After(time.Duration(fib()) * time.Second):
+			case <-rdrExit:
+				return utils.ErrDisconnected
+			}
+		}
+		if err = process(); err == nil ||
+			err == utils.ErrDisconnected ||
+			errors.Is(err, errMalformedMessage) {
+			return
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> processing message %s, attempt %d, error: %s",
+				utils.ERs, msgID, i+1, err.Error()))
+	}
+	return
+}
@@ -0,0 +1,225 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewS3ER return a new s3 event reader
+func NewS3ER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &S3ER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	er = rdr
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// S3ER implements EventReader interface for s3 objects
+type S3ER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL    string
+	bucket     string
+	folderPath string
+	awsRegion  string
+	awsID      string
+	awsKey     string
+	awsToken   string
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *S3ER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutine polling the s3 bucket each RunDelay
+func (rdr *S3ER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	var ses *session.Session
+	if ses, err = newAWSSession(rdr.dialURL, rdr.awsRegion,
+		rdr.awsID, rdr.awsKey, rdr.awsToken); err != nil {
+		return
+	}
+	svc := s3.New(ses)
+	go func() {
+		for {
+			select {
+			case <-rdr.rdrExit:
+				utils.Logger.Info(
+					fmt.Sprintf("<%s> stop monitoring s3 bucket <%s>",
+						utils.ERs, rdr.bucket))
+				return
+			case <-time.After(rdr.Config().RunDelay):
+			}
+			if err := rdr.readBucket(svc); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading s3 bucket <%s> error: %s",
+						utils.ERs, rdr.bucket, err.Error()))
+			}
+		}
+	}()
+	return
+}
+
+// readBucket processes all the objects within the folder, waiting for them to finish
+// so the objects which are still processed are not read again on the next run
+func (rdr *S3ER) readBucket(svc *s3.S3) (err error) {
+	var wg sync.WaitGroup
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(rdr.bucket),
+		Prefix: aws.String(rdr.folderPath),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			if rdr.Config().ConcurrentReqs != -1 {
+				<-rdr.cap // do not try to read if the limit is reached
+			}
+			wg.Add(1)
+			go func(key string) {
+				rdr.readObject(svc, key)
+				wg.Done()
+			}(aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	wg.Wait()
+	return
+}
+
+// readObject processes the object and deletes it from the bucket on success,
+// otherwise it is read again on the next run
+func (rdr *S3ER) readObject(svc *s3.S3, key string) {
+	defer func() {
+		if rdr.Config().ConcurrentReqs != -1 {
+			rdr.cap <- struct{}{}
+		}
+	}()
+	obj, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(rdr.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reading object %s error: %s",
+				utils.ERs, key, err.Error()))
+		return
+	}
+	body, err := ioutil.ReadAll(obj.Body)
+	obj.Body.Close()
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> reading object %s error: %s",
+				utils.ERs, key, err.Error()))
+		return
+	}
+	if err = rdr.processMessage(body); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> processing object %s error: %s",
+				utils.ERs, key, err.Error()))
+		return
+	}
+	if _, err = svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(rdr.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> deleting object %s error: %s",
+				utils.ERs, key, err.Error()))
+	}
+	if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+		if err = engine.PostersCache.PostS3(rdr.Config().ProcessedPath,
+			rdr.cgrCfg.GeneralCfg().PosterAttempts, body,
+			strings.TrimSuffix(path.Base(key), utils.JSNSuffix)); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> writing object %s error: %s",
+					utils.ERs, key, err.Error()))
+		}
+	}
+}
+
+// processMessage dispatches the event to ERs and waits for the processing result
+func (rdr *S3ER) processMessage(msg []byte) (err error) {
+	return processJSONMessage(msg, rdr.Config(), rdr.cgrCfg,
+		rdr.fltrS, rdr.rdrEvents, rdr.rdrExit)
+}
+
+func (rdr *S3ER) setURL(dialURL string) (err error) {
+	if _, err = url.Parse(dialURL); err != nil {
+		return
+	}
+	qry := utils.GetUrlRawArguments(dialURL)
+	rdr.dialURL = strings.Split(dialURL, "?")[0]
+	rdr.dialURL = strings.TrimSuffix(rdr.dialURL, "/") // used to remove / to point to correct endpoint
+	rdr.bucket = defaultQueueID
+	if val, has := qry[utils.QueueID]; has {
+		rdr.bucket = val
+	}
+	if val, has := qry[utils.S3FolderPath]; has {
+		rdr.folderPath = val
+	}
+	if val, has := qry[utils.AWSRegion]; has {
+		rdr.awsRegion = val
+	}
+	if val, has := qry[utils.AWSKey]; has {
+		rdr.awsID = val
+	}
+	if val, has := qry[utils.AWSSecret]; has {
+		rdr.awsKey = val
+	}
+	if val, has := qry[utils.AWSToken]; has {
+		rdr.awsToken = val
+	}
+	return
+}
@@ -0,0 +1,48 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestS3SetURL(t *testing.T) {
+	rdr := new(S3ER)
+	expRdr := &S3ER{
+		dialURL:    "http://s3.us-east-2.amazonaws.com",
+		bucket:     "cdrs",
+		folderPath: "in",
+		awsRegion:  "us-east-2",
+		awsID:      "testID",
+		awsKey:     "testKey",
+	}
+	rdr.setURL("http://s3.us-east-2.amazonaws.com/?aws_region=us-east-2&aws_key=testID&aws_secret=testKey&queue_id=cdrs&folder_path=in")
+	if !reflect.DeepEqual(expRdr, rdr) {
+		t.Errorf("Expected: %+v ,received: %+v", expRdr, rdr)
+	}
+	if err := new(S3ER).setURL(":"); err == nil {
+		t.Errorf("Expected error received: %v", err)
+	}
+	rdr = new(S3ER)
+	rdr.setURL("http://s3.us-east-2.amazonaws.com/")
+	if rdr.bucket != defaultQueueID {
+		t.Errorf("Expected: %s ,received: %s", defaultQueueID, rdr.bucket)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	sqsMaxMessages  = 10 // maximum number of messages SQS returns on one receive
+	sqsWaitTimeSecs = 20 // long polling interval
+)
+
+// NewSQSER return a new sqs event reader
+func NewSQSER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &SQSER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	er = rdr
+	err = rdr.setURL(rdr.Config().SourcePath)
+	return
+}
+
+// SQSER implements EventReader interface for sqs message
+type SQSER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL   string
+	queueID   string
+	awsRegion string
+	awsID     string
+	awsKey    string
+	awsToken  string
+
+	deadLetterQueue string // queue receiving the messages which cannot be decoded
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *SQSER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to poll the sqs queue
+func (rdr *SQSER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	var svc *sqs.SQS
+	if svc, err = rdr.newSession(); err != nil {
+		return
+	}
+	var queueURL *string
+	if queueURL, err = rdr.getQueueURL(svc, rdr.queueID); err != nil {
+		return
+	}
+	var dlqURL *string
+	if rdr.deadLetterQueue != utils.EmptyString {
+		if dlqURL, err = rdr.getQueueURL(svc, rdr.deadLetterQueue); err != nil {
+			return
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring sqs queue <%s>",
+					utils.ERs, rdr.queueID))
+			cancel() // stops the long polling
+			return
+		}
+	}()
+	go rdr.readLoop(ctx, svc, queueURL, dlqURL) // read until the reader is stopped
+	return
+}
+
+func (rdr *SQSER) readLoop(ctx context.Context, svc *sqs.SQS, queueURL, dlqURL *string) {
+	maxMsgs := int64(sqsMaxMessages)
+	if concReq := rdr.Config().ConcurrentReqs; concReq > 0 && concReq < sqsMaxMessages {
+		maxMsgs = int64(concReq)
+	}
+	for {
+		out, err := svc.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            queueURL,
+			MaxNumberOfMessages: aws.Int64(maxMsgs),
+			WaitTimeSeconds:     aws.Int64(sqsWaitTimeSecs),
+		})
+		if err != nil {
+			select {
+			case <-rdr.rdrExit: // closed by us
+			default:
+				rdr.rdrErr <- err
+			}
+			return
+		}
+		for _, msg := range out.Messages {
+			if rdr.Config().ConcurrentReqs != -1 {
+				<-rdr.cap // do not try to read if the limit is reached
+			}
+			go rdr.readMsg(svc, queueURL, dlqURL, msg)
+		}
+	}
+}
+
+// readMsg processes the message and deletes it from the queue on success,
+// otherwise it becomes visible again once the visibility timeout expires;
+// the messages which cannot be decoded are moved to the dead letter queue or dropped
+func (rdr *SQSER) readMsg(svc *sqs.SQS, queueURL, dlqURL *string, msg *sqs.Message) {
+	defer func() {
+		if rdr.Config().ConcurrentReqs != -1 {
+			rdr.cap <- struct{}{}
+		}
+	}()
+	body := []byte(aws.StringValue(msg.Body))
+	msgID := aws.StringValue(msg.MessageId)
+	if err := rdr.processMessage(body); err != nil {
+		if !errors.Is(err, errMalformedMessage) {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing message %s error: %s",
+					utils.ERs, msgID, err.Error()))
+			return
+		}
+		if dlqURL == nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> dropping malformed message %s, error: %s",
+					utils.ERs, msgID, err.Error()))
+		} else {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> moving malformed message %s to dead letter queue <%s>, error: %s",
+					utils.ERs, msgID, rdr.deadLetterQueue, err.Error()))
+			if _, err = svc.SendMessage(&sqs.SendMessageInput{
+				QueueUrl:    dlqURL,
+				MessageBody: msg.Body,
+			}); err != nil { // not deleted so it is not lost
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> writing message %s to dead letter queue <%s> error: %s",
+						utils.ERs, msgID, rdr.deadLetterQueue, err.Error()))
+				return
+			}
+		}
+		if _, err = svc.DeleteMessage(&sqs.DeleteMessageInput{
+			QueueUrl:      queueURL,
+			ReceiptHandle: msg.ReceiptHandle,
+		}); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> deleting message %s error: %s",
+					utils.ERs, msgID, err.Error()))
+		}
+		return
+	}
+	if _, err := svc.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      queueURL,
+		ReceiptHandle: msg.ReceiptHandle,
+	}); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> deleting message %s error: %s",
+				utils.ERs, msgID, err.Error()))
+	}
+	if rdr.Config().ProcessedPath != utils.EmptyString { // post it
+		if err := engine.PostersCache.PostSQS(rdr.Config().ProcessedPath,
+			rdr.cgrCfg.GeneralCfg().PosterAttempts, body); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> writing message %s error: %s",
+					utils.ERs, msgID, err.Error()))
+		}
+	}
+}
+
+// processMessage dispatches the event to ERs and waits for the processing result
+func (rdr *SQSER) processMessage(msg []byte) (err error) {
+	return processJSONMessage(msg, rdr.Config(), rdr.cgrCfg,
+		rdr.fltrS, rdr.rdrEvents, rdr.rdrExit)
+}
+
+func (rdr *SQSER) setURL(dialURL string) (err error) {
+	if _, err = url.Parse(dialURL); err != nil {
+		return
+	}
+	qry := utils.GetUrlRawArguments(dialURL)
+	rdr.dialURL = strings.Split(dialURL, "?")[0]
+	rdr.dialURL = strings.TrimSuffix(rdr.dialURL, "/") // used to remove / to point to correct endpoint
+	rdr.queueID = defaultQueueID
+	if val, has := qry[utils.QueueID]; has {
+		rdr.queueID = val
+	}
+	if val, has := qry[utils.AWSRegion]; has {
+		rdr.awsRegion = val
+	}
+	if val, has := qry[utils.AWSKey]; has {
+		rdr.awsID = val
+	}
+	if val, has := qry[utils.AWSSecret]; has {
+		rdr.awsKey = val
+	}
+	if val, has := qry[utils.AWSToken]; has {
+		rdr.awsToken = val
+	}
+	if val, has := qry[utils.DeadLetterQueue]; has {
+		rdr.deadLetterQueue = val
+	}
+	return
+}
+
+func (rdr *SQSER) newSession() (svc *sqs.SQS, err error) {
+	var ses *session.Session
+	if ses, err = newAWSSession(rdr.dialURL, rdr.awsRegion,
+		rdr.awsID, rdr.awsKey, rdr.awsToken); err != nil {
+		return
+	}
+	return sqs.New(ses), nil
+}
+
+func (rdr *SQSER) getQueueURL(svc *sqs.SQS, queueID string) (queueURL *string, err error) {
+	var result *sqs.GetQueueUrlOutput
+	if result, err = svc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(queueID),
+	}); err != nil {
+		return
+	}
+	return result.QueueUrl, nil
+}
+
+// newAWSSession creates a new session towards the AWS endpoint
+func newAWSSession(dialURL, awsRegion, awsID, awsKey, awsToken string) (*session.Session, error) {
+	cfg := aws.Config{Endpoint: aws.String(dialURL)}
+	if len(awsRegion) != 0 {
+		cfg.Region = aws.String(awsRegion)
+	}
+	if len(awsID) != 0 &&
+		len(awsKey) != 0 {
+		cfg.Credentials = credentials.NewStaticCredentials(awsID, awsKey, awsToken)
+	}
+	return session.NewSessionWithOptions(
+		session.Options{
+			Config: cfg,
+		},
+	)
+}
@@ -0,0 +1,50 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSQSSetURL(t *testing.T) {
+	rdr := new(SQSER)
+	expRdr := &SQSER{
+		dialURL:   "http://sqs.eu-west-2.amazonaws.com",
+		queueID:   "cdrs",
+		awsRegion: "eu-west-2",
+		awsID:     "testID",
+		awsKey:    "testKey",
+		awsToken:  "testToken",
+
+		deadLetterQueue: "cdrs_dlq",
+	}
+	rdr.setURL("http://sqs.eu-west-2.amazonaws.com/?aws_region=eu-west-2&aws_key=testID&aws_secret=testKey&aws_token=testToken&queue_id=cdrs&dead_letter_queue=cdrs_dlq")
+	if !reflect.DeepEqual(expRdr, rdr) {
+		t.Errorf("Expected: %+v ,received: %+v", expRdr, rdr)
+	}
+	if err := new(SQSER).setURL(":"); err == nil {
+		t.Errorf("Expected error received: %v", err)
+	}
+	rdr = new(SQSER)
+	rdr.setURL("http://sqs.eu-west-2.amazonaws.com/")
+	if rdr.queueID != defaultQueueID {
+		t.Errorf("Expected: %s ,received: %s", defaultQueueID, rdr.queueID)
+	}
+}
@@ -52,7 +52,7 @@ cgrates.org,call,testauthpostpaid2,2013-01-06T00:00:00Z,RP_1,*any
 cgrates.org,call,*any,2013-01-06T00:00:00Z,RP_ANY,`
 	sharedGroups := ``
 	actions := `TOPUP10_AC,*topup_reset,,,,*monetary,,*any,,,*unlimited,,0,10,false,false,10`
-	actionPlans := `TOPUP10_AT,TOPUP10_AC,*asap,10`
+	actionPlans := `TOPUP10_AT,TOPUP10_AC,*asap,10,`
 	actionTriggers := ``
 	accountActions := `cgrates.org,testauthpostpaid1,TOPUP10_AT,,,`
 	resLimits := ``
@@ -51,8 +51,8 @@ cgrates.org,call,discounted_minutes,2013-01-06T00:00:00Z,RP_UK_Mobile_BIG5_PKG,`
 	sharedGroups := ``
 	actions := `TOPUP10_AC,*topup_reset,,,,*monetary,,*any,,,*unlimited,,0,10,false,false,10
 TOPUP10_AC1,*topup_reset,,,,*voice,,DST_UK_Mobile_BIG5,discounted_minutes,,*unlimited,,40s,10,false,false,10`
-	actionPlans := `TOPUP10_AT,TOPUP10_AC,ASAP,10
-TOPUP10_AT,TOPUP10_AC1,ASAP,10`
+	actionPlans := `TOPUP10_AT,TOPUP10_AC,ASAP,10,
+TOPUP10_AT,TOPUP10_AC1,ASAP,10,`
 	actionTriggers := ``
 	accountActions := `cgrates.org,12345,TOPUP10_AT,,,`
 	resLimits := ``
@@ -128,7 +128,7 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 
 func TestExecuteActions2(t *testing.T) {
 	scheduler.NewScheduler(dataDB2, config.CgrConfig(),
-		engine.NewFilterS(config.CgrConfig(), nil, dataDB)).Reload()
+		engine.NewFilterS(config.CgrConfig(), nil, dataDB), nil).Reload()
 	time.Sleep(10 * time.Millisecond) // Give time to scheduler to topup the account
 	if acnt, err := dataDB2.GetAccount("cgrates.org:12345"); err != nil {
 		t.Error(err)
@@ -28,8 +28,31 @@ import (
 
 // global package variable
 var Guardian = &GuardianLocker{
-	locks: make(map[string]*itemLock),
-	refs:  make(map[string][]string)}
+	locks:   make(map[string]*itemLock),
+	refs:    make(map[string][]string),
+	remotes: make(map[string]*remoteLock)}
+
+const (
+	remoteLockTTL        = 10 * time.Second       // lease of the remote locks, renewed while locked and expiring only for a lost peer
+	remoteLockMaxBackoff = 100 * time.Millisecond // maximum interval between two attempts of taking a remote lock
+)
+
+// LockBackend shares the locks between the engines, eg: over the common DataDB
+type LockBackend interface {
+	// AcquireLock takes the lock for ttl if free or expired, returning false if owned by somebody else
+	AcquireLock(lkID, owner string, ttl time.Duration) (bool, error)
+	// RefreshLock extends the lock for ttl, returning false if not owned anymore
+	RefreshLock(lkID, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLock removes the lock if still owned
+	ReleaseLock(lkID, owner string) error
+}
+
+// remoteLock is a lock aquired on the LockBackend
+type remoteLock struct {
+	owner string
+	stop  chan struct{}
+	lost  chan struct{} // closed if the lease could not be renewed
+}
 
 type itemLock struct {
 	lk  chan struct{}
@@ -42,6 +65,134 @@ type GuardianLocker struct {
 	lkMux   sync.Mutex          // protects the locks
 	refs    map[string][]string // used in case of remote locks
 	refsMux sync.RWMutex        // protects the map
+
+	backend LockBackend            // shares the locks with other engines, nil for local locks only
+	remotes map[string]*remoteLock // locks held on the backend
+	rmtMux  sync.RWMutex           // protects the backend and the remotes
+}
+
+// SetBackend sets the backend used to share the locks with other engines, nil for local locks only
+func (gl *GuardianLocker) SetBackend(b LockBackend) {
+	gl.rmtMux.Lock()
+	gl.backend = b
+	gl.rmtMux.Unlock()
+}
+
+// lockRemote takes the locks on the backend after they were locked localy,
+// giving up after timeout so we do not deadlock on a lost peer.
+// On error none of the remote locks is kept.
+func (gl *GuardianLocker) lockRemote(timeout time.Duration, lkIDs []string) (err error) {
+	gl.rmtMux.RLock()
+	b := gl.backend
+	gl.rmtMux.RUnlock()
+	if b == nil {
+		return
+	}
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	var locked []string
+	for _, lkID := range lkIDs {
+		if lkID == "" {
+			continue
+		}
+		owner := utils.GenUUID()
+		for backoff := time.Millisecond; ; backoff *= 2 {
+			var acquired bool
+			if acquired, err = b.AcquireLock(lkID, owner, remoteLockTTL); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<Guardian> cannot lock remotely <%s>, error: %s", lkID, err.Error()))
+				err = utils.ErrRemoteLockUnavailable
+				break
+			}
+			if acquired {
+				rmtLk := &remoteLock{owner: owner, stop: make(chan struct{}), lost: make(chan struct{})}
+				gl.rmtMux.Lock()
+				gl.remotes[lkID] = rmtLk
+				gl.rmtMux.Unlock()
+				locked = append(locked, lkID)
+				go gl.refreshRemote(b, lkID, rmtLk, remoteLockTTL)
+				break
+			}
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				utils.Logger.Warning(fmt.Sprintf("<Guardian> timeout waiting for remote lock: %s", lkID))
+				err = utils.ErrRemoteLockUnavailable
+				break
+			}
+			if backoff > remoteLockMaxBackoff {
+				backoff = remoteLockMaxBackoff
+			}
+			time.Sleep(backoff)
+		}
+		if err != nil {
+			gl.unlockRemote(locked)
+			return
+		}
+	}
+	return
+}
+
+// refreshRemote keeps the remote lock from expiring while we hold it,
+// signaling on lost if the lease expired or was taken by somebody else
+func (gl *GuardianLocker) refreshRemote(b LockBackend, lkID string, rmtLk *remoteLock, ttl time.Duration) {
+	intvl := ttl / 2
+	if intvl <= 0 {
+		intvl = time.Millisecond
+	}
+	tckr := time.NewTicker(intvl)
+	defer tckr.Stop()
+	lastRefresh := time.Now()
+	for {
+		select {
+		case <-rmtLk.stop:
+			return
+		case <-tckr.C:
+			owned, err := b.RefreshLock(lkID, rmtLk.owner, ttl)
+			if err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<Guardian> cannot refresh remote lock <%s>, error: %s", lkID, err.Error()))
+				if time.Since(lastRefresh) < ttl {
+					continue // the lease is still valid
+				}
+			} else if owned {
+				lastRefresh = time.Now()
+				continue
+			}
+			utils.Logger.Err(fmt.Sprintf("<Guardian> lost remote lock: %s", lkID))
+			close(rmtLk.lost)
+			return
+		}
+	}
+}
+
+// unlockRemote releases the locks taken on the backend, before unlocking them localy,
+// returning ErrRemoteLockLost if any of them was lost while held
+func (gl *GuardianLocker) unlockRemote(lkIDs []string) (err error) {
+	gl.rmtMux.Lock()
+	b := gl.backend
+	rmtLks := make(map[string]*remoteLock)
+	for _, lkID := range lkIDs {
+		if rmtLk, has := gl.remotes[lkID]; has {
+			rmtLks[lkID] = rmtLk
+			delete(gl.remotes, lkID)
+		}
+	}
+	gl.rmtMux.Unlock()
+	for lkID, rmtLk := range rmtLks {
+		close(rmtLk.stop)
+		select {
+		case <-rmtLk.lost:
+			err = utils.ErrRemoteLockLost
+			continue
+		default:
+		}
+		if b == nil {
+			continue
+		}
+		if errRls := b.ReleaseLock(lkID, rmtLk.owner); errRls != nil {
+			utils.Logger.Warning(fmt.Sprintf("<Guardian> cannot unlock remotely <%s>, error: %s", lkID, errRls.Error()))
+		}
+	}
+	return
 }
 
 func (gl *GuardianLocker) lockItem(itmID string) {
@@ -82,7 +233,7 @@ func (gl *GuardianLocker) unlockItem(itmID string) {
 }
 
 // lockWithReference will perform locks and also generate a lock reference for it (so it can be used when remotely locking)
-func (gl *GuardianLocker) lockWithReference(refID string, lkIDs []string) string {
+func (gl *GuardianLocker) lockWithReference(refID string, timeout time.Duration, lkIDs []string) string {
 	var refEmpty bool
 	if refID == "" {
 		refEmpty = true
@@ -103,6 +254,17 @@ func (gl *GuardianLocker) lockWithReference(refID string, lkIDs []string) string
 	for _, lk := range lkIDs {
 		gl.lockItem(lk)
 	}
+	if err := gl.lockRemote(timeout, lkIDs); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<Guardian> cannot lock %+v, error: %s", lkIDs, err.Error()))
+		for _, lk := range lkIDs {
+			gl.unlockItem(lk)
+		}
+		gl.refsMux.Lock()
+		delete(gl.refs, refID)
+		gl.refsMux.Unlock()
+		gl.unlockItem(refID)
+		return "" // no locking was done
+	}
 	gl.unlockItem(refID)
 	return refID
 }
@@ -119,6 +281,9 @@ func (gl *GuardianLocker) unlockWithReference(refID string) (lkIDs []string) {
 	}
 	delete(gl.refs, refID)
 	gl.refsMux.Unlock()
+	if err := gl.unlockRemote(lkIDs); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<Guardian> unlocking %+v, error: %s", lkIDs, err.Error()))
+	}
 	for _, lk := range lkIDs {
 		gl.unlockItem(lk)
 	}
@@ -126,11 +291,19 @@ func (gl *GuardianLocker) unlockWithReference(refID string) (lkIDs []string) {
 	return
 }
 
-// Guard executes the handler between locks
+// Guard executes the handler between locks.
+// With a locking backend the handler is not executed if the remote locks cannot be taken (ErrRemoteLockUnavailable)
+// and ErrRemoteLockLost is returned, together with the reply, if they expired while the handler was running.
 func (gl *GuardianLocker) Guard(handler func() (interface{}, error), timeout time.Duration, lockIDs ...string) (reply interface{}, err error) {
 	for _, lockID := range lockIDs {
 		gl.lockItem(lockID)
 	}
+	if err = gl.lockRemote(timeout, lockIDs); err != nil {
+		for _, lockID := range lockIDs {
+			gl.unlockItem(lockID)
+		}
+		return
+	}
 	rplyChan := make(chan interface{})
 	errChan := make(chan error)
 	go func(rplyChan chan interface{}, errChan chan error) {
@@ -154,6 +327,9 @@ func (gl *GuardianLocker) Guard(handler func() (interface{}, error), timeout tim
 		case reply = <-rplyChan:
 		}
 	}
+	if errRmt := gl.unlockRemote(lockIDs); errRmt != nil && err == nil {
+		err = errRmt
+	}
 	for _, lockID := range lockIDs {
 		gl.unlockItem(lockID)
 	}
@@ -161,9 +337,9 @@ func (gl *GuardianLocker) Guard(handler func() (interface{}, error), timeout tim
 }
 
 // GuardIDs aquires a lock for duration
-// returns the reference ID for the lock group aquired
+// returns the reference ID for the lock group aquired, empty if the remote locks could not be taken
 func (gl *GuardianLocker) GuardIDs(refID string, timeout time.Duration, lkIDs ...string) (retRefID string) {
-	retRefID = gl.lockWithReference(refID, lkIDs)
+	retRefID = gl.lockWithReference(refID, timeout, lkIDs)
 	if timeout != 0 && retRefID != "" {
 		go func() {
 			time.Sleep(timeout)
@@ -278,3 +278,171 @@ func BenchmarkGuardIDs(b *testing.B) {
 		}()
 	}
 }
+
+// testLockBackend simulates the locks kept in a shared DataDB
+type testLockBackend struct {
+	sync.Mutex
+	owners map[string]string
+	expiry map[string]time.Time
+}
+
+func newTestLockBackend() *testLockBackend {
+	return &testLockBackend{
+		owners: make(map[string]string),
+		expiry: make(map[string]time.Time),
+	}
+}
+
+func (lb *testLockBackend) AcquireLock(lkID, owner string, ttl time.Duration) (bool, error) {
+	lb.Lock()
+	defer lb.Unlock()
+	if _, has := lb.owners[lkID]; has && time.Now().Before(lb.expiry[lkID]) {
+		return false, nil
+	}
+	lb.owners[lkID] = owner
+	lb.expiry[lkID] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (lb *testLockBackend) RefreshLock(lkID, owner string, ttl time.Duration) (bool, error) {
+	lb.Lock()
+	defer lb.Unlock()
+	if lb.owners[lkID] != owner {
+		return false, nil
+	}
+	lb.expiry[lkID] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (lb *testLockBackend) ReleaseLock(lkID, owner string) error {
+	lb.Lock()
+	defer lb.Unlock()
+	if lb.owners[lkID] == owner {
+		delete(lb.owners, lkID)
+		delete(lb.expiry, lkID)
+	}
+	return nil
+}
+
+func newTestGuardianLocker(b LockBackend) (gl *GuardianLocker) {
+	gl = &GuardianLocker{
+		locks:   make(map[string]*itemLock),
+		refs:    make(map[string][]string),
+		remotes: make(map[string]*remoteLock),
+	}
+	gl.SetBackend(b)
+	return
+}
+
+// two engines sharing the same backend need to execute one after the other
+func TestGuardianRemoteGuard(t *testing.T) {
+	lkBknd := newTestLockBackend()
+	engines := []*GuardianLocker{newTestGuardianLocker(lkBknd), newTestGuardianLocker(lkBknd)}
+	tStart := time.Now()
+	var wg sync.WaitGroup
+	for _, gl := range engines {
+		wg.Add(1)
+		go func(gl *GuardianLocker) {
+			gl.Guard(delayHandler, 0, "acc_1001")
+			wg.Done()
+		}(gl)
+	}
+	wg.Wait()
+	if execTime := time.Since(tStart); execTime < 200*time.Millisecond {
+		t.Errorf("remote lock not honoured, execution took: %v", execTime)
+	}
+	lkBknd.Lock()
+	if len(lkBknd.owners) != 0 {
+		t.Errorf("remote locks not released: %+v", lkBknd.owners)
+	}
+	lkBknd.Unlock()
+	for _, gl := range engines {
+		gl.rmtMux.RLock()
+		if len(gl.remotes) != 0 {
+			t.Errorf("possible memleak for remote locks: %+v", gl.remotes)
+		}
+		gl.rmtMux.RUnlock()
+	}
+}
+
+func TestGuardianRemoteGuardTimeout(t *testing.T) {
+	lkBknd := newTestLockBackend()
+	lkBknd.AcquireLock("acc_1001", "otherEngine", time.Hour)
+	gl := newTestGuardianLocker(lkBknd)
+	tStart := time.Now()
+	var executed bool
+	if _, err := gl.Guard(func() (interface{}, error) { executed = true; return nil, nil },
+		20*time.Millisecond, "acc_1001", "acc_1002"); err != utils.ErrRemoteLockUnavailable {
+		t.Errorf("expected: %v, received: %v", utils.ErrRemoteLockUnavailable, err)
+	}
+	if executed {
+		t.Error("handler executed without the remote lock")
+	}
+	if execTime := time.Since(tStart); execTime < 20*time.Millisecond ||
+		execTime > 200*time.Millisecond {
+		t.Errorf("locking timeout not honoured, execution took: %v", execTime)
+	}
+	lkBknd.Lock()
+	if lkBknd.owners["acc_1001"] != "otherEngine" {
+		t.Errorf("lock of another engine released: %+v", lkBknd.owners)
+	}
+	lkBknd.Unlock()
+	if refID := gl.GuardIDs("", 20*time.Millisecond, "acc_1001"); refID != "" {
+		t.Errorf("expected no lock, received reference: %s", refID)
+	}
+	gl.lkMux.Lock()
+	if len(gl.locks) != 0 {
+		t.Errorf("local locks not released: %+v", gl.locks)
+	}
+	gl.lkMux.Unlock()
+}
+
+func TestGuardianRemoteLockLost(t *testing.T) {
+	lkBknd := newTestLockBackend()
+	gl := newTestGuardianLocker(lkBknd)
+	if err := gl.lockRemote(0, []string{"acc_1001"}); err != nil {
+		t.Fatal(err)
+	}
+	gl.rmtMux.RLock()
+	rmtLk := gl.remotes["acc_1001"]
+	gl.rmtMux.RUnlock()
+	lkBknd.Lock()
+	lkBknd.owners["acc_1001"] = "otherEngine"
+	lkBknd.Unlock()
+	go gl.refreshRemote(lkBknd, "acc_1001", rmtLk, time.Nanosecond)
+	select {
+	case <-rmtLk.lost:
+	case <-time.After(time.Second):
+		t.Fatal("lost remote lock not signaled")
+	}
+	if err := gl.unlockRemote([]string{"acc_1001"}); err != utils.ErrRemoteLockLost {
+		t.Errorf("expected: %v, received: %v", utils.ErrRemoteLockLost, err)
+	}
+	lkBknd.Lock()
+	if lkBknd.owners["acc_1001"] != "otherEngine" {
+		t.Errorf("lock of another engine released: %+v", lkBknd.owners)
+	}
+	lkBknd.Unlock()
+}
+
+func TestGuardianRemoteGuardIDs(t *testing.T) {
+	lkBknd := newTestLockBackend()
+	gl := newTestGuardianLocker(lkBknd)
+	refID := gl.GuardIDs("", 0, "acc_1001", "acc_1002")
+	lkBknd.Lock()
+	if len(lkBknd.owners) != 2 {
+		t.Errorf("expected 2 remote locks, received: %+v", lkBknd.owners)
+	}
+	lkBknd.Unlock()
+	if acquired, _ := lkBknd.AcquireLock("acc_1001", "otherEngine", time.Second); acquired {
+		t.Error("remote lock taken by another engine while guarded")
+	}
+	if lkIDs := gl.UnguardIDs(refID); !reflect.DeepEqual([]string{"acc_1001", "acc_1002"}, lkIDs) {
+		t.Errorf("received: %v", lkIDs)
+	}
+	lkBknd.Lock()
+	if len(lkBknd.owners) != 0 {
+		t.Errorf("remote locks not released: %+v", lkBknd.owners)
+	}
+	lkBknd.Unlock()
+}
@@ -43,6 +43,20 @@ func (ld LoaderData) TenantIDStruct() utils.TenantID {
 	}
 }
 
+// newLookupTable builds the LookupTable out of the lines having the same TenantID
+func newLookupTable(lDataSet []LoaderData) (lt *engine.LookupTable) {
+	tntID := lDataSet[0].TenantIDStruct()
+	lt = &engine.LookupTable{
+		Tenant:  tntID.Tenant,
+		ID:      tntID.ID,
+		Entries: make(map[string]string, len(lDataSet)),
+	}
+	for _, ld := range lDataSet {
+		lt.Entries[utils.IfaceAsString(ld[utils.Key])] = utils.IfaceAsString(ld[utils.Value])
+	}
+	return
+}
+
 // UpdateFromCSV will update LoaderData with data received from fileName,
 // contained in record and processed with cfgTpl
 func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
@@ -45,6 +45,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		enabled:       cfg.Enabled,
 		tenant:        cfg.Tenant,
 		dryRun:        cfg.DryRun,
+		transactional: cfg.Transactional,
 		ldrID:         cfg.Id,
 		tpInDir:       cfg.TpInDir,
 		tpOutDir:      cfg.TpOutDir,
@@ -58,6 +59,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		filterS:       filterS,
 		connMgr:       connMgr,
 		cacheConns:    cacheConns,
+		schedConns:    cfg.SchedulerConns,
 	}
 	for _, ldrData := range cfg.Data {
 		ldr.dataTpls[ldrData.Type] = ldrData.Fields
@@ -81,6 +83,7 @@ type Loader struct {
 	enabled       bool
 	tenant        config.RSRParsers
 	dryRun        bool
+	transactional bool
 	ldrID         string
 	tpInDir       string
 	tpOutDir      string
@@ -95,6 +98,7 @@ type Loader struct {
 	filterS       *engine.FilterS
 	connMgr       *engine.ConnManager
 	cacheConns    []string
+	schedConns    []string
 }
 
 func (ldr *Loader) ListenAndServe(exitChan chan struct{}) (err error) {
@@ -110,6 +114,12 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string) (err error) {
 		return
 	}
 	defer ldr.unlockFolder()
+	if ldr.transactional && loadOption == utils.MetaStore {
+		if err = ldr.processFolderTransactional(caching); err != nil {
+			return // keep the files in place so they can be corrected
+		}
+		return ldr.moveFiles()
+	}
 	for ldrType := range ldr.rdrs {
 		if err = ldr.processFiles(ldrType, caching, loadOption); err != nil {
 			utils.Logger.Warning(fmt.Sprintf("<%s-%s> loaderType: <%s> cannot open files, err: %s",
@@ -579,6 +589,21 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				cachePartition = utils.CacheDispatcherProfiles
 			}
 		}
+	case utils.MetaLookupTables:
+		for tntID, lDataSet := range lds {
+			lt := newLookupTable(lDataSet)
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: LookupTable: %s with %d entries",
+						utils.LoaderS, ldr.ldrID, tntID, len(lt.Entries)))
+				continue
+			}
+			ids = append(ids, tntID)
+			if err := ldr.dm.SetLookupTable(lt, true); err != nil {
+				return err
+			}
+		}
+		return ldr.cacheLookupTables(caching, ids)
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -865,6 +890,19 @@ func (ldr *Loader) removeLoadedData(loaderType, tntID, caching string) (err erro
 			cacheArgs.RateProfileIDs = ids
 			cachePartition = utils.CacheRateProfiles
 		}
+	case utils.MetaLookupTables:
+		if ldr.dryRun {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> DRY_RUN: LookupTableID: %s",
+					utils.LoaderS, ldr.ldrID, tntID))
+			return
+		}
+		tntIDStruct := utils.NewTenantID(tntID)
+		if err := ldr.dm.RemoveLookupTable(tntIDStruct.Tenant,
+			tntIDStruct.ID); err != nil {
+			return err
+		}
+		return ldr.cacheLookupTables(caching, []string{tntID})
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -903,3 +941,28 @@ func (ldr *Loader) removeLoadedData(loaderType, tntID, caching string) (err erro
 	}
 	return
 }
+
+// cacheLookupTables updates the remote caches after loading the lookup tables
+// since the entries are cached individually, the whole table is dropped
+func (ldr *Loader) cacheLookupTables(caching string, tntIDs []string) (err error) {
+	if len(ldr.cacheConns) == 0 || caching == utils.META_NONE {
+		return
+	}
+	var reply string
+	if caching == utils.MetaClear {
+		return ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1Clear, new(utils.AttrCacheIDsWithArgDispatcher), &reply)
+	}
+	for _, tntID := range tntIDs {
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1RemoveGroup, &utils.ArgsGetGroupWithArgDispatcher{
+				ArgsGetGroup: utils.ArgsGetGroup{
+					CacheID: utils.CacheLookupTables,
+					GroupID: tntID,
+				},
+			}, &reply); err != nil {
+			return
+		}
+	}
+	return
+}
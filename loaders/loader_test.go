@@ -741,6 +741,14 @@ func TestLoaderProcessRoutes(t *testing.T) {
 				Path:  "Weight",
 				Type:  utils.META_COMPOSED,
 				Value: config.NewRSRParsersMustCompile("~15", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "QuarantineRules",
+				Path:  "QuarantineRules",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~16", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "QuarantineCooldown",
+				Path:  "QuarantineCooldown",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~17", true, utils.INFIELD_SEP)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.RoutesCSVContent))
@@ -862,6 +870,14 @@ func TestLoaderProcessChargers(t *testing.T) {
 				Path:  "Weight",
 				Type:  utils.META_COMPOSED,
 				Value: config.NewRSRParsersMustCompile("~6", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "Opts",
+				Path:  "Opts",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~7", true, utils.INFIELD_SEP)},
+			&config.FCTemplate{Tag: "Blocker",
+				Path:  "Blocker",
+				Type:  utils.META_COMPOSED,
+				Value: config.NewRSRParsersMustCompile("~8", true, utils.INFIELD_SEP)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ChargersCSVContent))
@@ -1140,6 +1156,100 @@ func TestLoaderProcessDispatcheHosts(t *testing.T) {
 	}
 }
 
+func TestLoaderProcessLookupTables(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessContent",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaLookupTables: []*config.FCTemplate{
+			&config.FCTemplate{
+				Tag:       "Tenant",
+				Path:      "Tenant",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:       "ID",
+				Path:      "ID",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:       "Key",
+				Path:      "Key",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP),
+				Mandatory: true,
+			},
+			&config.FCTemplate{
+				Tag:   "Value",
+				Path:  "Value",
+				Type:  utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP),
+			},
+		},
+	}
+	lktCSV := `
+#Tenant,ID,Key,Value
+cgrates.org,PORTED,4986517174963,D0014986517174963
+cgrates.org,PORTED,4986517174964,D0024986517174964
+cgrates.org,ZONES,49,GERMANY
+`
+	rdr := ioutil.NopCloser(strings.NewReader(lktCSV))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaLookupTables: map[string]*openedCSVFile{
+			utils.LookupTablesCsv: &openedCSVFile{
+				fileName: utils.LookupTablesCsv,
+				rdr:      rdr,
+				csvRdr:   csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaLookupTables, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if len(ldr.bufLoaderData) != 0 {
+		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
+	}
+	for key, eVal := range map[string]string{
+		"cgrates.org:PORTED:4986517174963": "D0014986517174963",
+		"cgrates.org:PORTED:4986517174964": "D0024986517174964",
+		"cgrates.org:ZONES:49":             "GERMANY",
+	} {
+		keys := strings.SplitN(key, utils.CONCATENATED_KEY_SEP, 3)
+		if val, err := ldr.dm.GetLookupTableValue(keys[0], keys[1], keys[2],
+			false, false, utils.NonTransactional); err != nil {
+			t.Error(err)
+		} else if val != eVal {
+			t.Errorf("expecting: %s, received: %s", eVal, val)
+		}
+	}
+	// remove the tables
+	rdr = ioutil.NopCloser(strings.NewReader(lktCSV))
+	csvRdr = csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs[utils.MetaLookupTables][utils.LookupTablesCsv] = &openedCSVFile{
+		fileName: utils.LookupTablesCsv,
+		rdr:      rdr,
+		csvRdr:   csvRdr,
+	}
+	if err := ldr.removeContent(utils.MetaLookupTables, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+	if _, err := ldr.dm.GetLookupTableValue("cgrates.org", "PORTED", "4986517174963",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
 func TestLoaderRemoveContentSingleFile(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	ldr := &Loader{
@@ -0,0 +1,963 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// txnLoaderTypes is the order used when applying a transactional load
+// so the referenced items are written before the ones referencing them
+var txnLoaderTypes = []string{
+	utils.MetaFilters,
+	utils.MetaDispatcherHosts,
+	utils.MetaAttributes,
+	utils.MetaThresholds,
+	utils.MetaStats,
+	utils.MetaResources,
+	utils.MetaRoutes,
+	utils.MetaChargers,
+	utils.MetaDispatchers,
+	utils.MetaRateProfiles,
+	utils.MetaActionPlans,
+	utils.MetaLookupTables,
+}
+
+// txnItem is one profile prepared for a transactional load
+type txnItem struct {
+	loaderType string
+	tntID      *utils.TenantID
+	prf        interface{}         // the profile, used for logging in dry_run
+	fltrIDs    []string            // filters referenced by the profile
+	refs       map[string][]string // references towards other items, indexed on their type
+	cacheID    string              // cache partition of the profile
+	// store will write the profile into DataDB
+	// returning the function to restore the previous state
+	store func() (rollback func() error, err error)
+}
+
+// cacheItemID returns the ID of the item inside its cache partition
+func (itm *txnItem) cacheItemID() string {
+	if itm.loaderType == utils.MetaActionPlans { // ActionPlans are not tenant aware
+		return itm.tntID.ID
+	}
+	return itm.tntID.TenantID()
+}
+
+// processFolderTransactional will parse and validate all the files before
+// writing anything to DataDB, rolling back the changes on the first error
+func (ldr *Loader) processFolderTransactional(caching string) (err error) {
+	var errs []string
+	itms := make(map[string][]*txnItem)
+	loadedIDs := make(map[string]utils.StringSet) // tenantIDs part of this load, indexed on loaderType
+	for ldrType := range ldr.rdrs {
+		lDataSet, rdErrs := ldr.readContentTransactional(ldrType)
+		errs = append(errs, rdErrs...)
+		if len(lDataSet) == 0 {
+			continue
+		}
+		var prepErrs []string
+		if itms[ldrType], prepErrs = ldr.prepareTxnItems(ldrType, lDataSet); len(prepErrs) != 0 {
+			errs = append(errs, prepErrs...)
+		}
+		loadedIDs[ldrType] = make(utils.StringSet)
+		for _, itm := range itms[ldrType] {
+			loadedIDs[ldrType].Add(itm.tntID.TenantID())
+		}
+	}
+	for _, ldrType := range txnLoaderTypes {
+		for _, itm := range itms[ldrType] {
+			errs = append(errs, ldr.checkTxnReferences(itm, loadedIDs)...)
+		}
+	}
+	if len(errs) != 0 {
+		return utils.NewErrLoadTransaction(errs)
+	}
+	if ldr.dryRun {
+		for _, ldrType := range txnLoaderTypes {
+			for _, itm := range itms[ldrType] {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: %s: %s",
+						utils.LoaderS, ldr.ldrID, ldrType, utils.ToJSON(itm.prf)))
+			}
+		}
+		return
+	}
+	transID := engine.Cache.BeginTransaction()
+	var rollbacks []func() error
+	var cacheArgs utils.ArgsCache
+	for _, ldrType := range txnLoaderTypes {
+		var ids []string
+		for _, itm := range itms[ldrType] {
+			rollback, errStore := itm.store()
+			if rollback != nil {
+				rollbacks = append(rollbacks, rollback)
+			}
+			if errStore != nil {
+				engine.Cache.RollbackTransaction(transID)
+				errs = append(errs, fmt.Sprintf("%s: <%s>, store error: %s",
+					ldrType, itm.tntID.TenantID(), errStore))
+				for i := len(rollbacks) - 1; i >= 0; i-- {
+					if errRb := rollbacks[i](); errRb != nil {
+						errs = append(errs, fmt.Sprintf("rollback error: %s", errRb))
+					}
+				}
+				return utils.NewErrLoadTransaction(errs)
+			}
+			ids = append(ids, itm.cacheItemID())
+			engine.Cache.Remove(itm.cacheID, itm.cacheItemID(), false, transID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		txnCacheArgs(&cacheArgs, ldrType, ids)
+	}
+	engine.Cache.CommitTransaction(transID)
+	if err = ldr.txnCache(caching, cacheArgs, itms); err != nil {
+		return
+	}
+	if len(itms[utils.MetaActionPlans]) == 0 ||
+		len(ldr.schedConns) == 0 {
+		return
+	}
+	var reply string
+	return ldr.connMgr.Call(ldr.schedConns, nil, utils.SchedulerSv1Reload,
+		new(utils.CGREventWithArgDispatcher), &reply)
+}
+
+// readContentTransactional will open the files of the loaderType and read
+// all the lines, collecting the errors instead of skipping the lines
+func (ldr *Loader) readContentTransactional(loaderType string) (lDataSet []LoaderData, errs []string) {
+	var opened bool
+	for fName := range ldr.rdrs[loaderType] {
+		rdr, err := os.Open(path.Join(ldr.tpInDir, fName))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Sprintf("%s: cannot open file: <%s>, error: %s",
+					loaderType, fName, err))
+			}
+			continue
+		}
+		csvReader := csv.NewReader(rdr)
+		csvReader.Comment = '#'
+		ldr.rdrs[loaderType][fName] = &openedCSVFile{
+			fileName: fName, rdr: rdr, csvRdr: csvReader}
+		defer ldr.unreferenceFile(loaderType, fName)
+		opened = true
+	}
+	if !opened {
+		return
+	}
+	for lineNr := 1; ; lineNr++ {
+		var eof, hasErrors bool
+		lData := make(LoaderData) // one row
+		for fName, rdr := range ldr.rdrs[loaderType] {
+			if rdr == nil { // file not present in the folder
+				continue
+			}
+			record, err := rdr.csvRdr.Read()
+			if err == io.EOF {
+				eof = true
+				break
+			}
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: file: <%s>, reading line: %d, error: %s",
+					loaderType, fName, lineNr, err))
+				hasErrors = true
+				continue
+			}
+			if err = lData.UpdateFromCSV(fName, record,
+				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: file: <%s>, line: %d, error: %s",
+					loaderType, fName, lineNr, err))
+				hasErrors = true
+			}
+		}
+		if eof {
+			return
+		}
+		if hasErrors || len(lData) == 0 {
+			continue
+		}
+		if _, has := lData[utils.Tenant]; !has && loaderType == utils.MetaActionPlans {
+			// ActionPlans are not tenant aware so the template does not need to populate it
+			tnt, err := ldr.tenant.ParseValue(utils.EmptyString)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: line: %d, error: %s",
+					loaderType, lineNr, err))
+				continue
+			}
+			lData[utils.Tenant] = tnt
+		}
+		if tnt, canCast := lData[utils.Tenant].(string); !canCast || tnt == utils.EmptyString {
+			errs = append(errs, fmt.Sprintf("%s: line: %d, error: %s",
+				loaderType, lineNr, utils.NewErrMandatoryIeMissing(utils.Tenant)))
+			continue
+		}
+		if id, canCast := lData[utils.ID].(string); !canCast || id == utils.EmptyString {
+			errs = append(errs, fmt.Sprintf("%s: line: %d, error: %s",
+				loaderType, lineNr, utils.NewErrMandatoryIeMissing(utils.ID)))
+			continue
+		}
+		lDataSet = append(lDataSet, lData)
+	}
+}
+
+// prepareTxnItems converts the loaded data into profiles, without writing them
+func (ldr *Loader) prepareTxnItems(loaderType string, lDataSet []LoaderData) (itms []*txnItem, errs []string) {
+	addErr := func(tntID string, err error) {
+		errs = append(errs, fmt.Sprintf("%s: <%s>, error: %s", loaderType, tntID, err))
+	}
+	switch loaderType {
+	case utils.MetaAttributes:
+		attrModels := make(engine.TPAttributes, len(lDataSet))
+		for i, ld := range lDataSet {
+			attrModels[i] = new(engine.TPAttribute)
+			if err := utils.UpdateStructWithIfaceMap(attrModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpApf := range attrModels.AsTPAttributes() {
+			apf, err := engine.APItoAttributeProfile(tpApf, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpApf.Tenant, tpApf.ID), err)
+				continue
+			}
+			fltrIDs := append([]string{}, apf.FilterIDs...)
+			for _, attr := range apf.Attributes {
+				fltrIDs = append(fltrIDs, attr.FilterIDs...)
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: apf.Tenant, ID: apf.ID},
+				prf:     apf,
+				fltrIDs: fltrIDs,
+				cacheID: utils.CacheAttributeProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldApf *engine.AttributeProfile
+					if oldApf, err = ldr.dm.GetAttributeProfile(apf.Tenant, apf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldApf == nil {
+							return ldr.dm.RemoveAttributeProfile(apf.Tenant, apf.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetAttributeProfile(oldApf, true)
+					}
+					err = ldr.dm.SetAttributeProfile(apf, true)
+					return
+				},
+			})
+		}
+	case utils.MetaResources:
+		resModels := make(engine.TpResources, len(lDataSet))
+		for i, ld := range lDataSet {
+			resModels[i] = new(engine.TpResource)
+			if err := utils.UpdateStructWithIfaceMap(resModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpRes := range resModels.AsTPResources() {
+			res, err := engine.APItoResource(tpRes, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpRes.Tenant, tpRes.ID), err)
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: res.Tenant, ID: res.ID},
+				prf:     res,
+				fltrIDs: res.FilterIDs,
+				refs:    map[string][]string{utils.MetaThresholds: res.ThresholdIDs},
+				cacheID: utils.CacheResourceProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldRes *engine.ResourceProfile
+					if oldRes, err = ldr.dm.GetResourceProfile(res.Tenant, res.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					var oldR *engine.Resource
+					if oldR, err = ldr.dm.GetResource(res.Tenant, res.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() (err error) {
+						if oldRes == nil {
+							if err = ldr.dm.RemoveResourceProfile(res.Tenant, res.ID,
+								utils.NonTransactional, true); err != nil && err != utils.ErrNotFound {
+								return
+							}
+						} else if err = ldr.dm.SetResourceProfile(oldRes, true); err != nil {
+							return
+						}
+						if oldR == nil {
+							return ldr.dm.RemoveResource(res.Tenant, res.ID, utils.NonTransactional)
+						}
+						return ldr.dm.SetResource(oldR)
+					}
+					if err = ldr.dm.SetResourceProfile(res, true); err != nil {
+						return
+					}
+					err = ldr.dm.SetResource(
+						&engine.Resource{Tenant: res.Tenant,
+							ID:     res.ID,
+							Usages: make(map[string]*engine.ResourceUsage)})
+					return
+				},
+			})
+		}
+	case utils.MetaFilters:
+		fltrModels := make(engine.TpFilterS, len(lDataSet))
+		for i, ld := range lDataSet {
+			fltrModels[i] = new(engine.TpFilter)
+			if err := utils.UpdateStructWithIfaceMap(fltrModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpFltr := range fltrModels.AsTPFilter() {
+			fltrPrf, err := engine.APItoFilter(tpFltr, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpFltr.Tenant, tpFltr.ID), err)
+				continue
+			}
+			var hasErrors bool
+			for _, rule := range fltrPrf.Rules { // make sure the rules are valid before storing them
+				if _, err = engine.NewFilterRule(rule.Type, rule.Element, rule.Values); err != nil {
+					addErr(fltrPrf.TenantID(), err)
+					hasErrors = true
+				}
+			}
+			if hasErrors {
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: fltrPrf.Tenant, ID: fltrPrf.ID},
+				prf:     fltrPrf,
+				cacheID: utils.CacheFilters,
+				store: func() (rollback func() error, err error) {
+					var oldFltr *engine.Filter
+					if oldFltr, err = ldr.dm.GetFilter(fltrPrf.Tenant, fltrPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldFltr == nil {
+							return ldr.dm.RemoveFilter(fltrPrf.Tenant, fltrPrf.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetFilter(oldFltr, true)
+					}
+					err = ldr.dm.SetFilter(fltrPrf, true)
+					return
+				},
+			})
+		}
+	case utils.MetaStats:
+		stsModels := make(engine.TpStats, len(lDataSet))
+		for i, ld := range lDataSet {
+			stsModels[i] = new(engine.TpStat)
+			if err := utils.UpdateStructWithIfaceMap(stsModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpSts := range stsModels.AsTPStats() {
+			stsPrf, err := engine.APItoStats(tpSts, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpSts.Tenant, tpSts.ID), err)
+				continue
+			}
+			metrics := make(map[string]engine.StatMetric)
+			fltrIDs := append([]string{}, stsPrf.FilterIDs...)
+			for _, metric := range stsPrf.Metrics {
+				stsMetric, err := engine.NewStatMetric(metric.MetricID, stsPrf.MinItems, metric.FilterIDs)
+				if err != nil {
+					addErr(stsPrf.TenantID(), err)
+					continue
+				}
+				metrics[metric.MetricID] = stsMetric
+				fltrIDs = append(fltrIDs, metric.FilterIDs...)
+			}
+			if len(metrics) != len(stsPrf.Metrics) {
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: stsPrf.Tenant, ID: stsPrf.ID},
+				prf:     stsPrf,
+				fltrIDs: fltrIDs,
+				refs:    map[string][]string{utils.MetaThresholds: stsPrf.ThresholdIDs},
+				cacheID: utils.CacheStatQueueProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldSts *engine.StatQueueProfile
+					if oldSts, err = ldr.dm.GetStatQueueProfile(stsPrf.Tenant, stsPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					var oldSq *engine.StatQueue
+					if oldSq, err = ldr.dm.GetStatQueue(stsPrf.Tenant, stsPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() (err error) {
+						if oldSts == nil {
+							if err = ldr.dm.RemoveStatQueueProfile(stsPrf.Tenant, stsPrf.ID,
+								utils.NonTransactional, true); err != nil && err != utils.ErrNotFound {
+								return
+							}
+						} else if err = ldr.dm.SetStatQueueProfile(oldSts, true); err != nil {
+							return
+						}
+						if oldSq == nil {
+							return ldr.dm.RemoveStatQueue(stsPrf.Tenant, stsPrf.ID, utils.NonTransactional)
+						}
+						return ldr.dm.SetStatQueue(oldSq)
+					}
+					if err = ldr.dm.SetStatQueueProfile(stsPrf, true); err != nil {
+						return
+					}
+					err = ldr.dm.SetStatQueue(&engine.StatQueue{Tenant: stsPrf.Tenant, ID: stsPrf.ID, SQMetrics: metrics})
+					return
+				},
+			})
+		}
+	case utils.MetaThresholds:
+		thModels := make(engine.TpThresholds, len(lDataSet))
+		for i, ld := range lDataSet {
+			thModels[i] = new(engine.TpThreshold)
+			if err := utils.UpdateStructWithIfaceMap(thModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpTh := range thModels.AsTPThreshold() {
+			thPrf, err := engine.APItoThresholdProfile(tpTh, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpTh.Tenant, tpTh.ID), err)
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: thPrf.Tenant, ID: thPrf.ID},
+				prf:     thPrf,
+				fltrIDs: thPrf.FilterIDs,
+				refs:    map[string][]string{utils.MetaActions: thPrf.ActionIDs},
+				cacheID: utils.CacheThresholdProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldTh *engine.ThresholdProfile
+					if oldTh, err = ldr.dm.GetThresholdProfile(thPrf.Tenant, thPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					var oldT *engine.Threshold
+					if oldT, err = ldr.dm.GetThreshold(thPrf.Tenant, thPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() (err error) {
+						if oldTh == nil {
+							if err = ldr.dm.RemoveThresholdProfile(thPrf.Tenant, thPrf.ID,
+								utils.NonTransactional, true); err != nil && err != utils.ErrNotFound {
+								return
+							}
+						} else if err = ldr.dm.SetThresholdProfile(oldTh, true); err != nil {
+							return
+						}
+						if oldT == nil {
+							return ldr.dm.RemoveThreshold(thPrf.Tenant, thPrf.ID, utils.NonTransactional)
+						}
+						return ldr.dm.SetThreshold(oldT)
+					}
+					if err = ldr.dm.SetThresholdProfile(thPrf, true); err != nil {
+						return
+					}
+					err = ldr.dm.SetThreshold(&engine.Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID})
+					return
+				},
+			})
+		}
+	case utils.MetaRoutes:
+		sppModels := make(engine.TPRoutes, len(lDataSet))
+		for i, ld := range lDataSet {
+			sppModels[i] = new(engine.TpRoute)
+			if err := utils.UpdateStructWithIfaceMap(sppModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpSpp := range sppModels.AsTPRouteProfile() {
+			spPrf, err := engine.APItoRouteProfile(tpSpp, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpSpp.Tenant, tpSpp.ID), err)
+				continue
+			}
+			fltrIDs := append([]string{}, spPrf.FilterIDs...)
+			for _, route := range spPrf.Routes {
+				fltrIDs = append(fltrIDs, route.FilterIDs...)
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: spPrf.Tenant, ID: spPrf.ID},
+				prf:     spPrf,
+				fltrIDs: fltrIDs,
+				cacheID: utils.CacheRouteProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldSpp *engine.RouteProfile
+					if oldSpp, err = ldr.dm.GetRouteProfile(spPrf.Tenant, spPrf.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldSpp == nil {
+							return ldr.dm.RemoveRouteProfile(spPrf.Tenant, spPrf.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetRouteProfile(oldSpp, true)
+					}
+					err = ldr.dm.SetRouteProfile(spPrf, true)
+					return
+				},
+			})
+		}
+	case utils.MetaChargers:
+		cppModels := make(engine.TPChargers, len(lDataSet))
+		for i, ld := range lDataSet {
+			cppModels[i] = new(engine.TPCharger)
+			if err := utils.UpdateStructWithIfaceMap(cppModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpCPP := range cppModels.AsTPChargers() {
+			cpp, err := engine.APItoChargerProfile(tpCPP, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpCPP.Tenant, tpCPP.ID), err)
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: cpp.Tenant, ID: cpp.ID},
+				prf:     cpp,
+				fltrIDs: cpp.FilterIDs,
+				refs:    map[string][]string{utils.MetaAttributes: cpp.AttributeIDs},
+				cacheID: utils.CacheChargerProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldCpp *engine.ChargerProfile
+					if oldCpp, err = ldr.dm.GetChargerProfile(cpp.Tenant, cpp.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldCpp == nil {
+							return ldr.dm.RemoveChargerProfile(cpp.Tenant, cpp.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetChargerProfile(oldCpp, true)
+					}
+					err = ldr.dm.SetChargerProfile(cpp, true)
+					return
+				},
+			})
+		}
+	case utils.MetaDispatchers:
+		dispModels := make(engine.TPDispatcherProfiles, len(lDataSet))
+		for i, ld := range lDataSet {
+			dispModels[i] = new(engine.TPDispatcherProfile)
+			if err := utils.UpdateStructWithIfaceMap(dispModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpDsp := range dispModels.AsTPDispatcherProfiles() {
+			dsp, err := engine.APItoDispatcherProfile(tpDsp, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpDsp.Tenant, tpDsp.ID), err)
+				continue
+			}
+			fltrIDs := append([]string{}, dsp.FilterIDs...)
+			hostIDs := make([]string, len(dsp.Hosts))
+			for i, host := range dsp.Hosts {
+				fltrIDs = append(fltrIDs, host.FilterIDs...)
+				hostIDs[i] = host.ID
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: dsp.Tenant, ID: dsp.ID},
+				prf:     dsp,
+				fltrIDs: fltrIDs,
+				refs:    map[string][]string{utils.MetaDispatcherHosts: hostIDs},
+				cacheID: utils.CacheDispatcherProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldDsp *engine.DispatcherProfile
+					if oldDsp, err = ldr.dm.GetDispatcherProfile(dsp.Tenant, dsp.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldDsp == nil {
+							return ldr.dm.RemoveDispatcherProfile(dsp.Tenant, dsp.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetDispatcherProfile(oldDsp, true)
+					}
+					err = ldr.dm.SetDispatcherProfile(dsp, true)
+					return
+				},
+			})
+		}
+	case utils.MetaDispatcherHosts:
+		dispModels := make(engine.TPDispatcherHosts, len(lDataSet))
+		for i, ld := range lDataSet {
+			dispModels[i] = new(engine.TPDispatcherHost)
+			if err := utils.UpdateStructWithIfaceMap(dispModels[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpDsp := range dispModels.AsTPDispatcherHosts() {
+			dsp := engine.APItoDispatcherHost(tpDsp)
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: dsp.Tenant, ID: dsp.ID},
+				prf:     dsp,
+				cacheID: utils.CacheDispatcherHosts,
+				store: func() (rollback func() error, err error) {
+					var oldDsp *engine.DispatcherHost
+					if oldDsp, err = ldr.dm.GetDispatcherHost(dsp.Tenant, dsp.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldDsp == nil {
+							return ldr.dm.RemoveDispatcherHost(dsp.Tenant, dsp.ID, utils.NonTransactional)
+						}
+						return ldr.dm.SetDispatcherHost(oldDsp)
+					}
+					err = ldr.dm.SetDispatcherHost(dsp)
+					return
+				},
+			})
+		}
+	case utils.MetaRateProfiles:
+		rpMdls := make(engine.RateProfileMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			rpMdls[i] = new(engine.RateProfileMdl)
+			if err := utils.UpdateStructWithIfaceMap(rpMdls[i], ld); err != nil {
+				addErr(ld.TenantID(), err)
+				return
+			}
+		}
+		for _, tpRpl := range rpMdls.AsTPRateProfile() {
+			rpl, err := engine.APItoRateProfile(tpRpl, ldr.timezone)
+			if err != nil {
+				addErr(utils.ConcatenatedKey(tpRpl.Tenant, tpRpl.ID), err)
+				continue
+			}
+			fltrIDs := append([]string{}, rpl.FilterIDs...)
+			for _, rt := range rpl.Rates {
+				fltrIDs = append(fltrIDs, rt.FilterIDs...)
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: rpl.Tenant, ID: rpl.ID},
+				prf:     rpl,
+				fltrIDs: fltrIDs,
+				cacheID: utils.CacheRateProfiles,
+				store: func() (rollback func() error, err error) {
+					var oldRpl *engine.RateProfile
+					if oldRpl, err = ldr.dm.GetRateProfile(rpl.Tenant, rpl.ID,
+						false, false, utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldRpl == nil {
+							return ldr.dm.RemoveRateProfile(rpl.Tenant, rpl.ID, utils.NonTransactional, true)
+						}
+						return ldr.dm.SetRateProfile(oldRpl, true)
+					}
+					err = ldr.dm.SetRateProfile(rpl, true)
+					return
+				},
+			})
+		}
+	case utils.MetaActionPlans:
+		var apIDs []string
+		lDataSets := make(map[string][]LoaderData)
+		for _, ld := range lDataSet {
+			apID := utils.IfaceAsString(ld[utils.ID])
+			if _, has := lDataSets[apID]; !has {
+				apIDs = append(apIDs, apID)
+			}
+			lDataSets[apID] = append(lDataSets[apID], ld)
+		}
+		for _, apID := range apIDs {
+			ap := &engine.ActionPlan{Id: apID}
+			actIDs := make([]string, 0, len(lDataSets[apID]))
+			var hasErrors bool
+			for _, ld := range lDataSets[apID] {
+				if catchUp := utils.IfaceAsString(ld[utils.CatchUp]); catchUp != utils.EmptyString {
+					ap.CatchUp = catchUp
+				}
+				at, err := ldr.newActionTiming(ld)
+				if err != nil {
+					addErr(apID, err)
+					hasErrors = true
+					continue
+				}
+				ap.ActionTimings = append(ap.ActionTimings, at)
+				actIDs = append(actIDs, at.ActionsID)
+			}
+			if err := engine.CheckCatchUp(ap.CatchUp); err != nil {
+				addErr(apID, err)
+				hasErrors = true
+			}
+			if hasErrors {
+				continue
+			}
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: lDataSets[apID][0].TenantIDStruct().Tenant, ID: apID},
+				prf:     ap,
+				refs:    map[string][]string{utils.MetaActions: actIDs},
+				cacheID: utils.CacheActionPlans,
+				store: func() (rollback func() error, err error) {
+					var oldAp *engine.ActionPlan
+					if oldAp, err = ldr.dm.GetActionPlan(ap.Id, true,
+						utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() error {
+						if oldAp == nil {
+							return ldr.dm.RemoveActionPlan(ap.Id, utils.NonTransactional)
+						}
+						return ldr.dm.SetActionPlan(oldAp.Id, oldAp, true, utils.NonTransactional)
+					}
+					// keep the accounts already bound to the ActionPlan
+					err = ldr.dm.SetActionPlan(ap.Id, ap, false, utils.NonTransactional)
+					return
+				},
+			})
+		}
+	case utils.MetaLookupTables:
+		var tntIDs []string
+		lDataSets := make(map[string][]LoaderData)
+		for _, ld := range lDataSet {
+			tntID := ld.TenantID()
+			if _, has := lDataSets[tntID]; !has {
+				tntIDs = append(tntIDs, tntID)
+			}
+			lDataSets[tntID] = append(lDataSets[tntID], ld)
+		}
+		for _, tntID := range tntIDs {
+			lt := newLookupTable(lDataSets[tntID])
+			itms = append(itms, &txnItem{
+				tntID:   &utils.TenantID{Tenant: lt.Tenant, ID: lt.ID},
+				prf:     lt,
+				cacheID: utils.CacheLookupTables,
+				store: func() (rollback func() error, err error) {
+					var oldLt *engine.LookupTable
+					if oldLt, err = ldr.dm.GetLookupTable(lt.Tenant, lt.ID); err != nil && err != utils.ErrNotFound {
+						return
+					}
+					rollback = func() (err error) {
+						if oldLt == nil {
+							if err = ldr.dm.RemoveLookupTable(lt.Tenant, lt.ID); err == utils.ErrNotFound {
+								err = nil
+							}
+							return
+						}
+						return ldr.dm.SetLookupTable(oldLt, true)
+					}
+					err = ldr.dm.SetLookupTable(lt, true)
+					return
+				},
+			})
+		}
+	}
+	for _, itm := range itms {
+		itm.loaderType = loaderType
+	}
+	return
+}
+
+// newActionTiming builds the ActionTiming out of one ActionPlan line
+// the timing is resolved out of DataDB, except *asap which needs no definition
+func (ldr *Loader) newActionTiming(ld LoaderData) (at *engine.ActionTiming, err error) {
+	actID := utils.IfaceAsString(ld[utils.ActionsID])
+	if actID == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.ActionsID)
+	}
+	tmID := utils.IfaceAsString(ld[utils.TimingID])
+	if tmID == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.TimingID)
+	}
+	at = &engine.ActionTiming{
+		Uuid:      utils.GenUUID(),
+		ActionsID: actID,
+		Timing:    &engine.RateInterval{Timing: &engine.RITiming{StartTime: utils.ASAP}},
+	}
+	if wght, has := ld[utils.Weight]; has && utils.IfaceAsString(wght) != utils.EmptyString {
+		if at.Weight, err = utils.IfaceAsFloat64(wght); err != nil {
+			return nil, err
+		}
+	}
+	if tmID == utils.ASAP {
+		return
+	}
+	var tm *utils.TPTiming
+	if tm, err = ldr.dm.GetTiming(tmID, false, utils.NonTransactional); err != nil {
+		return nil, fmt.Errorf("timing: <%s>, error: %s", tmID, err)
+	}
+	at.Timing.Timing = &engine.RITiming{
+		Years:     tm.Years,
+		Months:    tm.Months,
+		MonthDays: tm.MonthDays,
+		WeekDays:  tm.WeekDays,
+		StartTime: tm.StartTime,
+	}
+	return
+}
+
+// checkTxnReferences makes sure the filters and the items referenced by itm
+// are either part of the load or already present in DataDB
+func (ldr *Loader) checkTxnReferences(itm *txnItem, loadedIDs map[string]utils.StringSet) (errs []string) {
+	for _, fltrID := range itm.fltrIDs {
+		if strings.HasPrefix(fltrID, utils.Meta) {
+			if _, err := engine.NewFilterFromInline(itm.tntID.Tenant, fltrID); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: <%s>, error: %s",
+					itm.loaderType, itm.tntID.TenantID(), err))
+			}
+			continue
+		}
+		if loadedIDs[utils.MetaFilters].Has(utils.ConcatenatedKey(itm.tntID.Tenant, fltrID)) {
+			continue
+		}
+		if _, err := ldr.dm.GetFilter(itm.tntID.Tenant, fltrID,
+			true, false, utils.NonTransactional); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: <%s>, filter: <%s>, error: %s",
+				itm.loaderType, itm.tntID.TenantID(), fltrID, err))
+		}
+	}
+	for refType, refIDs := range itm.refs {
+		for _, refID := range refIDs {
+			if refID == utils.META_NONE ||
+				loadedIDs[refType].Has(utils.ConcatenatedKey(itm.tntID.Tenant, refID)) {
+				continue
+			}
+			var err error
+			switch refType {
+			case utils.MetaAttributes:
+				_, err = ldr.dm.GetAttributeProfile(itm.tntID.Tenant, refID,
+					true, false, utils.NonTransactional)
+			case utils.MetaThresholds:
+				_, err = ldr.dm.GetThresholdProfile(itm.tntID.Tenant, refID,
+					true, false, utils.NonTransactional)
+			case utils.MetaDispatcherHosts:
+				_, err = ldr.dm.GetDispatcherHost(itm.tntID.Tenant, refID,
+					true, false, utils.NonTransactional)
+			case utils.MetaActions:
+				_, err = ldr.dm.GetActions(refID, false, utils.NonTransactional)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: <%s>, reference %s: <%s>, error: %s",
+					itm.loaderType, itm.tntID.TenantID(), refType, refID, err))
+			}
+		}
+	}
+	return
+}
+
+// txnCacheArgs populates the cache arguments with the IDs of the loaderType
+func txnCacheArgs(cacheArgs *utils.ArgsCache, loaderType string, ids []string) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		cacheArgs.AttributeProfileIDs = ids
+	case utils.MetaResources:
+		cacheArgs.ResourceProfileIDs = ids
+		cacheArgs.ResourceIDs = ids
+	case utils.MetaFilters:
+		cacheArgs.FilterIDs = ids
+	case utils.MetaStats:
+		cacheArgs.StatsQueueProfileIDs = ids
+		cacheArgs.StatsQueueIDs = ids
+	case utils.MetaThresholds:
+		cacheArgs.ThresholdProfileIDs = ids
+		cacheArgs.ThresholdIDs = ids
+	case utils.MetaRoutes:
+		cacheArgs.RouteProfileIDs = ids
+	case utils.MetaChargers:
+		cacheArgs.ChargerProfileIDs = ids
+	case utils.MetaDispatchers:
+		cacheArgs.DispatcherProfileIDs = ids
+	case utils.MetaDispatcherHosts:
+		cacheArgs.DispatcherHostIDs = ids
+	case utils.MetaRateProfiles:
+		cacheArgs.RateProfileIDs = ids
+	case utils.MetaActionPlans:
+		cacheArgs.ActionPlanIDs = ids
+	}
+}
+
+// txnCache will update the remote caches once the transaction was committed
+func (ldr *Loader) txnCache(caching string, cacheArgs utils.ArgsCache,
+	itms map[string][]*txnItem) (err error) {
+	if len(ldr.cacheConns) == 0 {
+		return
+	}
+	var reply string
+	switch caching {
+	case utils.META_NONE:
+		return
+	case utils.MetaReload:
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1ReloadCache, utils.AttrReloadCacheWithArgDispatcher{
+				ArgsCache: cacheArgs}, &reply); err != nil {
+			return
+		}
+	case utils.MetaLoad:
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1LoadCache, utils.AttrReloadCacheWithArgDispatcher{
+				ArgsCache: cacheArgs}, &reply); err != nil {
+			return
+		}
+	case utils.MetaRemove:
+		for ldrType, ldrItms := range itms {
+			if ldrType == utils.MetaLookupTables {
+				continue // removed as groups bellow
+			}
+			for _, itm := range ldrItms {
+				if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+					utils.CacheSv1RemoveItem, &utils.ArgsGetCacheItemWithArgDispatcher{
+						ArgsGetCacheItem: utils.ArgsGetCacheItem{
+							CacheID: itm.cacheID,
+							ItemID:  itm.cacheItemID(),
+						},
+					}, &reply); err != nil {
+					return
+				}
+			}
+		}
+	case utils.MetaClear:
+		return ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1Clear, new(utils.AttrCacheIDsWithArgDispatcher), &reply)
+	}
+	lkTblIDs := make([]string, len(itms[utils.MetaLookupTables]))
+	for i, itm := range itms[utils.MetaLookupTables] {
+		lkTblIDs[i] = itm.tntID.TenantID()
+	}
+	return ldr.cacheLookupTables(caching, lkTblIDs)
+}
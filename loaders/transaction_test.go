@@ -0,0 +1,456 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func newTestTxnLoader(t *testing.T) (ldr *Loader) {
+	inDir, err := ioutil.TempDir("", "TestLoaderTxnIn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir, err := ioutil.TempDir("", "TestLoaderTxnOut")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	ldrCfg := &config.LoaderSCfg{
+		Id:            "TestLoaderTransactional",
+		Enabled:       true,
+		Tenant:        config.NewRSRParsersMustCompile("cgrates.org", true, utils.INFIELD_SEP),
+		Transactional: true,
+		TpInDir:       inDir,
+		TpOutDir:      outDir,
+		LockFileName:  ".cgr.lck",
+		Data: []*config.LoaderDataType{
+			{
+				Type:     utils.MetaFilters,
+				Filename: utils.FiltersCsv,
+				Fields: []*config.FCTemplate{
+					{Path: "Tenant", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP)},
+					{Path: "ID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP)},
+					{Path: "Type", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP)},
+					{Path: "Element", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP)},
+					{Path: "Values", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~4", true, utils.INFIELD_SEP)},
+				},
+			},
+			{
+				Type:     utils.MetaChargers,
+				Filename: utils.ChargersCsv,
+				Fields: []*config.FCTemplate{
+					{Path: "Tenant", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP)},
+					{Path: "ID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP)},
+					{Path: "FilterIDs", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP)},
+					{Path: "RunID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP)},
+					{Path: "AttributeIDs", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~4", true, utils.INFIELD_SEP)},
+					{Path: "Weight", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~5", true, utils.INFIELD_SEP)},
+				},
+			},
+			{
+				Type:     utils.MetaActionPlans,
+				Filename: utils.ActionPlansCsv,
+				Fields: []*config.FCTemplate{
+					{Path: "ID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~0", true, utils.INFIELD_SEP)},
+					{Path: "ActionsID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~1", true, utils.INFIELD_SEP)},
+					{Path: "TimingID", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~2", true, utils.INFIELD_SEP)},
+					{Path: "Weight", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~3", true, utils.INFIELD_SEP)},
+					{Path: "CatchUp", Type: utils.MetaVariable,
+						Value: config.NewRSRParsersMustCompile("~4", true, utils.INFIELD_SEP)},
+				},
+			},
+		},
+	}
+	return NewLoader(engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		ldrCfg, "UTC", nil, nil, nil, nil)
+}
+
+func TestLoaderTransactionalProcessFolder(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.FiltersCsv), []byte(`
+cgrates.org,FLTR_TXN,*string,~*req.Account,1001
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ChargersCsv), []byte(`
+cgrates.org,CPP_TXN,FLTR_TXN,*rated,ATTR_TXN,20
+cgrates.org,CPP_TXN2,FLTR_MISSING,*rated,*none,10
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore)
+	if err == nil {
+		t.Fatal("expecting error")
+	}
+	for _, expErr := range []string{"FLTR_MISSING", "ATTR_TXN"} {
+		if !strings.Contains(err.Error(), expErr) {
+			t.Errorf("expecting %q in error: %s", expErr, err)
+		}
+	}
+	// nothing written since the validation failed
+	if _, err := ldr.dm.GetFilter("cgrates.org", "FLTR_TXN",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := ldr.dm.GetChargerProfile("cgrates.org", "CPP_TXN",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := os.Stat(path.Join(ldr.tpInDir, utils.ChargersCsv)); err != nil {
+		t.Errorf("files should not be moved on errors, received: %v", err)
+	}
+
+	// fix the references and reload
+	if err := ldr.dm.SetAttributeProfile(&engine.AttributeProfile{
+		Tenant: "cgrates.org",
+		ID:     "ATTR_TXN",
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ChargersCsv), []byte(`
+cgrates.org,CPP_TXN,FLTR_TXN,*rated,ATTR_TXN,20
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore); err != nil {
+		t.Fatal(err)
+	}
+	eCpp := &engine.ChargerProfile{
+		Tenant:       "cgrates.org",
+		ID:           "CPP_TXN",
+		FilterIDs:    []string{"FLTR_TXN"},
+		RunID:        utils.META_RATED,
+		AttributeIDs: []string{"ATTR_TXN"},
+		Weight:       20,
+	}
+	if cpp, err := ldr.dm.GetChargerProfile("cgrates.org", "CPP_TXN",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCpp, cpp) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eCpp), utils.ToJSON(cpp))
+	}
+	if _, err := ldr.dm.GetFilter("cgrates.org", "FLTR_TXN",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(path.Join(ldr.tpOutDir, utils.ChargersCsv)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoaderTransactionalRollback(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	oldFltr := &engine.Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_TXN",
+		Rules: []*engine.FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1002"},
+		}},
+	}
+	if err := ldr.dm.SetFilter(oldFltr, true); err != nil {
+		t.Fatal(err)
+	}
+	itms, errs := ldr.prepareTxnItems(utils.MetaFilters, []LoaderData{
+		{"Tenant": "cgrates.org", "ID": "FLTR_TXN", "Type": utils.MetaString,
+			"Element": "~*req.Account", "Values": "1001"},
+		{"Tenant": "cgrates.org", "ID": "FLTR_TXN2", "Type": utils.MetaPrefix,
+			"Element": "~*req.Destination", "Values": "10"},
+	})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	} else if len(itms) != 2 {
+		t.Fatalf("expecting 2 items, received: %s", utils.ToJSON(itms))
+	}
+	var rollbacks []func() error
+	for _, itm := range itms {
+		rollback, err := itm.store()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+	if fltr, err := ldr.dm.GetFilter("cgrates.org", "FLTR_TXN",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if fltr.Rules[0].Values[0] != "1001" {
+		t.Errorf("filter not updated: %s", utils.ToJSON(fltr))
+	}
+	for i := len(rollbacks) - 1; i >= 0; i-- {
+		if err := rollbacks[i](); err != nil {
+			t.Error(err)
+		}
+	}
+	if fltr, err := ldr.dm.GetFilter("cgrates.org", "FLTR_TXN",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(oldFltr, fltr) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(oldFltr), utils.ToJSON(fltr))
+	}
+	if _, err := ldr.dm.GetFilter("cgrates.org", "FLTR_TXN2",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderTransactionalInvalidFilter(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	if _, errs := ldr.prepareTxnItems(utils.MetaFilters, []LoaderData{
+		{"Tenant": "cgrates.org", "ID": "FLTR_TXN", "Type": "*unsupported",
+			"Element": "~*req.Account", "Values": "1001"},
+	}); len(errs) != 1 {
+		t.Errorf("expecting one error, received: %v", errs)
+	}
+}
+
+func TestLoaderTransactionalActionPlans(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	if err := ldr.dm.SetActions("ACT_TXN", engine.Actions{
+		{Id: "ACT_TXN", ActionType: utils.LOG}}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ActionPlansCsv), []byte(`
+AP_TXN,ACT_TXN,*asap,10,
+AP_TXN2,ACT_MISSING,*asap,10,
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore)
+	if err == nil || !strings.Contains(err.Error(), "ACT_MISSING") {
+		t.Fatalf("expecting ACT_MISSING in error, received: %v", err)
+	}
+	if _, err := ldr.dm.GetActionPlan("AP_TXN", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ActionPlansCsv), []byte(`
+AP_TXN,ACT_TXN,*asap,10,*every
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ldr.ProcessFolder(utils.META_NONE, utils.MetaStore); err == nil ||
+		!strings.Contains(err.Error(), "CatchUp") {
+		t.Fatalf("expecting CatchUp in error, received: %v", err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ActionPlansCsv), []byte(`
+AP_TXN,ACT_TXN,*asap,10,*all
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore); err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetActionPlan("AP_TXN", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if ap.CatchUp != utils.MetaAll || len(ap.ActionTimings) != 1 ||
+		ap.ActionTimings[0].ActionsID != "ACT_TXN" ||
+		ap.ActionTimings[0].Weight != 10 ||
+		ap.ActionTimings[0].Timing.Timing.StartTime != utils.ASAP {
+		t.Errorf("unexpected ActionPlan: %s", utils.ToJSON(ap))
+	}
+}
+
+type testTxnScheduler struct {
+	reloads int
+}
+
+func (sched *testTxnScheduler) Call(serviceMethod string, _, reply interface{}) error {
+	if serviceMethod != utils.SchedulerSv1Reload {
+		return utils.ErrNotImplemented
+	}
+	sched.reloads++
+	*reply.(*string) = utils.OK
+	return nil
+}
+
+func TestLoaderTransactionalActionPlansReloadScheduler(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	sched := new(testTxnScheduler)
+	schedChan := make(chan rpcclient.ClientConnector, 1)
+	schedChan <- sched
+	ldr.schedConns = []string{"*txnScheduler"}
+	ldr.connMgr = engine.NewConnManager(config.CgrConfig(),
+		map[string]chan rpcclient.ClientConnector{"*txnScheduler": schedChan})
+	if err := ldr.dm.SetActions("ACT_TXN", engine.Actions{
+		{Id: "ACT_TXN", ActionType: utils.LOG}}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.FiltersCsv), []byte(`
+cgrates.org,FLTR_TXN,*string,~*req.Account,1001
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore); err != nil {
+		t.Fatal(err)
+	}
+	if sched.reloads != 0 {
+		t.Errorf("expecting no scheduler reload, received: %d", sched.reloads)
+	}
+	if err := ioutil.WriteFile(path.Join(ldr.tpInDir, utils.ActionPlansCsv), []byte(`
+AP_TXN,ACT_TXN,*asap,10,
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.META_NONE, utils.MetaStore); err != nil {
+		t.Fatal(err)
+	}
+	if sched.reloads != 1 {
+		t.Errorf("expecting one scheduler reload, received: %d", sched.reloads)
+	}
+}
+
+func TestLoaderTransactionalActionPlanRollback(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	oldAp := &engine.ActionPlan{
+		Id:         "AP_TXN",
+		AccountIDs: utils.StringMap{"cgrates.org:1001": true},
+		ActionTimings: []*engine.ActionTiming{{
+			Uuid:      "UUID_TXN",
+			ActionsID: "ACT_OLD",
+			Timing: &engine.RateInterval{
+				Timing: &engine.RITiming{StartTime: utils.ASAP}},
+		}},
+	}
+	if err := ldr.dm.SetActionPlan(oldAp.Id, oldAp, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	itms, errs := ldr.prepareTxnItems(utils.MetaActionPlans, []LoaderData{
+		{"Tenant": "cgrates.org", "ID": "AP_TXN", "ActionsID": "ACT_TXN",
+			"TimingID": utils.ASAP, "Weight": "10"},
+	})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	} else if len(itms) != 1 {
+		t.Fatalf("expecting 1 item, received: %s", utils.ToJSON(itms))
+	}
+	rollback, err := itms[0].store()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetActionPlan("AP_TXN", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if ap.ActionTimings[0].ActionsID != "ACT_TXN" {
+		t.Errorf("ActionPlan not updated: %s", utils.ToJSON(ap))
+	} else if !ap.AccountIDs.HasKey("cgrates.org:1001") {
+		t.Errorf("accounts not kept: %s", utils.ToJSON(ap))
+	}
+	if err := rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetActionPlan("AP_TXN", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(oldAp, ap) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(oldAp), utils.ToJSON(ap))
+	}
+	if _, errs := ldr.prepareTxnItems(utils.MetaActionPlans, []LoaderData{
+		{"Tenant": "cgrates.org", "ID": "AP_TXN", "ActionsID": "ACT_TXN",
+			"TimingID": "TM_MISSING"},
+	}); len(errs) != 1 {
+		t.Errorf("expecting one error, received: %v", errs)
+	}
+}
+
+func TestLoaderTransactionalLookupTableRollback(t *testing.T) {
+	ldr := newTestTxnLoader(t)
+	defer os.RemoveAll(ldr.tpInDir)
+	defer os.RemoveAll(ldr.tpOutDir)
+	oldLt := &engine.LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT_TXN",
+		Entries: map[string]string{"1001": "ACC1", "1002": "ACC2"},
+	}
+	if err := ldr.dm.SetLookupTable(oldLt, true); err != nil {
+		t.Fatal(err)
+	}
+	itms, errs := ldr.prepareTxnItems(utils.MetaLookupTables, []LoaderData{
+		{"Tenant": "cgrates.org", "ID": "LKT_TXN", "Key": "1001", "Value": "ACC11"},
+		{"Tenant": "cgrates.org", "ID": "LKT_TXN", "Key": "1003", "Value": "ACC3"},
+		{"Tenant": "cgrates.org", "ID": "LKT_TXN2", "Key": "1001", "Value": "ACC1"},
+	})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	} else if len(itms) != 2 {
+		t.Fatalf("expecting 2 items, received: %s", utils.ToJSON(itms))
+	}
+	var rollbacks []func() error
+	for _, itm := range itms {
+		rollback, err := itm.store()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+	exp := map[string]string{"1001": "ACC11", "1003": "ACC3"}
+	if lt, err := ldr.dm.GetLookupTable("cgrates.org", "LKT_TXN"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, lt.Entries) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(lt.Entries))
+	}
+	for i := len(rollbacks) - 1; i >= 0; i-- {
+		if err := rollbacks[i](); err != nil {
+			t.Error(err)
+		}
+	}
+	if lt, err := ldr.dm.GetLookupTable("cgrates.org", "LKT_TXN"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(oldLt, lt) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(oldLt), utils.ToJSON(lt))
+	}
+	if _, err := ldr.dm.GetLookupTable("cgrates.org", "LKT_TXN2"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
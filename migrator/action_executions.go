@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package migrator
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func (m *Migrator) migrateActionExecutions() (err error) {
+	var vrs engine.Versions
+	vrs, err = m.storDBIn.StorDB().GetVersions("")
+	if err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when querying oldDataDB for versions", err.Error()))
+	} else if len(vrs) == 0 {
+		return utils.NewCGRError(utils.Migrator,
+			utils.MandatoryIEMissingCaps,
+			utils.UndefinedVersion,
+			"version number is not defined for ActionExecutions model")
+	}
+	if vrs[utils.ActionExecutions] == 0 {
+		if err = m.migrateV0ActionExecutions(); err != nil {
+			return
+		}
+	}
+	return m.ensureIndexesStorDB(utils.ActionExecutionsTBL)
+}
+
+// migrateV0ActionExecutions creates the action_executions table inside the StorDBs created before the execution history
+func (m *Migrator) migrateV0ActionExecutions() (err error) {
+	if m.dryRun {
+		return
+	}
+	if err = m.storDBOut.createV1ActionExecutions(); err != nil {
+		return
+	}
+	vrs := engine.Versions{utils.ActionExecutions: 1}
+	if err = m.storDBOut.StorDB().SetVersions(vrs, false); err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when updating ActionExecutions version into StorDB", err.Error()))
+	}
+	return
+}
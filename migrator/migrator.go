@@ -109,6 +109,10 @@ func (m *Migrator) Migrate(taskIDs []string) (err error, stats map[string]int) {
 			err = m.migrateCDRs()
 		case utils.MetaSessionsCosts:
 			err = m.migrateSessionSCosts()
+		case utils.MetaAuditRecords:
+			err = m.migrateAuditRecords()
+		case utils.MetaActionExecutions:
+			err = m.migrateActionExecutions()
 		case utils.MetaAccounts:
 			err = m.migrateAccounts()
 		case utils.MetaActionPlans:
@@ -324,6 +328,12 @@ func (m *Migrator) Migrate(taskIDs []string) (err error, stats map[string]int) {
 			if err := m.migrateSessionSCosts(); err != nil {
 				log.Print("ERROR: ", utils.MetaSessionsCosts, " ", err)
 			}
+			if err := m.migrateAuditRecords(); err != nil {
+				log.Print("ERROR: ", utils.MetaAuditRecords, " ", err)
+			}
+			if err := m.migrateActionExecutions(); err != nil {
+				log.Print("ERROR: ", utils.MetaActionExecutions, " ", err)
+			}
 			err = nil
 		}
 	}
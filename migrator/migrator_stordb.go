@@ -28,6 +28,12 @@ type MigratorStorDB interface {
 	remV1CDRs(v1Cdr *v1Cdrs) (err error)
 	createV1SMCosts() (err error)
 	renameV1SMCosts() (err error)
+	alterV1TPTimings() (err error)
+	alterV1TPChargers() (err error)
+	alterV1TPActionPlans() (err error)
+	alterV1TPRoutes() (err error)
+	createV1AuditRecords() (err error)
+	createV1ActionExecutions() (err error)
 	getV2SMCost() (v2Cost *v2SessionsCost, err error)
 	setV2SMCost(v2Cost *v2SessionsCost) (err error)
 	remV2SMCost(v2Cost *v2SessionsCost) (err error)
@@ -65,6 +65,42 @@ func (iDBMig *internalStorDBMigrator) renameV1SMCosts() (err error) {
 	return utils.ErrNotImplemented
 }
 
+//TPTimings methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPTimings() (err error) {
+	return // no column size to change
+}
+
+//TPChargers methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPChargers() (err error) {
+	return // no columns to add
+}
+
+//TPActionPlans methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPActionPlans() (err error) {
+	return // no columns to add
+}
+
+//TPRoutes methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPRoutes() (err error) {
+	return // no columns to add
+}
+
+//AuditRecords methods
+//create
+func (iDBMig *internalStorDBMigrator) createV1AuditRecords() (err error) {
+	return // no table to create
+}
+
+//ActionExecutions methods
+//create
+func (iDBMig *internalStorDBMigrator) createV1ActionExecutions() (err error) {
+	return // no table to create
+}
+
 func (iDBMig *internalStorDBMigrator) createV1SMCosts() (err error) {
 	return utils.ErrNotImplemented
 }
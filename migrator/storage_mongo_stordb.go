@@ -90,6 +90,42 @@ func (v1ms *mongoStorDBMigrator) renameV1SMCosts() (err error) {
 		bson.D{{"create", utils.SessionCostsTBL}}).Err()
 }
 
+//TPTimings methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPTimings() (err error) {
+	return // no column size to change
+}
+
+//TPChargers methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPChargers() (err error) {
+	return // no columns to add
+}
+
+//TPActionPlans methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPActionPlans() (err error) {
+	return // no columns to add
+}
+
+//TPRoutes methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPRoutes() (err error) {
+	return // no columns to add
+}
+
+//AuditRecords methods
+//create
+func (v1ms *mongoStorDBMigrator) createV1AuditRecords() (err error) {
+	return // the collection is created on first insert, the indexes by ensureIndexesStorDB
+}
+
+//ActionExecutions methods
+//create
+func (v1ms *mongoStorDBMigrator) createV1ActionExecutions() (err error) {
+	return // the collection is created on first insert, the indexes by ensureIndexesStorDB
+}
+
 func (v1ms *mongoStorDBMigrator) createV1SMCosts() (err error) {
 	v1ms.mgoDB.DB().Collection(utils.OldSMCosts).Drop(v1ms.mgoDB.GetContext())
 	v1ms.mgoDB.DB().Collection(utils.SessionCostsTBL).Drop(v1ms.mgoDB.GetContext())
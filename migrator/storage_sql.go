@@ -107,6 +107,102 @@ func (mgSQL *migratorSQL) renameV1SMCosts() (err error) {
 	return
 }
 
+func (mgSQL *migratorSQL) alterV1TPTimings() (err error) {
+	qry := "ALTER TABLE tp_timings MODIFY `time` varchar(128) NOT NULL;"
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qry = "ALTER TABLE tp_timings ALTER COLUMN time TYPE VARCHAR(128)"
+	}
+	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+		return err
+	}
+	return
+}
+
+func (mgSQL *migratorSQL) alterV1TPChargers() (err error) {
+	qry := "ALTER TABLE tp_chargers ADD `opts` varchar(128) NOT NULL DEFAULT '', ADD `blocker` BOOLEAN NOT NULL DEFAULT false;"
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qry = "ALTER TABLE tp_chargers ADD COLUMN opts VARCHAR(128) NOT NULL DEFAULT '', ADD COLUMN blocker BOOLEAN NOT NULL DEFAULT false"
+	}
+	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+		return err
+	}
+	return
+}
+
+func (mgSQL *migratorSQL) alterV1TPActionPlans() (err error) {
+	qry := "ALTER TABLE tp_action_plans ADD `catch_up` varchar(8) NOT NULL DEFAULT '';"
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qry = "ALTER TABLE tp_action_plans ADD COLUMN catch_up VARCHAR(8) NOT NULL DEFAULT ''"
+	}
+	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+		return err
+	}
+	return
+}
+
+func (mgSQL *migratorSQL) alterV1TPRoutes() (err error) {
+	qry := "ALTER TABLE tp_routes ADD `quarantine_rules` varchar(128) NOT NULL DEFAULT '', ADD `quarantine_cooldown` varchar(32) NOT NULL DEFAULT '';"
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qry = "ALTER TABLE tp_routes ADD COLUMN quarantine_rules VARCHAR(128) NOT NULL DEFAULT '', ADD COLUMN quarantine_cooldown VARCHAR(32) NOT NULL DEFAULT ''"
+	}
+	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+		return err
+	}
+	return
+}
+
+func (mgSQL *migratorSQL) createV1AuditRecords() (err error) {
+	qrys := []string{"CREATE TABLE IF NOT EXISTS audit_records (  id int(11) NOT NULL AUTO_INCREMENT,  caller varchar(64) NOT NULL,  role varchar(64) NOT NULL,  remote_addr varchar(64) NOT NULL,  method varchar(128) NOT NULL,  args_digest varchar(40) NOT NULL,  result TEXT,  created_at TIMESTAMP(6) NULL,  PRIMARY KEY (`id`),  KEY created_at_idx (created_at),  KEY caller_idx (caller, created_at));"}
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qrys = []string{`
+	CREATE TABLE IF NOT EXISTS audit_records (
+	  id SERIAL PRIMARY KEY,
+	  caller VARCHAR(64) NOT NULL,
+	  role VARCHAR(64) NOT NULL,
+	  remote_addr VARCHAR(64) NOT NULL,
+	  method VARCHAR(128) NOT NULL,
+	  args_digest VARCHAR(40) NOT NULL,
+	  result TEXT,
+	  created_at TIMESTAMP WITH TIME ZONE
+	);`,
+			"CREATE INDEX IF NOT EXISTS created_at_auditrecords_idx ON audit_records (created_at);",
+			"CREATE INDEX IF NOT EXISTS caller_auditrecords_idx ON audit_records (caller, created_at);",
+		}
+	}
+	for _, qry := range qrys {
+		if _, err = mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (mgSQL *migratorSQL) createV1ActionExecutions() (err error) {
+	qrys := []string{"CREATE TABLE IF NOT EXISTS action_executions (  id int(11) NOT NULL AUTO_INCREMENT,  action_plan_id varchar(64) NOT NULL,  actions_id varchar(64) NOT NULL,  timing varchar(40) NOT NULL,  account_ids TEXT,  run_time TIMESTAMP(6) NULL,  error TEXT,  created_at TIMESTAMP(6) NULL,  PRIMARY KEY (`id`),  KEY created_at_idx (created_at),  KEY action_timing_idx (action_plan_id, actions_id, timing, created_at));"}
+	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
+		qrys = []string{`
+	CREATE TABLE IF NOT EXISTS action_executions (
+	  id SERIAL PRIMARY KEY,
+	  action_plan_id VARCHAR(64) NOT NULL,
+	  actions_id VARCHAR(64) NOT NULL,
+	  timing VARCHAR(40) NOT NULL,
+	  account_ids TEXT,
+	  run_time TIMESTAMP WITH TIME ZONE,
+	  error TEXT,
+	  created_at TIMESTAMP WITH TIME ZONE
+	);`,
+			"CREATE INDEX IF NOT EXISTS created_at_actionexecutions_idx ON action_executions (created_at);",
+			"CREATE INDEX IF NOT EXISTS action_timing_actionexecutions_idx ON action_executions (action_plan_id, actions_id, timing, created_at);",
+		}
+	}
+	for _, qry := range qrys {
+		if _, err = mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+			return
+		}
+	}
+	return
+}
+
 func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	qry := fmt.Sprint("CREATE TABLE sm_costs (  id int(11) NOT NULL AUTO_INCREMENT,  cgrid varchar(40) NOT NULL,  run_id  varchar(64) NOT NULL,  origin_host varchar(64) NOT NULL,  origin_id varchar(128) NOT NULL,  cost_source varchar(64) NOT NULL,  `usage` BIGINT NOT NULL,  cost_details MEDIUMTEXT,  created_at TIMESTAMP NULL,deleted_at TIMESTAMP NULL,  PRIMARY KEY (`id`),UNIQUE KEY costid (cgrid, run_id),KEY origin_idx (origin_host, origin_id),KEY run_origin_idx (run_id, origin_id),KEY deleted_at_idx (deleted_at));")
 	if mgSQL.StorDB().GetStorageType() == utils.POSTGRES {
@@ -76,6 +76,10 @@ func (m *Migrator) migrateTPactionplans() (err error) {
 			"version number is not defined for ActionTriggers model")
 	}
 	switch vrs[utils.TpActionPlans] {
+	case 1:
+		if err := m.migrateV1TPActionPlans(); err != nil {
+			return err
+		}
 	case current[utils.TpActionPlans]:
 		if m.sameStorDB {
 			break
@@ -86,3 +90,28 @@ func (m *Migrator) migrateTPactionplans() (err error) {
 	}
 	return m.ensureIndexesStorDB(utils.TBLTPActionPlans)
 }
+
+// migrateV1TPActionPlans adds the catch_up column to the tp_action_plans table
+func (m *Migrator) migrateV1TPActionPlans() (err error) {
+	if m.sameStorDB {
+		if m.dryRun {
+			return
+		}
+		if err = m.storDBIn.alterV1TPActionPlans(); err != nil {
+			return err
+		}
+	} else if err = m.migrateCurrentTPactionplans(); err != nil { // the out StorDB is created with the new column
+		return err
+	}
+	if m.dryRun {
+		return
+	}
+	vrs := engine.Versions{utils.TpActionPlans: 2}
+	if err = m.storDBOut.StorDB().SetVersions(vrs, false); err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when updating TpActionPlans version into StorDB", err.Error()))
+	}
+	return
+}
@@ -76,6 +76,10 @@ func (m *Migrator) migrateTPChargers() (err error) {
 			"version number is not defined for TPChargers model")
 	}
 	switch vrs[utils.TpChargers] {
+	case 1:
+		if err := m.migrateV1TPChargers(); err != nil {
+			return err
+		}
 	case current[utils.TpChargers]:
 		if m.sameStorDB {
 			break
@@ -86,3 +90,28 @@ func (m *Migrator) migrateTPChargers() (err error) {
 	}
 	return m.ensureIndexesStorDB(utils.TBLTPChargers)
 }
+
+// migrateV1TPChargers adds the opts and blocker columns to the tp_chargers table
+func (m *Migrator) migrateV1TPChargers() (err error) {
+	if m.sameStorDB {
+		if m.dryRun {
+			return
+		}
+		if err = m.storDBIn.alterV1TPChargers(); err != nil {
+			return err
+		}
+	} else if err = m.migrateCurrentTPChargers(); err != nil { // the out StorDB is created with the new columns
+		return err
+	}
+	if m.dryRun {
+		return
+	}
+	vrs := engine.Versions{utils.TpChargers: 2}
+	if err = m.storDBOut.StorDB().SetVersions(vrs, false); err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when updating TpChargers version into StorDB", err.Error()))
+	}
+	return
+}
@@ -77,6 +77,10 @@ func (m *Migrator) migrateTPRoutes() (err error) {
 			"version number is not defined for ActionTriggers model")
 	}
 	switch vrs[utils.TpRoutes] {
+	case 1:
+		if err := m.migrateV1TPRoutes(); err != nil {
+			return err
+		}
 	case current[utils.TpRoutes]:
 		if m.sameStorDB {
 			break
@@ -87,3 +91,28 @@ func (m *Migrator) migrateTPRoutes() (err error) {
 	}
 	return m.ensureIndexesStorDB(utils.TBLTPRoutes)
 }
+
+// migrateV1TPRoutes adds the quarantine_rules and quarantine_cooldown columns to the tp_routes table
+func (m *Migrator) migrateV1TPRoutes() (err error) {
+	if m.sameStorDB {
+		if m.dryRun {
+			return
+		}
+		if err = m.storDBIn.alterV1TPRoutes(); err != nil {
+			return err
+		}
+	} else if err = m.migrateCurrentTPRoutes(); err != nil { // the out StorDB is created with the new columns
+		return err
+	}
+	if m.dryRun {
+		return
+	}
+	vrs := engine.Versions{utils.TpRoutes: 2}
+	if err = m.storDBOut.StorDB().SetVersions(vrs, false); err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when updating TpRoutes version into StorDB", err.Error()))
+	}
+	return
+}
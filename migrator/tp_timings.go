@@ -77,6 +77,10 @@ func (m *Migrator) migrateTpTimings() (err error) {
 			"version number is not defined for ActionTriggers model")
 	}
 	switch vrs[utils.TpTiming] {
+	case 1:
+		if err := m.migrateV1TPTimings(); err != nil {
+			return err
+		}
 	case current[utils.TpTiming]:
 		if m.sameStorDB {
 			break
@@ -87,3 +91,28 @@ func (m *Migrator) migrateTpTimings() (err error) {
 	}
 	return m.ensureIndexesStorDB(utils.TBLTPTimings)
 }
+
+// migrateV1TPTimings widens the time column so it can hold the cron expressions
+func (m *Migrator) migrateV1TPTimings() (err error) {
+	if m.sameStorDB {
+		if m.dryRun {
+			return
+		}
+		if err = m.storDBIn.alterV1TPTimings(); err != nil {
+			return err
+		}
+	} else if err = m.migrateCurrentTPTiming(); err != nil { // the out StorDB is created with the wider column
+		return err
+	}
+	if m.dryRun {
+		return
+	}
+	vrs := engine.Versions{utils.TpTiming: 2}
+	if err = m.storDBOut.StorDB().SetVersions(vrs, false); err != nil {
+		return utils.NewCGRError(utils.Migrator,
+			utils.ServerErrorCaps,
+			err.Error(),
+			fmt.Sprintf("error: <%s> when updating TpTiming version into StorDB", err.Error()))
+	}
+	return
+}
@@ -0,0 +1,128 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// missedRuns are the runs of one ActionTiming missed while the scheduler was down
+type missedRuns struct {
+	at       *engine.ActionTiming
+	runTimes []time.Time
+}
+
+// SetStorDB is used on StorDB reload
+func (s *Scheduler) SetStorDB(storDB engine.ActionExecutionStorage) {
+	s.storDBMux.Lock()
+	s.storDB = storDB
+	s.storDBMux.Unlock()
+}
+
+func (s *Scheduler) getStorDB() (storDB engine.ActionExecutionStorage) {
+	s.storDBMux.RLock()
+	storDB = s.storDB
+	s.storDBMux.RUnlock()
+	return
+}
+
+// execute runs the actions of the ActionTiming due at runTime and records the execution
+func (s *Scheduler) execute(at *engine.ActionTiming, runTime time.Time) {
+	acntIDs := at.GetAccountIDs().Slice()
+	sort.Strings(acntIDs)
+	exec := &utils.ActionExecution{
+		ActionPlanID: at.GetActionPlanID(),
+		ActionsID:    at.ActionsID,
+		Timing:       at.GetTimingKey(),
+		AccountIDs:   acntIDs,
+		RunTime:      runTime,
+		Time:         time.Now(),
+	}
+	if err := at.Execute(s.actSucessChan, s.actFailedChan); err != nil {
+		exec.Error = err.Error()
+	}
+	storDB := s.getStorDB()
+	if storDB == nil { // execution history disabled
+		return
+	}
+	if err := storDB.SetActionExecution(exec); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed recording the execution of actions <%s> from action plan <%s>, error: %s",
+				utils.SchedulerS, exec.ActionsID, exec.ActionPlanID, err.Error()))
+	}
+}
+
+// getMissedRuns returns the runs of the ActionTiming due since its last recorded execution
+func (s *Scheduler) getMissedRuns(at *engine.ActionTiming, catchUp string, now time.Time) (runTimes []time.Time) {
+	storDB := s.getStorDB()
+	if storDB == nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot catch up the action plan <%s> with the execution history disabled",
+				utils.SchedulerS, at.GetActionPlanID()))
+		return
+	}
+	execs, err := storDB.GetActionExecutions(&utils.ActionExecutionsFilter{
+		ActionPlanIDs: []string{at.GetActionPlanID()},
+		ActionsIDs:    []string{at.ActionsID},
+		Timings:       []string{at.GetTimingKey()},
+		Paginator:     utils.Paginator{Limit: utils.IntPointer(1)},
+	})
+	if err != nil {
+		if err != utils.ErrNotFound { // never executed, nothing to catch up
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed querying the last execution of actions <%s> from action plan <%s>, error: %s",
+					utils.SchedulerS, at.ActionsID, at.GetActionPlanID(), err.Error()))
+		}
+		return
+	}
+	if runTimes = at.GetStartTimes(execs[0].RunTime, now); len(runTimes) != 0 &&
+		catchUp == utils.MetaOnce {
+		runTimes = runTimes[len(runTimes)-1:]
+	}
+	return
+}
+
+// catchUp executes in order the missed runs
+func (s *Scheduler) catchUp(mRuns []*missedRuns) {
+	for _, mr := range mRuns {
+		for _, runTime := range mr.runTimes {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> catching up the run due at %s of actions <%s> from action plan <%s>",
+					utils.SchedulerS, runTime, mr.at.ActionsID, mr.at.GetActionPlanID()))
+			s.execute(mr.at, runTime)
+		}
+	}
+}
+
+// needsCatchUp returns true once after the leadership is gained
+func (s *Scheduler) needsCatchUp() (catchUp bool) {
+	if !s.IsLeader() {
+		return
+	}
+	s.leaderMux.Lock()
+	catchUp = !s.caughtUp
+	s.caughtUp = true
+	s.leaderMux.Unlock()
+	return
+}
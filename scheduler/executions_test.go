@@ -0,0 +1,154 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSchedulerCatchUp(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	engine.SetDataStorage(dm)
+	storDB := engine.NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	if err := dm.SetActions("ACT_LOG", engine.Actions{{Id: "ACT_LOG", ActionType: utils.LOG}},
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	at := &engine.ActionTiming{
+		Uuid:      "AT_DAILY_RELOADED", // the Uuid is regenerated on each load
+		ActionsID: "ACT_LOG",
+		Timing: &engine.RateInterval{Timing: &engine.RITiming{
+			StartTime: "00:00:00",
+		}},
+	}
+	if err := dm.SetActionPlan("AP_DAILY", &engine.ActionPlan{
+		Id:            "AP_DAILY",
+		CatchUp:       utils.MetaAll,
+		ActionTimings: []*engine.ActionTiming{at},
+	}, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	tNow := time.Now()
+	midnight := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 0, 0, 0, 0, time.Local)
+	lastExec := &utils.ActionExecution{
+		ActionPlanID: "AP_DAILY",
+		ActionsID:    "ACT_LOG",
+		Timing:       at.GetTimingKey(),
+		RunTime:      midnight.AddDate(0, 0, -3),
+		Time:         midnight.AddDate(0, 0, -3),
+	}
+	if err := storDB.SetActionExecution(lastExec); err != nil {
+		t.Fatal(err)
+	}
+
+	sched := NewScheduler(dm, cfg, engine.NewFilterS(cfg, nil, dm), storDB)
+	var execs []*utils.ActionExecution
+	var err error
+	for i := 0; i < 100; i++ {
+		if execs, err = storDB.GetActionExecutions(&utils.ActionExecutionsFilter{}); len(execs) == 4 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	} else if len(execs) != 4 {
+		t.Fatalf("Expecting 4 executions, received: %s", utils.ToJSON(execs))
+	}
+	expRunTimes := []time.Time{midnight, midnight.AddDate(0, 0, -1),
+		midnight.AddDate(0, 0, -2), midnight.AddDate(0, 0, -3)}
+	for i, exec := range execs {
+		if !exec.RunTime.Equal(expRunTimes[i]) {
+			t.Errorf("Expecting run time: %s, received: %s", expRunTimes[i], exec.RunTime)
+		}
+		if exec.ActionPlanID != "AP_DAILY" || exec.Timing != lastExec.Timing ||
+			exec.Error != utils.EmptyString {
+			t.Errorf("Unexpected execution: %s", utils.ToJSON(exec))
+		}
+	}
+	// caught up only once after start
+	sched.Reload()
+	time.Sleep(10 * time.Millisecond)
+	if execs, err = storDB.GetActionExecutions(&utils.ActionExecutionsFilter{
+		Failed: utils.BoolPointer(false),
+	}); err != nil {
+		t.Error(err)
+	} else if len(execs) != 4 {
+		t.Errorf("Expecting 4 executions, received: %s", utils.ToJSON(execs))
+	}
+	if _, err = storDB.GetActionExecutions(&utils.ActionExecutionsFilter{
+		Failed: utils.BoolPointer(true),
+	}); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSchedulerGetMissedRuns(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	storDB := engine.NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	sched := &Scheduler{cfg: cfg}
+	at := &engine.ActionTiming{
+		Uuid:      "AT_DAILY",
+		ActionsID: "ACT_LOG",
+		Timing: &engine.RateInterval{Timing: &engine.RITiming{
+			StartTime: "00:00:00",
+		}},
+	}
+	at.SetActionPlanID("AP_DAILY")
+	tNow := time.Date(2020, 7, 21, 10, 30, 0, 0, time.Local)
+	if rcv := sched.getMissedRuns(at, utils.MetaAll, tNow); len(rcv) != 0 {
+		t.Errorf("Without history expecting no runs, received: %v", rcv)
+	}
+	sched.SetStorDB(storDB)
+	if rcv := sched.getMissedRuns(at, utils.MetaAll, tNow); len(rcv) != 0 {
+		t.Errorf("Never executed expecting no runs, received: %v", rcv)
+	}
+	if err := storDB.SetActionExecution(&utils.ActionExecution{
+		ActionPlanID: "AP_DAILY",
+		ActionsID:    "ACT_LOG",
+		Timing:       at.GetTimingKey(),
+		RunTime:      time.Date(2020, 7, 18, 0, 0, 0, 0, time.Local),
+		Time:         time.Date(2020, 7, 18, 0, 0, 1, 0, time.Local),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exp := []time.Time{
+		time.Date(2020, 7, 19, 0, 0, 0, 0, time.Local),
+		time.Date(2020, 7, 20, 0, 0, 0, 0, time.Local),
+		time.Date(2020, 7, 21, 0, 0, 0, 0, time.Local),
+	}
+	if rcv := sched.getMissedRuns(at, utils.MetaAll, tNow); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %v, received: %v", exp, rcv)
+	}
+	if rcv := sched.getMissedRuns(at, utils.MetaOnce, tNow); !reflect.DeepEqual(exp[2:], rcv) {
+		t.Errorf("Expecting: %v, received: %v", exp[2:], rcv)
+	} // another timing of the same actions has its own history
+	at.Timing.Timing.StartTime = "12:00:00"
+	if rcv := sched.getMissedRuns(at, utils.MetaAll, tNow); len(rcv) != 0 {
+		t.Errorf("Never executed expecting no runs, received: %v", rcv)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// leaderLockID is the lock on DataDB owned by the scheduler leader
+const leaderLockID = "*scheduler_leader"
+
+// LeaseBackend keeps the leadership of the schedulers, eg: over the common DataDB
+type LeaseBackend interface {
+	guardian.LockBackend
+	// GetLockOwner returns the current owner of the lock, utils.ErrNotFound if free
+	GetLockOwner(lkID string) (owner string, expiry time.Time, err error)
+}
+
+// newLeaseBackend returns the DataDB as LeaseBackend
+func newLeaseBackend(dm *engine.DataManager) (lb LeaseBackend, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	var canCast bool
+	if lb, canCast = dm.DataDB().(LeaseBackend); !canCast {
+		return nil, fmt.Errorf("unsupported leader election for db_type <%s>", dm.DataDB().GetStorageType())
+	}
+	return
+}
+
+// LeaderStatus is the status of the leader election as seen from one engine
+type LeaderStatus struct {
+	LeaderElection bool      // leader election enabled on this engine
+	NodeID         string    // the NodeID of this engine
+	Leader         string    // the NodeID of the current leader, empty if none
+	IsLeader       bool      // this engine is the leader
+	LeaseExpiry    time.Time // when the leader lease expires if not renewed
+}
+
+// GetLeaderStatus queries the DataDB for the current scheduler leader
+func GetLeaderStatus(cfg *config.CGRConfig, dm *engine.DataManager) (ls *LeaderStatus, err error) {
+	ls = &LeaderStatus{
+		LeaderElection: cfg.SchedulerCfg().LeaderElection,
+		NodeID:         cfg.GeneralCfg().NodeID,
+	}
+	if !ls.LeaderElection {
+		ls.IsLeader = true // executing all the actions
+		return
+	}
+	var lb LeaseBackend
+	if lb, err = newLeaseBackend(dm); err != nil {
+		return nil, err
+	}
+	if ls.Leader, ls.LeaseExpiry, err = lb.GetLockOwner(leaderLockID); err != nil {
+		if err != utils.ErrNotFound {
+			return nil, err
+		}
+		err = nil // no leader at the moment
+	}
+	ls.IsLeader = ls.Leader == ls.NodeID
+	return
+}
+
+// IsLeader returns true if this scheduler executes the actions
+func (s *Scheduler) IsLeader() bool {
+	if !s.cfg.SchedulerCfg().LeaderElection {
+		return true
+	}
+	s.leaderMux.RLock()
+	defer s.leaderMux.RUnlock()
+	return s.isLeader
+}
+
+// setLeader changes the leadership, returns true if it was changed
+func (s *Scheduler) setLeader(isLeader bool) (changed bool) {
+	s.leaderMux.Lock()
+	changed = s.isLeader != isLeader
+	s.isLeader = isLeader
+	if !isLeader { // catch up again once reelected
+		s.caughtUp = false
+	}
+	s.leaderMux.Unlock()
+	return
+}
+
+// startElection campaigns for the leadership until stopElection is called
+func (s *Scheduler) startElection() {
+	lb, err := newLeaseBackend(s.dm)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> cannot start the leader election, error: %s", utils.SchedulerS, err.Error()))
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	s.Lock()
+	s.electionStop, s.electionDone = stop, done
+	s.Unlock()
+	go s.runElection(lb, s.cfg.GeneralCfg().NodeID,
+		s.cfg.SchedulerCfg().LeaderLeaseTTL, stop, done)
+}
+
+// stopElection stops campaigning and releases the leadership
+func (s *Scheduler) stopElection() {
+	s.Lock()
+	stop, done := s.electionStop, s.electionDone
+	s.electionStop, s.electionDone = nil, nil
+	s.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done // not under lock since the election reloads the scheduler
+}
+
+// runElection renews the lease while leader, otherwise tries to take it over on expiry
+func (s *Scheduler) runElection(lb LeaseBackend, nodeID string, ttl time.Duration,
+	stop, done chan struct{}) {
+	defer close(done)
+	tckr := time.NewTicker(ttl / 3)
+	defer tckr.Stop()
+	for {
+		if s.campaign(lb, nodeID, ttl) {
+			s.Reload() // requeue the actions and execute the pending tasks as leader
+		}
+		select {
+		case <-stop:
+			if s.setLeader(false) {
+				if err := lb.ReleaseLock(leaderLockID, nodeID); err != nil {
+					utils.Logger.Warning(fmt.Sprintf("<%s> cannot release the leadership, error: %s",
+						utils.SchedulerS, err.Error()))
+				}
+			}
+			return
+		case <-tckr.C:
+		}
+	}
+}
+
+// campaign renews or acquires the lease, returns true if the leadership was gained
+func (s *Scheduler) campaign(lb LeaseBackend, nodeID string, ttl time.Duration) (elected bool) {
+	var isLeader bool
+	var err error
+	if s.IsLeader() {
+		isLeader, err = lb.RefreshLock(leaderLockID, nodeID, ttl)
+	}
+	if err == nil && !isLeader {
+		isLeader, err = lb.AcquireLock(leaderLockID, nodeID, ttl)
+	}
+	if err != nil { // cannot confirm the lease so step down, another scheduler will take over
+		utils.Logger.Warning(fmt.Sprintf("<%s> leader election error: %s", utils.SchedulerS, err.Error()))
+		isLeader = false
+	}
+	if !s.setLeader(isLeader) {
+		return
+	}
+	if isLeader {
+		utils.Logger.Info(fmt.Sprintf("<%s> node <%s> elected as leader", utils.SchedulerS, nodeID))
+	} else {
+		utils.Logger.Warning(fmt.Sprintf("<%s> node <%s> lost the leadership", utils.SchedulerS, nodeID))
+	}
+	return isLeader
+}
@@ -0,0 +1,129 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// testLeaseBackend keeps the leases in memory
+type testLeaseBackend struct {
+	sync.Mutex
+	owners  map[string]string
+	expires map[string]time.Time
+}
+
+func newTestLeaseBackend() *testLeaseBackend {
+	return &testLeaseBackend{
+		owners:  make(map[string]string),
+		expires: make(map[string]time.Time),
+	}
+}
+
+func (lb *testLeaseBackend) AcquireLock(lkID, owner string, ttl time.Duration) (bool, error) {
+	lb.Lock()
+	defer lb.Unlock()
+	if exp, has := lb.expires[lkID]; has && exp.After(time.Now()) {
+		return false, nil
+	}
+	lb.owners[lkID] = owner
+	lb.expires[lkID] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (lb *testLeaseBackend) RefreshLock(lkID, owner string, ttl time.Duration) (bool, error) {
+	lb.Lock()
+	defer lb.Unlock()
+	if lb.owners[lkID] != owner {
+		return false, nil
+	}
+	lb.expires[lkID] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (lb *testLeaseBackend) ReleaseLock(lkID, owner string) error {
+	lb.Lock()
+	defer lb.Unlock()
+	if lb.owners[lkID] == owner {
+		delete(lb.owners, lkID)
+		delete(lb.expires, lkID)
+	}
+	return nil
+}
+
+func (lb *testLeaseBackend) GetLockOwner(lkID string) (string, time.Time, error) {
+	lb.Lock()
+	defer lb.Unlock()
+	if exp, has := lb.expires[lkID]; has && exp.After(time.Now()) {
+		return lb.owners[lkID], exp, nil
+	}
+	return utils.EmptyString, time.Time{}, utils.ErrNotFound
+}
+
+func TestSchedulerCampaign(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.SchedulerCfg().LeaderElection = true
+	ttl := 50 * time.Millisecond
+	lb := newTestLeaseBackend()
+	sched1 := &Scheduler{cfg: cfg}
+	sched2 := &Scheduler{cfg: cfg}
+	if !sched1.campaign(lb, "node1", ttl) || !sched1.IsLeader() {
+		t.Fatal("node1 should be elected")
+	}
+	if sched2.campaign(lb, "node2", ttl) || sched2.IsLeader() {
+		t.Fatal("node2 should not be elected while node1 holds the lease")
+	}
+	// renewing the lease does not count as a new election
+	if sched1.campaign(lb, "node1", ttl) || !sched1.IsLeader() {
+		t.Error("node1 should keep the leadership")
+	}
+	if owner, _, err := lb.GetLockOwner(leaderLockID); err != nil || owner != "node1" {
+		t.Errorf("Expecting node1 as owner, received: %q, %v", owner, err)
+	}
+	// node1 stops renewing, node2 takes over on expiry
+	time.Sleep(ttl + 10*time.Millisecond)
+	if !sched2.campaign(lb, "node2", ttl) || !sched2.IsLeader() {
+		t.Fatal("node2 should take over the leadership")
+	}
+	if sched1.campaign(lb, "node1", ttl) || sched1.IsLeader() {
+		t.Error("node1 should lose the leadership")
+	}
+}
+
+func TestSchedulerIsLeaderNoElection(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	sched := &Scheduler{cfg: cfg}
+	if !sched.IsLeader() {
+		t.Error("without leader election every scheduler should execute the actions")
+	}
+	if ls, err := GetLeaderStatus(cfg, nil); err != nil {
+		t.Error(err)
+	} else if ls.LeaderElection || !ls.IsLeader || ls.NodeID != cfg.GeneralCfg().NodeID {
+		t.Errorf("Received: %s", utils.ToJSON(ls))
+	}
+	cfg.SchedulerCfg().LeaderElection = true
+	if _, err := GetLeaderStatus(cfg, nil); err != utils.ErrNoDatabaseConn {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNoDatabaseConn, err)
+	}
+}
@@ -43,15 +43,24 @@ type Scheduler struct {
 	actSucessChan, actFailedChan    chan *engine.Action           // ActionPlan will pass actions via these channels
 	aSMux, aFMux                    sync.RWMutex                  // protect schedStats
 	actSuccessStats, actFailedStats map[string]map[time.Time]bool // keep here stats regarding executed actions, map[actionType]map[execTime]bool
+
+	isLeader                   bool          // only the leader executes the actions when leader_election is enabled
+	caughtUp                   bool          // the missed runs were executed since the leadership was gained
+	leaderMux                  sync.RWMutex  // protects isLeader and caughtUp
+	electionStop, electionDone chan struct{} // stop the leader election, protected by the scheduler lock
+
+	storDB    engine.ActionExecutionStorage // records the executions, nil if the history is disabled
+	storDBMux sync.RWMutex                  // protects storDB
 }
 
 func NewScheduler(dm *engine.DataManager, cfg *config.CGRConfig,
-	fltrS *engine.FilterS) (s *Scheduler) {
+	fltrS *engine.FilterS, storDB engine.ActionExecutionStorage) (s *Scheduler) {
 	s = &Scheduler{
 		restartLoop: make(chan bool),
 		dm:          dm,
 		cfg:         cfg,
 		fltrS:       fltrS,
+		storDB:      storDB,
 	}
 	s.Reload()
 	return
@@ -88,6 +97,9 @@ func (s *Scheduler) updateActStats(act *engine.Action, isFailed bool) {
 
 func (s *Scheduler) Loop() {
 	s.schedulerStarted = true
+	if s.cfg.SchedulerCfg().LeaderElection {
+		s.startElection()
+	}
 	for {
 		if !s.schedulerStarted { // shutdown requested
 			break
@@ -102,7 +114,11 @@ func (s *Scheduler) Loop() {
 		now := time.Now()
 		start := a0.GetNextStartTime(now)
 		if start.Equal(now) || start.Before(now) {
-			go a0.Execute(s.actSucessChan, s.actFailedChan)
+			if s.IsLeader() {
+				go s.execute(a0, start)
+			} else {
+				utils.Logger.Info(fmt.Sprintf("<Scheduler> Not leader, skipping action: %s", a0.ActionsID))
+			}
 			// if after execute the next start time is in the past then
 			// do not add it to the queue
 			a0.ResetStartTimeCache()
@@ -142,8 +158,8 @@ func (s *Scheduler) loadActionPlans() {
 	defer s.Unlock()
 	// limit the number of concurrent tasks
 	limit := make(chan bool, 10)
-	// execute existing tasks
-	for {
+	// execute existing tasks, left to the leader if leader election is enabled
+	for s.IsLeader() {
 		task, err := s.dm.DataDB().PopTask()
 		if err != nil || task == nil {
 			break
@@ -181,6 +197,8 @@ func (s *Scheduler) loadActionPlans() {
 		utils.Logger.Warning(fmt.Sprintf("<Scheduler> Cannot get action plans: %v", err))
 	}
 	utils.Logger.Info(fmt.Sprintf("<Scheduler> processing %d action plans", len(actionPlans)))
+	needsCatchUp := s.needsCatchUp()
+	var mRuns []*missedRuns
 	// recreate the queue
 	s.queue = engine.ActionTimingPriorityList{}
 	for _, actionPlan := range actionPlans {
@@ -196,8 +214,10 @@ func (s *Scheduler) loadActionPlans() {
 				continue // should be already executed as task
 			}
 			now := time.Now()
-			if at.GetNextStartTime(now).Before(now) {
-				// the task is obsolete, do not add it to the queue
+			catchUp := needsCatchUp && actionPlan.CatchUp != utils.EmptyString
+			// the task is obsolete, do not add it to the queue
+			obsolete := at.GetNextStartTime(now).Before(now)
+			if obsolete && !catchUp {
 				continue
 			}
 			at.SetAccountIDs(actionPlan.AccountIDs) // copy the accounts
@@ -213,11 +233,26 @@ func (s *Scheduler) loadActionPlans() {
 					at.RemoveAccountID(task.AccountID)
 				}
 			}
+			if catchUp {
+				if runTimes := s.getMissedRuns(at, actionPlan.CatchUp, now); len(runTimes) != 0 {
+					// the queued ActionTiming is executed and reloaded while catching up
+					cln := at.Clone()
+					cln.SetAccountIDs(at.GetAccountIDs().Clone())
+					cln.SetActionPlanID(actionPlan.Id)
+					mRuns = append(mRuns, &missedRuns{at: cln, runTimes: runTimes})
+				}
+			}
+			if obsolete {
+				continue
+			}
 			s.queue = append(s.queue, at)
 		}
 	}
 	sort.Sort(s.queue)
 	utils.Logger.Info(fmt.Sprintf("<Scheduler> queued %d action plans", len(s.queue)))
+	if len(mRuns) != 0 {
+		go s.catchUp(mRuns)
+	}
 }
 
 func (s *Scheduler) restart() {
@@ -290,6 +325,7 @@ func (s *Scheduler) GetScheduledActions(fltr ArgsGetScheduledActions) (schedActi
 }
 
 func (s *Scheduler) Shutdown() {
+	s.stopElection()
 	s.schedulerStarted = false // disable loop on next run
 	s.restartLoop <- true      // cancel waiting tasks
 	if s.timer != nil {
@@ -102,8 +102,13 @@ func (apiService *APIerSv1Service) Start() (err error) {
 		SchedulerService: apiService.schedService,
 		FilterS:          filterS,
 		ConnMgr:          apiService.connMgr,
+		AuditS:           engine.NewAuditS(apiService.cfg, stordb),
 		StorDBChan:       storDBChan,
 	}
+	if apiService.cfg.ApierCfg().Audit {
+		go apiService.api.AuditS.ListenAndServe()
+		apiService.server.SetAuditor(apiService.api.AuditS)
+	}
 
 	go func(api *v1.APIerSv1, stopChan chan struct{}) {
 		if err := api.ListenAndServe(stopChan); err != nil {
@@ -140,6 +145,8 @@ func (apiService *APIerSv1Service) Reload() (err error) {
 func (apiService *APIerSv1Service) Shutdown() (err error) {
 	apiService.Lock()
 	close(apiService.syncStop)
+	apiService.server.SetAuditor(nil)
+	apiService.api.AuditS.Shutdown()
 	apiService.api = nil
 	<-apiService.connChan
 	apiService.Unlock()
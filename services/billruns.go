@@ -0,0 +1,129 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/billruns"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewBillRunService constructs BillRunService
+func NewBillRunService(cfg *config.CGRConfig, dmS *DataDBService,
+	connMgr *engine.ConnManager, server *utils.Server, exitChan chan bool,
+	intConnChan chan rpcclient.ClientConnector) servmanager.Service {
+	return &BillRunService{
+		cfg:         cfg,
+		dmS:         dmS,
+		connMgr:     connMgr,
+		server:      server,
+		exitChan:    exitChan,
+		intConnChan: intConnChan,
+		rldChan:     make(chan struct{}),
+	}
+}
+
+// BillRunService is the service structure for BillRunS
+type BillRunService struct {
+	sync.RWMutex
+
+	cfg      *config.CGRConfig
+	dmS      *DataDBService
+	connMgr  *engine.ConnManager
+	server   *utils.Server
+	exitChan chan bool
+
+	rldChan chan struct{}
+
+	bS          *billruns.BillRunS
+	rpc         *v1.BillRunSv1
+	intConnChan chan rpcclient.ClientConnector
+}
+
+// ServiceName returns the service name
+func (bs *BillRunService) ServiceName() string {
+	return utils.BillRunS
+}
+
+// ShouldRun returns if the service should be running
+func (bs *BillRunService) ShouldRun() (should bool) {
+	return bs.cfg.BillRunSCfg().Enabled
+}
+
+// IsRunning returns if the service is running
+func (bs *BillRunService) IsRunning() bool {
+	bs.RLock()
+	defer bs.RUnlock()
+	return bs.bS != nil
+}
+
+// Reload handles the change of config
+func (bs *BillRunService) Reload() (err error) {
+	bs.rldChan <- struct{}{}
+	return
+}
+
+// Shutdown stops the service
+func (bs *BillRunService) Shutdown() (err error) {
+	bs.Lock()
+	defer bs.Unlock()
+	if err = bs.bS.Shutdown(); err != nil {
+		return
+	}
+	bs.bS = nil
+	bs.rpc = nil
+	<-bs.intConnChan
+	return
+}
+
+// Start should handle the service start
+func (bs *BillRunService) Start() (err error) {
+	if bs.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	dbchan := bs.dmS.GetDMChan()
+	dm := <-dbchan
+	dbchan <- dm
+	bs.Lock()
+	bs.bS = billruns.NewBillRunS(bs.cfg, dm, bs.connMgr)
+	bs.Unlock()
+
+	bs.rpc = v1.NewBillRunSv1(bs.bS)
+	if !bs.cfg.DispatcherSCfg().Enabled {
+		bs.server.RpcRegister(bs.rpc)
+	}
+
+	bs.intConnChan <- bs.rpc
+
+	go func(bS *billruns.BillRunS, exitChan chan bool, rldChan chan struct{}) {
+		if err := bS.ListenAndServe(exitChan, rldChan); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.BillRunS, err.Error()))
+			exitChan <- true
+		}
+	}(bs.bS, bs.exitChan, bs.rldChan)
+	return
+}
@@ -64,7 +64,7 @@ func TestCdrsReload(t *testing.T) {
 	cfg.StorDbCfg().Type = utils.INTERNAL
 	stordb := NewStorDBService(cfg)
 	chrS := NewChargerService(cfg, db, chS, filterSChan, server, nil, nil)
-	schS := NewSchedulerService(cfg, db, chS, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil)
+	schS := NewSchedulerService(cfg, db, stordb, chS, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil)
 	ralS := NewRalService(cfg, chS, server,
 		make(chan rpcclient.ClientConnector, 1),
 		make(chan rpcclient.ClientConnector, 1),
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -68,8 +69,32 @@ func (db *DataDBService) Start() (err error) {
 		err = nil // reset the error in case of only SessionS active
 		return
 	}
+	if iDB, isInternal := d.(*engine.InternalDB); isInternal &&
+		db.cfg.DataDbCfg().InternalDBDumpPath != utils.EmptyString {
+		if err = iDB.EnablePersistence(db.cfg.DataDbCfg().InternalDBDumpPath,
+			db.cfg.DataDbCfg().InternalDBSnapshotInterval,
+			db.cfg.DataDbCfg().InternalDBSyncWrites); err != nil {
+			utils.Logger.Crit(fmt.Sprintf("Could not restore the internal dataDb: %s exiting!", err))
+			return
+		}
+	}
 
 	db.dm = engine.NewDataManager(d, db.cfg.CacheCfg(), db.connMgr)
+	if db.cfg.DataDbCfg().ReplicationQueuePath != utils.EmptyString {
+		if err = db.dm.StartReplicationQueue(db.cfg.DataDbCfg().ReplicationQueuePath,
+			db.cfg.DataDbCfg().ReplicationRetryInterval); err != nil {
+			utils.Logger.Crit(fmt.Sprintf("Could not start the replication queue: %s exiting!", err))
+			return
+		}
+	}
+	if err = db.setLockBackend(); err != nil {
+		utils.Logger.Crit(fmt.Sprintf("Could not configure the locking backend: %s exiting!", err))
+		return
+	}
+	if err = db.setCacheInvalidation(); err != nil {
+		utils.Logger.Crit(fmt.Sprintf("Could not configure the cache invalidation: %s exiting!", err))
+		return
+	}
 	engine.SetDataStorage(db.dm)
 	if err = engine.CheckVersions(db.dm.DataDB()); err != nil {
 		fmt.Println(err)
@@ -83,10 +108,19 @@ func (db *DataDBService) Start() (err error) {
 func (db *DataDBService) Reload() (err error) {
 	db.Lock()
 	defer db.Unlock()
+	if err = db.reloadReplicationQueue(); err != nil {
+		return
+	}
 	if db.needsConnectionReload() {
 		if err = db.dm.Reconnect(db.cfg.GeneralCfg().DBDataEncoding, db.cfg.DataDbCfg()); err != nil {
 			return
 		}
+		if err = db.setLockBackend(); err != nil {
+			return
+		}
+		if err = db.setCacheInvalidation(); err != nil {
+			return
+		}
 		db.oldDBCfg = db.cfg.DataDbCfg().Clone()
 		return
 	}
@@ -104,6 +138,9 @@ func (db *DataDBService) Reload() (err error) {
 // Shutdown stops the service
 func (db *DataDBService) Shutdown() (err error) {
 	db.Lock()
+	guardian.Guardian.SetBackend(nil)
+	db.dm.StopCacheInvalidation()
+	db.dm.StopReplicationQueue()
 	db.dm.DataDB().Close()
 	db.dm = nil
 	db.Unlock()
@@ -155,6 +192,9 @@ func (db *DataDBService) needsConnectionReload() bool {
 	if db.oldDBCfg.DataDbType == utils.REDIS {
 		return db.oldDBCfg.DataDbSentinelName != db.cfg.DataDbCfg().DataDbSentinelName
 	}
+	if db.oldDBCfg.DataDbType == utils.INTERNAL {
+		return db.oldDBCfg.InternalDBDumpPath != db.cfg.DataDbCfg().InternalDBDumpPath
+	}
 	return false
 }
 
@@ -164,3 +204,47 @@ func (db *DataDBService) GetDMChan() chan *engine.DataManager {
 	defer db.RUnlock()
 	return db.dbchan
 }
+
+// reloadReplicationQueue restarts the replication queue if its config changed (not thread safe)
+func (db *DataDBService) reloadReplicationQueue() (err error) {
+	if db.oldDBCfg.ReplicationQueuePath == db.cfg.DataDbCfg().ReplicationQueuePath &&
+		db.oldDBCfg.ReplicationRetryInterval == db.cfg.DataDbCfg().ReplicationRetryInterval {
+		return
+	}
+	if db.cfg.DataDbCfg().ReplicationQueuePath == utils.EmptyString {
+		db.dm.StopReplicationQueue()
+	} else if err = db.dm.StartReplicationQueue(db.cfg.DataDbCfg().ReplicationQueuePath,
+		db.cfg.DataDbCfg().ReplicationRetryInterval); err != nil {
+		return
+	}
+	db.oldDBCfg.ReplicationQueuePath = db.cfg.DataDbCfg().ReplicationQueuePath
+	db.oldDBCfg.ReplicationRetryInterval = db.cfg.DataDbCfg().ReplicationRetryInterval
+	return
+}
+
+// setLockBackend shares the Guardian locks over the DataDB if configured (not thread safe)
+func (db *DataDBService) setLockBackend() (err error) {
+	if db.cfg.GeneralCfg().LockingBackend != utils.MetaDataDB {
+		return
+	}
+	lkBknd, err := engine.NewLockBackend(db.dm.DataDB())
+	if err != nil {
+		return
+	}
+	guardian.Guardian.SetBackend(lkBknd)
+	return
+}
+
+// setCacheInvalidation shares the cache invalidations over the DataDB if configured (not thread safe)
+func (db *DataDBService) setCacheInvalidation() (err error) {
+	if !db.cfg.CacheCfg().RemoteInvalidation {
+		db.dm.StopCacheInvalidation()
+		return
+	}
+	bus, err := engine.NewCacheInvalidationBus(db.dm.DataDB())
+	if err != nil {
+		return
+	}
+	db.dm.StartCacheInvalidation(bus)
+	return
+}
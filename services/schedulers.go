@@ -31,13 +31,14 @@ import (
 
 // NewSchedulerService returns the Scheduler Service
 func NewSchedulerService(cfg *config.CGRConfig, dm *DataDBService,
-	cacheS *engine.CacheS, fltrSChan chan *engine.FilterS,
+	storDB *StorDBService, cacheS *engine.CacheS, fltrSChan chan *engine.FilterS,
 	server *utils.Server, internalSchedulerrSChan chan rpcclient.ClientConnector,
 	connMgr *engine.ConnManager) *SchedulerService {
 	return &SchedulerService{
 		connChan:  internalSchedulerrSChan,
 		cfg:       cfg,
 		dm:        dm,
+		storDB:    storDB,
 		cacheS:    cacheS,
 		fltrSChan: fltrSChan,
 		server:    server,
@@ -50,6 +51,7 @@ type SchedulerService struct {
 	sync.RWMutex
 	cfg       *config.CGRConfig
 	dm        *DataDBService
+	storDB    *StorDBService
 	cacheS    *engine.CacheS
 	fltrSChan chan *engine.FilterS
 	server    *utils.Server
@@ -58,6 +60,8 @@ type SchedulerService struct {
 	rpc      *v1.SchedulerSv1
 	connChan chan rpcclient.ClientConnector
 	connMgr  *engine.ConnManager
+
+	syncStop chan struct{}
 }
 
 // Start should handle the sercive start
@@ -77,8 +81,19 @@ func (schS *SchedulerService) Start() (err error) {
 	schS.Lock()
 	defer schS.Unlock()
 	utils.Logger.Info("<ServiceManager> Starting CGRateS Scheduler.")
-	schS.schS = scheduler.NewScheduler(datadb, schS.cfg, fltrS)
+	schS.syncStop = make(chan struct{})
+	var storDBChan chan engine.StorDB
+	var stordb engine.ActionExecutionStorage
+	if schS.cfg.SchedulerCfg().ExecHistory {
+		storDBChan = make(chan engine.StorDB, 1)
+		schS.storDB.RegisterSyncChan(storDBChan)
+		stordb = <-storDBChan
+	}
+	schS.schS = scheduler.NewScheduler(datadb, schS.cfg, fltrS, stordb)
 	go schS.schS.Loop()
+	if storDBChan != nil {
+		go schS.syncStorDB(schS.schS, storDBChan, schS.syncStop)
+	}
 
 	schS.rpc = v1.NewSchedulerSv1(schS.cfg, datadb)
 	if !schS.cfg.DispatcherSCfg().Enabled {
@@ -100,6 +115,7 @@ func (schS *SchedulerService) Reload() (err error) {
 // Shutdown stops the service
 func (schS *SchedulerService) Shutdown() (err error) {
 	schS.Lock()
+	close(schS.syncStop)
 	schS.schS.Shutdown()
 	schS.schS = nil
 	schS.rpc = nil
@@ -108,6 +124,22 @@ func (schS *SchedulerService) Shutdown() (err error) {
 	return
 }
 
+// syncStorDB updates the StorDB recording the execution history on reload
+func (schS *SchedulerService) syncStorDB(sched *scheduler.Scheduler,
+	storDBChan chan engine.StorDB, stopChan chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case stordb, ok := <-storDBChan:
+			if !ok { // the chanel was closed by the shutdown of stordbService
+				return
+			}
+			sched.SetStorDB(stordb)
+		}
+	}
+}
+
 // IsRunning returns if the service is running
 func (schS *SchedulerService) IsRunning() bool {
 	schS.RLock()
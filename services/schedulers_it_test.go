@@ -46,7 +46,8 @@ func TestSchedulerSReload(t *testing.T) {
 	server := utils.NewServer()
 	srvMngr := servmanager.NewServiceManager(cfg, engineShutdown)
 	db := NewDataDBService(cfg, nil)
-	schS := NewSchedulerService(cfg, db, chS, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil)
+	stordb := NewStorDBService(cfg)
+	schS := NewSchedulerService(cfg, db, stordb, chS, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(schS,
 		NewLoaderService(cfg, db, filterSChan, server, engineShutdown, make(chan rpcclient.ClientConnector, 1), nil), db)
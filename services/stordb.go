@@ -67,6 +67,10 @@ func (db *StorDBService) Start() (err error) {
 		utils.Logger.Crit(fmt.Sprintf("Could not configure storDB: %s exiting!", err))
 		return
 	}
+	if err = db.enablePersistence(d); err != nil {
+		utils.Logger.Crit(fmt.Sprintf("Could not restore the internal storDB: %s exiting!", err))
+		return
+	}
 	db.db = d
 	engine.SetCdrStorage(db.db)
 	if err = engine.CheckVersions(db.db); err != nil {
@@ -93,6 +97,9 @@ func (db *StorDBService) Reload() (err error) {
 			return
 		}
 		db.db.Close()
+		if err = db.enablePersistence(d); err != nil { // after close so we read the last snapshot
+			return
+		}
 		db.db = d
 		db.oldDBCfg = db.cfg.StorDbCfg().Clone()
 		db.sync() // sync only if needed
@@ -160,7 +167,8 @@ func (db *StorDBService) ServiceName() string {
 
 // ShouldRun returns if the service should be running
 func (db *StorDBService) ShouldRun() bool {
-	return db.cfg.RalsCfg().Enabled || db.cfg.CdrsCfg().Enabled || db.cfg.ApierCfg().Enabled
+	return db.cfg.RalsCfg().Enabled || db.cfg.CdrsCfg().Enabled || db.cfg.ApierCfg().Enabled ||
+		db.cfg.SchedulerCfg().Enabled && db.cfg.SchedulerCfg().ExecHistory
 }
 
 // RegisterSyncChan used by dependent subsystems to register a chanel to reload only the storDB(thread safe)
@@ -198,5 +206,21 @@ func (db *StorDBService) needsConnectionReload() bool {
 		db.oldDBCfg.SSLMode != db.cfg.StorDbCfg().SSLMode {
 		return true
 	}
+	if db.cfg.StorDbCfg().Type == utils.INTERNAL &&
+		db.oldDBCfg.InternalDBDumpPath != db.cfg.StorDbCfg().InternalDBDumpPath {
+		return true
+	}
 	return false
 }
+
+// enablePersistence restores the *internal storDB from disk if configured
+func (db *StorDBService) enablePersistence(d engine.StorDB) (err error) {
+	iDB, isInternal := d.(*engine.InternalDB)
+	if !isInternal ||
+		db.cfg.StorDbCfg().InternalDBDumpPath == utils.EmptyString {
+		return
+	}
+	return iDB.EnablePersistence(db.cfg.StorDbCfg().InternalDBDumpPath,
+		db.cfg.StorDbCfg().InternalDBSnapshotInterval,
+		db.cfg.StorDbCfg().InternalDBSyncWrites)
+}
@@ -299,6 +299,10 @@ func (srvMngr *ServiceManager) handleReload() {
 			if err = srvMngr.reloadService(utils.RateS); err != nil {
 				return
 			}
+		case <-srvMngr.GetConfig().GetReloadChan(config.BillRunSJson):
+			if err = srvMngr.reloadService(utils.BillRunS); err != nil {
+				return
+			}
 		case <-srvMngr.GetConfig().GetReloadChan(config.RPCConnsJsonName):
 			engine.Cache.Clear([]string{utils.CacheRPCConnections})
 		case <-srvMngr.GetConfig().GetReloadChan(config.SIPAgentJson):
@@ -352,6 +352,7 @@ type TPActionPlan struct {
 	TPid       string            // Tariff plan id
 	ID         string            // ActionPlan id
 	ActionPlan []*TPActionTiming // Set of ActionTiming bindings this profile will group
+	CatchUp    string            // Executes the runs missed while the scheduler was down: <""|*once|*all>
 }
 
 type TPActionTiming struct {
@@ -745,6 +746,7 @@ type RPCCDRsFilter struct {
 	MaxUsage               string                 // End of the usage interval (<)
 	OrderBy                string                 // Ascendent/Descendent
 	ExtraArgs              map[string]interface{} // it will contain optional arguments like: OrderIDStart,OrderIDEnd,MinCost and MaxCost
+	Archived               bool                   // query the archived CDRs as well
 	Paginator                                     // Add pagination
 }
 
@@ -1076,6 +1078,8 @@ type TPRouteProfile struct {
 	Sorting            string
 	SortingParameters  []string
 	Routes             []*TPRoute
+	QuarantineRules    []string
+	QuarantineCooldown string
 	Weight             float64
 }
 
@@ -1109,6 +1113,8 @@ type TPChargerProfile struct {
 	ActivationInterval *TPActivationInterval // Time when this limit becomes active and expires
 	RunID              string
 	AttributeIDs       []string
+	Opts               []string // key:value
+	Blocker            bool
 	Weight             float64
 }
 
@@ -1172,6 +1178,28 @@ type AttrRemoteLock struct {
 	Timeout     time.Duration // Automatically unlock on timeout
 }
 
+// ActionExecution is the record of one ActionTiming executed by the Scheduler
+type ActionExecution struct {
+	ActionPlanID string
+	ActionsID    string
+	Timing       string // key of the timing, identifies the ActionTiming together with the ActionPlanID and ActionsID
+	AccountIDs   []string
+	RunTime      time.Time // when the run was due, earlier than Time for the missed runs
+	Time         time.Time // when the actions were executed
+	Error        string    // empty if the execution was successful
+}
+
+// ActionExecutionsFilter is used to query the execution history of the ActionPlans
+type ActionExecutionsFilter struct {
+	ActionPlanIDs []string
+	ActionsIDs    []string
+	Timings       []string
+	Failed        *bool      // filter on the outcome of the execution
+	TimeStart     *time.Time // filter on the execution time
+	TimeEnd       *time.Time
+	Paginator
+}
+
 type SMCostFilter struct { //id cu litere mare
 	CGRIDs         []string
 	NotCGRIDs      []string
@@ -1423,6 +1451,24 @@ type ArgCacheReplicateRemove struct {
 	TenantArg
 }
 
+// ArgsGetCacheChunk requests the next chunk of a cache partition from a peer
+type ArgsGetCacheChunk struct {
+	CacheID  string
+	ExportID string // empty to start a new export
+	Offset   int
+	Limit    int
+	*ArgDispatcher
+	TenantArg
+}
+
+// CacheChunk is a chunk of the items within a cache partition
+type CacheChunk struct {
+	ExportID string
+	Items    []byte // encoded by the exporting CacheS
+	Next     int    // Offset of the next chunk
+	Done     bool
+}
+
 type AttrsExecuteActions struct {
 	ActionPlanID       string
 	TimeStart, TimeEnd time.Time // replay the action timings between the two dates
@@ -56,7 +56,7 @@ var (
 		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses,
 		CacheClosedSessions, CacheCDRIDs, CacheLoadIDs, CacheRPCConnections, CacheRatingProfilesTmp,
 		CacheUCH, CacheSTIR, CacheEventCharges, CacheRateProfiles, CacheRateProfilesFilterIndexes,
-		CacheRateFilterIndexes, CacheReverseFilterIndexes})
+		CacheRateFilterIndexes, CacheReverseFilterIndexes, CacheLookupTables, CacheHTTPLookups})
 	CacheInstanceToPrefix = map[string]string{
 		CacheDestinations:              DESTINATION_PREFIX,
 		CacheReverseDestinations:       REVERSE_DESTINATION_PREFIX,
@@ -81,6 +81,7 @@ var (
 		CacheDispatcherProfiles:        DispatcherProfilePrefix,
 		CacheDispatcherHosts:           DispatcherHostPrefix,
 		CacheRateProfiles:              RateProfilePrefix,
+		CacheLookupTables:              LookupTablePrefix,
 		CacheResourceFilterIndexes:     ResourceFilterIndexes,
 		CacheStatFilterIndexes:         StatFilterIndexes,
 		CacheThresholdFilterIndexes:    ThresholdFilterIndexes,
@@ -122,12 +123,12 @@ var (
 		CacheThresholdFilterIndexes, CacheRouteFilterIndexes, CacheAttributeFilterIndexes,
 		CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs, CacheAccounts,
 		CacheRateProfiles, CacheRateProfilesFilterIndexes, CacheRateFilterIndexes,
-		CacheReverseFilterIndexes})
+		CacheReverseFilterIndexes, CacheLookupTables})
 
 	CacheStorDBPartitions = NewStringSet([]string{TBLTPTimings, TBLTPDestinations, TBLTPRates,
 		TBLTPDestinationRates, TBLTPRatingPlans, TBLTPRatingProfiles, TBLTPSharedGroups,
 		TBLTPActions, TBLTPActionPlans, TBLTPActionTriggers, TBLTPAccountActions, TBLTPResources, TBLTPStats,
-		TBLTPThresholds, TBLTPFilters, SessionCostsTBL, CDRsTBL,
+		TBLTPThresholds, TBLTPFilters, SessionCostsTBL, CDRsTBL, AuditRecordsTBL, ActionExecutionsTBL,
 		TBLTPRoutes, TBLTPAttributes, TBLTPChargers, TBLTPDispatchers, TBLTPDispatcherHosts})
 	// ProtectedSFlds are the fields that sessions should not alter
 	ProtectedSFlds = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage})
@@ -196,11 +197,13 @@ const (
 	AnswerTime                   = "AnswerTime"
 	Usage                        = "Usage"
 	Value                        = "Value"
+	Key                          = "Key"
 	LastUsed                     = "LastUsed"
 	PDD                          = "PDD"
 	SUPPLIER                     = "Supplier"
 	RunID                        = "RunID"
 	AttributeIDs                 = "AttributeIDs"
+	Opts                         = "Opts"
 	MetaReqRunID                 = "*req.RunID"
 	COST                         = "Cost"
 	CostDetails                  = "CostDetails"
@@ -266,9 +269,12 @@ const (
 	DispatcherProfilePrefix      = "dpp_"
 	RateProfilePrefix            = "rtp_"
 	DispatcherHostPrefix         = "dph_"
+	LookupTablePrefix            = "lkt_"
 	ThresholdProfilePrefix       = "thp_"
 	StatQueuePrefix              = "stq_"
 	LoadIDPrefix                 = "lid_"
+	GuardianLockPrefix           = "glk_"
+	CacheInvalidationChannel     = "cache_invalidations"
 	LOADINST_KEY                 = "load_history"
 	CREATE_CDRS_TABLES_SQL       = "create_cdrs_tables.sql"
 	CREATE_TARIFFPLAN_TABLES_SQL = "create_tariffplan_tables.sql"
@@ -334,10 +340,15 @@ const (
 	XML                         = "xml"
 	MetaGOB                     = "*gob"
 	MetaJSON                    = "*json"
+	MetaCSV                     = "*csv"
+	MetaHTML                    = "*html"
 	MetaMSGPACK                 = "*msgpack"
 	MetaDateTime                = "*datetime"
 	MetaMaskedDestination       = "*masked_destination"
 	MetaUnixTimestamp           = "*unix_timestamp"
+	MetaLookup                  = "*lookup"
+	MetaPrefixLookup            = "*prefix_lookup"
+	MetaHTTPLookup              = "*http_lookup"
 	MetaPostCDR                 = "*post_cdr"
 	MetaDumpToFile              = "*dump_to_file"
 	NonTransactional            = ""
@@ -357,6 +368,8 @@ const (
 	SchedulerNotRunningCaps     = "SCHEDULLER_NOT_RUNNING"
 	MetaScheduler               = "*scheduler"
 	MetaSessionsCosts           = "*sessions_costs"
+	MetaAuditRecords            = "*audit_records"
+	MetaActionExecutions        = "*action_executions"
 	MetaRALs                    = "*rals"
 	MetaReplicator              = "*replicator"
 	MetaRerate                  = "*rerate"
@@ -371,12 +384,17 @@ const (
 	MetaDispatcherHosts         = "*dispatcher_hosts"
 	MetaFilters                 = "*filters"
 	MetaCDRs                    = "*cdrs"
+	MetaSMCosts                 = "*sm_costs"
 	MetaDC                      = "*dc"
 	MetaCaches                  = "*caches"
 	MetaCache                   = "*cache"
 	MetaGuardian                = "*guardians"
 	MetaEEs                     = "*ees"
 	MetaRateS                   = "*rates"
+	MetaBillRuns                = "*billruns"
+	MetaDrop                    = "*drop"
+	MetaUpdateIfNewer           = "*update_if_newer"
+	MetaMerge                   = "*merge"
 	MetaContinue                = "*continue"
 	Migrator                    = "migrator"
 	UnsupportedMigrationTask    = "unsupported migration task"
@@ -388,6 +406,7 @@ const (
 	FormSuffix                  = ".form"
 	XMLSuffix                   = ".xml"
 	CSVSuffix                   = ".csv"
+	HTMLSuffix                  = ".html"
 	FWVSuffix                   = ".fwv"
 	CONTENT_JSON                = "json"
 	CONTENT_FORM                = "form"
@@ -419,6 +438,8 @@ const (
 	RateProfiles                = "RateProfiles"
 	MetaEveryMinute             = "*every_minute"
 	MetaHourly                  = "*hourly"
+	CronTZPrefix                = "TZ="
+	CronTZAltPrefix             = "CRON_TZ="
 	ID                          = "ID"
 	Address                     = "Address"
 	Transport                   = "Transport"
@@ -467,6 +488,7 @@ const (
 	BalanceUpdate            = "BalanceUpdate"
 	StatUpdate               = "StatUpdate"
 	ResourceUpdate           = "ResourceUpdate"
+	RouteUpdate              = "RouteUpdate"
 	CDR                      = "CDR"
 	CDRs                     = "CDRs"
 	ExpiryTime               = "ExpiryTime"
@@ -475,6 +497,8 @@ const (
 	Action                   = "Action"
 	MetaNow                  = "*now"
 	SessionSCosts            = "SessionSCosts"
+	AuditRecords             = "AuditRecords"
+	ActionExecutions         = "ActionExecutions"
 	Timing                   = "Timing"
 	RQF                      = "RQF"
 	Resource                 = "Resource"
@@ -498,6 +522,7 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaMulti                = "*multi"
 	Weight                   = "Weight"
 	ThresholdIDs             = "ThresholdIDs"
 	Cost                     = "Cost"
@@ -511,6 +536,7 @@ const (
 	Blocker                  = "Blocker"
 	RatingPlanID             = "RatingPlanID"
 	StartTime                = "StartTime"
+	EndTime                  = "EndTime"
 	AccountSummary           = "AccountSummary"
 	RatingFilters            = "RatingFilters"
 	RatingFilter             = "RatingFilter"
@@ -567,6 +593,9 @@ const (
 	RouteStatIDs             = "RouteStatIDs"
 	RouteWeight              = "RouteWeight"
 	RouteParameters          = "RouteParameters"
+	QuarantineRules          = "QuarantineRules"
+	QuarantineCooldown       = "QuarantineCooldown"
+	Quarantined              = "Quarantined"
 	RouteBlocker             = "RouteBlocker"
 	RouteResourceIDs         = "RouteResourceIDs"
 	RouteFilterIDs           = "RouteFilterIDs"
@@ -692,6 +721,7 @@ const (
 	EEs                      = "EEs"
 	Ratio                    = "Ratio"
 	Load                     = "Load"
+	Score                    = "Score"
 	Slash                    = "/"
 	UUID                     = "UUID"
 	ActionsID                = "ActionsID"
@@ -745,7 +775,17 @@ const (
 	MetaDaily                = "*daily"
 	MetaWeekly               = "*weekly"
 	RateS                    = "RateS"
+	BillRunS                 = "BillRunS"
 	Underline                = "_"
+	RPCAuthz                 = "RPCAuthz"
+	AuditS                   = "AuditS"
+	Caller                   = "Caller"
+	Method                   = "Method"
+	RemoteAddr               = "RemoteAddr"
+	Time                     = "Time"
+	ActionPlanID             = "ActionPlanID"
+	MetaOnce                 = "*once"
+	CatchUp                  = "CatchUp"
 )
 
 // Migrator Action
@@ -771,6 +811,7 @@ const (
 	MetaIndexes             = "*indexes"
 	MetaDispatcherProfiles  = "*dispatcher_profiles"
 	MetaRateProfiles        = "*rate_profiles"
+	MetaLookupTables        = "*lookup_tables"
 	MetaChargerProfiles     = "*charger_profiles"
 	MetaSharedGroups        = "*shared_groups"
 	MetaThresholds          = "*thresholds"
@@ -1011,6 +1052,7 @@ const (
 	ReplicatorSv1GetDispatcherProfile    = "ReplicatorSv1.GetDispatcherProfile"
 	ReplicatorSv1GetRateProfile          = "ReplicatorSv1.GetRateProfile"
 	ReplicatorSv1GetDispatcherHost       = "ReplicatorSv1.GetDispatcherHost"
+	ReplicatorSv1GetLookupTableValue     = "ReplicatorSv1.GetLookupTableValue"
 	ReplicatorSv1GetItemLoadIDs          = "ReplicatorSv1.GetItemLoadIDs"
 	ReplicatorSv1SetThresholdProfile     = "ReplicatorSv1.SetThresholdProfile"
 	ReplicatorSv1SetThreshold            = "ReplicatorSv1.SetThreshold"
@@ -1036,6 +1078,7 @@ const (
 	ReplicatorSv1SetDispatcherProfile    = "ReplicatorSv1.SetDispatcherProfile"
 	ReplicatorSv1SetRateProfile          = "ReplicatorSv1.SetRateProfile"
 	ReplicatorSv1SetDispatcherHost       = "ReplicatorSv1.SetDispatcherHost"
+	ReplicatorSv1SetLookupTable          = "ReplicatorSv1.SetLookupTable"
 	ReplicatorSv1SetLoadIDs              = "ReplicatorSv1.SetLoadIDs"
 	ReplicatorSv1RemoveThreshold         = "ReplicatorSv1.RemoveThreshold"
 	ReplicatorSv1RemoveDestination       = "ReplicatorSv1.RemoveDestination"
@@ -1060,9 +1103,13 @@ const (
 	ReplicatorSv1RemoveDispatcherProfile = "ReplicatorSv1.RemoveDispatcherProfile"
 	ReplicatorSv1RemoveRateProfile       = "ReplicatorSv1.RemoveRateProfile"
 	ReplicatorSv1RemoveDispatcherHost    = "ReplicatorSv1.RemoveDispatcherHost"
+	ReplicatorSv1RemoveLookupTable       = "ReplicatorSv1.RemoveLookupTable"
 	ReplicatorSv1GetIndexes              = "ReplicatorSv1.GetIndexes"
 	ReplicatorSv1SetIndexes              = "ReplicatorSv1.SetIndexes"
 	ReplicatorSv1RemoveIndexes           = "ReplicatorSv1.RemoveIndexes"
+	ReplicatorSv1GetChecksums            = "ReplicatorSv1.GetChecksums"
+	ReplicatorSv1Resync                  = "ReplicatorSv1.Resync"
+	ReplicatorSv1GetReplicationStats     = "ReplicatorSv1.GetReplicationStats"
 )
 
 // APIerSv1 APIs
@@ -1084,6 +1131,11 @@ const (
 	APIerSv1GetEventCost                = "APIerSv1.GetEventCost"
 	APIerSv1LoadTariffPlanFromFolder    = "APIerSv1.LoadTariffPlanFromFolder"
 	APIerSv1ExportToFolder              = "APIerSv1.ExportToFolder"
+	APIerSv1GetAuditRecords             = "APIerSv1.GetAuditRecords"
+	APIerSv1GetActionExecutions         = "APIerSv1.GetActionExecutions"
+	APIerSv1SetLookupTable              = "APIerSv1.SetLookupTable"
+	APIerSv1GetLookupTableValue         = "APIerSv1.GetLookupTableValue"
+	APIerSv1RemoveLookupTable           = "APIerSv1.RemoveLookupTable"
 	APIerSv1GetCost                     = "APIerSv1.GetCost"
 	APIerSv1SetBalance                  = "APIerSv1.SetBalance"
 	APIerSv1GetFilter                   = "APIerSv1.GetFilter"
@@ -1241,6 +1293,8 @@ const (
 	APIerSv1GetTPTimingIds           = "APIerSv1.GetTPTimingIds"
 	APIerSv1LoadTariffPlanFromStorDb = "APIerSv1.LoadTariffPlanFromStorDb"
 	APIerSv1RemoveTPFromFolder       = "APIerSv1.RemoveTPFromFolder"
+	APIerSv1DiffTariffPlanFromFolder = "APIerSv1.DiffTariffPlanFromFolder"
+	APIerSv1DiffTariffPlanFromStorDb = "APIerSv1.DiffTariffPlanFromStorDb"
 )
 
 // APIerSv2 APIs
@@ -1302,6 +1356,17 @@ const (
 	RateSv1Ping         = "RateSv1.Ping"
 )
 
+const (
+	BillRunSv1                 = "BillRunSv1"
+	BillRunSv1Ping             = "BillRunSv1.Ping"
+	BillRunSv1GenerateInvoices = "BillRunSv1.GenerateInvoices"
+)
+
+// AuthSv1 APIs
+const (
+	AuthSv1Authenticate = "AuthSv1.Authenticate"
+)
+
 const (
 	CoreS         = "CoreS"
 	CoreSv1       = "CoreSv1"
@@ -1482,6 +1547,7 @@ const (
 	CacheSv1Ping              = "CacheSv1.Ping"
 	CacheSv1ReplicateSet      = "CacheSv1.ReplicateSet"
 	CacheSv1ReplicateRemove   = "CacheSv1.ReplicateRemove"
+	CacheSv1GetCacheChunk     = "CacheSv1.GetCacheChunk"
 )
 
 // GuardianS APIs
@@ -1497,6 +1563,10 @@ const (
 	CDRsV1                   = "CDRsV1"
 	CDRsV1GetCDRsCount       = "CDRsV1.GetCDRsCount"
 	CDRsV1RateCDRs           = "CDRsV1.RateCDRs"
+	CDRsV1RateCDRsReport     = "CDRsV1.RateCDRsReport"
+	CDRsV1GetDedupStats      = "CDRsV1.GetDedupStats"
+	CDRsV1ArchiveCDRs        = "CDRsV1.ArchiveCDRs"
+	CDRsV1GetCDRsReport      = "CDRsV1.GetCDRsReport"
 	CDRsV1GetCDRs            = "CDRsV1.GetCDRs"
 	CDRsV1ProcessCDR         = "CDRsV1.ProcessCDR"
 	CDRsV1ProcessExternalCDR = "CDRsV1.ProcessExternalCDR"
@@ -1515,13 +1585,17 @@ const (
 	SchedulerSv1Reload             = "SchedulerSv1.Reload"
 	SchedulerSv1ExecuteActions     = "SchedulerSv1.ExecuteActions"
 	SchedulerSv1ExecuteActionPlans = "SchedulerSv1.ExecuteActionPlans"
+	SchedulerSv1GetLeaderStatus    = "SchedulerSv1.GetLeaderStatus"
 )
 
 // EEs
 const (
-	EventExporterSv1             = "EventExporterSv1"
-	EventExporterSv1Ping         = "EventExporterSv1.Ping"
-	EventExporterSv1ProcessEvent = "EventExporterSv1.ProcessEvent"
+	EventExporterSv1                    = "EventExporterSv1"
+	EventExporterSv1Ping                = "EventExporterSv1.Ping"
+	EventExporterSv1ProcessEvent        = "EventExporterSv1.ProcessEvent"
+	EventExporterSv1GetFailedExports    = "EventExporterSv1.GetFailedExports"
+	EventExporterSv1ReplayFailedExports = "EventExporterSv1.ReplayFailedExports"
+	EventExporterSv1PurgeFailedExports  = "EventExporterSv1.PurgeFailedExports"
 )
 
 //cgr_ variables
@@ -1564,6 +1638,7 @@ const (
 	DispatcherProfilesCsv = "DispatcherProfiles.csv"
 	DispatcherHostsCsv    = "DispatcherHosts.csv"
 	RateProfilesCsv       = "RateProfiles.csv"
+	LookupTablesCsv       = "LookupTables.csv"
 )
 
 // Table Name
@@ -1584,6 +1659,8 @@ const (
 	TBLTPThresholds       = "tp_thresholds"
 	TBLTPFilters          = "tp_filters"
 	SessionCostsTBL       = "session_costs"
+	AuditRecordsTBL       = "audit_records"
+	ActionExecutionsTBL   = "action_executions"
 	CDRsTBL               = "cdrs"
 	TBLTPRoutes           = "tp_routes"
 	TBLTPAttributes       = "tp_attributes"
@@ -1624,6 +1701,7 @@ const (
 	CacheDispatcherRoutes          = "*dispatcher_routes"
 	CacheDispatcherLoads           = "*dispatcher_loads"
 	CacheRateProfiles              = "*rate_profiles"
+	CacheLookupTables              = "*lookup_tables"
 	CacheResourceFilterIndexes     = "*resource_filter_indexes"
 	CacheStatFilterIndexes         = "*stat_filter_indexes"
 	CacheThresholdFilterIndexes    = "*threshold_filter_indexes"
@@ -1645,6 +1723,7 @@ const (
 	CacheRatingProfilesTmp         = "*tmp_rating_profiles"
 	CacheUCH                       = "*uch"
 	CacheSTIR                      = "*stir"
+	CacheHTTPLookups               = "*http_lookups"
 	CacheEventCharges              = "*event_charges"
 	CacheReverseFilterIndexes      = "*reverse_filter_indexes"
 )
@@ -1685,6 +1764,19 @@ const (
 	KafkaTopic   = "topic"
 	KafkaGroupID = "group_id"
 	KafkaMaxWait = "max_wait"
+
+	KafkaManualCommit       = "manual_commit"
+	KafkaDeadLetterTopic    = "dead_letter_topic"
+	KafkaProcessingAttempts = "processing_attempts"
+
+	AWSToken         = "aws_token"
+	QueueID          = "queue_id"
+	AMQPExchange     = "exchange"
+	AMQPExchangeType = "exchange_type"
+	AMQPRoutingKey   = "routing_key"
+	AMQPConsumerTag  = "consumer_tag"
+	DeadLetterQueue  = "dead_letter_queue"
+	S3FolderPath     = "folder_path"
 )
 
 // Google_API
@@ -1727,6 +1819,7 @@ const (
 	ConnectTimeoutCfg    = "connect_timeout"
 	ReplyTimeoutCfg      = "reply_timeout"
 	LockingTimeoutCfg    = "locking_timeout"
+	LockingBackendCfg    = "locking_backend"
 	DigestSeparatorCfg   = "digest_separator"
 	DigestEqualCfg       = "digest_equal"
 	RSRSepCfg            = "rsr_separator"
@@ -1744,6 +1837,12 @@ const (
 	QueryTimeoutCfg        = "query_timeout"
 	SSLModeCfg             = "sslmode"
 	ItemsCfg               = "items"
+
+	InternalDBDumpPathCfg         = "internal_db_dump_path"
+	InternalDBSnapshotIntervalCfg = "internal_db_snapshot_interval"
+	InternalDBSyncWritesCfg       = "internal_db_sync_writes"
+	ReplicationQueuePathCfg       = "replication_queue_path"
+	ReplicationRetryIntervalCfg   = "replication_retry_interval"
 )
 
 // DataDbCfg
@@ -1820,8 +1919,11 @@ const (
 
 // SchedulerCfg
 const (
-	CDRsConnsCfg = "cdrs_conns"
-	FiltersCfg   = "filters"
+	CDRsConnsCfg      = "cdrs_conns"
+	FiltersCfg        = "filters"
+	LeaderElectionCfg = "leader_election"
+	LeaderLeaseTTLCfg = "leader_lease_ttl"
+	ExecHistoryCfg    = "execution_history"
 )
 
 // CdrsCfg
@@ -1832,6 +1934,17 @@ const (
 	ChargerSConnsCfg    = "chargers_conns"
 	AttributeSConnsCfg  = "attributes_conns"
 	OnlineCDRExportsCfg = "online_cdr_exports"
+	DedupPolicyCfg      = "dedup_policy"
+	DedupFieldsCfg      = "dedup_fields"
+	DedupWindowCfg      = "dedup_window"
+	DedupNewerFieldCfg  = "dedup_newer_field"
+	ArchiveCfg          = "archive"
+	RunIntervalCfg      = "run_interval"
+	ArchivePathCfg      = "archive_path"
+	FormatCfg           = "format"
+	MaxFileSizeCfg      = "max_file_size"
+	RulesCfg            = "rules"
+	MaxAgeCfg           = "max_age"
 )
 
 // SessionSCfg
@@ -1927,6 +2040,14 @@ const (
 	IndexedSelectsCfg = "indexed_selects"
 	ProcessRunsCfg    = "process_runs"
 	NestedFieldsCfg   = "nested_fields"
+	HTTPLookupsCfg    = "http_lookups"
+	MethodCfg         = "method"
+	QueryParamsCfg    = "query_params"
+	BodyCfg           = "body"
+	ReplyPathCfg      = "reply_path"
+	TimeoutCfg        = "timeout"
+	FallbackCfg       = "fallback"
+	FallbackTTLCfg    = "fallback_ttl"
 
 	// ChargerSCfg
 	StoreIntervalCfg = "store_interval"
@@ -1935,8 +2056,11 @@ const (
 	StoreUncompressedLimitCfg = "store_uncompressed_limit"
 
 	// Cache
-	PartitionsCfg = "partitions"
-	PrecacheCfg   = "precache"
+	PartitionsCfg         = "partitions"
+	PrecacheCfg           = "precache"
+	RemoteInvalidationCfg = "remote_invalidation"
+	PrecacheConnsCfg      = "precache_conns"
+	PrecacheChunkSizeCfg  = "precache_chunk_size"
 
 	// CdreCfg
 	ExportFormatCfg      = "export_format"
@@ -1944,16 +2068,32 @@ const (
 	AttributeSContextCfg = "attributes_context"
 	SynchronousCfg       = "synchronous"
 	AttemptsCfg          = "attempts"
+	RetriesCfg           = "retries"
+	RetryIntervalCfg     = "retry_interval"
+	FailedExportsDirCfg  = "failed_exports_dir"
+	HTMLTemplateCfg      = "html_template"
+	RunIDsCfg            = "run_ids"
 	AttributeContextCfg  = "attribute_context"
 	AttributeIDsCfg      = "attribute_ids"
 
+	// RPCAuthzCfg
+	DefaultRoleCfg = "default_role"
+	RolesCfg       = "roles"
+	TokensCfg      = "tokens"
+	UsersCfg       = "users"
+
+	// ApierCfg
+	AuditCfg        = "audit"
+	AuditMethodsCfg = "audit_methods"
+
 	//LoaderSCfg
-	IdCfg           = "id"
-	DryRunCfg       = "dry_run"
-	LockFileNameCfg = "lock_filename"
-	TpInDirCfg      = "tp_in_dir"
-	TpOutDirCfg     = "tp_out_dir"
-	DataCfg         = "data"
+	IdCfg            = "id"
+	DryRunCfg        = "dry_run"
+	TransactionalCfg = "transactional"
+	LockFileNameCfg  = "lock_filename"
+	TpInDirCfg       = "tp_in_dir"
+	TpOutDirCfg      = "tp_out_dir"
+	DataCfg          = "data"
 
 	DefaultRatioCfg            = "default_ratio"
 	ReadersCfg                 = "readers"
@@ -2207,6 +2347,9 @@ const (
 // Event Opts
 const (
 	OptsRatesStartTime = "*ratesStartTime"
+	OptsRequestType    = "*requestType"
+	OptsRatingSubject  = "*ratingSubject"
+	OptsInformational  = "*informational"
 )
 
 func buildCacheInstRevPrefixes() {
@@ -305,6 +305,30 @@ func SplitPrefix(prefix string, minLength int) []string {
 	return subs
 }
 
+// IsCronExpr returns true if the timing is defined as cron expression, eg: "0 0 1 * *"
+func IsCronExpr(tm string) bool {
+	return len(strings.Fields(tm)) > 1
+}
+
+// SplitCronTZ splits the optional time zone out of the cron expression, eg: "TZ=Europe/Berlin 0 0 1 * *"
+func SplitCronTZ(cron string) (expr, tz string) {
+	expr = strings.TrimSpace(cron)
+	for _, prfx := range []string{CronTZPrefix, CronTZAltPrefix} {
+		if !strings.HasPrefix(expr, prfx) {
+			continue
+		}
+		flds := strings.SplitN(expr, " ", 2)
+		tz = strings.TrimPrefix(flds[0], prfx)
+		if len(flds) == 2 {
+			expr = strings.TrimSpace(flds[1])
+		} else {
+			expr = EmptyString
+		}
+		break
+	}
+	return
+}
+
 func CopyHour(src, dest time.Time) time.Time {
 	if src.Hour() == 0 && src.Minute() == 0 && src.Second() == 0 {
 		return src
@@ -520,6 +520,37 @@ func TestSplitPrefix(t *testing.T) {
 	}
 }
 
+func TestIsCronExpr(t *testing.T) {
+	for tm, exp := range map[string]bool{
+		"0 0 1 * *":                          true,
+		"*/15 9-17 * * 1-5":                  true,
+		"TZ=Europe/Berlin 0 0 L * *":         true,
+		"00:00:00":                           false,
+		ASAP:                                 false,
+		MetaEveryMinute:                      false,
+		EmptyString:                          false,
+		"TZ=Europe/Berlin":                   false,
+		"CRON_TZ=America/New_York 0 8 * * *": true,
+	} {
+		if rcv := IsCronExpr(tm); rcv != exp {
+			t.Errorf("For %q expecting: %v, received: %v", tm, exp, rcv)
+		}
+	}
+}
+
+func TestSplitCronTZ(t *testing.T) {
+	for cron, exp := range map[string][2]string{
+		"0 0 1 * *":                            {"0 0 1 * *", ""},
+		" TZ=Europe/Berlin 0 0 L * * ":         {"0 0 L * *", "Europe/Berlin"},
+		"CRON_TZ=America/New_York 0 8 * * 1-5": {"0 8 * * 1-5", "America/New_York"},
+		"TZ=UTC":                               {"", "UTC"},
+	} {
+		if expr, tz := SplitCronTZ(cron); expr != exp[0] || tz != exp[1] {
+			t.Errorf("For %q expecting: %q, %q, received: %q, %q", cron, exp[0], exp[1], expr, tz)
+		}
+	}
+}
+
 func TestCopyHour(t *testing.T) {
 	var src, dst, eOut time.Time
 	if rcv := CopyHour(src, dst); !reflect.DeepEqual(rcv, eOut) {
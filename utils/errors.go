@@ -70,6 +70,8 @@ var (
 	ErrMaxIncrementsExceeded    = errors.New("MAX_INCREMENTS_EXCEEDED")
 	ErrIndexOutOfBounds         = errors.New("INDEX_OUT_OF_BOUNDS")
 	ErrWrongPath                = errors.New("WRONG_PATH")
+	ErrRemoteLockUnavailable    = errors.New("REMOTE_LOCK_UNAVAILABLE")
+	ErrRemoteLockLost           = errors.New("REMOTE_LOCK_LOST")
 	ErrServiceAlreadyRunning    = fmt.Errorf("service already running")
 
 	ErrMap = map[string]error{
@@ -109,6 +111,8 @@ var (
 		ErrMaxIncrementsExceeded.Error():   ErrMaxIncrementsExceeded,
 		ErrIndexOutOfBounds.Error():        ErrIndexOutOfBounds,
 		ErrWrongPath.Error():               ErrWrongPath,
+		ErrRemoteLockUnavailable.Error():   ErrRemoteLockUnavailable,
+		ErrRemoteLockLost.Error():          ErrRemoteLockLost,
 	}
 )
 
@@ -152,6 +156,10 @@ func NewErrMandatoryIeMissing(fields ...string) error {
 	return fmt.Errorf("MANDATORY_IE_MISSING: %v", fields)
 }
 
+func NewErrLoadTransaction(errs []string) error {
+	return fmt.Errorf("LOAD_TRANSACTION_FAILED: %s", strings.Join(errs, "; "))
+}
+
 func NewErrServerError(err error) error {
 	return fmt.Errorf("SERVER_ERROR: %s", err)
 }
@@ -159,6 +159,11 @@ func (c *jsonServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
 	return c.enc.Encode(resp)
 }
 
+// requestMethod returns the method as received, before redirecting the APIer methods
+func (c *jsonServerCodec) requestMethod() string {
+	return c.req.Method
+}
+
 func (c *jsonServerCodec) Close() error {
 	return c.c.Close()
 }
@@ -0,0 +1,213 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package utils
+
+import (
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+)
+
+// AuditRecord is one administrative call recorded by the audit trail
+type AuditRecord struct {
+	Caller     string // HTTP user or API token fingerprint, empty if not authenticated
+	Role       string // role of the caller
+	RemoteAddr string
+	Method     string
+	ArgsDigest string // SHA1 of the JSON encoded arguments
+	Result     string // OK or the error returned
+	Time       time.Time
+}
+
+// AuditRecordsFilter is used to query the audit trail
+type AuditRecordsFilter struct {
+	Callers     []string // filter on the callers
+	Methods     []string // filter on the methods
+	RemoteAddrs []string // filter on the remote addresses
+	TimeStart   *time.Time
+	TimeEnd     *time.Time
+	Paginator
+}
+
+// RPCAuditor receives the calls recorded by the audit trail
+type RPCAuditor interface {
+	AuditMethod(method string) bool // returns true if the method needs to be recorded
+	Audit(rec *AuditRecord)         // called with the result before the response is sent
+}
+
+// newAuditServerCodec wraps the codec so the audited calls reach the auditor
+func newAuditServerCodec(codec rpc.ServerCodec, auditor RPCAuditor,
+	caller, role, remote string) rpc.ServerCodec {
+	return &auditServerCodec{
+		ServerCodec: codec,
+		auditor:     auditor,
+		caller:      caller,
+		role:        role,
+		remote:      remote,
+		pending:     make(map[uint64]*AuditRecord),
+	}
+}
+
+// auditServerCodec builds the AuditRecord out of the request and the response
+type auditServerCodec struct {
+	rpc.ServerCodec
+	auditor RPCAuditor
+	caller  string
+	role    string
+	remote  string
+
+	rec     *AuditRecord // record of the request being read
+	mux     sync.Mutex   // protects pending
+	pending map[uint64]*AuditRecord
+}
+
+func (c *auditServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.ServerCodec.ReadRequestHeader(r); err != nil {
+		return
+	}
+	c.rec = nil
+	method := requestMethod(c.ServerCodec, r)
+	if !c.auditor.AuditMethod(method) {
+		return
+	}
+	c.rec = &AuditRecord{
+		Caller:     c.caller,
+		Role:       c.role,
+		RemoteAddr: c.remote,
+		Method:     method,
+		Time:       time.Now(),
+	}
+	if cc, canCast := c.ServerCodec.(callerCodec); canCast { // authenticated on this connection
+		c.rec.Caller, c.rec.Role = cc.callerIdentity()
+	}
+	c.mux.Lock()
+	c.pending[r.Seq] = c.rec
+	c.mux.Unlock()
+	return
+}
+
+func (c *auditServerCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.ServerCodec.ReadRequestBody(x); err != nil ||
+		c.rec == nil || x == nil {
+		return
+	}
+	args := x
+	if mp, canCast := x.(*MethodParameters); canCast { // dispatched APIer call
+		args = mp.Parameters
+	}
+	c.rec.ArgsDigest = Sha1(ToJSON(args))
+	return
+}
+
+func (c *auditServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.mux.Lock()
+	rec, has := c.pending[r.Seq]
+	delete(c.pending, r.Seq)
+	c.mux.Unlock()
+	if has {
+		rec.Result = OK
+		if r.Error != EmptyString {
+			rec.Result = r.Error
+		}
+		c.auditor.Audit(rec)
+	}
+	return c.ServerCodec.WriteResponse(r, x)
+}
+
+// newAuditBiRPCCodec wraps the bidirectional codec so the audited requests reach the auditor
+func newAuditBiRPCCodec(codec rpc2.Codec, auditor RPCAuditor, role, remote string) rpc2.Codec {
+	return &auditBiRPCCodec{
+		Codec:   codec,
+		auditor: auditor,
+		role:    role,
+		remote:  remote,
+		pending: make(map[uint64]*AuditRecord),
+	}
+}
+
+// auditBiRPCCodec audits only the requests, the responses to our own requests are passed as they are
+type auditBiRPCCodec struct {
+	rpc2.Codec
+	auditor RPCAuditor
+	role    string
+	remote  string
+
+	rec     *AuditRecord // record of the request being read
+	recSeq  uint64
+	mux     sync.Mutex // protects pending
+	pending map[uint64]*AuditRecord
+}
+
+func (c *auditBiRPCCodec) ReadHeader(req *rpc2.Request, resp *rpc2.Response) (err error) {
+	if err = c.Codec.ReadHeader(req, resp); err != nil {
+		return
+	}
+	c.rec = nil
+	if req.Method == EmptyString || // response to one of our requests
+		!c.auditor.AuditMethod(req.Method) {
+		return
+	}
+	c.rec = &AuditRecord{
+		Role:       c.role,
+		RemoteAddr: c.remote,
+		Method:     req.Method,
+		Time:       time.Now(),
+	}
+	if cc, canCast := c.Codec.(callerCodec); canCast { // authenticated on this connection
+		c.rec.Caller, c.rec.Role = cc.callerIdentity()
+	}
+	c.recSeq = req.Seq
+	if req.Seq != 0 {
+		c.mux.Lock()
+		c.pending[req.Seq] = c.rec
+		c.mux.Unlock()
+	}
+	return
+}
+
+func (c *auditBiRPCCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.Codec.ReadRequestBody(x); err != nil ||
+		c.rec == nil {
+		return
+	}
+	if x != nil {
+		c.rec.ArgsDigest = Sha1(ToJSON(x))
+	}
+	if c.recSeq == 0 { // notifications have no response
+		c.rec.Result = OK
+		c.auditor.Audit(c.rec)
+	}
+	return
+}
+
+func (c *auditBiRPCCodec) WriteResponse(r *rpc2.Response, x interface{}) error {
+	c.mux.Lock()
+	rec, has := c.pending[r.Seq]
+	delete(c.pending, r.Seq)
+	c.mux.Unlock()
+	if has {
+		rec.Result = OK
+		if r.Error != EmptyString {
+			rec.Result = r.Error
+		}
+		c.auditor.Audit(rec)
+	}
+	return c.Codec.WriteResponse(r, x)
+}
@@ -0,0 +1,149 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package utils
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/rpc2"
+	rpc2_jsonrpc "github.com/cenkalti/rpc2/jsonrpc"
+)
+
+type testRPCAuditor struct {
+	sync.Mutex
+	recs []*AuditRecord
+}
+
+func (a *testRPCAuditor) AuditMethod(method string) bool {
+	return RPCMethodMatches([]string{"*.Remove*"}, method)
+}
+
+func (a *testRPCAuditor) Audit(rec *AuditRecord) {
+	a.Lock()
+	a.recs = append(a.recs, rec)
+	a.Unlock()
+}
+
+func TestRPCAuditServerCodec(t *testing.T) {
+	auditor := new(testRPCAuditor)
+	srv := NewServer()
+	srv.SetAuthorizer(newTestRPCAuthorizer())
+	srv.SetAuditor(auditor)
+	srvConn, cliConn := net.Pipe()
+	go rpc.ServeCodec(srv.wrapCodec(jsonrpc.NewServerCodec(srvConn),
+		"", "readonly", "pipe"))
+	client := jsonrpc.NewClient(cliConn)
+	defer client.Close()
+	testRPCAuthzClient(t, client)
+
+	auditor.Lock()
+	defer auditor.Unlock()
+	// the denied call is only logged by the authorizer
+	if len(auditor.recs) != 1 {
+		t.Fatalf("Expected one record, received: %s", ToJSON(auditor.recs))
+	}
+	rec := auditor.recs[0]
+	if rec.Time.IsZero() {
+		t.Error("Expected the time of the call")
+	}
+	eRec := &AuditRecord{
+		Caller:     tokenCaller("token1"),
+		Role:       "admin",
+		RemoteAddr: "pipe",
+		Method:     "AuthzTestSv1.RemoveValue",
+		ArgsDigest: Sha1(ToJSON("val1")),
+		Result:     OK,
+		Time:       rec.Time,
+	}
+	if ToJSON(eRec) != ToJSON(rec) {
+		t.Errorf("Expected: %s, received: %s", ToJSON(eRec), ToJSON(rec))
+	}
+}
+
+func TestRPCAuditServerCodecError(t *testing.T) {
+	auditor := new(testRPCAuditor)
+	srvConn, cliConn := net.Pipe()
+	go rpc.ServeCodec(newAuditServerCodec(jsonrpc.NewServerCodec(srvConn),
+		auditor, "user1", "", "pipe"))
+	client := jsonrpc.NewClient(cliConn)
+	defer client.Close()
+	var reply string
+	if err := client.Call("AuthzTestSv1.RemoveMissing", "val1", &reply); err == nil {
+		t.Error("Expected error")
+	}
+	auditor.Lock()
+	defer auditor.Unlock()
+	if len(auditor.recs) != 1 {
+		t.Fatalf("Expected one record, received: %s", ToJSON(auditor.recs))
+	} else if rec := auditor.recs[0]; rec.Caller != "user1" ||
+		rec.Result != "rpc: can't find method AuthzTestSv1.RemoveMissing" {
+		t.Errorf("Received: %s", ToJSON(rec))
+	}
+}
+
+func TestRPCAuditBiRPCCodec(t *testing.T) {
+	auditor := new(testRPCAuditor)
+	srv := NewServer()
+	srv.SetAuthorizer(newTestRPCAuthorizer())
+	srv.SetAuditor(auditor)
+	biSrv := rpc2.NewServer()
+	biSrv.Handle("SessionSv1.RemoveValue", func(c *rpc2.Client, args string, reply *string) error {
+		*reply = OK
+		return nil
+	})
+	srvConn, cliConn := net.Pipe()
+	go biSrv.ServeCodec(srv.wrapBiRPCCodec(rpc2_jsonrpc.NewJSONCodec(srvConn), "pipe"))
+	client := rpc2.NewClientWithCodec(rpc2_jsonrpc.NewJSONCodec(cliConn))
+	go client.Run()
+	defer client.Close()
+	var reply string
+	if err := client.Call("SessionSv1.RemoveValue", "val1", &reply); err == nil ||
+		err.Error() != ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", ErrUnauthorizedApi, err)
+	}
+	if err := client.Call(AuthSv1Authenticate, "token1", &reply); err != nil {
+		t.Error(err)
+	}
+	if err := client.Call("SessionSv1.RemoveValue", "val1", &reply); err != nil {
+		t.Error(err)
+	}
+
+	auditor.Lock()
+	defer auditor.Unlock()
+	// the denied call is only logged by the authorizer
+	if len(auditor.recs) != 1 {
+		t.Fatalf("Expected one record, received: %s", ToJSON(auditor.recs))
+	}
+	rec := auditor.recs[0]
+	eRec := &AuditRecord{
+		Caller:     tokenCaller("token1"),
+		Role:       "admin",
+		RemoteAddr: "pipe",
+		Method:     "SessionSv1.RemoveValue",
+		ArgsDigest: Sha1(ToJSON("val1")),
+		Result:     OK,
+		Time:       rec.Time,
+	}
+	if ToJSON(eRec) != ToJSON(rec) {
+		t.Errorf("Expected: %s, received: %s", ToJSON(eRec), ToJSON(rec))
+	}
+}
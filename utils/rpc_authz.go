@@ -0,0 +1,271 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/rpc"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cenkalti/rpc2"
+)
+
+// NewRPCAuthorizer returns the authorizer enforcing the role based access on the RPC listeners
+// roles map the role name to the allowed method patterns(ie: *.Get*, SessionSv1.*)
+// tokens map the API tokens to roles while users map the HTTP basic auth users to roles
+// with their credentials checked against the httpUsers(base64 passwords)
+func NewRPCAuthorizer(defaultRole string, roles map[string][]string,
+	tokens, users, httpUsers map[string]string) *RPCAuthorizer {
+	return &RPCAuthorizer{
+		defaultRole: defaultRole,
+		roles:       roles,
+		tokens:      tokens,
+		users:       users,
+		httpUsers:   httpUsers,
+	}
+}
+
+// RPCAuthorizer checks the RPC methods against the allow-list of the caller role
+type RPCAuthorizer struct {
+	defaultRole string              // role of the connections not authenticated
+	roles       map[string][]string // role -> allowed method patterns
+	tokens      map[string]string   // API token -> role
+	users       map[string]string   // HTTP user -> role
+	httpUsers   map[string]string   // HTTP user -> base64 password
+}
+
+// Authorized returns true if the role is allowed to call the method
+func (a *RPCAuthorizer) Authorized(role, method string) bool {
+	return method == AuthSv1Authenticate ||
+		RPCMethodMatches(a.roles[role], method)
+}
+
+// RPCMethodMatches returns true if the method matches one of the patterns(ie: *.Get*, SessionSv1.*)
+func RPCMethodMatches(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, method); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenRole returns the role of the API token
+func (a *RPCAuthorizer) tokenRole(token string) (role string, has bool) {
+	role, has = a.tokens[token]
+	return
+}
+
+// tokenCaller identifies the caller of an API token without exposing the token
+func tokenCaller(token string) string {
+	return "token:" + Sha1(token)[:8]
+}
+
+// httpIdentity returns the caller and its role based on the Authorization header of the HTTP request
+// accepting both basic auth and bearer tokens
+// the basic auth users with invalid credentials get the default role
+func (a *RPCAuthorizer) httpIdentity(r *http.Request) (caller, role string) {
+	if user, pass, has := r.BasicAuth(); has {
+		if !verifyCredential(user, pass, a.httpUsers) {
+			return EmptyString, a.defaultRole
+		}
+		if role, has := a.users[user]; has {
+			return user, role
+		}
+		return user, a.defaultRole
+	}
+	authHeader := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(authHeader) == 2 && strings.EqualFold(authHeader[0], "Bearer") {
+		if role, has := a.tokenRole(authHeader[1]); has {
+			return tokenCaller(authHeader[1]), role
+		}
+	}
+	return EmptyString, a.defaultRole
+}
+
+// logDenied writes the audit log for the denied calls
+func (a *RPCAuthorizer) logDenied(method, role, remote string) {
+	Logger.Warning(fmt.Sprintf("<%s> denied call to <%s> for role <%s> from <%s>",
+		RPCAuthz, method, role, remote))
+}
+
+// authenticate returns the caller and the role of the token or error if the token is not known
+func (a *RPCAuthorizer) authenticate(token, remote string) (caller, role string, err error) {
+	var has bool
+	if role, has = a.tokenRole(token); !has {
+		Logger.Warning(fmt.Sprintf("<%s> failed authentication from <%s>", RPCAuthz, remote))
+		return EmptyString, EmptyString, ErrUnauthorizedApi
+	}
+	return tokenCaller(token), role, nil
+}
+
+// NewServerCodec wraps the codec so the requests are checked against the role
+func (a *RPCAuthorizer) NewServerCodec(codec rpc.ServerCodec, caller, role, remote string) rpc.ServerCodec {
+	return &authzServerCodec{
+		ServerCodec: codec,
+		authz:       a,
+		caller:      caller,
+		role:        role,
+		remote:      remote,
+	}
+}
+
+// NewBiRPCCodec wraps the bidirectional codec so the requests are checked against the role
+func (a *RPCAuthorizer) NewBiRPCCodec(codec rpc2.Codec, role, remote string) rpc2.Codec {
+	return &authzBiRPCCodec{
+		Codec:  codec,
+		authz:  a,
+		role:   role,
+		remote: remote,
+	}
+}
+
+// requestMethodCodec is implemented by the codecs that rewrite the ServiceMethod
+type requestMethodCodec interface {
+	requestMethod() string
+}
+
+// callerCodec is implemented by the codecs that authenticate the caller
+type callerCodec interface {
+	callerIdentity() (caller, role string)
+}
+
+// requestMethod returns the method as received from the client
+func requestMethod(codec rpc.ServerCodec, r *rpc.Request) string {
+	if mc, canCast := codec.(requestMethodCodec); canCast {
+		return mc.requestMethod()
+	}
+	return r.ServiceMethod
+}
+
+// authzServerCodec answers itself the denied requests so they never reach the service
+type authzServerCodec struct {
+	rpc.ServerCodec
+	authz  *RPCAuthorizer
+	caller string
+	role   string
+	remote string
+	method string     // method of the last request read
+	wrMux  sync.Mutex // the rpc server only locks its own responses
+}
+
+func (c *authzServerCodec) requestMethod() string {
+	return c.method
+}
+
+func (c *authzServerCodec) callerIdentity() (caller, role string) {
+	return c.caller, c.role
+}
+
+func (c *authzServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	for {
+		if err = c.ServerCodec.ReadRequestHeader(r); err != nil {
+			return
+		}
+		c.method = requestMethod(c.ServerCodec, r)
+		if c.method == AuthSv1Authenticate {
+			var token string
+			if err = c.ServerCodec.ReadRequestBody(&token); err != nil {
+				return
+			}
+			resp := &rpc.Response{ServiceMethod: r.ServiceMethod, Seq: r.Seq}
+			if caller, role, errAuth := c.authz.authenticate(token, c.remote); errAuth != nil {
+				resp.Error = errAuth.Error()
+			} else {
+				c.caller, c.role = caller, role
+			}
+			if err = c.WriteResponse(resp, OK); err != nil {
+				return
+			}
+			continue
+		}
+		if c.authz.Authorized(c.role, c.method) {
+			return
+		}
+		c.authz.logDenied(c.method, c.role, c.remote)
+		if err = c.ServerCodec.ReadRequestBody(nil); err != nil {
+			return
+		}
+		if err = c.WriteResponse(&rpc.Response{
+			ServiceMethod: r.ServiceMethod,
+			Seq:           r.Seq,
+			Error:         ErrUnauthorizedApi.Error(),
+		}, struct{}{}); err != nil {
+			return
+		}
+	}
+}
+
+func (c *authzServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.wrMux.Lock()
+	defer c.wrMux.Unlock()
+	return c.ServerCodec.WriteResponse(r, x)
+}
+
+// authzBiRPCCodec checks only the requests, the responses to our own requests are passed as they are
+type authzBiRPCCodec struct {
+	rpc2.Codec
+	authz  *RPCAuthorizer
+	caller string
+	role   string
+	remote string
+}
+
+func (c *authzBiRPCCodec) callerIdentity() (caller, role string) {
+	return c.caller, c.role
+}
+
+func (c *authzBiRPCCodec) ReadHeader(req *rpc2.Request, resp *rpc2.Response) (err error) {
+	for {
+		if err = c.Codec.ReadHeader(req, resp); err != nil ||
+			req.Method == "" { // response to one of our requests
+			return
+		}
+		var reply *rpc2.Response
+		if req.Method == AuthSv1Authenticate {
+			var token string
+			if err = c.Codec.ReadRequestBody(&token); err != nil {
+				return
+			}
+			reply = &rpc2.Response{Seq: req.Seq}
+			if caller, role, errAuth := c.authz.authenticate(token, c.remote); errAuth != nil {
+				reply.Error = errAuth.Error()
+			} else {
+				c.caller, c.role = caller, role
+			}
+		} else if c.authz.Authorized(c.role, req.Method) {
+			return
+		} else {
+			c.authz.logDenied(req.Method, c.role, c.remote)
+			if err = c.Codec.ReadRequestBody(nil); err != nil {
+				return
+			}
+			reply = &rpc2.Response{Seq: req.Seq, Error: ErrUnauthorizedApi.Error()}
+		}
+		if req.Seq != 0 { // notifications do not expect a reply
+			if err = c.Codec.WriteResponse(reply, OK); err != nil {
+				return
+			}
+		}
+		*req = rpc2.Request{}
+		*resp = rpc2.Response{}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package utils
+
+import (
+	"net"
+	"net/http"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"testing"
+
+	"github.com/cenkalti/rpc2"
+	rpc2_jsonrpc "github.com/cenkalti/rpc2/jsonrpc"
+)
+
+type authzTestService struct{}
+
+func (authzTestService) GetValue(args string, reply *string) error {
+	*reply = args
+	return nil
+}
+
+func (authzTestService) RemoveValue(args string, reply *string) error {
+	*reply = OK
+	return nil
+}
+
+func init() {
+	rpc.RegisterName("AuthzTestSv1", new(authzTestService))
+}
+
+func newTestRPCAuthorizer() *RPCAuthorizer {
+	return NewRPCAuthorizer("readonly",
+		map[string][]string{
+			"readonly": {"*.Get*"},
+			"admin":    {"*"},
+			"sessions": {"SessionSv1.*"},
+		},
+		map[string]string{"token1": "admin"},
+		map[string]string{"user1": "sessions"},
+		map[string]string{"user1": "cGFzcw=="})
+}
+
+func TestRPCAuthorizerAuthorized(t *testing.T) {
+	authz := newTestRPCAuthorizer()
+	for _, tc := range []struct {
+		role, method string
+		exp          bool
+	}{
+		{"readonly", "APIerSv1.GetAccount", true},
+		{"readonly", "APIerSv1.RemoveAccount", false},
+		{"admin", "APIerSv1.RemoveAccount", true},
+		{"sessions", "SessionSv1.AuthorizeEvent", true},
+		{"sessions", "APIerSv1.GetAccount", false},
+		{"", "APIerSv1.GetAccount", false},
+		{"", AuthSv1Authenticate, true},
+	} {
+		if rcv := authz.Authorized(tc.role, tc.method); rcv != tc.exp {
+			t.Errorf("role: %s, method: %s expected: %v, received: %v", tc.role, tc.method, tc.exp, rcv)
+		}
+	}
+}
+
+func TestRPCAuthorizerHTTPIdentity(t *testing.T) {
+	authz := newTestRPCAuthorizer()
+	r, _ := http.NewRequest(http.MethodPost, "/jsonrpc", nil)
+	if caller, role := authz.httpIdentity(r); caller != "" || role != "readonly" {
+		t.Errorf("Expected readonly, received: %s, %s", caller, role)
+	}
+	r.SetBasicAuth("user1", "wrong")
+	if caller, role := authz.httpIdentity(r); caller != "" || role != "readonly" {
+		t.Errorf("Expected readonly, received: %s, %s", caller, role)
+	}
+	r.SetBasicAuth("user1", "pass")
+	if caller, role := authz.httpIdentity(r); caller != "user1" || role != "sessions" {
+		t.Errorf("Expected user1 with sessions, received: %s, %s", caller, role)
+	}
+	r.Header.Set("Authorization", "Bearer token1")
+	if caller, role := authz.httpIdentity(r); caller != tokenCaller("token1") || role != "admin" {
+		t.Errorf("Expected %s with admin, received: %s, %s", tokenCaller("token1"), caller, role)
+	}
+	r.Header.Set("Authorization", "Bearer token2")
+	if caller, role := authz.httpIdentity(r); caller != "" || role != "readonly" {
+		t.Errorf("Expected readonly, received: %s, %s", caller, role)
+	}
+}
+
+func testRPCAuthzClient(t *testing.T, client *rpc.Client) {
+	var reply string
+	if err := client.Call("AuthzTestSv1.GetValue", "val1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != "val1" {
+		t.Errorf("Expected val1, received: %s", reply)
+	}
+	if err := client.Call("AuthzTestSv1.RemoveValue", "val1", &reply); err == nil ||
+		err.Error() != ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", ErrUnauthorizedApi, err)
+	}
+	if err := client.Call(AuthSv1Authenticate, "token2", &reply); err == nil ||
+		err.Error() != ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", ErrUnauthorizedApi, err)
+	}
+	if err := client.Call(AuthSv1Authenticate, "token1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != OK {
+		t.Errorf("Expected OK, received: %s", reply)
+	}
+	if err := client.Call("AuthzTestSv1.RemoveValue", "val1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != OK {
+		t.Errorf("Expected OK, received: %s", reply)
+	}
+}
+
+func TestRPCAuthzServerCodecJSON(t *testing.T) {
+	srvConn, cliConn := net.Pipe()
+	go rpc.ServeCodec(newTestRPCAuthorizer().NewServerCodec(
+		jsonrpc.NewServerCodec(srvConn), "", "readonly", "pipe"))
+	client := jsonrpc.NewClient(cliConn)
+	defer client.Close()
+	testRPCAuthzClient(t, client)
+}
+
+func TestRPCAuthzServerCodecGOB(t *testing.T) {
+	srvConn, cliConn := net.Pipe()
+	go rpc.ServeCodec(newTestRPCAuthorizer().NewServerCodec(
+		newGobServerCodec(srvConn), "", "readonly", "pipe"))
+	client := rpc.NewClient(cliConn)
+	defer client.Close()
+	testRPCAuthzClient(t, client)
+}
+
+func TestRPCAuthzServerCodecDispatched(t *testing.T) {
+	srvConn, cliConn := net.Pipe()
+	go rpc.ServeCodec(newTestRPCAuthorizer().NewServerCodec(
+		NewCustomJSONServerCodec(srvConn), "", "readonly", "pipe"))
+	client := jsonrpc.NewClient(cliConn)
+	defer client.Close()
+	var reply string
+	// the APIer methods are checked before being redirected to the DispatcherS
+	if err := client.Call("APIerSv1.RemoveAccount", "val1", &reply); err == nil ||
+		err.Error() != ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", ErrUnauthorizedApi, err)
+	}
+	if err := client.Call("AuthzTestSv1.GetValue", "val1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != "val1" {
+		t.Errorf("Expected val1, received: %s", reply)
+	}
+}
+
+func TestRPCAuthzBiRPCCodec(t *testing.T) {
+	srv := rpc2.NewServer()
+	srv.Handle("SessionSv1.GetValue", func(c *rpc2.Client, args string, reply *string) error {
+		*reply = args
+		return nil
+	})
+	srvConn, cliConn := net.Pipe()
+	go srv.ServeCodec(newTestRPCAuthorizer().NewBiRPCCodec(
+		rpc2_jsonrpc.NewJSONCodec(srvConn), "", "pipe"))
+	client := rpc2.NewClientWithCodec(rpc2_jsonrpc.NewJSONCodec(cliConn))
+	go client.Run()
+	defer client.Close()
+	var reply string
+	if err := client.Call("SessionSv1.GetValue", "val1", &reply); err == nil ||
+		err.Error() != ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", ErrUnauthorizedApi, err)
+	}
+	if err := client.Call(AuthSv1Authenticate, "token1", &reply); err != nil {
+		t.Error(err)
+	}
+	if err := client.Call("SessionSv1.GetValue", "val1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != "val1" {
+		t.Errorf("Expected val1, received: %s", reply)
+	}
+}
@@ -67,12 +67,100 @@ type Server struct {
 	httpsMux        *http.ServeMux
 	httpMux         *http.ServeMux
 	isDispatched    bool
+	authz           *RPCAuthorizer
+	auditor         RPCAuditor
 }
 
 func (s *Server) SetDispatched() {
 	s.isDispatched = true
 }
 
+// SetAuthorizer enables the role based authorization on all RPC listeners
+func (s *Server) SetAuthorizer(authz *RPCAuthorizer) {
+	s.authz = authz
+}
+
+// SetAuditor enables the audit trail on the RPC listeners, nil to disable it
+func (s *Server) SetAuditor(auditor RPCAuditor) {
+	s.Lock()
+	s.auditor = auditor
+	s.Unlock()
+}
+
+// wrapCodec adds the authorization and the audit trail on top of the codec if enabled
+func (s *Server) wrapCodec(codec rpc.ServerCodec, caller, role, remote string) rpc.ServerCodec {
+	s.RLock()
+	auditor := s.auditor
+	s.RUnlock()
+	if s.authz != nil {
+		codec = s.authz.NewServerCodec(codec, caller, role, remote)
+	}
+	if auditor != nil {
+		codec = newAuditServerCodec(codec, auditor, caller, role, remote)
+	}
+	return codec
+}
+
+// wrapBiRPCCodec adds the authorization and the audit trail on top of the bidirectional codec if enabled
+func (s *Server) wrapBiRPCCodec(codec rpc2.Codec, remote string) rpc2.Codec {
+	s.RLock()
+	auditor := s.auditor
+	s.RUnlock()
+	role := s.defaultRole()
+	if s.authz != nil {
+		codec = s.authz.NewBiRPCCodec(codec, role, remote)
+	}
+	if auditor != nil {
+		codec = newAuditBiRPCCodec(codec, auditor, role, remote)
+	}
+	return codec
+}
+
+// isWrapped returns true if the connections need the codec wrapped
+func (s *Server) isWrapped() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.authz != nil || s.auditor != nil
+}
+
+// serveJSONConn serves a JSON connection enforcing the authorization if enabled
+func (s *Server) serveJSONConn(conn io.ReadWriteCloser, caller, role, remote string) {
+	var codec rpc.ServerCodec
+	if s.isDispatched {
+		codec = NewCustomJSONServerCodec(conn)
+	} else {
+		codec = jsonrpc.NewServerCodec(conn)
+	}
+	rpc.ServeCodec(s.wrapCodec(codec, caller, role, remote))
+}
+
+// serveGOBConn serves a GOB connection enforcing the authorization if enabled
+func (s *Server) serveGOBConn(conn net.Conn) {
+	if !s.isWrapped() {
+		rpc.ServeConn(conn)
+		return
+	}
+	rpc.ServeCodec(s.wrapCodec(newGobServerCodec(conn),
+		EmptyString, s.defaultRole(), conn.RemoteAddr().String()))
+}
+
+// defaultRole returns the role of the connections not authenticated
+func (s *Server) defaultRole() string {
+	if s.authz == nil {
+		return EmptyString
+	}
+	return s.authz.defaultRole
+}
+
+// httpIdentity returns the caller and its role for the HTTP request
+func (s *Server) httpIdentity(r *http.Request) (caller, role string) {
+	if s.authz != nil {
+		return s.authz.httpIdentity(r)
+	}
+	caller, _, _ = r.BasicAuth()
+	return
+}
+
 func (s *Server) RpcRegister(rcvr interface{}) {
 	rpc.Register(rcvr)
 	s.Lock()
@@ -175,11 +263,7 @@ func (s *Server) ServeJSON(addr string, exitChan chan bool) {
 			continue
 		}
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go jsonrpc.ServeConn(conn)
-		}
+		go s.serveJSONConn(conn, EmptyString, s.defaultRole(), conn.RemoteAddr().String())
 
 	}
 
@@ -218,14 +302,22 @@ func (s *Server) ServeGOB(addr string, exitChan chan bool) {
 		}
 
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		go rpc.ServeConn(conn)
+		go s.serveGOBConn(conn)
 	}
 }
 
-func handleRequest(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	w.Header().Set("Content-Type", "application/json")
-	res := NewRPCRequest(r.Body).Call()
+	rpcReq := NewRPCRequest(r.Body)
+	var res io.Reader
+	if !s.isWrapped() {
+		res = rpcReq.Call()
+	} else {
+		caller, role := s.httpIdentity(r)
+		res = rpcReq.CallCodec(s.wrapCodec(jsonrpc.NewServerCodec(rpcReq),
+			caller, role, r.RemoteAddr))
+	}
 	io.Copy(w, res)
 }
 
@@ -267,9 +359,9 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 
 		Logger.Info("<HTTP> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpMux.HandleFunc(jsonRPCURL, use(s.handleRequest, basicAuth(userList)))
 		} else {
-			s.httpMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpMux.HandleFunc(jsonRPCURL, s.handleRequest)
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -278,11 +370,8 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 		s.Unlock()
 		Logger.Info("<HTTP> enabling handler for WebSocket connections")
 		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				jsonrpc.ServeConn(ws)
-			}
+			caller, role := s.httpIdentity(ws.Request())
+			s.serveJSONConn(ws, caller, role, ws.Request().RemoteAddr)
 		})
 		if useBasicAuth {
 			s.httpMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {
@@ -333,7 +422,8 @@ func (s *Server) ServeBiJSON(addr string, onConn func(*rpc2.Client), onDis func(
 				log.Fatal(err)
 				return // stop if we get Accept error
 			}
-			go s.birpcSrv.ServeCodec(rpc2_jsonrpc.NewJSONCodec(conn))
+			go s.birpcSrv.ServeCodec(s.wrapBiRPCCodec(
+				rpc2_jsonrpc.NewJSONCodec(conn), conn.RemoteAddr().String()))
 		}
 	}(lBiJSON)
 	<-s.stopbiRPCServer // wait until server is stoped to close the listener
@@ -383,6 +473,13 @@ func (r *rpcRequest) Call() io.Reader {
 	return r.rw
 }
 
+// CallCodec invokes the RPC request using the given codec, waits for it to complete, and returns the results.
+func (r *rpcRequest) CallCodec(codec rpc.ServerCodec) io.Reader {
+	go rpc.ServeCodec(codec)
+	<-r.done
+	return r.rw
+}
+
 func loadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
 	serverName string) (config tls.Config, err error) {
 	cert, err := tls.LoadX509KeyPair(serverCrt, serverKey)
@@ -461,7 +558,7 @@ func (s *Server) ServeGOBTLS(addr, serverCrt, serverKey, caCert string,
 			continue
 		}
 		//utils.Logger.Info(fmt.Sprintf("<CGRServer> New incoming connection: %v", conn.RemoteAddr()))
-		go rpc.ServeConn(conn)
+		go s.serveGOBConn(conn)
 	}
 }
 
@@ -502,11 +599,7 @@ func (s *Server) ServeJSONTLS(addr, serverCrt, serverKey, caCert string,
 			}
 			continue
 		}
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go jsonrpc.ServeConn(conn)
-		}
+		go s.serveJSONConn(conn, EmptyString, s.defaultRole(), conn.RemoteAddr().String())
 	}
 }
 
@@ -526,9 +619,9 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpsMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpsMux.HandleFunc(jsonRPCURL, use(s.handleRequest, basicAuth(userList)))
 		} else {
-			s.httpsMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpsMux.HandleFunc(jsonRPCURL, s.handleRequest)
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -537,11 +630,8 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for WebSocket connections")
 		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				jsonrpc.ServeConn(ws)
-			}
+			caller, role := s.httpIdentity(ws.Request())
+			s.serveJSONConn(ws, caller, role, ws.Request().RemoteAddr)
 		})
 		if useBasicAuth {
 			s.httpsMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {